@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/database"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+)
+
+// analytics-refresher rebuilds the transit legs, dwells and throughput of products and
+// stakeholder days that recorded events made stale, until it is interrupted. Several instances
+// can run side by side; each stale product or day is rebuilt by one of them.
+func main() {
+	config := conf.LoadConfig()
+
+	db, err := database.NewPostgres(config.DatabaseConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	service := services.NewServiceManager(repository.NewRepositories(db), services.NewNopMetrics())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("analytics refresher started")
+	service.Analytics.Run(ctx)
+	log.Printf("analytics refresher stopped")
+}
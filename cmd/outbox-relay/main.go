@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/database"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+)
+
+// outbox-relay publishes the domain events written to the outbox to the configured event bus
+// until it is interrupted. Several instances can run side by side.
+func main() {
+	config := conf.LoadConfig()
+
+	db, err := database.NewPostgres(config.DatabaseConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	publisher, err := eventbus.NewPublisher(config.EventBusConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer publisher.Close()
+
+	relay := services.NewOutboxRelay(repository.NewRepositories(db).Outbox, publisher)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("outbox relay started, publishing to %s", config.EventBusConfig.Driver)
+	relay.Run(ctx)
+	log.Printf("outbox relay stopped")
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/database"
+	"github.com/koriebruh/suplyChainTrack/internal/metirc"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"github.com/koriebruh/suplyChainTrack/internal/telemetry"
+)
+
+// telemetry-bridge subscribes to sensor readings on the configured MQTT broker and stores them
+// until it is interrupted. Instances running side by side need distinct MQTT_CLIENT_IDs and a
+// shared subscription topic to split the load. Its metrics are served on MQTT_METRICS_PORT.
+func main() {
+	config := conf.LoadConfig()
+
+	db, err := database.NewPostgres(config.DatabaseConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	metricsExporter := metirc.NewAppMetricsExporter()
+	service := services.NewServiceManager(repository.NewRepositories(db), metricsExporter)
+	bridge := telemetry.NewBridge(config.MQTTConfig, service.Telemetry)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if config.MQTTConfig.MetricsPort != 0 {
+		server := serveMetrics(config.MQTTConfig.MetricsPort, metricsExporter)
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server.Shutdown(shutdownCtx)
+		}()
+	}
+
+	log.Printf("telemetry bridge started, subscribed to %s on %s", config.MQTTConfig.Topic, config.MQTTConfig.BrokerURL)
+	if err := bridge.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("telemetry bridge stopped")
+}
+
+// serveMetrics exposes the exporter on /metrics in the background
+func serveMetrics(port int, exporter *metirc.AppMetricsExporter) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter.MetricsHandler())
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("telemetry bridge metrics: %v", err)
+		}
+	}()
+	log.Printf("telemetry bridge metrics on :%d/metrics", port)
+	return server
+}
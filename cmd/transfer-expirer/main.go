@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/database"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+)
+
+// transfer-expirer marks pending custody transfers past their expires_at as expired, every
+// minute until it is interrupted. Several instances can run side by side.
+func main() {
+	config := conf.LoadConfig()
+
+	db, err := database.NewPostgres(config.DatabaseConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	service := services.NewServiceManager(repository.NewRepositories(db), services.NewNopMetrics())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("transfer expirer started")
+	service.Custody.RunExpiry(ctx)
+	log.Printf("transfer expirer stopped")
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/database"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+)
+
+// webhook-dispatcher sends queued webhook deliveries and their retries until it is interrupted.
+// Several instances can run side by side; each delivery is claimed by one of them.
+func main() {
+	config := conf.LoadConfig()
+
+	db, err := database.NewPostgres(config.DatabaseConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	service := services.NewServiceManager(repository.NewRepositories(db), services.NewNopMetrics())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("webhook dispatcher started")
+	service.Webhook.Run(ctx)
+	log.Printf("webhook dispatcher stopped")
+}
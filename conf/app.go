@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/etag"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -11,6 +12,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"log"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -158,9 +160,33 @@ var RateLimitConfig = limiter.New(limiter.Config{
 // 100KB     30KB      100KB
 // JSON   (compressed)  JSON
 var CompressionConfig = compress.New(compress.Config{
+	Next:  IsStreamRequest,
 	Level: compress.LevelBestSpeed,
 })
 
+// ETagConfig untuk caching, kecuali stream
+var ETagConfig = etag.New(etag.Config{
+	Next: IsStreamRequest,
+})
+
+// streamPathPrefixes are routes whose handlers write the body while it is produced (file and EPCIS XML exports)
+var streamPathPrefixes = []string{"/api/v1/exports/", "/api/v1/epcis/xml/events"}
+
+// IsStreamRequest reports SSE, WebSocket and export requests, whose bodies must reach the client as
+// they are written; middleware that buffers the whole response skips them
+func IsStreamRequest(c *fiber.Ctx) bool {
+	if strings.Contains(c.Get(fiber.HeaderAccept), "text/event-stream") ||
+		strings.EqualFold(c.Get(fiber.HeaderUpgrade), "websocket") {
+		return true
+	}
+	for _, prefix := range streamPathPrefixes {
+		if strings.HasPrefix(c.Path(), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // LoggerConfig untuk logging
 // for logging http requests and responses only
 var LoggerConfig = logger.New(logger.Config{
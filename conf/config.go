@@ -9,8 +9,13 @@ import (
 )
 
 type Config struct {
-	AppConfig      AppConfig
-	DatabaseConfig DatabaseConfig
+	AppConfig         AppConfig
+	DatabaseConfig    DatabaseConfig
+	DigitalLinkConfig DigitalLinkConfig
+	EventBusConfig    EventBusConfig
+	StreamConfig      StreamConfig
+	GRPCConfig        GRPCConfig
+	MQTTConfig        MQTTConfig
 }
 
 type AppConfig struct {
@@ -28,6 +33,43 @@ type DatabaseConfig struct {
 	Username string
 	Password string
 	Database string
+	SSLMode  string
+}
+
+// DigitalLinkConfig drives the GS1 Digital Link resolver
+type DigitalLinkConfig struct {
+	BaseURL         string // public origin printed on labels; empty uses the request's origin
+	ConsumerPageURL string // template with {gtin}, {lot}, {serial}, {sku} and {product_id}; empty disables the redirect
+}
+
+// EventBusConfig selects where the outbox relay publishes domain events
+type EventBusConfig struct {
+	Driver        string // 'memory' (default), 'nats' or 'kafka'
+	Prefix        string // subject/topic prefix, e.g. supplychain.product.created
+	NATSURL       string
+	NATSJetStream bool // publish with JetStream acknowledgements and de-duplication
+	KafkaBrokers  []string
+}
+
+// StreamConfig drives the real-time event stream
+type StreamConfig struct {
+	TokenSecret string // signs stream tokens; falls back to API_KEY
+}
+
+// GRPCConfig drives the gRPC API served next to the REST API
+type GRPCConfig struct {
+	Port int // 0 disables the gRPC server
+}
+
+// MQTTConfig drives the telemetry bridge that subscribes sensor readings from an MQTT broker
+type MQTTConfig struct {
+	BrokerURL string // e.g. tcp://localhost:1883
+	ClientID  string // also names the persistent session, so keep it stable across restarts
+	Topic     string // subscription filter; the last level of a message's topic is the device serial
+	Username  string
+	Password  string
+
+	MetricsPort int // serves /metrics for the bridge process; 0 disables it
 }
 
 var (
@@ -38,6 +80,8 @@ var (
 func LoadConfig() *Config {
 	appPort, _ := strconv.Atoi(GetEnv("APP_PORT", "3000"))
 	dbPort, _ := strconv.Atoi(GetEnv("DB_PORT", "3000"))
+	grpcPort, _ := strconv.Atoi(GetEnv("GRPC_PORT", "50051"))
+	mqttMetricsPort, _ := strconv.Atoi(GetEnv("MQTT_METRICS_PORT", "9102"))
 
 	log.Printf("MODE %v | %v Using APP_PORT: %d, | DB_PORT: %d",
 		GetEnv("APP_ENV", "dev-bg"),
@@ -59,6 +103,33 @@ func LoadConfig() *Config {
 			Username: GetEnv("DB_USER", "root"),
 			Password: GetEnv("DB_PASS", ""),
 			Database: GetEnv("DB_NAME", "mydb"),
+			SSLMode:  GetEnv("DB_SSL_MODE", "disable"),
+		},
+		DigitalLinkConfig: DigitalLinkConfig{
+			BaseURL:         GetEnv("DIGITAL_LINK_BASE_URL", ""),
+			ConsumerPageURL: GetEnv("DIGITAL_LINK_CONSUMER_URL", ""),
+		},
+		EventBusConfig: EventBusConfig{
+			Driver:        GetEnv("EVENT_BUS_DRIVER", "memory"),
+			Prefix:        GetEnv("EVENT_BUS_PREFIX", "supplychain"),
+			NATSURL:       GetEnv("NATS_URL", "nats://localhost:4222"),
+			NATSJetStream: GetEnv("NATS_JETSTREAM", "false") == "true",
+			KafkaBrokers:  strings.Split(GetEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+		},
+		StreamConfig: StreamConfig{
+			TokenSecret: GetEnv("STREAM_TOKEN_SECRET", GetEnv("API_KEY", "")),
+		},
+		GRPCConfig: GRPCConfig{
+			Port: grpcPort,
+		},
+		MQTTConfig: MQTTConfig{
+			BrokerURL: GetEnv("MQTT_BROKER_URL", "tcp://localhost:1883"),
+			ClientID:  GetEnv("MQTT_CLIENT_ID", "supplychain-telemetry-bridge"),
+			Topic:     GetEnv("MQTT_TOPIC", "supplychain/telemetry/#"),
+			Username:  GetEnv("MQTT_USERNAME", ""),
+			Password:  GetEnv("MQTT_PASSWORD", ""),
+
+			MetricsPort: mqttMetricsPort,
 		},
 	}
 }
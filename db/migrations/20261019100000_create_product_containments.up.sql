@@ -0,0 +1,19 @@
+-- Parent/child packing hierarchy (item -> case -> pallet -> container)
+CREATE TABLE product_containments
+(
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    parent_id       UUID      NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    child_id        UUID      NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    pack_event_id   UUID REFERENCES supply_chain_events (id) ON DELETE SET NULL,
+    unpack_event_id UUID REFERENCES supply_chain_events (id) ON DELETE SET NULL,
+    packed_at       TIMESTAMP NOT NULL,
+    unpacked_at     TIMESTAMP,
+    created_at      TIMESTAMP        DEFAULT NOW(),
+    CHECK (parent_id <> child_id)
+);
+
+CREATE INDEX idx_product_containments_parent ON product_containments (parent_id, packed_at);
+CREATE INDEX idx_product_containments_child ON product_containments (child_id, packed_at);
+
+-- A product can only be packed in one container at a time
+CREATE UNIQUE INDEX idx_product_containments_active_child ON product_containments (child_id) WHERE unpacked_at IS NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transformation_lines;
@@ -0,0 +1,16 @@
+-- Inputs consumed and outputs produced by 'transformed' events
+CREATE TABLE transformation_lines
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id   UUID           NOT NULL REFERENCES supply_chain_events (id) ON DELETE CASCADE,
+    product_id UUID           NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    direction  VARCHAR(10)    NOT NULL, -- 'input', 'output'
+    quantity   NUMERIC(18, 4) NOT NULL CHECK (quantity > 0),
+    unit       VARCHAR(20),
+    lot_number VARCHAR(100),
+    created_at TIMESTAMP      DEFAULT NOW()
+);
+
+CREATE INDEX idx_transformation_lines_event ON transformation_lines (event_id, direction);
+CREATE INDEX idx_transformation_lines_product ON transformation_lines (product_id, direction);
+CREATE INDEX idx_transformation_lines_lot ON transformation_lines (lot_number);
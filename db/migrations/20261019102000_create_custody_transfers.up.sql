@@ -0,0 +1,30 @@
+-- Two-party custody handoffs: custody only moves once the receiver accepts
+CREATE TABLE custody_transfers
+(
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id          UUID        NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    sender_id           UUID        NOT NULL REFERENCES stakeholders (id) ON DELETE CASCADE,
+    receiver_id         UUID        NOT NULL REFERENCES stakeholders (id) ON DELETE CASCADE,
+    status              VARCHAR(20)      DEFAULT 'pending', -- 'pending', 'accepted', 'rejected', 'expired'
+    quantity            NUMERIC(18, 4),
+    received_quantity   NUMERIC(18, 4),
+    discrepancies       JSONB,
+    has_discrepancy     BOOLEAN          DEFAULT false,
+    notes               TEXT,
+    rejection_reason    TEXT,
+    ship_event_id       UUID REFERENCES supply_chain_events (id) ON DELETE SET NULL,
+    receive_event_id    UUID REFERENCES supply_chain_events (id) ON DELETE SET NULL,
+    expires_at          TIMESTAMP   NOT NULL,
+    expected_arrival_at TIMESTAMP, -- when the receiver should have the goods; receipts by then are on time
+    responded_at        TIMESTAMP,
+    created_at          TIMESTAMP        DEFAULT NOW(),
+    updated_at          TIMESTAMP        DEFAULT NOW(),
+    CHECK (sender_id <> receiver_id)
+);
+
+CREATE INDEX idx_custody_transfers_sender ON custody_transfers (sender_id, status);
+CREATE INDEX idx_custody_transfers_receiver ON custody_transfers (receiver_id, status);
+CREATE INDEX idx_custody_transfers_expiry ON custody_transfers (expires_at) WHERE status = 'pending';
+
+-- Only one handoff per product can be in flight
+CREATE UNIQUE INDEX idx_custody_transfers_pending_product ON custody_transfers (product_id) WHERE status = 'pending';
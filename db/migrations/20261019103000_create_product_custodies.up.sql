@@ -0,0 +1,17 @@
+-- Current custody/location projection, maintained by every event write
+CREATE TABLE product_custodies
+(
+    product_id      UUID PRIMARY KEY REFERENCES products (id) ON DELETE CASCADE,
+    holder_id       UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    location        VARCHAR(255),
+    state           VARCHAR(20) NOT NULL, -- 'in_stock', 'in_transit', 'sold', 'consumed'
+    container_id    UUID REFERENCES products (id) ON DELETE SET NULL,
+    last_event_id   UUID REFERENCES supply_chain_events (id) ON DELETE SET NULL,
+    last_event_type VARCHAR(50) NOT NULL,
+    last_event_at   TIMESTAMP   NOT NULL,
+    updated_at      TIMESTAMP        DEFAULT NOW()
+);
+
+CREATE INDEX idx_product_custodies_holder ON product_custodies (holder_id, state);
+CREATE INDEX idx_product_custodies_location ON product_custodies (location, state);
+CREATE INDEX idx_product_custodies_container ON product_custodies (container_id);
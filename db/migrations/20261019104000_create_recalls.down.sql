@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS recall_notifications;
+DROP TABLE IF EXISTS recall_units;
+DROP TABLE IF EXISTS recalls;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS serial_number,
+    DROP COLUMN IF EXISTS lot_number;
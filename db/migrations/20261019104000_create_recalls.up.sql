@@ -0,0 +1,64 @@
+-- Lot and serial identifiers on products, used to scope recalls. Their indexes are built
+-- CONCURRENTLY by the next migrations, since products already holds data
+ALTER TABLE products
+    ADD COLUMN lot_number    VARCHAR(100),
+    ADD COLUMN serial_number VARCHAR(100);
+
+-- Recall campaigns scoped by product, lot, serial range and/or manufacture date window
+CREATE TABLE recalls
+(
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title             VARCHAR(255) NOT NULL,
+    reason            TEXT,
+    severity          VARCHAR(20)      DEFAULT 'medium', -- 'low', 'medium', 'high', 'critical'
+    status            VARCHAR(20)      DEFAULT 'draft',  -- 'draft', 'active', 'closed'
+    initiated_by      UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    product_id        UUID REFERENCES products (id) ON DELETE SET NULL,
+    lot_number        VARCHAR(100),
+    serial_from       VARCHAR(100),
+    serial_to         VARCHAR(100),
+    manufactured_from TIMESTAMP,
+    manufactured_to   TIMESTAMP,
+    affected_units    INTEGER          DEFAULT 0,
+    activated_at      TIMESTAMP,
+    closed_at         TIMESTAMP,
+    created_at        TIMESTAMP        DEFAULT NOW(),
+    updated_at        TIMESTAMP        DEFAULT NOW()
+);
+
+CREATE INDEX idx_recalls_status ON recalls (status);
+
+-- Units affected by a recall and who held them when the recall was activated
+CREATE TABLE recall_units
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    recall_id  UUID        NOT NULL REFERENCES recalls (id) ON DELETE CASCADE,
+    product_id UUID        NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    holder_id  UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    location   VARCHAR(255),
+    state      VARCHAR(20),
+    source     VARCHAR(20) NOT NULL, -- 'scope', 'contents', 'downstream'
+    created_at TIMESTAMP        DEFAULT NOW(),
+    UNIQUE (recall_id, product_id)
+);
+
+CREATE INDEX idx_recall_units_holder ON recall_units (recall_id, holder_id);
+
+-- One notice per stakeholder holding affected units
+CREATE TABLE recall_notifications
+(
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    recall_id       UUID        NOT NULL REFERENCES recalls (id) ON DELETE CASCADE,
+    stakeholder_id  UUID        NOT NULL REFERENCES stakeholders (id) ON DELETE CASCADE,
+    unit_count      INTEGER     NOT NULL DEFAULT 0,
+    status          VARCHAR(20)      DEFAULT 'pending', -- 'pending', 'sent', 'failed', 'acknowledged'
+    error           TEXT,
+    notes           TEXT,
+    sent_at         TIMESTAMP,
+    acknowledged_at TIMESTAMP,
+    created_at      TIMESTAMP        DEFAULT NOW(),
+    updated_at      TIMESTAMP        DEFAULT NOW(),
+    UNIQUE (recall_id, stakeholder_id)
+);
+
+CREATE INDEX idx_recall_notifications_stakeholder ON recall_notifications (stakeholder_id, status);
@@ -0,0 +1,2 @@
+-- Recalls scoped by lot
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_products_lot_number ON products (lot_number);
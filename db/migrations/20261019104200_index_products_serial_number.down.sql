@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS idx_products_serial_number;
@@ -0,0 +1,2 @@
+-- Recalls scoped by serial range
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_products_serial_number ON products (serial_number);
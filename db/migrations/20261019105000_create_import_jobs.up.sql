@@ -0,0 +1,39 @@
+-- Bulk CSV/XLSX imports of products, stakeholders and events
+CREATE TABLE import_jobs
+(
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind           VARCHAR(20) NOT NULL, -- 'products', 'stakeholders', 'events'
+    format         VARCHAR(10) NOT NULL, -- 'csv', 'xlsx'
+    file_name      VARCHAR(255),
+    dry_run        BOOLEAN     DEFAULT FALSE,
+    status         VARCHAR(20) DEFAULT 'pending', -- 'pending', 'running', 'completed', 'failed'
+    total_rows     INTEGER     DEFAULT 0,
+    processed_rows INTEGER     DEFAULT 0,
+    created_rows   INTEGER     DEFAULT 0,
+    updated_rows   INTEGER     DEFAULT 0,
+    failed_rows    INTEGER     DEFAULT 0,
+    error          TEXT,
+    started_at     TIMESTAMP,
+    heartbeat_at   TIMESTAMP, -- touched while running; a job whose heartbeat stopped was interrupted
+    finished_at    TIMESTAMP,
+    created_at     TIMESTAMP   DEFAULT NOW(),
+    updated_at     TIMESTAMP   DEFAULT NOW()
+);
+
+CREATE INDEX idx_import_jobs_kind ON import_jobs (kind);
+CREATE INDEX idx_import_jobs_status ON import_jobs (status);
+CREATE INDEX idx_import_jobs_unfinished ON import_jobs (COALESCE(heartbeat_at, created_at))
+    WHERE status IN ('pending', 'running');
+
+-- Row-level problems found by an import, downloadable as an error report
+CREATE TABLE import_row_errors
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id     UUID    NOT NULL REFERENCES import_jobs (id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    field      VARCHAR(100),
+    message    TEXT    NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_import_row_errors_job_row ON import_row_errors (job_id, row_number);
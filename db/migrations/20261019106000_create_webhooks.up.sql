@@ -0,0 +1,44 @@
+-- Partner endpoints notified of changes to events that touch them
+CREATE TABLE webhook_subscriptions
+(
+    id                   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    stakeholder_id       UUID         NOT NULL REFERENCES stakeholders (id) ON DELETE CASCADE,
+    url                  TEXT         NOT NULL,
+    secret               VARCHAR(100) NOT NULL,
+    topics               JSONB, -- empty matches every topic
+    event_types          JSONB, -- empty matches every event type
+    product_ids          JSONB, -- empty matches every product
+    is_active            BOOLEAN   DEFAULT TRUE,
+    consecutive_failures INTEGER   DEFAULT 0,
+    disabled_at          TIMESTAMP,
+    disabled_reason      TEXT,
+    created_at           TIMESTAMP DEFAULT NOW(),
+    updated_at           TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_subscriptions_stakeholder ON webhook_subscriptions (stakeholder_id);
+CREATE INDEX idx_webhook_subscriptions_active ON webhook_subscriptions (is_active);
+
+-- One notification to one subscription, retried with exponential backoff until it succeeds or gives up
+CREATE TABLE webhook_deliveries
+(
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID        NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    topic           VARCHAR(50) NOT NULL,
+    event_id        UUID,
+    payload         TEXT        NOT NULL,
+    status          VARCHAR(20) DEFAULT 'pending', -- 'pending', 'succeeded', 'failed'
+    attempts        INTEGER     DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    last_attempt_at TIMESTAMP,
+    response_status INTEGER,
+    last_error      TEXT,
+    delivered_at    TIMESTAMP,
+    replay_of       UUID REFERENCES webhook_deliveries (id) ON DELETE SET NULL,
+    created_at      TIMESTAMP   DEFAULT NOW(),
+    updated_at      TIMESTAMP   DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries (subscription_id, created_at);
+CREATE INDEX idx_webhook_deliveries_event ON webhook_deliveries (event_id);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
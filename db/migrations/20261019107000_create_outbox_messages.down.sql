@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS outbox_consumers;
+DROP TABLE IF EXISTS outbox_messages;
+DROP SEQUENCE IF EXISTS outbox_messages_sequence;
@@ -0,0 +1,34 @@
+-- Domain events waiting to be relayed to the event bus, written in the transaction of their change.
+-- The relay numbers messages once their transaction has committed, so a consumer that has read up
+-- to a sequence number never misses a message written before it
+CREATE SEQUENCE outbox_messages_sequence;
+
+CREATE TABLE outbox_messages
+(
+    id            UUID PRIMARY KEY,
+    name          VARCHAR(100) NOT NULL,
+    aggregate_key VARCHAR(100) NOT NULL,
+    payload       TEXT         NOT NULL,
+    occurred_at   TIMESTAMP    NOT NULL,
+    sequence      BIGINT,
+    published_at  TIMESTAMP,
+    attempts      INTEGER   DEFAULT 0,
+    last_error    TEXT,
+    created_at    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_outbox_messages_sequence ON outbox_messages (sequence);
+CREATE INDEX idx_outbox_messages_unsequenced ON outbox_messages (created_at, id) WHERE sequence IS NULL;
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages (sequence) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_messages_published ON outbox_messages (published_at);
+
+-- How far each reader of the outbox log has got
+CREATE TABLE outbox_consumers
+(
+    name       VARCHAR(100) PRIMARY KEY,
+    position   BIGINT    NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Webhooks are queued from the whole log
+INSERT INTO outbox_consumers (name, position) VALUES ('webhooks', 0);
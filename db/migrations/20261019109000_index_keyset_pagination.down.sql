@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_page;
+DROP INDEX IF EXISTS idx_webhook_subscriptions_page;
+DROP INDEX IF EXISTS idx_import_jobs_page;
+DROP INDEX IF EXISTS idx_recall_units_page;
+DROP INDEX IF EXISTS idx_recalls_page;
+DROP INDEX IF EXISTS idx_product_custodies_page;
+DROP INDEX IF EXISTS idx_custody_transfers_page;
@@ -0,0 +1,9 @@
+-- List endpoints page newest first on a timestamp and the ID. These tables are new, so their indexes
+-- are built in place; the following migrations index tables that already hold data
+CREATE INDEX idx_custody_transfers_page ON custody_transfers (created_at DESC, id DESC);
+CREATE INDEX idx_product_custodies_page ON product_custodies (last_event_at DESC, product_id DESC);
+CREATE INDEX idx_recalls_page ON recalls (created_at DESC, id DESC);
+CREATE INDEX idx_recall_units_page ON recall_units (recall_id, created_at, id);
+CREATE INDEX idx_import_jobs_page ON import_jobs (created_at DESC, id DESC);
+CREATE INDEX idx_webhook_subscriptions_page ON webhook_subscriptions (created_at DESC, id DESC);
+CREATE INDEX idx_webhook_deliveries_page ON webhook_deliveries (created_at DESC, id DESC);
@@ -0,0 +1,4 @@
+-- Events page on (timestamp, id), newest first. Indexes on tables that already hold data are built
+-- CONCURRENTLY so writes carry on; golang-migrate runs a single-statement file outside a transaction,
+-- which CONCURRENTLY requires, so each of these migrations creates one index
+CREATE INDEX CONCURRENTLY idx_supply_chain_events_page ON supply_chain_events (timestamp DESC, id DESC);
@@ -0,0 +1,2 @@
+-- Events of one product, newest first
+CREATE INDEX CONCURRENTLY idx_supply_chain_events_product_page ON supply_chain_events (product_id, timestamp DESC, id DESC);
@@ -0,0 +1,2 @@
+-- Events recorded by one stakeholder, newest first
+CREATE INDEX CONCURRENTLY idx_supply_chain_events_stakeholder_page ON supply_chain_events (stakeholder_id, timestamp DESC, id DESC);
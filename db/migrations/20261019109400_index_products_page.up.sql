@@ -0,0 +1,2 @@
+-- Products page on (created_at, id), newest first
+CREATE INDEX CONCURRENTLY idx_products_page ON products (created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+-- Stakeholders page on (created_at, id), newest first
+CREATE INDEX CONCURRENTLY idx_stakeholders_page ON stakeholders (created_at DESC, id DESC);
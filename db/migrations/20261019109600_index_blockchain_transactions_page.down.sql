@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS idx_blockchain_transactions_page;
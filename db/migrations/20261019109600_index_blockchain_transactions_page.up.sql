@@ -0,0 +1,2 @@
+-- Blockchain transactions page on (created_at, id), newest first
+CREATE INDEX CONCURRENTLY idx_blockchain_transactions_page ON blockchain_transactions (created_at DESC, id DESC);
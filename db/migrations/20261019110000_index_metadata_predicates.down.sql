@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS idx_supply_chain_events_metadata;
@@ -0,0 +1,3 @@
+-- Equality predicates on metadata (filter[metadata.key]=value) are containment queries (@>). Both
+-- tables already hold data, so each GIN index is built CONCURRENTLY in a migration of its own
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_supply_chain_events_metadata ON supply_chain_events USING GIN (metadata jsonb_path_ops);
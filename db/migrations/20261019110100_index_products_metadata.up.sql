@@ -0,0 +1,2 @@
+-- Containment queries on product metadata
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_products_metadata ON products USING GIN (metadata jsonb_path_ops);
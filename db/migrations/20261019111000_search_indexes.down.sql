@@ -0,0 +1 @@
+-- pg_trgm stays installed: other indexes or functions may use it
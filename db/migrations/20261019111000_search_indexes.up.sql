@@ -0,0 +1,3 @@
+-- Trigram matching for the typo-tolerant search. The search indexes on tables that already hold data
+-- follow, one CONCURRENTLY built index per migration as with the paging indexes
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
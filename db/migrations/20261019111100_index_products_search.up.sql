@@ -0,0 +1,2 @@
+-- Full-text document of products; the expression must match the search repository's
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_products_search ON products USING GIN ((setweight(to_tsvector('english', name), 'A') || setweight(to_tsvector('english', sku), 'A') || setweight(to_tsvector('english', coalesce(category, '')), 'B') || setweight(to_tsvector('english', coalesce(description, '')), 'C')));
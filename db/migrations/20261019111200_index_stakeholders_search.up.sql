@@ -0,0 +1,2 @@
+-- Full-text document of stakeholders; the expression must match the search repository's
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_stakeholders_search ON stakeholders USING GIN ((setweight(to_tsvector('english', name), 'A') || setweight(to_tsvector('english', coalesce(address, '')), 'B')));
@@ -0,0 +1,2 @@
+-- Full-text document of event locations; the expression must match the search repository's
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_supply_chain_events_location_search ON supply_chain_events USING GIN (to_tsvector('english', coalesce(location, '')));
@@ -0,0 +1,2 @@
+-- Trigram indexes serve the typo-tolerant matches, and the ILIKE filters on the same columns
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_products_name_trgm ON products USING GIN (name gin_trgm_ops);
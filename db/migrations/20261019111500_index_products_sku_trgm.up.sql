@@ -0,0 +1,2 @@
+-- Typo-tolerant SKU matches and the sku list filter
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_products_sku_trgm ON products USING GIN (sku gin_trgm_ops);
@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS idx_stakeholders_name_trgm;
@@ -0,0 +1,2 @@
+-- Typo-tolerant stakeholder name matches and the name list filter
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_stakeholders_name_trgm ON stakeholders USING GIN (name gin_trgm_ops);
@@ -0,0 +1,2 @@
+-- Typo-tolerant location matches and the location list filter
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_supply_chain_events_location_trgm ON supply_chain_events USING GIN (location gin_trgm_ops);
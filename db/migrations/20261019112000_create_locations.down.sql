@@ -0,0 +1,5 @@
+ALTER TABLE supply_chain_events DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS locations;
+
+-- cube and earthdistance stay installed: other objects may use them
@@ -0,0 +1,41 @@
+-- earthdistance measures great-circle distances on a spherical earth; it needs cube
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+-- Registered facilities events are recorded at
+CREATE TABLE locations
+(
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name           VARCHAR(255) NOT NULL,
+    gln            VARCHAR(13) UNIQUE,
+    type           VARCHAR(50)  NOT NULL, -- 'factory', 'farm', 'warehouse', 'distribution_center', 'port', 'store', 'other'
+    address        TEXT,
+    latitude       DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude      DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    stakeholder_id UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    created_at     TIMESTAMP DEFAULT NOW(),
+    updated_at     TIMESTAMP DEFAULT NOW(),
+    CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+-- Free-text event locations resolve to a location by name, regardless of case
+CREATE UNIQUE INDEX idx_locations_name ON locations (lower(name));
+CREATE INDEX idx_locations_stakeholder_id ON locations (stakeholder_id);
+CREATE INDEX idx_locations_created_at_id ON locations (created_at DESC, id DESC);
+CREATE INDEX idx_locations_earth ON locations USING GIST (ll_to_earth(latitude, longitude));
+
+-- Events link to their location; the next migration indexes the link CONCURRENTLY
+ALTER TABLE supply_chain_events ADD COLUMN location_id UUID REFERENCES locations (id) ON DELETE SET NULL;
+
+-- Register every location events were recorded at, and link the events to it. Spellings that
+-- differ only in case or surrounding spaces become one location, named after the first seen.
+INSERT INTO locations (name, type, created_at, updated_at)
+SELECT (array_agg(btrim(location) ORDER BY timestamp, id))[1], 'other', MIN(timestamp), NOW()
+FROM supply_chain_events
+WHERE btrim(location) <> ''
+GROUP BY lower(btrim(location));
+
+UPDATE supply_chain_events
+SET location_id = locations.id
+FROM locations
+WHERE lower(btrim(supply_chain_events.location)) = lower(locations.name);
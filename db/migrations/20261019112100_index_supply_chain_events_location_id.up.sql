@@ -0,0 +1,2 @@
+-- Events at a location, newest first
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_supply_chain_events_location_id ON supply_chain_events (location_id, timestamp DESC, id DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS route_alerts;
+DROP TABLE IF EXISTS route_plans;
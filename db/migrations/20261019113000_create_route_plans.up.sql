@@ -0,0 +1,46 @@
+-- How a shipment (custody transfer) is expected to travel
+CREATE TABLE route_plans
+(
+    id                    UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    custody_transfer_id   UUID             NOT NULL UNIQUE REFERENCES custody_transfers (id) ON DELETE CASCADE,
+    product_id            UUID             NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    waypoints             JSONB,                               -- [{latitude, longitude}], in travel order
+    corridor_km           DOUBLE PRECISION NOT NULL DEFAULT 0, -- allowed distance from the route
+    geofences             JSONB,                               -- [{name, latitude, longitude, radius_km}] to stay out of
+    expected_location_ids JSONB,                               -- locations events may be recorded at
+    created_at            TIMESTAMP DEFAULT NOW(),
+    updated_at            TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_route_plans_product_id ON route_plans (product_id);
+
+-- Positions of a shipment its route plan did not expect
+CREATE TABLE route_alerts
+(
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    route_plan_id       UUID         NOT NULL REFERENCES route_plans (id) ON DELETE CASCADE,
+    custody_transfer_id UUID         NOT NULL REFERENCES custody_transfers (id) ON DELETE CASCADE,
+    product_id          UUID         NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    type                VARCHAR(30)  NOT NULL, -- 'route_deviation', 'geofence_entered', 'unexpected_facility'
+    subject             VARCHAR(255) NOT NULL DEFAULT '', -- geofence name or unexpected location ID
+    source              VARCHAR(20)  NOT NULL, -- 'event', 'ping'
+    event_id            UUID REFERENCES supply_chain_events (id) ON DELETE SET NULL,
+    location_id         UUID REFERENCES locations (id) ON DELETE SET NULL,
+    latitude            DOUBLE PRECISION,
+    longitude           DOUBLE PRECISION,
+    distance_km         DOUBLE PRECISION,
+    message             TEXT         NOT NULL,
+    status              VARCHAR(20) DEFAULT 'open', -- 'open', 'acknowledged'
+    observed_at         TIMESTAMP    NOT NULL,
+    acknowledged_at     TIMESTAMP,
+    acknowledged_by     UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    notes               TEXT,
+    created_at          TIMESTAMP DEFAULT NOW()
+);
+
+-- A plan has one open alert per type and subject until it is acknowledged
+CREATE UNIQUE INDEX idx_route_alerts_open ON route_alerts (route_plan_id, type, subject) WHERE status = 'open';
+CREATE INDEX idx_route_alerts_custody_transfer_id ON route_alerts (custody_transfer_id);
+CREATE INDEX idx_route_alerts_product_id ON route_alerts (product_id);
+CREATE INDEX idx_route_alerts_status ON route_alerts (status);
+CREATE INDEX idx_route_alerts_created_at_id ON route_alerts (created_at DESC, id DESC);
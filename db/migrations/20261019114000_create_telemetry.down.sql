@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS telemetry_readings;
+DROP TABLE IF EXISTS sensor_devices;
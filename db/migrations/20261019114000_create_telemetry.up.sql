@@ -0,0 +1,39 @@
+-- Data loggers and trackers that report telemetry
+CREATE TABLE sensor_devices
+(
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    serial         VARCHAR(100) NOT NULL UNIQUE,
+    name           VARCHAR(255),
+    model          VARCHAR(100),
+    stakeholder_id UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    product_id     UUID REFERENCES products (id) ON DELETE SET NULL, -- attached product
+    attached_at    TIMESTAMP,
+    last_seen_at   TIMESTAMP,
+    created_at     TIMESTAMP DEFAULT NOW(),
+    updated_at     TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_sensor_devices_stakeholder_id ON sensor_devices (stakeholder_id);
+CREATE INDEX idx_sensor_devices_product_id ON sensor_devices (product_id);
+CREATE INDEX idx_sensor_devices_created_at_id ON sensor_devices (created_at DESC, id DESC);
+
+-- Time series of device readings; append-only and read by device, product or shipment over a time range
+CREATE TABLE telemetry_readings
+(
+    id                  BIGSERIAL PRIMARY KEY,
+    device_id           UUID      NOT NULL REFERENCES sensor_devices (id) ON DELETE CASCADE,
+    product_id          UUID REFERENCES products (id) ON DELETE SET NULL,
+    custody_transfer_id UUID REFERENCES custody_transfers (id) ON DELETE SET NULL,
+    recorded_at         TIMESTAMP NOT NULL,
+    temperature         DOUBLE PRECISION, -- degrees Celsius
+    humidity            DOUBLE PRECISION CHECK (humidity BETWEEN 0 AND 100),
+    latitude            DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude           DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    received_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+-- One reading per device and instant, so a batch sent again is not stored twice
+CREATE UNIQUE INDEX idx_telemetry_readings_device ON telemetry_readings (device_id, recorded_at);
+CREATE INDEX idx_telemetry_readings_product ON telemetry_readings (product_id, recorded_at) WHERE product_id IS NOT NULL;
+CREATE INDEX idx_telemetry_readings_custody_transfer ON telemetry_readings (custody_transfer_id, recorded_at) WHERE custody_transfer_id IS NOT NULL;
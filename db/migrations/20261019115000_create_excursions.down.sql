@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS product_quarantines;
+DROP TABLE IF EXISTS excursion_incidents;
+DROP TABLE IF EXISTS excursion_rules;
@@ -0,0 +1,78 @@
+-- Acceptable ranges of a metric per product category
+CREATE TABLE excursion_rules
+(
+    id                    UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name                  VARCHAR(255) NOT NULL,
+    category              VARCHAR(100) NOT NULL, -- matched against products.category regardless of case
+    metric                VARCHAR(20)  NOT NULL, -- 'temperature', 'humidity'
+    min                   DOUBLE PRECISION,
+    max                   DOUBLE PRECISION,
+    max_excursion_minutes INTEGER      NOT NULL DEFAULT 0, -- how long a product may stay out of range
+    quarantine_scope      VARCHAR(20)  NOT NULL DEFAULT 'product', -- 'none', 'product', 'lot'
+    is_active             BOOLEAN DEFAULT TRUE,
+    created_at            TIMESTAMP DEFAULT NOW(),
+    updated_at            TIMESTAMP DEFAULT NOW(),
+    CHECK (min IS NOT NULL OR max IS NOT NULL),
+    CHECK (min IS NULL OR max IS NULL OR min < max),
+    CHECK (max_excursion_minutes >= 0)
+);
+
+CREATE INDEX idx_excursion_rules_category ON excursion_rules (LOWER(category)) WHERE is_active;
+CREATE INDEX idx_excursion_rules_created_at_id ON excursion_rules (created_at DESC, id DESC);
+
+-- Stretches of time a product spent outside the range of a rule
+CREATE TABLE excursion_incidents
+(
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    rule_id             UUID             NOT NULL REFERENCES excursion_rules (id) ON DELETE CASCADE,
+    product_id          UUID             NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    custody_transfer_id UUID REFERENCES custody_transfers (id) ON DELETE SET NULL,
+    device_id           UUID REFERENCES sensor_devices (id) ON DELETE SET NULL,
+    event_id            UUID REFERENCES supply_chain_events (id) ON DELETE SET NULL,
+    metric              VARCHAR(20)      NOT NULL,
+    min                 DOUBLE PRECISION,
+    max                 DOUBLE PRECISION,
+    status              VARCHAR(20) DEFAULT 'open', -- 'open', 'closed'
+    started_at          TIMESTAMP        NOT NULL,
+    last_out_at         TIMESTAMP        NOT NULL,
+    ended_at            TIMESTAMP,
+    peak_value          DOUBLE PRECISION NOT NULL,
+    measurements        INTEGER          NOT NULL DEFAULT 0,
+    breached_at         TIMESTAMP,
+    holder_id           UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    acknowledged_at     TIMESTAMP,
+    acknowledged_by     UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    notes               TEXT,
+    created_at          TIMESTAMP DEFAULT NOW(),
+    updated_at          TIMESTAMP DEFAULT NOW()
+);
+
+-- A product has one open incident per rule
+CREATE UNIQUE INDEX idx_excursion_incidents_open ON excursion_incidents (rule_id, product_id) WHERE status = 'open';
+CREATE INDEX idx_excursion_incidents_rule_id ON excursion_incidents (rule_id);
+CREATE INDEX idx_excursion_incidents_product_id ON excursion_incidents (product_id);
+CREATE INDEX idx_excursion_incidents_custody_transfer_id ON excursion_incidents (custody_transfer_id);
+CREATE INDEX idx_excursion_incidents_status ON excursion_incidents (status);
+CREATE INDEX idx_excursion_incidents_created_at_id ON excursion_incidents (created_at DESC, id DESC);
+
+-- Products held back until released
+CREATE TABLE product_quarantines
+(
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id  UUID NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    incident_id UUID REFERENCES excursion_incidents (id) ON DELETE SET NULL,
+    reason      TEXT NOT NULL,
+    status      VARCHAR(20) DEFAULT 'active', -- 'active', 'released'
+    released_at TIMESTAMP,
+    released_by UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    notes       TEXT,
+    created_at  TIMESTAMP DEFAULT NOW(),
+    updated_at  TIMESTAMP DEFAULT NOW()
+);
+
+-- A product has at most one active quarantine
+CREATE UNIQUE INDEX idx_product_quarantines_active ON product_quarantines (product_id) WHERE status = 'active';
+CREATE INDEX idx_product_quarantines_product_id ON product_quarantines (product_id);
+CREATE INDEX idx_product_quarantines_incident_id ON product_quarantines (incident_id);
+CREATE INDEX idx_product_quarantines_status ON product_quarantines (status);
+CREATE INDEX idx_product_quarantines_created_at_id ON product_quarantines (created_at DESC, id DESC);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS analytics_stale_days;
+DROP TABLE IF EXISTS analytics_stale_products;
+DROP TABLE IF EXISTS stakeholder_throughput;
+DROP TABLE IF EXISTS product_dwells;
+DROP TABLE IF EXISTS transit_legs;
@@ -0,0 +1,85 @@
+-- Supply chain analytics are kept in tables derived from supply_chain_events. They work like
+-- materialized views, but are refreshed incrementally: recording an event marks its product and
+-- its stakeholder's day stale, and a refresh rebuilds only the stale rows.
+
+-- One row per shipment leg: a shipped event and the first received event of the product after it
+CREATE TABLE transit_legs
+(
+    ship_event_id           UUID PRIMARY KEY REFERENCES supply_chain_events (id) ON DELETE CASCADE,
+    receive_event_id        UUID             NOT NULL REFERENCES supply_chain_events (id) ON DELETE CASCADE,
+    product_id              UUID             NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    custody_transfer_id     UUID REFERENCES custody_transfers (id) ON DELETE SET NULL,
+    sender_id               UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    receiver_id             UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    origin_location_id      UUID REFERENCES locations (id) ON DELETE SET NULL,
+    destination_location_id UUID REFERENCES locations (id) ON DELETE SET NULL,
+    shipped_at              TIMESTAMP        NOT NULL,
+    received_at             TIMESTAMP        NOT NULL,
+    transit_seconds         DOUBLE PRECISION NOT NULL GENERATED ALWAYS AS (EXTRACT(EPOCH FROM received_at - shipped_at)::DOUBLE PRECISION) STORED
+);
+
+CREATE INDEX idx_transit_legs_received_at ON transit_legs (received_at DESC, ship_event_id DESC);
+CREATE INDEX idx_transit_legs_product_id ON transit_legs (product_id);
+CREATE INDEX idx_transit_legs_sender ON transit_legs (sender_id, received_at);
+CREATE INDEX idx_transit_legs_receiver ON transit_legs (receiver_id, received_at);
+
+-- One row per stay of a product with a stakeholder: from a manufactured or received event to the
+-- next shipped or sold event, open while the product is still there
+CREATE TABLE product_dwells
+(
+    arrival_event_id   UUID PRIMARY KEY REFERENCES supply_chain_events (id) ON DELETE CASCADE,
+    departure_event_id UUID REFERENCES supply_chain_events (id) ON DELETE CASCADE,
+    product_id         UUID      NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    stakeholder_id     UUID REFERENCES stakeholders (id) ON DELETE SET NULL,
+    location_id        UUID REFERENCES locations (id) ON DELETE SET NULL,
+    location           VARCHAR(255),
+    arrived_at         TIMESTAMP NOT NULL,
+    departed_at        TIMESTAMP,
+    dwell_seconds      DOUBLE PRECISION GENERATED ALWAYS AS (EXTRACT(EPOCH FROM departed_at - arrived_at)::DOUBLE PRECISION) STORED
+);
+
+CREATE INDEX idx_product_dwells_arrived_at ON product_dwells (arrived_at DESC, arrival_event_id DESC);
+CREATE INDEX idx_product_dwells_product_id ON product_dwells (product_id);
+CREATE INDEX idx_product_dwells_stakeholder ON product_dwells (stakeholder_id, departed_at);
+CREATE INDEX idx_product_dwells_location ON product_dwells (location_id, departed_at);
+
+-- Events per stakeholder, day and event type
+CREATE TABLE stakeholder_throughput
+(
+    stakeholder_id UUID        NOT NULL REFERENCES stakeholders (id) ON DELETE CASCADE,
+    day            DATE        NOT NULL,
+    event_type     VARCHAR(50) NOT NULL,
+    events         BIGINT      NOT NULL,
+    PRIMARY KEY (stakeholder_id, day, event_type)
+);
+
+CREATE INDEX idx_stakeholder_throughput_day ON stakeholder_throughput (day);
+
+-- What the next refresh rebuilds
+CREATE TABLE analytics_stale_products
+(
+    product_id UUID PRIMARY KEY REFERENCES products (id) ON DELETE CASCADE,
+    marked_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE analytics_stale_days
+(
+    stakeholder_id UUID      NOT NULL REFERENCES stakeholders (id) ON DELETE CASCADE,
+    day            DATE      NOT NULL,
+    marked_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (stakeholder_id, day)
+);
+
+CREATE INDEX idx_analytics_stale_products_marked_at ON analytics_stale_products (marked_at);
+CREATE INDEX idx_analytics_stale_days_marked_at ON analytics_stale_days (marked_at);
+
+-- Existing events are picked up by the first refresh
+INSERT INTO analytics_stale_products (product_id)
+SELECT DISTINCT product_id
+FROM supply_chain_events
+WHERE product_id IS NOT NULL;
+
+INSERT INTO analytics_stale_days (stakeholder_id, day)
+SELECT DISTINCT stakeholder_id, timestamp::date
+FROM supply_chain_events
+WHERE stakeholder_id IS NOT NULL;
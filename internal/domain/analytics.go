@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TransitLeg is one shipment of a product: a shipped event and the first received event after it,
+// before the product ships again. Legs are derived from events by an analytics refresh.
+type TransitLeg struct {
+	ShipEventID           uuid.UUID  `json:"ship_event_id" gorm:"type:uuid;primaryKey"`
+	ReceiveEventID        uuid.UUID  `json:"receive_event_id" gorm:"type:uuid;not null"`
+	ProductID             uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
+	CustodyTransferID     *uuid.UUID `json:"custody_transfer_id" gorm:"type:uuid"` // when the leg was a custody transfer
+	SenderID              *uuid.UUID `json:"sender_id" gorm:"type:uuid"`
+	ReceiverID            *uuid.UUID `json:"receiver_id" gorm:"type:uuid"`
+	OriginLocationID      *uuid.UUID `json:"origin_location_id" gorm:"type:uuid"`
+	DestinationLocationID *uuid.UUID `json:"destination_location_id" gorm:"type:uuid"`
+	ShippedAt             time.Time  `json:"shipped_at" gorm:"not null"`
+	ReceivedAt            time.Time  `json:"received_at" gorm:"not null"`
+	TransitSeconds        float64    `json:"transit_seconds" gorm:"->"` // generated from the two timestamps
+}
+
+// ProductDwell is one stay of a product with a stakeholder: from the manufactured or received
+// event that brought it there to the shipped or sold event that took it away. It is open while
+// the product is still there. Dwells are derived from events by an analytics refresh.
+type ProductDwell struct {
+	ArrivalEventID   uuid.UUID  `json:"arrival_event_id" gorm:"type:uuid;primaryKey"`
+	DepartureEventID *uuid.UUID `json:"departure_event_id" gorm:"type:uuid"`
+	ProductID        uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
+	StakeholderID    *uuid.UUID `json:"stakeholder_id" gorm:"type:uuid"`
+	LocationID       *uuid.UUID `json:"location_id" gorm:"type:uuid"`
+	Location         *string    `json:"location" gorm:"type:varchar(255)"` // as recorded on the arrival event
+	ArrivedAt        time.Time  `json:"arrived_at" gorm:"not null"`
+	DepartedAt       *time.Time `json:"departed_at"`
+	DwellSeconds     *float64   `json:"dwell_seconds" gorm:"->"` // generated; nil while open
+}
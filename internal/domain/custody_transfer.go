@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CustodyTransferStatus constants
+const (
+	CustodyTransferStatusPending  = "pending"
+	CustodyTransferStatusAccepted = "accepted"
+	CustodyTransferStatusRejected = "rejected"
+	CustodyTransferStatusExpired  = "expired"
+)
+
+func IsValidCustodyTransferStatus(status string) bool {
+	switch status {
+	case CustodyTransferStatusPending,
+		CustodyTransferStatusAccepted,
+		CustodyTransferStatusRejected,
+		CustodyTransferStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// CustodyTransfer is a handoff of a product from a sender to a receiver.
+// Custody only moves once the receiver accepts it.
+type CustodyTransfer struct {
+	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ProductID         uuid.UUID  `json:"product_id" gorm:"type:uuid;index;not null"`
+	SenderID          uuid.UUID  `json:"sender_id" gorm:"type:uuid;index;not null"`
+	ReceiverID        uuid.UUID  `json:"receiver_id" gorm:"type:uuid;index;not null"`
+	Status            string     `json:"status" gorm:"type:varchar(20);default:'pending';index"` // 'pending', 'accepted', 'rejected', 'expired'
+	Quantity          *float64   `json:"quantity" gorm:"type:numeric(18,4)"`
+	ReceivedQuantity  *float64   `json:"received_quantity" gorm:"type:numeric(18,4)"`
+	Discrepancies     JSONB      `json:"discrepancies" gorm:"type:jsonb"`
+	HasDiscrepancy    bool       `json:"has_discrepancy" gorm:"default:false"`
+	Notes             *string    `json:"notes" gorm:"type:text"`
+	RejectionReason   *string    `json:"rejection_reason" gorm:"type:text"`
+	ShipEventID       *uuid.UUID `json:"ship_event_id" gorm:"type:uuid"`
+	ReceiveEventID    *uuid.UUID `json:"receive_event_id" gorm:"type:uuid"`
+	ExpiresAt         time.Time  `json:"expires_at" gorm:"not null"`
+	ExpectedArrivalAt *time.Time `json:"expected_arrival_at"` // due time of the receipt, if the sender gave one
+	RespondedAt       *time.Time `json:"responded_at"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Product  *Product     `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Sender   *Stakeholder `json:"sender,omitempty" gorm:"foreignKey:SenderID;constraint:OnDelete:CASCADE"`
+	Receiver *Stakeholder `json:"receiver,omitempty" gorm:"foreignKey:ReceiverID;constraint:OnDelete:CASCADE"`
+}
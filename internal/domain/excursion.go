@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ExcursionMetric constants name what an excursion rule measures
+const (
+	ExcursionMetricTemperature = "temperature" // degrees Celsius
+	ExcursionMetricHumidity    = "humidity"    // relative humidity in percent
+)
+
+// QuarantineScope constants: what a breached excursion rule quarantines
+const (
+	QuarantineScopeNone    = "none"
+	QuarantineScopeProduct = "product" // the product that was out of range
+	QuarantineScopeLot     = "lot"     // every product sharing its lot number
+)
+
+// ExcursionIncidentStatus constants
+const (
+	ExcursionIncidentStatusOpen   = "open"   // the product is still out of range
+	ExcursionIncidentStatusClosed = "closed" // a later measurement was back in range
+)
+
+// QuarantineStatus constants
+const (
+	QuarantineStatusActive   = "active"
+	QuarantineStatusReleased = "released"
+)
+
+func IsValidExcursionMetric(metric string) bool {
+	switch metric {
+	case ExcursionMetricTemperature,
+		ExcursionMetricHumidity:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidQuarantineScope(scope string) bool {
+	switch scope {
+	case QuarantineScopeNone,
+		QuarantineScopeProduct,
+		QuarantineScopeLot:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidExcursionIncidentStatus(status string) bool {
+	switch status {
+	case ExcursionIncidentStatusOpen,
+		ExcursionIncidentStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidQuarantineStatus(status string) bool {
+	switch status {
+	case QuarantineStatusActive,
+		QuarantineStatusReleased:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExcursionRule is the acceptable range of one metric for the products of a category, e.g.
+// 2-8 °C for vaccines. A product may leave the range for up to MaxExcursionMinutes; an excursion
+// lasting longer breaches the rule.
+type ExcursionRule struct {
+	ID                  uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name                string    `json:"name" gorm:"type:varchar(255);not null"`
+	Category            string    `json:"category" gorm:"type:varchar(100);not null;index"` // matched against Product.Category regardless of case
+	Metric              string    `json:"metric" gorm:"type:varchar(20);not null"`          // 'temperature', 'humidity'
+	Min                 *float64  `json:"min"`
+	Max                 *float64  `json:"max"`
+	MaxExcursionMinutes int       `json:"max_excursion_minutes" gorm:"not null;default:0"`
+	QuarantineScope     string    `json:"quarantine_scope" gorm:"type:varchar(20);not null;default:'product'"` // 'none', 'product', 'lot'
+	IsActive            bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt           time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// OutOfRange reports whether value breaks the rule's range
+func (r *ExcursionRule) OutOfRange(value float64) bool {
+	return (r.Min != nil && value < *r.Min) || (r.Max != nil && value > *r.Max)
+}
+
+// Deviation is how far value lies outside the rule's range, 0 inside it
+func (r *ExcursionRule) Deviation(value float64) float64 {
+	switch {
+	case r.Min != nil && value < *r.Min:
+		return *r.Min - value
+	case r.Max != nil && value > *r.Max:
+		return value - *r.Max
+	default:
+		return 0
+	}
+}
+
+// ExcursionIncident is a stretch of time a product spent outside the range of a rule. It stays
+// open until a measurement is back in range; once it has lasted longer than the rule tolerates it
+// is breached, which quarantines the rule's scope and notifies the product's custody holder.
+type ExcursionIncident struct {
+	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RuleID            uuid.UUID  `json:"rule_id" gorm:"type:uuid;not null;index"`
+	ProductID         uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
+	CustodyTransferID *uuid.UUID `json:"custody_transfer_id" gorm:"type:uuid;index"`
+	DeviceID          *uuid.UUID `json:"device_id" gorm:"type:uuid"`
+	EventID           *uuid.UUID `json:"event_id" gorm:"type:uuid"`
+	Metric            string     `json:"metric" gorm:"type:varchar(20);not null"`
+	Min               *float64   `json:"min"` // the rule's range when the incident opened
+	Max               *float64   `json:"max"`
+	Status            string     `json:"status" gorm:"type:varchar(20);default:'open';index"` // 'open', 'closed'
+	StartedAt         time.Time  `json:"started_at" gorm:"not null"`
+	LastOutAt         time.Time  `json:"last_out_at" gorm:"not null"`
+	EndedAt           *time.Time `json:"ended_at"`
+	PeakValue         float64    `json:"peak_value"` // the measurement farthest out of range
+	Measurements      int        `json:"measurements" gorm:"not null;default:0"`
+	BreachedAt        *time.Time `json:"breached_at"`
+	HolderID          *uuid.UUID `json:"holder_id" gorm:"type:uuid"` // custody holder notified of the breach
+	AcknowledgedAt    *time.Time `json:"acknowledged_at"`
+	AcknowledgedBy    *uuid.UUID `json:"acknowledged_by" gorm:"type:uuid"`
+	Notes             *string    `json:"notes" gorm:"type:text"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Rule    *ExcursionRule `json:"rule,omitempty" gorm:"foreignKey:RuleID;constraint:OnDelete:CASCADE"`
+	Product *Product       `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+}
+
+// ProductQuarantine holds a product back until someone releases it. A product has at most one
+// active quarantine.
+type ProductQuarantine struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ProductID  uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
+	IncidentID *uuid.UUID `json:"incident_id" gorm:"type:uuid;index"`
+	Reason     string     `json:"reason" gorm:"type:text;not null"`
+	Status     string     `json:"status" gorm:"type:varchar(20);default:'active';index"` // 'active', 'released'
+	ReleasedAt *time.Time `json:"released_at"`
+	ReleasedBy *uuid.UUID `json:"released_by" gorm:"type:uuid"`
+	Notes      *string    `json:"notes" gorm:"type:text"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Product *Product `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ImportJobKind constants
+const (
+	ImportJobKindProducts     = "products"
+	ImportJobKindStakeholders = "stakeholders"
+	ImportJobKindEvents       = "events"
+)
+
+// ImportJobStatus constants
+const (
+	ImportJobStatusPending   = "pending"
+	ImportJobStatusRunning   = "running"
+	ImportJobStatusCompleted = "completed"
+	ImportJobStatusFailed    = "failed"
+)
+
+func IsValidImportJobKind(kind string) bool {
+	switch kind {
+	case ImportJobKindProducts, ImportJobKindStakeholders, ImportJobKindEvents:
+		return true
+	default:
+		return false
+	}
+}
+
+// ImportJob is a bulk import of one file. A dry run validates every row and records the
+// errors without writing anything.
+type ImportJob struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Kind          string     `json:"kind" gorm:"type:varchar(20);not null;index"` // 'products', 'stakeholders', 'events'
+	Format        string     `json:"format" gorm:"type:varchar(10);not null"`     // 'csv', 'xlsx'
+	FileName      string     `json:"file_name" gorm:"type:varchar(255)"`
+	DryRun        bool       `json:"dry_run" gorm:"default:false"`
+	Status        string     `json:"status" gorm:"type:varchar(20);default:'pending';index"` // 'pending', 'running', 'completed', 'failed'
+	TotalRows     int        `json:"total_rows" gorm:"default:0"`
+	ProcessedRows int        `json:"processed_rows" gorm:"default:0"`
+	CreatedRows   int        `json:"created_rows" gorm:"default:0"`
+	UpdatedRows   int        `json:"updated_rows" gorm:"default:0"`
+	FailedRows    int        `json:"failed_rows" gorm:"default:0"`
+	Error         *string    `json:"error" gorm:"type:text"` // why a failed job stopped
+	StartedAt     *time.Time `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	HeartbeatAt   *time.Time `json:"heartbeat_at"` // last sign of life while pending or running
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ImportRowError is one problem found in one row of an import file
+type ImportRowError struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	JobID     uuid.UUID `json:"job_id" gorm:"type:uuid;not null;index"`
+	Row       int       `json:"row" gorm:"column:row_number;not null"`
+	Field     *string   `json:"field" gorm:"type:varchar(100)"` // column at fault, nil when the row as a whole was rejected
+	Message   string    `json:"message" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// LocationType constants
+const (
+	LocationTypeFactory            = "factory"
+	LocationTypeFarm               = "farm"
+	LocationTypeWarehouse          = "warehouse"
+	LocationTypeDistributionCenter = "distribution_center"
+	LocationTypePort               = "port"
+	LocationTypeStore              = "store"
+	LocationTypeOther              = "other" // also given to locations registered from free-text event locations
+)
+
+func IsValidLocationType(t string) bool {
+	switch t {
+	case LocationTypeFactory,
+		LocationTypeFarm,
+		LocationTypeWarehouse,
+		LocationTypeDistributionCenter,
+		LocationTypePort,
+		LocationTypeStore,
+		LocationTypeOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// Location is a registered facility events are recorded at. Names are unique regardless of case,
+// so a free-text event location resolves to one location.
+type Location struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name          string     `json:"name" gorm:"type:varchar(255);not null"`
+	GLN           *string    `json:"gln" gorm:"column:gln;type:varchar(13);uniqueIndex"`
+	Type          string     `json:"type" gorm:"type:varchar(50);not null"`
+	Address       *string    `json:"address" gorm:"type:text"`
+	Latitude      *float64   `json:"latitude"`
+	Longitude     *float64   `json:"longitude"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id" gorm:"type:uuid;index"` // owner
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Stakeholder *Stakeholder `json:"stakeholder,omitempty" gorm:"foreignKey:StakeholderID;constraint:OnDelete:SET NULL"`
+}
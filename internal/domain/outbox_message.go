@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// OutboxMessage is a domain event written in the same transaction as the change it describes and
+// published to the event bus afterwards, so an event is published if and only if its change commits
+type OutboxMessage struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"` // the event's message ID
+	Name         string     `json:"name" gorm:"type:varchar(100);not null"`
+	AggregateKey string     `json:"aggregate_key" gorm:"type:varchar(100);not null"`
+	Payload      string     `json:"payload" gorm:"type:text;not null"`
+	OccurredAt   time.Time  `json:"occurred_at" gorm:"not null"`
+	Sequence     *int64     `json:"sequence" gorm:"uniqueIndex"` // commit order, nil until the relay numbers it
+	PublishedAt  *time.Time `json:"published_at" gorm:"index"`   // nil until the broker accepted it
+	Attempts     int        `json:"attempts" gorm:"default:0"`   // failed publish attempts
+	LastError    *string    `json:"last_error" gorm:"type:text"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// OutboxConsumer is how far a reader of the outbox log has got, by message sequence
+type OutboxConsumer struct {
+	Name      string    `json:"name" gorm:"type:varchar(100);primaryKey"`
+	Position  int64     `json:"position" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
@@ -12,6 +12,8 @@ type Product struct {
 	Description    *string    `json:"description" gorm:"type:text"`
 	Category       *string    `json:"category" gorm:"type:varchar(100)"`
 	ManufacturerID *uuid.UUID `json:"manufacturer_id" gorm:"type:uuid;index"`
+	LotNumber      *string    `json:"lot_number" gorm:"type:varchar(100);index"`
+	SerialNumber   *string    `json:"serial_number" gorm:"type:varchar(100);index"`
 	Metadata       JSONB      `json:"metadata" gorm:"type:jsonb"`
 	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ProductContainment records a child product (item, case) packed inside a parent product (case, pallet, container).
+// A containment is active while UnpackedAt is nil.
+type ProductContainment struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ParentID      uuid.UUID  `json:"parent_id" gorm:"type:uuid;index;not null"`
+	ChildID       uuid.UUID  `json:"child_id" gorm:"type:uuid;index;not null"`
+	PackEventID   *uuid.UUID `json:"pack_event_id" gorm:"type:uuid"`
+	UnpackEventID *uuid.UUID `json:"unpack_event_id" gorm:"type:uuid"`
+	PackedAt      time.Time  `json:"packed_at" gorm:"not null"`
+	UnpackedAt    *time.Time `json:"unpacked_at"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+
+	// Relationships
+	Parent *Product `json:"parent,omitempty" gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE"`
+	Child  *Product `json:"child,omitempty" gorm:"foreignKey:ChildID;constraint:OnDelete:CASCADE"`
+}
+
+// IsActiveAt reports whether the child was inside the parent at the given time
+func (c *ProductContainment) IsActiveAt(at time.Time) bool {
+	if at.Before(c.PackedAt) {
+		return false
+	}
+	return c.UnpackedAt == nil || at.Before(*c.UnpackedAt)
+}
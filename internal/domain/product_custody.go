@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CustodyState constants
+const (
+	CustodyStateInStock   = "in_stock"
+	CustodyStateInTransit = "in_transit"
+	CustodyStateSold      = "sold"
+	CustodyStateConsumed  = "consumed"
+)
+
+func IsValidCustodyState(state string) bool {
+	switch state {
+	case CustodyStateInStock,
+		CustodyStateInTransit,
+		CustodyStateSold,
+		CustodyStateConsumed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ProductCustody is the maintained projection of where a product is and who holds it,
+// derived from its events (and those of the containers it is packed in).
+type ProductCustody struct {
+	ProductID     uuid.UUID  `json:"product_id" gorm:"type:uuid;primaryKey"`
+	HolderID      *uuid.UUID `json:"holder_id" gorm:"type:uuid;index"`
+	Location      *string    `json:"location" gorm:"type:varchar(255);index"`
+	State         string     `json:"state" gorm:"type:varchar(20);not null;index"` // 'in_stock', 'in_transit', 'sold', 'consumed'
+	ContainerID   *uuid.UUID `json:"container_id" gorm:"type:uuid;index"`
+	LastEventID   *uuid.UUID `json:"last_event_id" gorm:"type:uuid"`
+	LastEventType string     `json:"last_event_type" gorm:"type:varchar(50);not null"`
+	LastEventAt   time.Time  `json:"last_event_at" gorm:"not null"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Product *Product     `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Holder  *Stakeholder `json:"holder,omitempty" gorm:"foreignKey:HolderID;constraint:OnDelete:SET NULL"`
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// RecallStatus constants
+const (
+	RecallStatusDraft  = "draft"
+	RecallStatusActive = "active"
+	RecallStatusClosed = "closed"
+)
+
+// RecallSeverity constants
+const (
+	RecallSeverityLow      = "low"
+	RecallSeverityMedium   = "medium"
+	RecallSeverityHigh     = "high"
+	RecallSeverityCritical = "critical"
+)
+
+// RecallUnitSource records why a unit was included in a recall
+const (
+	RecallUnitSourceScope      = "scope"      // matched the recall scope directly
+	RecallUnitSourceContents   = "contents"   // packed inside an affected container
+	RecallUnitSourceDownstream = "downstream" // made from an affected unit
+)
+
+// RecallNotificationStatus constants
+const (
+	RecallNotificationStatusPending      = "pending"
+	RecallNotificationStatusSent         = "sent"
+	RecallNotificationStatusFailed       = "failed"
+	RecallNotificationStatusAcknowledged = "acknowledged"
+)
+
+func IsValidRecallStatus(status string) bool {
+	switch status {
+	case RecallStatusDraft, RecallStatusActive, RecallStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidRecallSeverity(severity string) bool {
+	switch severity {
+	case RecallSeverityLow, RecallSeverityMedium, RecallSeverityHigh, RecallSeverityCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// Recall is a campaign to pull affected units back from the supply chain.
+// Scope fields that are set are combined with AND; at least one must be set.
+type Recall struct {
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Title            string     `json:"title" gorm:"type:varchar(255);not null"`
+	Reason           *string    `json:"reason" gorm:"type:text"`
+	Severity         string     `json:"severity" gorm:"type:varchar(20);default:'medium'"`    // 'low', 'medium', 'high', 'critical'
+	Status           string     `json:"status" gorm:"type:varchar(20);default:'draft';index"` // 'draft', 'active', 'closed'
+	InitiatedBy      *uuid.UUID `json:"initiated_by" gorm:"type:uuid"`
+	ProductID        *uuid.UUID `json:"product_id" gorm:"type:uuid"`
+	LotNumber        *string    `json:"lot_number" gorm:"type:varchar(100)"`
+	SerialFrom       *string    `json:"serial_from" gorm:"type:varchar(100)"`
+	SerialTo         *string    `json:"serial_to" gorm:"type:varchar(100)"`
+	ManufacturedFrom *time.Time `json:"manufactured_from"`
+	ManufacturedTo   *time.Time `json:"manufactured_to"`
+	AffectedUnits    int        `json:"affected_units" gorm:"default:0"`
+	ActivatedAt      *time.Time `json:"activated_at"`
+	ClosedAt         *time.Time `json:"closed_at"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Initiator *Stakeholder `json:"initiator,omitempty" gorm:"foreignKey:InitiatedBy;constraint:OnDelete:SET NULL"`
+	Product   *Product     `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:SET NULL"`
+}
+
+// HasScope reports whether any scope criterion is set
+func (r *Recall) HasScope() bool {
+	return r.ProductID != nil || r.LotNumber != nil || r.SerialFrom != nil || r.SerialTo != nil ||
+		r.ManufacturedFrom != nil || r.ManufacturedTo != nil
+}
+
+// RecallUnit is a product affected by a recall, with its holder when the recall was activated
+type RecallUnit struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RecallID  uuid.UUID  `json:"recall_id" gorm:"type:uuid;uniqueIndex:idx_recall_unit;not null"`
+	ProductID uuid.UUID  `json:"product_id" gorm:"type:uuid;uniqueIndex:idx_recall_unit;not null"`
+	HolderID  *uuid.UUID `json:"holder_id" gorm:"type:uuid;index"`
+	Location  *string    `json:"location" gorm:"type:varchar(255)"`
+	State     *string    `json:"state" gorm:"type:varchar(20)"`
+	Source    string     `json:"source" gorm:"type:varchar(20);not null"` // 'scope', 'contents', 'downstream'
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+
+	// Relationships
+	Recall  *Recall      `json:"-" gorm:"foreignKey:RecallID;constraint:OnDelete:CASCADE"`
+	Product *Product     `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Holder  *Stakeholder `json:"holder,omitempty" gorm:"foreignKey:HolderID;constraint:OnDelete:SET NULL"`
+}
+
+// RecallNotification is the notice sent to one stakeholder holding affected units
+type RecallNotification struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RecallID       uuid.UUID  `json:"recall_id" gorm:"type:uuid;uniqueIndex:idx_recall_notification;not null"`
+	StakeholderID  uuid.UUID  `json:"stakeholder_id" gorm:"type:uuid;uniqueIndex:idx_recall_notification;not null"`
+	UnitCount      int        `json:"unit_count" gorm:"not null;default:0"`
+	Status         string     `json:"status" gorm:"type:varchar(20);default:'pending'"` // 'pending', 'sent', 'failed', 'acknowledged'
+	Error          *string    `json:"error" gorm:"type:text"`
+	Notes          *string    `json:"notes" gorm:"type:text"`
+	SentAt         *time.Time `json:"sent_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Recall      *Recall      `json:"recall,omitempty" gorm:"foreignKey:RecallID;constraint:OnDelete:CASCADE"`
+	Stakeholder *Stakeholder `json:"stakeholder,omitempty" gorm:"foreignKey:StakeholderID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// RouteAlertType constants
+const (
+	RouteAlertTypeRouteDeviation     = "route_deviation"     // observed farther from the planned route than its corridor
+	RouteAlertTypeGeofenceEntered    = "geofence_entered"    // observed inside one of the plan's geofences
+	RouteAlertTypeUnexpectedFacility = "unexpected_facility" // recorded at a location the plan does not expect
+)
+
+// RouteAlertStatus constants
+const (
+	RouteAlertStatusOpen         = "open"
+	RouteAlertStatusAcknowledged = "acknowledged"
+)
+
+// RouteAlertSource constants: what placed the product where the alert was raised
+const (
+	RouteAlertSourceEvent     = "event"     // a supply chain event at a registered location
+	RouteAlertSourcePing      = "ping"      // a reported position
+	RouteAlertSourceTelemetry = "telemetry" // a telemetry reading with coordinates
+)
+
+func IsValidRouteAlertType(t string) bool {
+	switch t {
+	case RouteAlertTypeRouteDeviation,
+		RouteAlertTypeGeofenceEntered,
+		RouteAlertTypeUnexpectedFacility:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidRouteAlertStatus(status string) bool {
+	switch status {
+	case RouteAlertStatusOpen,
+		RouteAlertStatusAcknowledged:
+		return true
+	default:
+		return false
+	}
+}
+
+// GeoPoint is a waypoint of a planned route
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Geofence is a circular zone a shipment must stay out of
+type Geofence struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	RadiusKm  float64 `json:"radius_km"`
+}
+
+// RoutePlan is how a shipment, a custody transfer, is expected to travel. While the transfer is
+// pending, every position of its product is checked against the route and its corridor, the
+// geofences and the expected locations; the receive event that completes it is checked too.
+// Each check is skipped when the plan leaves it empty.
+type RoutePlan struct {
+	ID                  uuid.UUID   `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CustodyTransferID   uuid.UUID   `json:"custody_transfer_id" gorm:"type:uuid;not null;uniqueIndex"`
+	ProductID           uuid.UUID   `json:"product_id" gorm:"type:uuid;not null;index"`
+	Waypoints           []GeoPoint  `json:"waypoints" gorm:"type:jsonb;serializer:json"`
+	CorridorKm          float64     `json:"corridor_km"`
+	Geofences           []Geofence  `json:"geofences" gorm:"type:jsonb;serializer:json"`
+	ExpectedLocationIDs []uuid.UUID `json:"expected_location_ids" gorm:"type:jsonb;serializer:json"`
+	CreatedAt           time.Time   `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	CustodyTransfer *CustodyTransfer `json:"custody_transfer,omitempty" gorm:"foreignKey:CustodyTransferID;constraint:OnDelete:CASCADE"`
+}
+
+// RouteAlert is a position of a shipment its route plan did not expect. One alert stays open per
+// plan, type and subject (the geofence name or the unexpected location's ID), so a shipment that
+// keeps reporting from off its route raises one alert until it is acknowledged.
+type RouteAlert struct {
+	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RoutePlanID       uuid.UUID  `json:"route_plan_id" gorm:"type:uuid;not null;index"`
+	CustodyTransferID uuid.UUID  `json:"custody_transfer_id" gorm:"type:uuid;not null;index"`
+	ProductID         uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
+	Type              string     `json:"type" gorm:"type:varchar(30);not null"` // 'route_deviation', 'geofence_entered', 'unexpected_facility'
+	Subject           string     `json:"subject" gorm:"type:varchar(255);not null;default:''"`
+	Source            string     `json:"source" gorm:"type:varchar(20);not null"` // 'event', 'ping', 'telemetry'
+	EventID           *uuid.UUID `json:"event_id" gorm:"type:uuid"`
+	LocationID        *uuid.UUID `json:"location_id" gorm:"type:uuid"`
+	Latitude          *float64   `json:"latitude"`
+	Longitude         *float64   `json:"longitude"`
+	DistanceKm        *float64   `json:"distance_km"` // from the route for a deviation, from the centre for a geofence
+	Message           string     `json:"message" gorm:"type:text;not null"`
+	Status            string     `json:"status" gorm:"type:varchar(20);default:'open';index"` // 'open', 'acknowledged'
+	ObservedAt        time.Time  `json:"observed_at" gorm:"not null"`
+	AcknowledgedAt    *time.Time `json:"acknowledged_at"`
+	AcknowledgedBy    *uuid.UUID `json:"acknowledged_by" gorm:"type:uuid"`
+	Notes             *string    `json:"notes" gorm:"type:text"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+
+	// Relationships
+	Product *Product `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+}
@@ -11,6 +11,9 @@ const (
 	EventTypeShipped      = "shipped"
 	EventTypeReceived     = "received"
 	EventTypeSold         = "sold"
+	EventTypePack         = "pack"
+	EventTypeUnpack       = "unpack"
+	EventTypeTransformed  = "transformed"
 )
 
 func IsValidEventType(t string) bool {
@@ -18,7 +21,10 @@ func IsValidEventType(t string) bool {
 	case EventTypeManufactured,
 		EventTypeShipped,
 		EventTypeReceived,
-		EventTypeSold:
+		EventTypeSold,
+		EventTypePack,
+		EventTypeUnpack,
+		EventTypeTransformed:
 		return true
 	default:
 		return false
@@ -30,7 +36,8 @@ type SupplyChainEvent struct {
 	ProductID      *uuid.UUID `json:"product_id" gorm:"type:uuid;index"`
 	StakeholderID  *uuid.UUID `json:"stakeholder_id" gorm:"type:uuid;index"`
 	EventType      string     `json:"event_type" gorm:"type:varchar(50);not null"`
-	Location       *string    `json:"location" gorm:"type:varchar(255)"`
+	Location       *string    `json:"location" gorm:"type:varchar(255)"` // as recorded; the registered name when LocationID is set
+	LocationID     *uuid.UUID `json:"location_id" gorm:"type:uuid;index"`
 	Timestamp      time.Time  `json:"timestamp" gorm:"not null"`
 	Metadata       JSONB      `json:"metadata" gorm:"type:jsonb"`
 	BlockchainHash *string    `json:"blockchain_hash" gorm:"type:varchar(66)"`
@@ -40,4 +47,5 @@ type SupplyChainEvent struct {
 	// Relationships
 	Product     *Product     `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
 	Stakeholder *Stakeholder `json:"stakeholder,omitempty" gorm:"foreignKey:StakeholderID;constraint:OnDelete:CASCADE"`
+	Site        *Location    `json:"site,omitempty" gorm:"foreignKey:LocationID;constraint:OnDelete:SET NULL"`
 }
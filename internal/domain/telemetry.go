@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SensorDevice is a data logger or tracker that reports telemetry. While it is attached to a
+// product its readings are linked to that product and to the shipment carrying it.
+type SensorDevice struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Serial        string     `json:"serial" gorm:"type:varchar(100);uniqueIndex;not null"`
+	Name          *string    `json:"name" gorm:"type:varchar(255)"`
+	Model         *string    `json:"model" gorm:"type:varchar(100)"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id" gorm:"type:uuid;index"` // owner
+	ProductID     *uuid.UUID `json:"product_id" gorm:"type:uuid;index"`     // attached product
+	AttachedAt    *time.Time `json:"attached_at"`
+	LastSeenAt    *time.Time `json:"last_seen_at"` // newest reading received
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Stakeholder *Stakeholder `json:"stakeholder,omitempty" gorm:"foreignKey:StakeholderID;constraint:OnDelete:SET NULL"`
+	Product     *Product     `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:SET NULL"`
+}
+
+// TelemetryReading is one measurement of a device. A device reports at most one reading per
+// instant, so a batch sent again is not stored twice.
+type TelemetryReading struct {
+	ID                int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	DeviceID          uuid.UUID  `json:"device_id" gorm:"type:uuid;not null"`
+	ProductID         *uuid.UUID `json:"product_id" gorm:"type:uuid"`
+	CustodyTransferID *uuid.UUID `json:"custody_transfer_id" gorm:"type:uuid"` // shipment in flight when it was received
+	RecordedAt        time.Time  `json:"recorded_at" gorm:"not null"`
+	Temperature       *float64   `json:"temperature"` // degrees Celsius
+	Humidity          *float64   `json:"humidity"`    // percent relative humidity
+	Latitude          *float64   `json:"latitude"`
+	Longitude         *float64   `json:"longitude"`
+	ReceivedAt        time.Time  `json:"received_at" gorm:"not null"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TransformationDirection constants
+const (
+	TransformationDirectionInput  = "input"
+	TransformationDirectionOutput = "output"
+)
+
+// TransformationLine is one consumed input or produced output of a transformation event
+type TransformationLine struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID   uuid.UUID `json:"event_id" gorm:"type:uuid;index;not null"`
+	ProductID uuid.UUID `json:"product_id" gorm:"type:uuid;index;not null"`
+	Direction string    `json:"direction" gorm:"type:varchar(10);not null"` // 'input', 'output'
+	Quantity  float64   `json:"quantity" gorm:"type:numeric(18,4);not null"`
+	Unit      *string   `json:"unit" gorm:"type:varchar(20)"`
+	LotNumber *string   `json:"lot_number" gorm:"type:varchar(100);index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	// Relationships
+	Event   *SupplyChainEvent `json:"event,omitempty" gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"`
+	Product *Product          `json:"product,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// WebhookTopic constants name the changes a subscription can be notified of
+const (
+	WebhookTopicEventCreated      = "event.created"
+	WebhookTopicEventVerified     = "event.verified"
+	WebhookTopicTransactionStatus = "transaction.status_changed"
+	WebhookTopicRecallNotice      = "recall.notice"
+	WebhookTopicExcursionBreached = "excursion.breached"
+)
+
+// WebhookDeliveryStatus constants
+const (
+	WebhookDeliveryStatusPending   = "pending"   // waiting for its first or next attempt
+	WebhookDeliveryStatusSucceeded = "succeeded" // the endpoint answered 2xx
+	WebhookDeliveryStatusFailed    = "failed"    // retries exhausted or the endpoint was disabled
+)
+
+func IsValidWebhookTopic(topic string) bool {
+	switch topic {
+	case WebhookTopicEventCreated,
+		WebhookTopicEventVerified,
+		WebhookTopicTransactionStatus,
+		WebhookTopicRecallNotice,
+		WebhookTopicExcursionBreached:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidWebhookDeliveryStatus(status string) bool {
+	switch status {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// WebhookSubscription sends a stakeholder's endpoint the changes to events that touch it: events it
+// recorded and events on products it manufactured or currently holds, and the notices addressed to
+// it. Empty Topics, EventTypes and ProductIDs match everything; EventTypes only filter events.
+type WebhookSubscription struct {
+	ID                  uuid.UUID   `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	StakeholderID       uuid.UUID   `json:"stakeholder_id" gorm:"type:uuid;not null;index"`
+	URL                 string      `json:"url" gorm:"type:text;not null"`
+	Secret              string      `json:"-" gorm:"type:varchar(100);not null"`
+	Topics              []string    `json:"topics" gorm:"type:jsonb;serializer:json"`
+	EventTypes          []string    `json:"event_types" gorm:"type:jsonb;serializer:json"`
+	ProductIDs          []uuid.UUID `json:"product_ids" gorm:"type:jsonb;serializer:json"`
+	IsActive            bool        `json:"is_active" gorm:"default:true;index"`
+	ConsecutiveFailures int         `json:"consecutive_failures" gorm:"default:0"`
+	DisabledAt          *time.Time  `json:"disabled_at"`
+	DisabledReason      *string     `json:"disabled_reason" gorm:"type:text"`
+	CreatedAt           time.Time   `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Stakeholder *Stakeholder `json:"stakeholder,omitempty" gorm:"foreignKey:StakeholderID;constraint:OnDelete:CASCADE"`
+}
+
+// WebhookDelivery is one notification to one subscription and the outcome of its latest attempt.
+// Payload is kept byte for byte so retries and replays carry exactly what was first signed.
+type WebhookDelivery struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	SubscriptionID uuid.UUID  `json:"subscription_id" gorm:"type:uuid;not null;index"`
+	Topic          string     `json:"topic" gorm:"type:varchar(50);not null"`
+	EventID        *uuid.UUID `json:"event_id" gorm:"type:uuid;index"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"type:varchar(20);default:'pending';index"` // 'pending', 'succeeded', 'failed'
+	Attempts       int        `json:"attempts" gorm:"default:0"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at" gorm:"index"` // nil once the delivery is finished
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int       `json:"response_status"`
+	LastError      *string    `json:"last_error" gorm:"type:text"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	ReplayOf       *uuid.UUID `json:"replay_of" gorm:"type:uuid"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Subscription *WebhookSubscription `json:"subscription,omitempty" gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE"`
+}
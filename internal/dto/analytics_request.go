@@ -0,0 +1,122 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// AnalyticsQuery selects the transit legs or dwells a report summarises: those completed within
+// From and To, narrowed by the IDs given, and grouped by GroupBy. Throughput reports use Period
+// instead of GroupBy.
+type AnalyticsQuery struct {
+	From          *time.Time `json:"from"`
+	To            *time.Time `json:"to"`
+	ProductID     *uuid.UUID `json:"product_id"`
+	SenderID      *uuid.UUID `json:"sender_id"`
+	ReceiverID    *uuid.UUID `json:"receiver_id"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+	LocationID    *uuid.UUID `json:"location_id"`
+	GroupBy       string     `json:"group_by"` // transit: 'route', 'lane', 'sender', 'receiver', 'none'; dwell: 'stakeholder', 'location', 'none'
+	Period        string     `json:"period"`   // throughput: 'day', 'week', 'month'
+	Limit         int        `json:"limit"`    // most groups or points returned
+}
+
+// DurationStats summarises durations in hours. The statistics are nil when Count is zero.
+type DurationStats struct {
+	Count int64    `json:"count" gorm:"column:count"`
+	Mean  *float64 `json:"mean_hours" gorm:"column:mean_hours"`
+	Min   *float64 `json:"min_hours" gorm:"column:min_hours"`
+	P50   *float64 `json:"p50_hours" gorm:"column:p50_hours"`
+	P75   *float64 `json:"p75_hours" gorm:"column:p75_hours"`
+	P90   *float64 `json:"p90_hours" gorm:"column:p90_hours"`
+	P95   *float64 `json:"p95_hours" gorm:"column:p95_hours"`
+	P99   *float64 `json:"p99_hours" gorm:"column:p99_hours"`
+	Max   *float64 `json:"max_hours" gorm:"column:max_hours"`
+}
+
+// TransitStats summarises the transit times of one group of legs; the IDs it is not grouped by
+// are left out
+type TransitStats struct {
+	SenderID              *uuid.UUID `json:"sender_id,omitempty"`
+	ReceiverID            *uuid.UUID `json:"receiver_id,omitempty"`
+	OriginLocationID      *uuid.UUID `json:"origin_location_id,omitempty"`
+	DestinationLocationID *uuid.UUID `json:"destination_location_id,omitempty"`
+	DurationStats
+}
+
+// DwellStats summarises the completed stays of one group; the IDs it is not grouped by are left
+// out
+type DwellStats struct {
+	StakeholderID *uuid.UUID `json:"stakeholder_id,omitempty"`
+	LocationID    *uuid.UUID `json:"location_id,omitempty"`
+	DurationStats
+}
+
+type TransitReport struct {
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	GroupBy string          `json:"group_by"`
+	Overall *DurationStats  `json:"overall"`
+	Groups  []*TransitStats `json:"groups"` // busiest first
+}
+
+type DwellReport struct {
+	From    time.Time      `json:"from"`
+	To      time.Time      `json:"to"`
+	GroupBy string         `json:"group_by"`
+	Overall *DurationStats `json:"overall"`
+	Groups  []*DwellStats  `json:"groups"` // busiest first
+	Open    int64          `json:"open"`   // stays still open among those selected, whenever they began
+}
+
+// ThroughputPoint counts the events of one stakeholder in one period by type
+type ThroughputPoint struct {
+	StakeholderID uuid.UUID `json:"stakeholder_id"`
+	PeriodStart   time.Time `json:"period_start"`
+	Manufactured  int64     `json:"manufactured"`
+	Shipped       int64     `json:"shipped"`
+	Received      int64     `json:"received"`
+	Sold          int64     `json:"sold"`
+	Total         int64     `json:"total"` // of every event type
+}
+
+type ThroughputReport struct {
+	From   time.Time          `json:"from"`
+	To     time.Time          `json:"to"`
+	Period string             `json:"period"`
+	Points []*ThroughputPoint `json:"points"` // by period, then stakeholder
+}
+
+// StakeholderDay is a day of a stakeholder's events whose throughput is to be rebuilt
+type StakeholderDay struct {
+	StakeholderID uuid.UUID `json:"stakeholder_id"`
+	Day           time.Time `json:"day"`
+}
+
+// AnalyticsBacklog counts what the next analytics refresh will rebuild
+type AnalyticsBacklog struct {
+	Products int64 `json:"products"`
+	Days     int64 `json:"days"`
+}
+
+// AnalyticsRefresh counts what one refresh rebuilt and what is left
+type AnalyticsRefresh struct {
+	Products  int               `json:"products"`
+	Days      int               `json:"days"`
+	Remaining *AnalyticsBacklog `json:"remaining"`
+}
+
+// AnalyticsDashboard sums up the supply chain over a period
+type AnalyticsDashboard struct {
+	From          time.Time         `json:"from"`
+	To            time.Time         `json:"to"`
+	Products      int64             `json:"products"`
+	Stakeholders  int64             `json:"stakeholders"`
+	Events        int64             `json:"events"`     // recorded in the period
+	InTransit     int64             `json:"in_transit"` // products in transit now
+	Throughput    map[string]int64  `json:"throughput"` // events in the period by type
+	Transit       *DurationStats    `json:"transit"`
+	Dwell         *DurationStats    `json:"dwell"`
+	BusiestRoutes []*TransitStats   `json:"busiest_routes"`
+	Backlog       *AnalyticsBacklog `json:"backlog"` // not yet reflected in the figures above
+}
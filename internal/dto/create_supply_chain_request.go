@@ -9,9 +9,29 @@ import (
 type CreateSupplyChainEventRequest struct {
 	ProductID      *uuid.UUID   `json:"product_id"`
 	StakeholderID  *uuid.UUID   `json:"stakeholder_id"`
-	EventType      string       `json:"event_type" validate:"required,oneof=manufactured shipped received sold"`
-	Location       *string      `json:"location"`
+	EventType      string       `json:"event_type" validate:"required,oneof=manufactured shipped received sold pack unpack transformed"`
+	Location       *string      `json:"location"`    // free text, a GLN or an SGLN URN; resolved to a registered location
+	LocationID     *uuid.UUID   `json:"location_id"` // a registered location; takes precedence over location
 	Timestamp      time.Time    `json:"timestamp" validate:"required"`
 	Metadata       domain.JSONB `json:"metadata"`
 	BlockchainHash *string      `json:"blockchain_hash"`
+
+	// ChildIDs lists the products packed into (pack) or taken out of (unpack) ProductID.
+	// An unpack without ChildIDs empties the container.
+	ChildIDs []uuid.UUID `json:"child_ids"`
+
+	// Inputs and Outputs describe what a transformed event consumed and produced
+	Inputs  []TransformationLineRequest `json:"inputs" validate:"dive"`
+	Outputs []TransformationLineRequest `json:"outputs" validate:"dive"`
+
+	// CustodyTransferID is the accepted transfer a received event records. Clients cannot set
+	// it: a received event without one accepts the product's pending transfer to its stakeholder.
+	CustodyTransferID *uuid.UUID `json:"-"`
+}
+
+type TransformationLineRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  float64   `json:"quantity" validate:"gt=0"`
+	Unit      *string   `json:"unit"`
+	LotNumber *string   `json:"lot_number"`
 }
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"time"
+)
+
+type CreateCustodyTransferRequest struct {
+	ProductID         uuid.UUID    `json:"product_id" validate:"required"`
+	SenderID          uuid.UUID    `json:"sender_id" validate:"required"`
+	ReceiverID        uuid.UUID    `json:"receiver_id" validate:"required"`
+	Quantity          *float64     `json:"quantity" validate:"omitempty,gt=0"`
+	Location          *string      `json:"location"`
+	Notes             *string      `json:"notes"`
+	Metadata          domain.JSONB `json:"metadata"`
+	ExpiresAt         *time.Time   `json:"expires_at"`
+	ExpectedArrivalAt *time.Time   `json:"expected_arrival_at"`
+}
+
+type AcceptCustodyTransferRequest struct {
+	StakeholderID    uuid.UUID    `json:"stakeholder_id" validate:"required"`
+	ReceivedQuantity *float64     `json:"received_quantity" validate:"omitempty,gte=0"`
+	Location         *string      `json:"location"`
+	Discrepancies    domain.JSONB `json:"discrepancies"` // e.g. {"damaged": 2, "missing": 1}
+	Notes            *string      `json:"notes"`
+}
+
+type RejectCustodyTransferRequest struct {
+	StakeholderID uuid.UUID `json:"stakeholder_id" validate:"required"`
+	Reason        *string   `json:"reason"`
+}
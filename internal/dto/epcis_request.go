@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/epcis"
+	"time"
+)
+
+// EPCISQuery holds the SimpleEventQuery parameters that are supported
+type EPCISQuery struct {
+	EventTypes    []string   `json:"eventType"`  // EPCIS event types, e.g. ObjectEvent
+	BizStep       *string    `json:"EQ_bizStep"` // bare or URI form
+	EPC           *string    `json:"MATCH_epc"`  // any EPC form understood by epcis.ParseEPC
+	BizLocation   *string    `json:"EQ_bizLocation"`
+	FromTime      *time.Time `json:"GE_eventTime"`
+	ToTime        *time.Time `json:"LT_eventTime"`
+	PerPage       int        `json:"perPage"`
+	NextPageToken string     `json:"nextPageToken"`
+}
+
+type EPCISQueryResult struct {
+	Events        []*epcis.Event `json:"events"`
+	NextPageToken string         `json:"next_page_token,omitempty"`
+}
+
+// EPCISCaptureResult reports the outcome of every event in a capture document
+type EPCISCaptureResult struct {
+	Received   int                  `json:"received"`
+	Captured   int                  `json:"captured"`
+	Duplicates int                  `json:"duplicates"`
+	Failed     int                  `json:"failed"`
+	EventIDs   []uuid.UUID          `json:"event_ids"`
+	Errors     []*EPCISCaptureError `json:"errors"`
+}
+
+type EPCISCaptureError struct {
+	Index   int    `json:"index"`
+	EventID string `json:"event_id,omitempty"`
+	Error   string `json:"error"`
+}
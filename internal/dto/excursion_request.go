@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateExcursionRuleRequest struct {
+	Name                string   `json:"name" validate:"required"`
+	Category            string   `json:"category" validate:"required"`
+	Metric              string   `json:"metric" validate:"required"`
+	Min                 *float64 `json:"min"`
+	Max                 *float64 `json:"max"`
+	MaxExcursionMinutes int      `json:"max_excursion_minutes"`
+	QuarantineScope     *string  `json:"quarantine_scope"` // defaults to 'product'
+}
+
+// UpdateExcursionRuleRequest changes a rule; the range applies to incidents opened afterwards
+type UpdateExcursionRuleRequest struct {
+	Name                *string  `json:"name"`
+	Min                 *float64 `json:"min"`
+	Max                 *float64 `json:"max"`
+	MaxExcursionMinutes *int     `json:"max_excursion_minutes"`
+	QuarantineScope     *string  `json:"quarantine_scope"`
+	IsActive            *bool    `json:"is_active"`
+}
+
+type AcknowledgeExcursionRequest struct {
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+	Notes         *string    `json:"notes"`
+}
+
+type ReleaseQuarantineRequest struct {
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+	Notes         *string    `json:"notes"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// StartImportRequest describes an uploaded import file; the format follows the file extension
+type StartImportRequest struct {
+	Kind     string `json:"kind"` // 'products', 'stakeholders', 'events'
+	FileName string `json:"file_name"`
+	DryRun   bool   `json:"dry_run"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/google/uuid"
+
+// CreateLabelSheetRequest lists the products to print, in sheet order
+type CreateLabelSheetRequest struct {
+	ProductIDs []uuid.UUID `json:"product_ids"`
+}
+
+// ProductLabel is what is printed on a product or lot label. The GS1 fields are only set when the
+// product's SKU is a GTIN; otherwise the QR code carries the product's trace URL.
+type ProductLabel struct {
+	ProductID         uuid.UUID `json:"product_id"`
+	SKU               string    `json:"sku"`
+	Name              string    `json:"name"`
+	Lot               string    `json:"lot,omitempty"`
+	Serial            string    `json:"serial,omitempty"`
+	URL               string    `json:"url"` // encoded in the QR code
+	DigitalLink       string    `json:"digital_link,omitempty"`
+	ElementString     string    `json:"element_string,omitempty"`     // human-readable, e.g. (01)...(21)...
+	GS1128Payload     string    `json:"gs1_128_payload,omitempty"`    // ]C1 data with GS separators
+	DataMatrixPayload string    `json:"datamatrix_payload,omitempty"` // ]d2 data with GS separators
+}
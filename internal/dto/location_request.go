@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+)
+
+type CreateLocationRequest struct {
+	Name          string     `json:"name" validate:"required"`
+	GLN           *string    `json:"gln"`
+	Type          string     `json:"type" validate:"required,oneof=factory farm warehouse distribution_center port store other"`
+	Address       *string    `json:"address"`
+	Latitude      *float64   `json:"latitude"` // latitude and longitude are set together
+	Longitude     *float64   `json:"longitude"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+}
+
+// UpdateLocationRequest changes the fields that are set. Events keep the location name they
+// were recorded with.
+type UpdateLocationRequest struct {
+	Name          *string    `json:"name"`
+	GLN           *string    `json:"gln"`
+	Type          *string    `json:"type"`
+	Address       *string    `json:"address"`
+	Latitude      *float64   `json:"latitude"`
+	Longitude     *float64   `json:"longitude"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+}
+
+// GeoRadius selects what lies within RadiusKm kilometres of a point
+type GeoRadius struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	RadiusKm  float64 `json:"radius_km"`
+}
+
+type NearbyLocation struct {
+	*domain.Location
+	DistanceKm float64 `json:"distance_km"`
+}
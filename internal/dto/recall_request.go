@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateRecallRequest struct {
+	Title            string     `json:"title" validate:"required,min=1,max=255"`
+	Reason           *string    `json:"reason"`
+	Severity         string     `json:"severity" validate:"omitempty,oneof=low medium high critical"`
+	InitiatedBy      *uuid.UUID `json:"initiated_by"`
+	ProductID        *uuid.UUID `json:"product_id"`
+	LotNumber        *string    `json:"lot_number"`
+	SerialFrom       *string    `json:"serial_from"`
+	SerialTo         *string    `json:"serial_to"`
+	ManufacturedFrom *time.Time `json:"manufactured_from"`
+	ManufacturedTo   *time.Time `json:"manufactured_to"`
+}
+
+type AcknowledgeRecallRequest struct {
+	StakeholderID uuid.UUID `json:"stakeholder_id" validate:"required"`
+	Notes         *string   `json:"notes"`
+}
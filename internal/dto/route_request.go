@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"time"
+)
+
+// SetRoutePlanRequest replaces the route plan of a shipment. A corridor needs at least two
+// waypoints; the geofences and expected locations may be given without a route.
+type SetRoutePlanRequest struct {
+	Waypoints           []domain.GeoPoint `json:"waypoints"`
+	CorridorKm          float64           `json:"corridor_km"`
+	Geofences           []domain.Geofence `json:"geofences"`
+	ExpectedLocationIDs []uuid.UUID       `json:"expected_location_ids"`
+}
+
+// PositionReport is where a product was at a moment, as a tracker or driver app reports it
+type PositionReport struct {
+	ProductID  uuid.UUID  `json:"product_id" validate:"required"`
+	Latitude   float64    `json:"latitude"`
+	Longitude  float64    `json:"longitude"`
+	RecordedAt *time.Time `json:"recorded_at"` // defaults to now
+}
+
+// PositionResult lists the alerts a position raised
+type PositionResult struct {
+	Evaluated int                  `json:"evaluated"` // route plans the position was checked against
+	Alerts    []*domain.RouteAlert `json:"alerts"`
+}
+
+type AcknowledgeRouteAlertRequest struct {
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+	Notes         *string    `json:"notes"`
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"time"
+)
+
+// ScorecardQuery selects the stakeholders scored, all of Type or just StakeholderID, and the
+// period their activity is taken from. With Period set, metrics are broken down per day, week or
+// month.
+type ScorecardQuery struct {
+	Type          *string    `json:"type"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+	From          *time.Time `json:"from"`
+	To            *time.Time `json:"to"`
+	Period        string     `json:"period"` // trend: 'day', 'week', 'month'
+	Limit         int        `json:"limit"`
+}
+
+// ScorecardMetrics is the performance of a stakeholder over a period. Transfers are the custody
+// transfers it sent and receipts those it received, by when they were created; a rate is nil
+// when there is nothing to take it over.
+type ScorecardMetrics struct {
+	StakeholderID    uuid.UUID  `json:"-"`
+	PeriodStart      *time.Time `json:"period_start,omitempty"` // set in a trend
+	TransfersSent    int64      `json:"transfers_sent"`
+	Accepted         int64      `json:"accepted"`
+	Rejected         int64      `json:"rejected"`
+	Expired          int64      `json:"expired"`
+	Discrepancies    int64      `json:"discrepancies"`    // accepted with a discrepancy
+	ReceiptsDue      int64      `json:"receipts_due"`     // transfers it accepted that had an expected arrival
+	OnTimeReceipts   int64      `json:"on_time_receipts"` // of those, received by the expected arrival
+	OnTimeRate       *float64   `json:"on_time_rate"`     // of the receipts due
+	DiscrepancyRate  *float64   `json:"discrepancy_rate"`
+	RejectRate       *float64   `json:"reject_rate"` // of those accepted, rejected or expired
+	Events           int64      `json:"events"`
+	VerifiedEvents   int64      `json:"verified_events"`
+	VerificationRate *float64   `json:"verification_rate"`
+	Stays            int64      `json:"stays"`              // products that left it in the period
+	AvgHandlingHours *float64   `json:"avg_handling_hours"` // mean time those products stayed
+}
+
+// Scorecard rates a stakeholder against the other stakeholders of its type. Score is the mean of
+// its on-time, verification, discrepancy-free, reject-free and handling speed scores, each from 0
+// to 1; Rank is its place by score among Peers, and both are nil without any activity to score.
+type Scorecard struct {
+	Stakeholder *domain.Stakeholder `json:"stakeholder"`
+	From        time.Time           `json:"from"`
+	To          time.Time           `json:"to"`
+	Metrics     *ScorecardMetrics   `json:"metrics"`
+	Score       *float64            `json:"score"`
+	Rank        *int                `json:"rank"`
+	Peers       int                 `json:"peers"`
+	Trend       []*ScorecardMetrics `json:"trend,omitempty"`
+}
+
+type ScorecardRanking struct {
+	Type       string       `json:"type"`
+	From       time.Time    `json:"from"`
+	To         time.Time    `json:"to"`
+	Peers      int          `json:"peers"`
+	Scorecards []*Scorecard `json:"scorecards"` // best first; unscored last
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"time"
+)
+
+// Search result types
+const (
+	SearchProducts     = "products"
+	SearchStakeholders = "stakeholders"
+	SearchLocations    = "locations"
+)
+
+// SearchRequest is a free-text query, in web search syntax ("quoted phrases", -excluded, or)
+type SearchRequest struct {
+	Query string   `json:"q"`
+	Types []string `json:"types"` // every type when empty
+	Limit int      `json:"limit"` // per type
+}
+
+// SearchResults holds the best matches of each requested type, best first. Highlights mark the
+// matched words with <mark>...</mark>.
+type SearchResults struct {
+	Query        string            `json:"query"`
+	Products     []*ProductHit     `json:"products,omitempty"`
+	Stakeholders []*StakeholderHit `json:"stakeholders,omitempty"`
+	Locations    []*LocationHit    `json:"locations,omitempty"`
+}
+
+type ProductHit struct {
+	Product    *domain.Product   `json:"product"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"` // name, and description when set
+}
+
+type StakeholderHit struct {
+	Stakeholder *domain.Stakeholder `json:"stakeholder"`
+	Score       float64             `json:"score"`
+	Highlights  map[string]string   `json:"highlights"` // name, and address when set
+}
+
+// LocationHit is a distinct event location
+type LocationHit struct {
+	Location  string    `json:"location"`
+	Highlight string    `json:"highlight"`
+	Score     float64   `json:"score"`
+	Events    int64     `json:"events"`
+	LastSeen  time.Time `json:"last_seen"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CreateStreamTokenRequest issues a token for the WebSocket/SSE stream. With a stakeholder the
+// token only sees events that stakeholder is involved in.
+type CreateStreamTokenRequest struct {
+	StakeholderID *uuid.UUID `json:"stakeholder_id,omitempty"`
+	TTLSeconds    int        `json:"ttl_seconds,omitempty"` // default 1 hour, at most 24 hours
+}
+
+type StreamToken struct {
+	Token         string     `json:"token"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id,omitempty"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+}
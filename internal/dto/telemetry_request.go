@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"time"
+)
+
+type CreateSensorDeviceRequest struct {
+	Serial        string     `json:"serial" validate:"required"`
+	Name          *string    `json:"name"`
+	Model         *string    `json:"model"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+	ProductID     *uuid.UUID `json:"product_id"` // attach right away
+}
+
+type UpdateSensorDeviceRequest struct {
+	Name          *string    `json:"name"`
+	Model         *string    `json:"model"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+}
+
+type AttachSensorDeviceRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+}
+
+// TelemetryReadingInput is one reading as a device reports it. The device is named by ID or
+// serial; the product defaults to the one the device is attached to.
+type TelemetryReadingInput struct {
+	DeviceID    *uuid.UUID `json:"device_id"`
+	Serial      *string    `json:"serial"`
+	ProductID   *uuid.UUID `json:"product_id"`
+	RecordedAt  time.Time  `json:"recorded_at"`
+	Temperature *float64   `json:"temperature"`
+	Humidity    *float64   `json:"humidity"`
+	Latitude    *float64   `json:"latitude"`
+	Longitude   *float64   `json:"longitude"`
+}
+
+type TelemetryBatch struct {
+	Readings []*TelemetryReadingInput `json:"readings"`
+}
+
+// TelemetryIngestResult counts what became of a batch. Duplicates were stored before.
+type TelemetryIngestResult struct {
+	Received   int                         `json:"received"`
+	Stored     int                         `json:"stored"`
+	Duplicates int                         `json:"duplicates"`
+	Rejected   []*TelemetryRejection       `json:"rejected"`
+	Alerts     []*domain.RouteAlert        `json:"alerts"`
+	Excursions []*domain.ExcursionIncident `json:"excursions"` // incidents the batch opened or breached
+}
+
+type TelemetryRejection struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// TelemetryQuery selects the readings of a device, product or shipment between From and To
+type TelemetryQuery struct {
+	DeviceID          *uuid.UUID    `json:"device_id"`
+	ProductID         *uuid.UUID    `json:"product_id"`
+	CustodyTransferID *uuid.UUID    `json:"custody_transfer_id"`
+	From              *time.Time    `json:"from"`
+	To                *time.Time    `json:"to"`
+	Bucket            time.Duration `json:"bucket"` // downsampling interval of a series
+	Limit             int           `json:"limit"`
+}
+
+// TelemetryBucket summarises the readings of one interval. Latitude and Longitude are the last
+// position reported in it.
+type TelemetryBucket struct {
+	Start          time.Time `json:"start"`
+	Readings       int       `json:"readings"`
+	MinTemperature *float64  `json:"min_temperature"`
+	MaxTemperature *float64  `json:"max_temperature"`
+	AvgTemperature *float64  `json:"avg_temperature"`
+	MinHumidity    *float64  `json:"min_humidity"`
+	MaxHumidity    *float64  `json:"max_humidity"`
+	AvgHumidity    *float64  `json:"avg_humidity"`
+	Latitude       *float64  `json:"latitude"`
+	Longitude      *float64  `json:"longitude"`
+}
+
+type TelemetrySeries struct {
+	From    time.Time          `json:"from"`
+	To      time.Time          `json:"to"`
+	Bucket  string             `json:"bucket"`
+	Buckets []*TelemetryBucket `json:"buckets"`
+}
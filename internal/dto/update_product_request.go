@@ -10,5 +10,7 @@ type UpdateProductRequest struct {
 	Description    *string      `json:"description"`
 	Category       *string      `json:"category"`
 	ManufacturerID *uuid.UUID   `json:"manufacturer_id"`
+	LotNumber      *string      `json:"lot_number"`
+	SerialNumber   *string      `json:"serial_number"`
 	Metadata       domain.JSONB `json:"metadata"`
 }
@@ -3,13 +3,97 @@ package dto
 import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
 	"time"
 )
 
 // SupplyChainTrace represents a complete trace of product through supply chain
 type SupplyChainTrace struct {
-	Product *domain.Product            `json:"product"`
-	Events  []*domain.SupplyChainEvent `json:"events"`
+	Product         *domain.Product            `json:"product"`
+	Events          []*domain.SupplyChainEvent `json:"events"`
+	InheritedEvents []*InheritedEvent          `json:"inherited_events,omitempty"`
+	Telemetry       *TelemetrySeries           `json:"telemetry,omitempty"`
+}
+
+// InheritedEvent is an event recorded against a container while the product was packed inside it
+type InheritedEvent struct {
+	ContainerID uuid.UUID                `json:"container_id"`
+	Event       *domain.SupplyChainEvent `json:"event"`
+}
+
+// ContainmentSnapshot describes where a product sat in the packing hierarchy at a point in time
+type ContainmentSnapshot struct {
+	ProductID  uuid.UUID                    `json:"product_id"`
+	At         time.Time                    `json:"at"`
+	Containers []*domain.ProductContainment `json:"containers"` // innermost container first
+	Contents   []*domain.ProductContainment `json:"contents"`
+}
+
+// GenealogyEdge links a known product (and the lot it was in on that transformation) to a line on the other side
+type GenealogyEdge struct {
+	ProductID uuid.UUID                  `json:"product_id"`
+	LotNumber *string                    `json:"lot_number,omitempty"`
+	Line      *domain.TransformationLine `json:"line"`
+}
+
+// ProductLot is one lot of one product; an empty LotNumber stands for lines without a lot
+type ProductLot struct {
+	ProductID uuid.UUID `json:"product_id"`
+	LotNumber string    `json:"lot_number"`
+}
+
+// GenealogyNode is a product in a genealogy tree, with the transformation line that linked it to its parent
+type GenealogyNode struct {
+	Product   *domain.Product  `json:"product"`
+	EventID   *uuid.UUID       `json:"event_id,omitempty"`
+	Quantity  float64          `json:"quantity,omitempty"`
+	Unit      *string          `json:"unit,omitempty"`
+	LotNumber *string          `json:"lot_number,omitempty"`
+	Depth     int              `json:"depth"`
+	Children  []*GenealogyNode `json:"children,omitempty"`
+}
+
+// Genealogy is the upstream (ingredients) or downstream (finished goods) tree of a product
+type Genealogy struct {
+	Direction string            `json:"direction"`
+	Root      *GenealogyNode    `json:"root"`
+	Products  []*domain.Product `json:"products"` // every product reached, without duplicates
+	Lots      []string          `json:"lots"`
+}
+
+// RecallReport summarises the reach of a recall and how many notified stakeholders have acknowledged it
+type RecallReport struct {
+	Recall              *domain.Recall         `json:"recall"`
+	TotalUnits          int64                  `json:"total_units"`
+	UnitsBySource       map[string]int64       `json:"units_by_source"`
+	UnitsByState        map[string]int64       `json:"units_by_state"`
+	UnitsWithoutHolder  int64                  `json:"units_without_holder"`
+	Stakeholders        int64                  `json:"stakeholders"`
+	Acknowledged        int64                  `json:"acknowledged"`
+	Outstanding         int64                  `json:"outstanding"`
+	Failed              int64                  `json:"failed"`
+	AcknowledgementRate float64                `json:"acknowledgement_rate"`
+	Holders             []*RecallHolderSummary `json:"holders"`
+}
+
+// RecallUnitCount is the number of recall units sharing a source, custody state and holder presence
+type RecallUnitCount struct {
+	Source    string `json:"source"`
+	State     string `json:"state"`
+	HasHolder bool   `json:"has_holder"`
+	Count     int64  `json:"count"`
+}
+
+// RecallHolderSummary is one notified stakeholder's share of a recall
+type RecallHolderSummary struct {
+	StakeholderID  uuid.UUID  `json:"stakeholder_id"`
+	Name           string     `json:"name"`
+	Type           string     `json:"type"`
+	Units          int        `json:"units"`
+	Status         string     `json:"status"`
+	SentAt         *time.Time `json:"sent_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
 }
 
 // StakeholderStats represents statistics for a stakeholder
@@ -28,52 +112,266 @@ type ProductStats struct {
 	TotalEvents     int64      `json:"total_events"`
 	VerifiedEvents  int64      `json:"verified_events"`
 	CurrentLocation *string    `json:"current_location"`
+	CurrentHolder   *uuid.UUID `json:"current_holder"`
+	CurrentState    *string    `json:"current_state"`
 	LastStakeholder *uuid.UUID `json:"last_stakeholder"`
 	LastActivity    time.Time  `json:"last_activity"`
 }
 
-// Filter untuk query
+// Filter untuk query. Every list filter pages by Limit with either Offset or Cursor (the
+// next_cursor of the previous page), and reports its total according to Count (see paging.Request).
+// Stakeholders, products, events and transactions also take Query: the sort, sparse fieldset,
+// includes and predicates of the list query language (see listquery), set by the API layers.
 type StakeholderFilter struct {
-	Type       *string `json:"type"`
-	IsVerified *bool   `json:"is_verified"`
-	Email      *string `json:"email"`
-	Limit      int     `json:"limit"`
-	Offset     int     `json:"offset"`
+	Type       *string           `json:"type"`
+	IsVerified *bool             `json:"is_verified"`
+	Email      *string           `json:"email"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+	Cursor     *paging.Cursor    `json:"cursor"`
+	Count      string            `json:"count"`
+	Query      listquery.Options `json:"-"`
 }
 
 type ProductFilter struct {
-	Category       *string    `json:"category"`
-	ManufacturerID *uuid.UUID `json:"manufacturer_id"`
-	SKU            *string    `json:"sku"`
-	Name           *string    `json:"name"`
-	Limit          int        `json:"limit"`
-	Offset         int        `json:"offset"`
+	Category       *string           `json:"category"`
+	ManufacturerID *uuid.UUID        `json:"manufacturer_id"`
+	SKU            *string           `json:"sku"`
+	Name           *string           `json:"name"`
+	LotNumber      *string           `json:"lot_number"`
+	Limit          int               `json:"limit"`
+	Offset         int               `json:"offset"`
+	Cursor         *paging.Cursor    `json:"cursor"`
+	Count          string            `json:"count"`
+	Query          listquery.Options `json:"-"`
 }
 
 type SupplyChainEventFilter struct {
-	ProductID     *uuid.UUID `json:"product_id"`
-	StakeholderID *uuid.UUID `json:"stakeholder_id"`
-	EventType     *string    `json:"event_type"`
-	Location      *string    `json:"location"`
-	IsVerified    *bool      `json:"is_verified"`
-	FromDate      *time.Time `json:"from_date"`
-	ToDate        *time.Time `json:"to_date"`
-	Limit         int        `json:"limit"`
-	Offset        int        `json:"offset"`
+	ProductID     *uuid.UUID        `json:"product_id"`
+	StakeholderID *uuid.UUID        `json:"stakeholder_id"`
+	EventType     *string           `json:"event_type"`
+	EventTypes    []string          `json:"event_types"`
+	Location      *string           `json:"location"`
+	LocationID    *uuid.UUID        `json:"location_id"`
+	Near          *GeoRadius        `json:"near"` // events at registered locations within the radius
+	IsVerified    *bool             `json:"is_verified"`
+	FromDate      *time.Time        `json:"from_date"`
+	ToDate        *time.Time        `json:"to_date"`
+	Limit         int               `json:"limit"`
+	Offset        int               `json:"offset"`
+	Cursor        *paging.Cursor    `json:"cursor"`
+	Count         string            `json:"count"`
+	Query         listquery.Options `json:"-"`
 }
 
 type BlockchainTransactionFilter struct {
-	EventID *uuid.UUID `json:"event_id"`
-	Status  *string    `json:"status"`
-	Limit   int        `json:"limit"`
-	Offset  int        `json:"offset"`
+	EventID *uuid.UUID        `json:"event_id"`
+	Status  *string           `json:"status"`
+	Limit   int               `json:"limit"`
+	Offset  int               `json:"offset"`
+	Cursor  *paging.Cursor    `json:"cursor"`
+	Count   string            `json:"count"`
+	Query   listquery.Options `json:"-"`
+}
+
+type CustodyTransferFilter struct {
+	StakeholderID *uuid.UUID     `json:"stakeholder_id"`
+	Direction     *string        `json:"direction"` // 'incoming', 'outgoing'; both when nil
+	ProductID     *uuid.UUID     `json:"product_id"`
+	Status        *string        `json:"status"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+	Cursor        *paging.Cursor `json:"cursor"`
+	Count         string         `json:"count"`
+}
+
+type LocationFilter struct {
+	Type          *string        `json:"type"`
+	StakeholderID *uuid.UUID     `json:"stakeholder_id"`
+	Name          *string        `json:"name"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+	Cursor        *paging.Cursor `json:"cursor"`
+	Count         string         `json:"count"`
+}
+
+type RouteAlertFilter struct {
+	CustodyTransferID *uuid.UUID     `json:"custody_transfer_id"`
+	ProductID         *uuid.UUID     `json:"product_id"`
+	Type              *string        `json:"type"`
+	Status            *string        `json:"status"`
+	Limit             int            `json:"limit"`
+	Offset            int            `json:"offset"`
+	Cursor            *paging.Cursor `json:"cursor"`
+	Count             string         `json:"count"`
+}
+
+type SensorDeviceFilter struct {
+	StakeholderID *uuid.UUID     `json:"stakeholder_id"`
+	ProductID     *uuid.UUID     `json:"product_id"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+	Cursor        *paging.Cursor `json:"cursor"`
+	Count         string         `json:"count"`
 }
 
-// Response wrapper
+// TransitLegFilter selects legs received within From and To
+type TransitLegFilter struct {
+	ProductID         *uuid.UUID     `json:"product_id"`
+	SenderID          *uuid.UUID     `json:"sender_id"`
+	ReceiverID        *uuid.UUID     `json:"receiver_id"`
+	CustodyTransferID *uuid.UUID     `json:"custody_transfer_id"`
+	From              *time.Time     `json:"from"`
+	To                *time.Time     `json:"to"`
+	Limit             int            `json:"limit"`
+	Offset            int            `json:"offset"`
+	Cursor            *paging.Cursor `json:"cursor"`
+	Count             string         `json:"count"`
+}
+
+// DwellFilter selects stays that began within From and To
+type DwellFilter struct {
+	ProductID     *uuid.UUID     `json:"product_id"`
+	StakeholderID *uuid.UUID     `json:"stakeholder_id"`
+	LocationID    *uuid.UUID     `json:"location_id"`
+	Open          *bool          `json:"open"`
+	From          *time.Time     `json:"from"`
+	To            *time.Time     `json:"to"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+	Cursor        *paging.Cursor `json:"cursor"`
+	Count         string         `json:"count"`
+}
+
+type InventoryFilter struct {
+	HolderID    *uuid.UUID     `json:"holder_id"`
+	Location    *string        `json:"location"`
+	State       *string        `json:"state"`
+	ContainerID *uuid.UUID     `json:"container_id"`
+	Limit       int            `json:"limit"`
+	Offset      int            `json:"offset"`
+	Cursor      *paging.Cursor `json:"cursor"`
+	Count       string         `json:"count"`
+}
+
+type RecallFilter struct {
+	Status    *string        `json:"status"`
+	Severity  *string        `json:"severity"`
+	ProductID *uuid.UUID     `json:"product_id"`
+	Limit     int            `json:"limit"`
+	Offset    int            `json:"offset"`
+	Cursor    *paging.Cursor `json:"cursor"`
+	Count     string         `json:"count"`
+}
+
+type RecallUnitFilter struct {
+	HolderID *uuid.UUID     `json:"holder_id"`
+	Source   *string        `json:"source"`
+	Limit    int            `json:"limit"`
+	Offset   int            `json:"offset"`
+	Cursor   *paging.Cursor `json:"cursor"`
+	Count    string         `json:"count"`
+}
+
+// Response wrapper. Follow next_cursor rather than raising offset to walk a large list.
 type PaginatedResponse struct {
-	Data    interface{} `json:"data"`
-	Total   int         `json:"total"`
-	Limit   int         `json:"limit"`
-	Offset  int         `json:"offset"`
-	HasMore bool        `json:"has_more"`
+	Data           interface{} `json:"data"`
+	Total          *int        `json:"total"` // null when not counted
+	TotalEstimated bool        `json:"total_estimated,omitempty"`
+	Limit          int         `json:"limit"`
+	Offset         int         `json:"offset"`
+	HasMore        bool        `json:"has_more"`
+	NextCursor     *string     `json:"next_cursor"`
+}
+
+func NewPaginatedResponse(data interface{}, page *paging.Page, limit, offset int) *PaginatedResponse {
+	response := &PaginatedResponse{
+		Data:           data,
+		TotalEstimated: page.TotalEstimated,
+		Limit:          limit,
+		Offset:         offset,
+		HasMore:        page.HasMore,
+	}
+	if page.Total != nil {
+		total := int(*page.Total)
+		response.Total = &total
+	}
+	if page.NextCursor != nil {
+		next := page.NextCursor.String()
+		response.NextCursor = &next
+	}
+	return response
+}
+
+// DigitalLinkResolution is what a GS1 Digital Link URI resolves to
+type DigitalLinkResolution struct {
+	Link       string            `json:"link"` // canonical URI under the resolver domain
+	GTIN       string            `json:"gtin"`
+	Lot        string            `json:"lot,omitempty"`
+	Serial     string            `json:"serial,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Product    *domain.Product   `json:"product"`
+	Trace      *SupplyChainTrace `json:"trace"`
+}
+
+type ImportJobFilter struct {
+	Kind   *string        `json:"kind"`
+	Status *string        `json:"status"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+	Cursor *paging.Cursor `json:"cursor"`
+	Count  string         `json:"count"`
+}
+
+type WebhookSubscriptionFilter struct {
+	StakeholderID *uuid.UUID     `json:"stakeholder_id"`
+	IsActive      *bool          `json:"is_active"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+	Cursor        *paging.Cursor `json:"cursor"`
+	Count         string         `json:"count"`
+}
+
+type WebhookDeliveryFilter struct {
+	SubscriptionID *uuid.UUID     `json:"subscription_id"`
+	EventID        *uuid.UUID     `json:"event_id"`
+	Topic          *string        `json:"topic"`
+	Status         *string        `json:"status"`
+	Limit          int            `json:"limit"`
+	Offset         int            `json:"offset"`
+	Cursor         *paging.Cursor `json:"cursor"`
+	Count          string         `json:"count"`
+}
+
+type ExcursionRuleFilter struct {
+	Category *string        `json:"category"`
+	Metric   *string        `json:"metric"`
+	IsActive *bool          `json:"is_active"`
+	Limit    int            `json:"limit"`
+	Offset   int            `json:"offset"`
+	Cursor   *paging.Cursor `json:"cursor"`
+	Count    string         `json:"count"`
+}
+
+type ExcursionIncidentFilter struct {
+	RuleID            *uuid.UUID     `json:"rule_id"`
+	ProductID         *uuid.UUID     `json:"product_id"`
+	CustodyTransferID *uuid.UUID     `json:"custody_transfer_id"`
+	Status            *string        `json:"status"`
+	Breached          *bool          `json:"breached"`
+	Limit             int            `json:"limit"`
+	Offset            int            `json:"offset"`
+	Cursor            *paging.Cursor `json:"cursor"`
+	Count             string         `json:"count"`
+}
+
+type QuarantineFilter struct {
+	ProductID  *uuid.UUID     `json:"product_id"`
+	IncidentID *uuid.UUID     `json:"incident_id"`
+	LotNumber  *string        `json:"lot_number"`
+	Status     *string        `json:"status"`
+	Limit      int            `json:"limit"`
+	Offset     int            `json:"offset"`
+	Cursor     *paging.Cursor `json:"cursor"`
+	Count      string         `json:"count"`
 }
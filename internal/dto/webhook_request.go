@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+)
+
+type CreateWebhookSubscriptionRequest struct {
+	StakeholderID uuid.UUID   `json:"stakeholder_id" validate:"required"`
+	URL           string      `json:"url" validate:"required,url"`
+	Topics        []string    `json:"topics"`      // empty subscribes to every topic
+	EventTypes    []string    `json:"event_types"` // empty matches every event type
+	ProductIDs    []uuid.UUID `json:"product_ids"` // empty matches every product
+}
+
+// UpdateWebhookSubscriptionRequest changes the fields that are set. Setting is_active to true
+// re-enables an endpoint that was disabled for failing and resets its failure count.
+type UpdateWebhookSubscriptionRequest struct {
+	URL        *string      `json:"url" validate:"omitempty,url"`
+	Topics     *[]string    `json:"topics"`
+	EventTypes *[]string    `json:"event_types"`
+	ProductIDs *[]uuid.UUID `json:"product_ids"`
+	IsActive   *bool        `json:"is_active"`
+}
+
+// WebhookSubscriptionSecret carries the signing secret, which is only ever shown when a
+// subscription is created or its secret is rotated
+type WebhookSubscriptionSecret struct {
+	*domain.WebhookSubscription
+	Secret string `json:"secret"`
+}
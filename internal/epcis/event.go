@@ -0,0 +1,76 @@
+package epcis
+
+import (
+	"time"
+)
+
+// EPCIS event types
+const (
+	ObjectEvent         = "ObjectEvent"
+	AggregationEvent    = "AggregationEvent"
+	TransformationEvent = "TransformationEvent"
+)
+
+// EPCIS actions
+const (
+	ActionAdd     = "ADD"
+	ActionObserve = "OBSERVE"
+	ActionDelete  = "DELETE"
+)
+
+// Event is a format-neutral EPCIS event. Its JSON form is the EPCIS 2.0 JSON-LD event;
+// other bindings convert to and from it so every format shares the same mapping rules.
+// CBV values (bizStep, disposition, source/destination and transaction types) are kept
+// as bare names, e.g. "shipping" rather than "urn:epcglobal:cbv:bizstep:shipping".
+type Event struct {
+	Type                string            `json:"type"`
+	EventID             string            `json:"eventID,omitempty"`
+	EventTime           time.Time         `json:"eventTime"`
+	EventTimeZoneOffset string            `json:"eventTimeZoneOffset"`
+	RecordTime          *time.Time        `json:"recordTime,omitempty"`
+	Action              string            `json:"action,omitempty"`
+	BizStep             string            `json:"bizStep,omitempty"`
+	Disposition         string            `json:"disposition,omitempty"`
+	ReadPoint           *Location         `json:"readPoint,omitempty"`
+	BizLocation         *Location         `json:"bizLocation,omitempty"`
+	EPCList             []string          `json:"epcList,omitempty"`
+	QuantityList        []QuantityElement `json:"quantityList,omitempty"`
+	ParentID            string            `json:"parentID,omitempty"`
+	ChildEPCs           []string          `json:"childEPCs,omitempty"`
+	ChildQuantityList   []QuantityElement `json:"childQuantityList,omitempty"`
+	InputEPCList        []string          `json:"inputEPCList,omitempty"`
+	InputQuantityList   []QuantityElement `json:"inputQuantityList,omitempty"`
+	OutputEPCList       []string          `json:"outputEPCList,omitempty"`
+	OutputQuantityList  []QuantityElement `json:"outputQuantityList,omitempty"`
+	BizTransactionList  []BizTransaction  `json:"bizTransactionList,omitempty"`
+	SourceList          []Source          `json:"sourceList,omitempty"`
+	DestinationList     []Destination     `json:"destinationList,omitempty"`
+
+	// Stakeholder is the party that recorded the event (tracer extension)
+	Stakeholder string `json:"tracer:stakeholder,omitempty"`
+}
+
+type Location struct {
+	ID string `json:"id" xml:"id"`
+}
+
+type QuantityElement struct {
+	EPCClass string   `json:"epcClass"`
+	Quantity *float64 `json:"quantity,omitempty"`
+	UOM      string   `json:"uom,omitempty"`
+}
+
+type BizTransaction struct {
+	Type           string `json:"type,omitempty"`
+	BizTransaction string `json:"bizTransaction"`
+}
+
+type Source struct {
+	Type   string `json:"type"`
+	Source string `json:"source"`
+}
+
+type Destination struct {
+	Type        string `json:"type"`
+	Destination string `json:"destination"`
+}
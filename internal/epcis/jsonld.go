@@ -0,0 +1,94 @@
+package epcis
+
+import (
+	"github.com/goccy/go-json"
+	"io"
+	"time"
+)
+
+// Document types
+const (
+	DocumentTypeEPCIS = "EPCISDocument"
+	DocumentTypeQuery = "EPCISQueryDocument"
+)
+
+const (
+	SchemaVersion   = "2.0"
+	ContextURI      = "https://ref.gs1.org/standards/epcis/2.0.0/epcis-context.jsonld"
+	ContentTypeJSON = "application/ld+json"
+)
+
+// SimpleEventQuery is the only query name served
+const SimpleEventQuery = "SimpleEventQuery"
+
+// Document is an EPCIS 2.0 JSON-LD capture or query document
+type Document struct {
+	Context       interface{} `json:"@context"`
+	Type          string      `json:"type"`
+	SchemaVersion string      `json:"schemaVersion"`
+	CreationDate  time.Time   `json:"creationDate"`
+	EPCISBody     Body        `json:"epcisBody"`
+}
+
+type Body struct {
+	EventList    []*Event      `json:"eventList,omitempty"`
+	QueryResults *QueryResults `json:"queryResults,omitempty"`
+}
+
+type QueryResults struct {
+	QueryName   string      `json:"queryName"`
+	ResultsBody ResultsBody `json:"resultsBody"`
+}
+
+type ResultsBody struct {
+	EventList []*Event `json:"eventList"`
+}
+
+// DecodeDocument reads an EPCISDocument and returns its events
+func DecodeDocument(r io.Reader) ([]*Event, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	if doc.Type != DocumentTypeEPCIS {
+		return nil, ErrInvalidEvent
+	}
+	return doc.EPCISBody.EventList, nil
+}
+
+// NewQueryDocument wraps query results in an EPCISQueryDocument
+func NewQueryDocument(events []*Event) *Document {
+	if events == nil {
+		events = []*Event{}
+	}
+	return &Document{
+		Context:       defaultContext(),
+		Type:          DocumentTypeQuery,
+		SchemaVersion: SchemaVersion,
+		CreationDate:  time.Now().UTC(),
+		EPCISBody: Body{
+			QueryResults: &QueryResults{
+				QueryName:   SimpleEventQuery,
+				ResultsBody: ResultsBody{EventList: events},
+			},
+		},
+	}
+}
+
+// NewDocument wraps events in an EPCISDocument, the form used for exports
+func NewDocument(events []*Event) *Document {
+	if events == nil {
+		events = []*Event{}
+	}
+	return &Document{
+		Context:       defaultContext(),
+		Type:          DocumentTypeEPCIS,
+		SchemaVersion: SchemaVersion,
+		CreationDate:  time.Now().UTC(),
+		EPCISBody:     Body{EventList: events},
+	}
+}
+
+func defaultContext() interface{} {
+	return []interface{}{ContextURI, map[string]string{"tracer": Namespace}}
+}
@@ -0,0 +1,304 @@
+package epcis
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/gs1"
+	"net/url"
+	"strings"
+)
+
+// Namespace is the IRI of the tracer extension fields
+const Namespace = "urn:supplychain-tracer:epcis:"
+
+// LocationURNPrefix wraps free-text locations, which EPCIS requires to be URIs
+const LocationURNPrefix = "urn:supplychain-tracer:location:"
+
+const uuidURNPrefix = "urn:uuid:"
+
+// CBV business steps
+const (
+	BizStepCommissioning = "commissioning"
+	BizStepShipping      = "shipping"
+	BizStepDeparting     = "departing"
+	BizStepReceiving     = "receiving"
+	BizStepArriving      = "arriving"
+	BizStepAccepting     = "accepting"
+	BizStepRetailSelling = "retail_selling"
+	BizStepPacking       = "packing"
+	BizStepUnpacking     = "unpacking"
+)
+
+// CBV dispositions
+const (
+	DispositionActive     = "active"
+	DispositionInTransit  = "in_transit"
+	DispositionInProgress = "in_progress"
+	DispositionRetailSold = "retail_sold"
+)
+
+// CBV source/destination types
+const (
+	SourceDestOwningParty     = "owning_party"
+	SourceDestPossessingParty = "possessing_party"
+	SourceDestLocation        = "location"
+)
+
+var (
+	ErrUnsupportedEvent = errors.New("unsupported EPCIS event")
+	ErrInvalidEvent     = errors.New("invalid EPCIS event")
+)
+
+// cbvPrefixes are the URN and web URI forms CBV values may arrive in
+var cbvPrefixes = []string{
+	"urn:epcglobal:cbv:bizstep:",
+	"urn:epcglobal:cbv:disp:",
+	"urn:epcglobal:cbv:sdt:",
+	"urn:epcglobal:cbv:btt:",
+	"https://ref.gs1.org/cbv/BizStep-",
+	"https://ref.gs1.org/cbv/Disp-",
+	"https://ref.gs1.org/cbv/SDT-",
+	"https://ref.gs1.org/cbv/BTT-",
+	"cbv:BizStep-",
+	"cbv:Disp-",
+	"cbv:SDT-",
+	"cbv:BTT-",
+}
+
+// ShortCBV reduces a CBV value in any of its forms to the bare name
+func ShortCBV(value string) string {
+	for _, prefix := range cbvPrefixes {
+		if strings.HasPrefix(value, prefix) {
+			return strings.TrimPrefix(value, prefix)
+		}
+	}
+	return value
+}
+
+// DomainEventType maps an EPCIS event onto the tracer event type
+func DomainEventType(event *Event) (string, error) {
+	switch event.Type {
+	case ObjectEvent:
+		switch ShortCBV(event.BizStep) {
+		case BizStepCommissioning:
+			return domain.EventTypeManufactured, nil
+		case BizStepShipping, BizStepDeparting:
+			return domain.EventTypeShipped, nil
+		case BizStepReceiving, BizStepArriving, BizStepAccepting:
+			return domain.EventTypeReceived, nil
+		case BizStepRetailSelling:
+			return domain.EventTypeSold, nil
+		case "":
+			// An ADD without a business step is a commissioning
+			if event.Action == ActionAdd {
+				return domain.EventTypeManufactured, nil
+			}
+		}
+		return "", ErrUnsupportedEvent
+	case AggregationEvent:
+		switch event.Action {
+		case ActionAdd:
+			return domain.EventTypePack, nil
+		case ActionDelete:
+			return domain.EventTypeUnpack, nil
+		}
+		return "", ErrUnsupportedEvent
+	case TransformationEvent:
+		return domain.EventTypeTransformed, nil
+	default:
+		return "", ErrUnsupportedEvent
+	}
+}
+
+// EventTypesFor returns the tracer event types an EPCIS event type covers
+func EventTypesFor(epcisType string) ([]string, error) {
+	switch epcisType {
+	case ObjectEvent:
+		return []string{domain.EventTypeManufactured, domain.EventTypeShipped, domain.EventTypeReceived, domain.EventTypeSold}, nil
+	case AggregationEvent:
+		return []string{domain.EventTypePack, domain.EventTypeUnpack}, nil
+	case TransformationEvent:
+		return []string{domain.EventTypeTransformed}, nil
+	default:
+		return nil, ErrUnsupportedEvent
+	}
+}
+
+// EventTypesForBizStep returns the tracer event types recorded under a business step
+func EventTypesForBizStep(bizStep string) []string {
+	switch ShortCBV(bizStep) {
+	case BizStepCommissioning:
+		return []string{domain.EventTypeManufactured}
+	case BizStepShipping, BizStepDeparting:
+		return []string{domain.EventTypeShipped}
+	case BizStepReceiving, BizStepArriving, BizStepAccepting:
+		return []string{domain.EventTypeReceived}
+	case BizStepRetailSelling:
+		return []string{domain.EventTypeSold}
+	case BizStepPacking:
+		return []string{domain.EventTypePack}
+	case BizStepUnpacking:
+		return []string{domain.EventTypeUnpack}
+	default:
+		return nil
+	}
+}
+
+// Describe returns the EPCIS event type, action, business step and disposition a tracer event type is rendered with
+func Describe(eventType string) (epcisType, action, bizStep, disposition string) {
+	switch eventType {
+	case domain.EventTypeManufactured:
+		return ObjectEvent, ActionAdd, BizStepCommissioning, DispositionActive
+	case domain.EventTypeShipped:
+		return ObjectEvent, ActionObserve, BizStepShipping, DispositionInTransit
+	case domain.EventTypeReceived:
+		return ObjectEvent, ActionObserve, BizStepReceiving, DispositionInProgress
+	case domain.EventTypeSold:
+		return ObjectEvent, ActionObserve, BizStepRetailSelling, DispositionRetailSold
+	case domain.EventTypePack:
+		return AggregationEvent, ActionAdd, BizStepPacking, DispositionInProgress
+	case domain.EventTypeUnpack:
+		return AggregationEvent, ActionDelete, BizStepUnpacking, DispositionInProgress
+	case domain.EventTypeTransformed:
+		return TransformationEvent, "", "", DispositionActive
+	default:
+		return ObjectEvent, ActionObserve, "", ""
+	}
+}
+
+// Validate checks the structural rules of an event before it is mapped
+func Validate(event *Event) error {
+	if event.EventTime.IsZero() {
+		return ErrInvalidEvent
+	}
+	switch event.Type {
+	case ObjectEvent:
+		if len(event.EPCList) == 0 && len(event.QuantityList) == 0 {
+			return ErrInvalidEvent
+		}
+	case AggregationEvent:
+		if event.ParentID == "" {
+			return ErrInvalidEvent
+		}
+	case TransformationEvent:
+		if (len(event.InputEPCList) == 0 && len(event.InputQuantityList) == 0) ||
+			(len(event.OutputEPCList) == 0 && len(event.OutputQuantityList) == 0) {
+			return ErrInvalidEvent
+		}
+	default:
+		return ErrUnsupportedEvent
+	}
+	return nil
+}
+
+// LocationURI renders a tracer location as an EPCIS location identifier
+func LocationURI(location string) string {
+	if isURI(location) {
+		return location
+	}
+	return LocationURNPrefix + url.PathEscape(location)
+}
+
+// LocationFromURI is the inverse of LocationURI
+func LocationFromURI(uri string) string {
+	if strings.HasPrefix(uri, LocationURNPrefix) {
+		if location, err := url.PathUnescape(strings.TrimPrefix(uri, LocationURNPrefix)); err == nil {
+			return location
+		}
+	}
+	return uri
+}
+
+// EventLocation picks the location an EPCIS event is recorded at: bizLocation, else readPoint
+func EventLocation(event *Event) *string {
+	var id string
+	switch {
+	case event.BizLocation != nil && event.BizLocation.ID != "":
+		id = event.BizLocation.ID
+	case event.ReadPoint != nil && event.ReadPoint.ID != "":
+		id = event.ReadPoint.ID
+	default:
+		return nil
+	}
+	location := LocationFromURI(id)
+	return &location
+}
+
+// PartyURI identifies a stakeholder in source/destination lists and the stakeholder extension
+func PartyURI(id uuid.UUID) string {
+	return uuidURNPrefix + id.String()
+}
+
+// ParsePartyURI is the inverse of PartyURI
+func ParsePartyURI(uri string) (uuid.UUID, bool) {
+	if !strings.HasPrefix(uri, uuidURNPrefix) {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(strings.TrimPrefix(uri, uuidURNPrefix))
+	return id, err == nil
+}
+
+// ProductEPC is the instance-level identifier of a product: a Digital Link URI when the SKU is
+// a GTIN and the product is serialised, otherwise the product's UUID URN
+func ProductEPC(product *domain.Product) string {
+	if product.SerialNumber != nil && *product.SerialNumber != "" {
+		if gtin, err := gs1.NormalizeGTIN(product.SKU); err == nil {
+			link := &gs1.DigitalLink{GTIN: gtin, Serial: *product.SerialNumber}
+			return link.URI(gs1.DefaultResolver)
+		}
+	}
+	return PartyURI(product.ID)
+}
+
+// ProductClass is the class-level identifier used in quantity lists: GTIN plus lot when the SKU is a GTIN
+func ProductClass(product *domain.Product, lot *string) string {
+	gtin, err := gs1.NormalizeGTIN(product.SKU)
+	if err != nil {
+		return PartyURI(product.ID)
+	}
+	link := &gs1.DigitalLink{GTIN: gtin}
+	switch {
+	case lot != nil:
+		link.Lot = *lot
+	case product.LotNumber != nil:
+		link.Lot = *product.LotNumber
+	}
+	return link.URI(gs1.DefaultResolver)
+}
+
+// Identifier is what an EPC or EPC class says about the product it names
+type Identifier struct {
+	ProductID *uuid.UUID // urn:uuid form
+	GTIN      string     // 14 digits, from Digital Link or EPC URNs
+	Lot       string
+	Serial    string
+	Raw       string // anything else, matched against the SKU
+}
+
+// ParseEPC understands urn:uuid, SGTIN/LGTIN URNs and GS1 Digital Link URIs; anything else is kept raw
+func ParseEPC(epc string) *Identifier {
+	if id, ok := ParsePartyURI(epc); ok {
+		return &Identifier{ProductID: &id}
+	}
+
+	var link *gs1.DigitalLink
+	var err error
+	switch {
+	case strings.HasPrefix(epc, "urn:epc:"):
+		link, err = gs1.ParseEPCURN(epc)
+	case strings.Contains(epc, "/"+gs1.AIGTIN+"/"):
+		link, err = gs1.ParseDigitalLink(epc)
+	default:
+		return &Identifier{Raw: epc}
+	}
+	if err != nil {
+		return &Identifier{Raw: epc}
+	}
+	return &Identifier{GTIN: link.GTIN, Lot: link.Lot, Serial: link.Serial}
+}
+
+func isURI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && !strings.ContainsAny(s, " \t")
+}
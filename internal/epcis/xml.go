@@ -0,0 +1,414 @@
+package epcis
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	XMLNamespace     = "urn:epcglobal:epcis:xsd:1"
+	XMLSchemaVersion = "1.2"
+	ContentTypeXML   = "application/xml"
+)
+
+// CBV URN prefixes used by EPCIS 1.2, which has no bare-word form
+const (
+	cbvBizStepURN     = "urn:epcglobal:cbv:bizstep:"
+	cbvDispositionURN = "urn:epcglobal:cbv:disp:"
+	cbvSourceDestURN  = "urn:epcglobal:cbv:sdt:"
+	cbvBizTxnURN      = "urn:epcglobal:cbv:btt:"
+)
+
+const xmlTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+var ErrMalformedXML = errors.New("malformed EPCIS XML")
+
+// xmlEvent covers ObjectEvent, AggregationEvent and TransformationEvent. Fields are in XSD order
+// and each event type only sets its own, so the encoded element validates against EPCIS 1.2.
+type xmlEvent struct {
+	XMLName             xml.Name
+	EventTime           string              `xml:"eventTime"`
+	RecordTime          string              `xml:"recordTime,omitempty"`
+	EventTimeZoneOffset string              `xml:"eventTimeZoneOffset"`
+	BaseExtension       *xmlBaseExtension   `xml:"baseExtension,omitempty"`
+	EPCList             *xmlEPCList         `xml:"epcList,omitempty"`
+	ParentID            string              `xml:"parentID,omitempty"`
+	ChildEPCs           *xmlEPCList         `xml:"childEPCs,omitempty"`
+	InputEPCList        *xmlEPCList         `xml:"inputEPCList,omitempty"`
+	InputQuantityList   *xmlQuantityList    `xml:"inputQuantityList,omitempty"`
+	OutputEPCList       *xmlEPCList         `xml:"outputEPCList,omitempty"`
+	OutputQuantityList  *xmlQuantityList    `xml:"outputQuantityList,omitempty"`
+	Action              string              `xml:"action,omitempty"`
+	BizStep             string              `xml:"bizStep,omitempty"`
+	Disposition         string              `xml:"disposition,omitempty"`
+	ReadPoint           *Location           `xml:"readPoint,omitempty"`
+	BizLocation         *Location           `xml:"bizLocation,omitempty"`
+	BizTransactionList  *xmlBizTransactions `xml:"bizTransactionList,omitempty"`
+	SourceList          *xmlSourceList      `xml:"sourceList,omitempty"`      // TransformationEvent only
+	DestinationList     *xmlDestinationList `xml:"destinationList,omitempty"` // TransformationEvent only
+	Extension           *xmlEventExtension  `xml:"extension,omitempty"`
+	Stakeholder         string              `xml:"urn:supplychain-tracer:epcis: stakeholder,omitempty"`
+}
+
+type xmlBaseExtension struct {
+	EventID string `xml:"eventID,omitempty"`
+}
+
+type xmlEPCList struct {
+	EPCs []string `xml:"epc"`
+}
+
+type xmlQuantityList struct {
+	Elements []xmlQuantity `xml:"quantityElement"`
+}
+
+type xmlQuantity struct {
+	EPCClass string `xml:"epcClass"`
+	Quantity string `xml:"quantity,omitempty"`
+	UOM      string `xml:"uom,omitempty"`
+}
+
+type xmlBizTransactions struct {
+	Items []xmlTyped `xml:"bizTransaction"`
+}
+
+type xmlSourceList struct {
+	Items []xmlTyped `xml:"source"`
+}
+
+type xmlDestinationList struct {
+	Items []xmlTyped `xml:"destination"`
+}
+
+type xmlTyped struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// xmlEventExtension holds the EPCIS 1.1 additions to ObjectEvent and AggregationEvent
+type xmlEventExtension struct {
+	QuantityList      *xmlQuantityList    `xml:"quantityList,omitempty"`
+	ChildQuantityList *xmlQuantityList    `xml:"childQuantityList,omitempty"`
+	SourceList        *xmlSourceList      `xml:"sourceList,omitempty"`
+	DestinationList   *xmlDestinationList `xml:"destinationList,omitempty"`
+}
+
+// DecodeXML streams the events of an EPCIS 1.2 EPCISDocument to fn one at a time, with the event's
+// position in the document. Events that cannot be read are passed with a non-nil error so they can be
+// reported individually. A document-level syntax error stops the stream and is returned.
+func DecodeXML(r io.Reader, fn func(index int, event *Event, err error) error) error {
+	decoder := xml.NewDecoder(r)
+	index := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedXML, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case ObjectEvent, AggregationEvent, TransformationEvent:
+			var raw xmlEvent
+			if err := decoder.DecodeElement(&raw, &start); err != nil {
+				return fmt.Errorf("%w: %v", ErrMalformedXML, err)
+			}
+			event, err := raw.event()
+			if err := fn(index, event, err); err != nil {
+				return err
+			}
+			index++
+		case "QuantityEvent", "TransactionEvent", "AssociationEvent":
+			if err := decoder.Skip(); err != nil {
+				return fmt.Errorf("%w: %v", ErrMalformedXML, err)
+			}
+			if err := fn(index, nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, start.Name.Local)); err != nil {
+				return err
+			}
+			index++
+		}
+	}
+}
+
+// XMLWriter streams events into an EPCIS 1.2 EPCISDocument
+type XMLWriter struct {
+	w       *bufio.Writer
+	encoder *xml.Encoder
+}
+
+// NewXMLWriter writes the document header; Close must be called to complete the document
+func NewXMLWriter(w io.Writer) (*XMLWriter, error) {
+	buffered := bufio.NewWriter(w)
+	header := xml.Header + fmt.Sprintf(
+		`<epcis:EPCISDocument xmlns:epcis="%s" xmlns:tracer="%s" schemaVersion="%s" creationDate="%s"><EPCISBody><EventList>`,
+		XMLNamespace, Namespace, XMLSchemaVersion, time.Now().UTC().Format(xmlTimeLayout))
+	if _, err := buffered.WriteString(header); err != nil {
+		return nil, err
+	}
+	return &XMLWriter{w: buffered, encoder: xml.NewEncoder(buffered)}, nil
+}
+
+func (x *XMLWriter) WriteEvent(event *Event) error {
+	// EPCIS 1.2 carries TransformationEvent inside the EventList extension point
+	wrapped := event.Type == TransformationEvent
+	if wrapped {
+		if _, err := x.w.WriteString("<extension>"); err != nil {
+			return err
+		}
+	}
+	if err := x.encoder.Encode(newXMLEvent(event)); err != nil {
+		return err
+	}
+	if wrapped {
+		if err := x.encoder.Flush(); err != nil {
+			return err
+		}
+		if _, err := x.w.WriteString("</extension>"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *XMLWriter) Close() error {
+	if err := x.encoder.Flush(); err != nil {
+		return err
+	}
+	if _, err := x.w.WriteString("</EventList></EPCISBody></epcis:EPCISDocument>"); err != nil {
+		return err
+	}
+	return x.w.Flush()
+}
+
+// EncodeXML writes a complete EPCIS 1.2 document holding events
+func EncodeXML(w io.Writer, events []*Event) error {
+	writer, err := NewXMLWriter(w)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := writer.WriteEvent(event); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func newXMLEvent(event *Event) *xmlEvent {
+	out := &xmlEvent{
+		XMLName:             xml.Name{Local: event.Type},
+		EventTime:           event.EventTime.Format(xmlTimeLayout),
+		EventTimeZoneOffset: event.EventTimeZoneOffset,
+		Action:              event.Action,
+		BizStep:             cbvURN(cbvBizStepURN, event.BizStep),
+		Disposition:         cbvURN(cbvDispositionURN, event.Disposition),
+		ReadPoint:           event.ReadPoint,
+		BizLocation:         event.BizLocation,
+		ParentID:            event.ParentID,
+		Stakeholder:         event.Stakeholder,
+		EPCList:             xmlEPCs(event.EPCList),
+		ChildEPCs:           xmlEPCs(event.ChildEPCs),
+		InputEPCList:        xmlEPCs(event.InputEPCList),
+		InputQuantityList:   xmlQuantities(event.InputQuantityList),
+		OutputEPCList:       xmlEPCs(event.OutputEPCList),
+		OutputQuantityList:  xmlQuantities(event.OutputQuantityList),
+	}
+	if event.RecordTime != nil {
+		out.RecordTime = event.RecordTime.Format(xmlTimeLayout)
+	}
+	if event.EventID != "" {
+		out.BaseExtension = &xmlBaseExtension{EventID: event.EventID}
+	}
+	if len(event.BizTransactionList) > 0 {
+		out.BizTransactionList = &xmlBizTransactions{}
+		for _, txn := range event.BizTransactionList {
+			out.BizTransactionList.Items = append(out.BizTransactionList.Items, xmlTyped{Type: cbvURN(cbvBizTxnURN, txn.Type), Value: txn.BizTransaction})
+		}
+	}
+
+	var sources *xmlSourceList
+	if len(event.SourceList) > 0 {
+		sources = &xmlSourceList{}
+		for _, source := range event.SourceList {
+			sources.Items = append(sources.Items, xmlTyped{Type: cbvURN(cbvSourceDestURN, source.Type), Value: source.Source})
+		}
+	}
+	var destinations *xmlDestinationList
+	if len(event.DestinationList) > 0 {
+		destinations = &xmlDestinationList{}
+		for _, destination := range event.DestinationList {
+			destinations.Items = append(destinations.Items, xmlTyped{Type: cbvURN(cbvSourceDestURN, destination.Type), Value: destination.Destination})
+		}
+	}
+
+	if event.Type == TransformationEvent {
+		out.SourceList, out.DestinationList = sources, destinations
+		return out
+	}
+
+	extension := &xmlEventExtension{
+		QuantityList:      xmlQuantities(event.QuantityList),
+		ChildQuantityList: xmlQuantities(event.ChildQuantityList),
+		SourceList:        sources,
+		DestinationList:   destinations,
+	}
+	if extension.QuantityList != nil || extension.ChildQuantityList != nil || sources != nil || destinations != nil {
+		out.Extension = extension
+	}
+	return out
+}
+
+// event converts a decoded element into the format-neutral event
+func (x *xmlEvent) event() (*Event, error) {
+	eventTime, err := time.Parse(time.RFC3339Nano, x.EventTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: eventTime %q", ErrInvalidEvent, x.EventTime)
+	}
+
+	event := &Event{
+		Type:                x.XMLName.Local,
+		EventTime:           eventTime,
+		EventTimeZoneOffset: x.EventTimeZoneOffset,
+		Action:              x.Action,
+		BizStep:             ShortCBV(x.BizStep),
+		Disposition:         ShortCBV(x.Disposition),
+		ReadPoint:           x.ReadPoint,
+		BizLocation:         x.BizLocation,
+		ParentID:            x.ParentID,
+		Stakeholder:         x.Stakeholder,
+		EPCList:             epcsOf(x.EPCList),
+		ChildEPCs:           epcsOf(x.ChildEPCs),
+		InputEPCList:        epcsOf(x.InputEPCList),
+		OutputEPCList:       epcsOf(x.OutputEPCList),
+	}
+	if x.RecordTime != "" {
+		if recordTime, err := time.Parse(time.RFC3339Nano, x.RecordTime); err == nil {
+			event.RecordTime = &recordTime
+		}
+	}
+	if x.BaseExtension != nil {
+		event.EventID = x.BaseExtension.EventID
+	}
+	if x.BizTransactionList != nil {
+		for _, txn := range x.BizTransactionList.Items {
+			event.BizTransactionList = append(event.BizTransactionList, BizTransaction{Type: ShortCBV(txn.Type), BizTransaction: txn.Value})
+		}
+	}
+
+	sources, destinations := x.SourceList, x.DestinationList
+	if x.Extension != nil {
+		if sources == nil {
+			sources = x.Extension.SourceList
+		}
+		if destinations == nil {
+			destinations = x.Extension.DestinationList
+		}
+	}
+	if sources != nil {
+		for _, source := range sources.Items {
+			event.SourceList = append(event.SourceList, Source{Type: ShortCBV(source.Type), Source: source.Value})
+		}
+	}
+	if destinations != nil {
+		for _, destination := range destinations.Items {
+			event.DestinationList = append(event.DestinationList, Destination{Type: ShortCBV(destination.Type), Destination: destination.Value})
+		}
+	}
+
+	for _, list := range []struct {
+		raw    *xmlQuantityList
+		target *[]QuantityElement
+	}{
+		{x.InputQuantityList, &event.InputQuantityList},
+		{x.OutputQuantityList, &event.OutputQuantityList},
+		{extensionQuantities(x.Extension, false), &event.QuantityList},
+		{extensionQuantities(x.Extension, true), &event.ChildQuantityList},
+	} {
+		elements, err := quantitiesOf(list.raw)
+		if err != nil {
+			return nil, err
+		}
+		*list.target = elements
+	}
+
+	return event, nil
+}
+
+func extensionQuantities(extension *xmlEventExtension, children bool) *xmlQuantityList {
+	switch {
+	case extension == nil:
+		return nil
+	case children:
+		return extension.ChildQuantityList
+	default:
+		return extension.QuantityList
+	}
+}
+
+func xmlEPCs(epcs []string) *xmlEPCList {
+	if len(epcs) == 0 {
+		return nil
+	}
+	return &xmlEPCList{EPCs: epcs}
+}
+
+func epcsOf(list *xmlEPCList) []string {
+	if list == nil {
+		return nil
+	}
+	return list.EPCs
+}
+
+func xmlQuantities(elements []QuantityElement) *xmlQuantityList {
+	if len(elements) == 0 {
+		return nil
+	}
+	list := &xmlQuantityList{}
+	for _, element := range elements {
+		raw := xmlQuantity{EPCClass: element.EPCClass, UOM: element.UOM}
+		if element.Quantity != nil {
+			raw.Quantity = strconv.FormatFloat(*element.Quantity, 'f', -1, 64)
+		}
+		list.Elements = append(list.Elements, raw)
+	}
+	return list
+}
+
+func quantitiesOf(list *xmlQuantityList) ([]QuantityElement, error) {
+	if list == nil {
+		return nil, nil
+	}
+	elements := make([]QuantityElement, 0, len(list.Elements))
+	for _, raw := range list.Elements {
+		element := QuantityElement{EPCClass: raw.EPCClass, UOM: raw.UOM}
+		if raw.Quantity != "" {
+			quantity, err := strconv.ParseFloat(raw.Quantity, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: quantity %q", ErrInvalidEvent, raw.Quantity)
+			}
+			element.Quantity = &quantity
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+// cbvURN renders a bare CBV name in its URN form; values that already are URIs pass through
+func cbvURN(prefix, value string) string {
+	if value == "" || isURI(value) {
+		return value
+	}
+	return prefix + value
+}
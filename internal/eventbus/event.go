@@ -0,0 +1,165 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"time"
+)
+
+// Names of the domain events, also the last part of their broker subject or topic
+const (
+	NameProductCreated       = "product.created"
+	NameEventRecorded        = "event.recorded"
+	NameEventVerified        = "event.verified"
+	NameTransactionConfirmed = "transaction.confirmed"
+	NameTransactionStatus    = "transaction.status_changed"
+	NameStakeholderVerified  = "stakeholder.verified"
+	NameRouteAlertRaised     = "route.alert_raised"
+	NameExcursionBreached    = "excursion.breached"
+	NameRecallNotice         = "recall.notice"
+)
+
+var ErrUnexpectedEvent = errors.New("message does not hold the expected domain event")
+
+// Event is something that happened to one aggregate. Brokers partition by the aggregate ID, so
+// the events of one product, supply chain event or stakeholder stay in order.
+type Event interface {
+	EventName() string
+	AggregateID() uuid.UUID
+}
+
+// ProductCreated is emitted when a product is registered
+type ProductCreated struct {
+	Product *domain.Product `json:"product"`
+}
+
+// EventRecorded is emitted for every supply chain event written, whichever path recorded it
+type EventRecorded struct {
+	Event *domain.SupplyChainEvent `json:"event"`
+}
+
+// EventVerified is emitted when an event is anchored to a blockchain transaction
+type EventVerified struct {
+	EventID        uuid.UUID  `json:"event_id"`
+	ProductID      *uuid.UUID `json:"product_id"`
+	BlockchainHash string     `json:"blockchain_hash"`
+}
+
+// TransactionConfirmed is emitted when a blockchain transaction reaches the confirmed status
+type TransactionConfirmed struct {
+	Transaction *domain.BlockchainTransaction `json:"transaction"`
+}
+
+// TransactionStatusChanged is emitted whenever a blockchain transaction's status changes
+type TransactionStatusChanged struct {
+	Transaction    *domain.BlockchainTransaction `json:"transaction"`
+	PreviousStatus string                        `json:"previous_status"`
+}
+
+// StakeholderVerified is emitted when a stakeholder becomes verified
+type StakeholderVerified struct {
+	StakeholderID uuid.UUID `json:"stakeholder_id"`
+}
+
+// RouteAlertRaised is emitted when a shipment is seen somewhere its route plan does not expect
+type RouteAlertRaised struct {
+	Alert *domain.RouteAlert `json:"alert"`
+}
+
+// ExcursionBreached is emitted when a product stays out of range longer than its excursion rule
+// tolerates, with the products the breach quarantined
+type ExcursionBreached struct {
+	Incident    *domain.ExcursionIncident `json:"incident"`
+	Quarantined []uuid.UUID               `json:"quarantined"`
+}
+
+// RecallNotice is emitted for every notice of an active recall sent to a stakeholder holding
+// affected units, with those units
+type RecallNotice struct {
+	Recall        *domain.Recall       `json:"recall"`
+	StakeholderID uuid.UUID            `json:"stakeholder_id"`
+	Units         []*domain.RecallUnit `json:"units"`
+}
+
+func (e ProductCreated) EventName() string      { return NameProductCreated }
+func (e ProductCreated) AggregateID() uuid.UUID { return e.Product.ID }
+
+func (e EventRecorded) EventName() string      { return NameEventRecorded }
+func (e EventRecorded) AggregateID() uuid.UUID { return e.Event.ID }
+
+func (e EventVerified) EventName() string      { return NameEventVerified }
+func (e EventVerified) AggregateID() uuid.UUID { return e.EventID }
+
+func (e TransactionConfirmed) EventName() string      { return NameTransactionConfirmed }
+func (e TransactionConfirmed) AggregateID() uuid.UUID { return e.Transaction.ID }
+
+func (e TransactionStatusChanged) EventName() string      { return NameTransactionStatus }
+func (e TransactionStatusChanged) AggregateID() uuid.UUID { return e.Transaction.ID }
+
+func (e StakeholderVerified) EventName() string      { return NameStakeholderVerified }
+func (e StakeholderVerified) AggregateID() uuid.UUID { return e.StakeholderID }
+
+func (e RouteAlertRaised) EventName() string      { return NameRouteAlertRaised }
+func (e RouteAlertRaised) AggregateID() uuid.UUID { return e.Alert.ID }
+
+func (e ExcursionBreached) EventName() string      { return NameExcursionBreached }
+func (e ExcursionBreached) AggregateID() uuid.UUID { return e.Incident.ID }
+
+func (e RecallNotice) EventName() string      { return NameRecallNotice }
+func (e RecallNotice) AggregateID() uuid.UUID { return e.Recall.ID }
+
+// Message is a domain event in transport form. ID is unique per event, so consumers and brokers
+// that deduplicate can drop the redeliveries at-least-once publishing may cause.
+type Message struct {
+	ID         uuid.UUID       `json:"id"`
+	Name       string          `json:"name"`
+	Key        string          `json:"key"` // aggregate ID
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// NewMessage encodes a domain event that happened at occurredAt
+func NewMessage(event Event, occurredAt time.Time) (Message, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{
+		ID:         uuid.New(),
+		Name:       event.EventName(),
+		Key:        event.AggregateID().String(),
+		OccurredAt: occurredAt.UTC(),
+		Payload:    payload,
+	}, nil
+}
+
+// Decode reads the domain event a message carries, checking its name
+func Decode[T Event](message Message) (T, error) {
+	var event T
+	if message.Name != event.EventName() {
+		return event, ErrUnexpectedEvent
+	}
+	err := json.Unmarshal(message.Payload, &event)
+	return event, err
+}
+
+// Publisher hands messages to a broker. Publish returns once the broker has accepted every
+// message, so a nil error lets the outbox mark them published.
+type Publisher interface {
+	Publish(ctx context.Context, messages ...Message) error
+	Close() error
+}
+
+// Handler consumes one message; it is how in-process subscribers react to domain events
+type Handler func(ctx context.Context, message Message) error
+
+// subject is where a message is published: the configured prefix and the event name
+func subject(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,51 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/goccy/go-json"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaPublisher writes each message to the topic <prefix>.<event name>, keyed by aggregate ID so
+// one aggregate's events land on one partition in order. Writes wait for all in-sync replicas.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+	prefix string
+}
+
+func NewKafkaPublisher(brokers []string, prefix string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+		prefix: prefix,
+	}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, messages ...Message) error {
+	records := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		records = append(records, kafka.Message{
+			Topic: subject(p.prefix, message.Name),
+			Key:   []byte(message.Key),
+			Value: data,
+			Time:  message.OccurredAt,
+			Headers: []kafka.Header{
+				{Key: "event-id", Value: []byte(message.ID.String())},
+				{Key: "event-name", Value: []byte(message.Name)},
+			},
+		})
+	}
+	return p.writer.WriteMessages(ctx, records...)
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
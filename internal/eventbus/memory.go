@@ -0,0 +1,58 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MemoryBus delivers messages to in-process subscribers as they are published. It is the default
+// publisher and lets tests assert on what was emitted.
+type MemoryBus struct {
+	mu        sync.RWMutex
+	handlers  map[string][]Handler
+	published []Message
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{handlers: map[string][]Handler{}}
+}
+
+// Subscribe calls handler for every message with the given event name; "*" receives all of them
+func (b *MemoryBus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish runs the subscribers of each message in turn and stops at the first error, which
+// leaves that message and the ones after it to be published again
+func (b *MemoryBus) Publish(ctx context.Context, messages ...Message) error {
+	for _, message := range messages {
+		b.mu.RLock()
+		handlers := append(append([]Handler{}, b.handlers[message.Name]...), b.handlers["*"]...)
+		b.mu.RUnlock()
+
+		for _, handler := range handlers {
+			if err := handler(ctx, message); err != nil {
+				return fmt.Errorf("subscriber of %s failed: %w", message.Name, err)
+			}
+		}
+
+		b.mu.Lock()
+		b.published = append(b.published, message)
+		b.mu.Unlock()
+	}
+	return nil
+}
+
+// Published lists every message published so far, oldest first
+func (b *MemoryBus) Published() []Message {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]Message(nil), b.published...)
+}
+
+func (b *MemoryBus) Close() error {
+	return nil
+}
@@ -0,0 +1,69 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSPublisher publishes each message to <prefix>.<event name>. With JetStream every message is
+// acknowledged by the stream and carries its ID as Nats-Msg-Id, so the stream drops redeliveries
+// inside its duplicate window; core NATS only guarantees the server received the batch.
+type NATSPublisher struct {
+	conn   *nats.Conn
+	js     jetstream.JetStream
+	prefix string
+}
+
+func NewNATSPublisher(url, prefix string, useJetStream bool) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url, nats.Name("supply-chain-outbox"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	publisher := &NATSPublisher{conn: conn, prefix: prefix}
+	if useJetStream {
+		if publisher.js, err = jetstream.New(conn); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to open JetStream: %w", err)
+		}
+	}
+	return publisher, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, messages ...Message) error {
+	for _, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		msg := &nats.Msg{
+			Subject: subject(p.prefix, message.Name),
+			Header:  nats.Header{},
+			Data:    data,
+		}
+		msg.Header.Set(nats.MsgIdHdr, message.ID.String())
+		msg.Header.Set("Content-Type", "application/json")
+
+		if p.js != nil {
+			if _, err := p.js.PublishMsg(ctx, msg); err != nil {
+				return fmt.Errorf("failed to publish %s to JetStream: %w", message.ID, err)
+			}
+			continue
+		}
+		if err := p.conn.PublishMsg(msg); err != nil {
+			return fmt.Errorf("failed to publish %s to NATS: %w", message.ID, err)
+		}
+	}
+
+	if p.js == nil {
+		return p.conn.FlushWithContext(ctx)
+	}
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
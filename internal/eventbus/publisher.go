@@ -0,0 +1,27 @@
+package eventbus
+
+import (
+	"fmt"
+	"github.com/koriebruh/suplyChainTrack/conf"
+)
+
+// Event bus drivers
+const (
+	DriverMemory = "memory"
+	DriverNATS   = "nats"
+	DriverKafka  = "kafka"
+)
+
+// NewPublisher opens the publisher the configuration selects; the in-memory bus is the default
+func NewPublisher(config conf.EventBusConfig) (Publisher, error) {
+	switch config.Driver {
+	case "", DriverMemory:
+		return NewMemoryBus(), nil
+	case DriverNATS:
+		return NewNATSPublisher(config.NATSURL, config.Prefix, config.NATSJetStream)
+	case DriverKafka:
+		return NewKafkaPublisher(config.KafkaBrokers, config.Prefix), nil
+	default:
+		return nil, fmt.Errorf("unknown event bus driver %q", config.Driver)
+	}
+}
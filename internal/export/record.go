@@ -0,0 +1,149 @@
+package export
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"strconv"
+	"time"
+)
+
+// EventRecord is the flat, columnar shape of a supply chain event in an export
+type EventRecord struct {
+	ID             string    `json:"id" parquet:"id"`
+	ProductID      *string   `json:"product_id" parquet:"product_id,optional"`
+	ProductSKU     *string   `json:"product_sku" parquet:"product_sku,optional"`
+	StakeholderID  *string   `json:"stakeholder_id" parquet:"stakeholder_id,optional"`
+	EventType      string    `json:"event_type" parquet:"event_type,dict"`
+	Location       *string   `json:"location" parquet:"location,optional"`
+	Timestamp      time.Time `json:"timestamp" parquet:"timestamp,timestamp(millisecond)"`
+	Metadata       *string   `json:"metadata" parquet:"metadata,optional"`
+	BlockchainHash *string   `json:"blockchain_hash" parquet:"blockchain_hash,optional"`
+	IsVerified     bool      `json:"is_verified" parquet:"is_verified"`
+	CreatedAt      time.Time `json:"created_at" parquet:"created_at,timestamp(millisecond)"`
+}
+
+// ProductRecord is the flat, columnar shape of a product in an export
+type ProductRecord struct {
+	ID             string    `json:"id" parquet:"id"`
+	SKU            string    `json:"sku" parquet:"sku"`
+	Name           string    `json:"name" parquet:"name"`
+	Description    *string   `json:"description" parquet:"description,optional"`
+	Category       *string   `json:"category" parquet:"category,optional,dict"`
+	ManufacturerID *string   `json:"manufacturer_id" parquet:"manufacturer_id,optional"`
+	LotNumber      *string   `json:"lot_number" parquet:"lot_number,optional"`
+	SerialNumber   *string   `json:"serial_number" parquet:"serial_number,optional"`
+	Metadata       *string   `json:"metadata" parquet:"metadata,optional"`
+	CreatedAt      time.Time `json:"created_at" parquet:"created_at,timestamp(millisecond)"`
+	UpdatedAt      time.Time `json:"updated_at" parquet:"updated_at,timestamp(millisecond)"`
+}
+
+var eventColumns = []string{
+	"id", "product_id", "product_sku", "stakeholder_id", "event_type", "location",
+	"timestamp", "metadata", "blockchain_hash", "is_verified", "created_at",
+}
+
+var productColumns = []string{
+	"id", "sku", "name", "description", "category", "manufacturer_id",
+	"lot_number", "serial_number", "metadata", "created_at", "updated_at",
+}
+
+// NewEventRecord flattens an event; the product SKU is filled in when Product is preloaded
+func NewEventRecord(event *domain.SupplyChainEvent) (EventRecord, error) {
+	metadata, err := metadataString(event.Metadata)
+	if err != nil {
+		return EventRecord{}, err
+	}
+
+	record := EventRecord{
+		ID:             event.ID.String(),
+		EventType:      event.EventType,
+		Location:       event.Location,
+		Timestamp:      event.Timestamp.UTC(),
+		Metadata:       metadata,
+		BlockchainHash: event.BlockchainHash,
+		IsVerified:     event.IsVerified,
+		CreatedAt:      event.CreatedAt.UTC(),
+	}
+	if event.ProductID != nil {
+		id := event.ProductID.String()
+		record.ProductID = &id
+	}
+	if event.Product != nil {
+		record.ProductSKU = &event.Product.SKU
+	}
+	if event.StakeholderID != nil {
+		id := event.StakeholderID.String()
+		record.StakeholderID = &id
+	}
+	return record, nil
+}
+
+// NewProductRecord flattens a product
+func NewProductRecord(product *domain.Product) (ProductRecord, error) {
+	metadata, err := metadataString(product.Metadata)
+	if err != nil {
+		return ProductRecord{}, err
+	}
+
+	record := ProductRecord{
+		ID:           product.ID.String(),
+		SKU:          product.SKU,
+		Name:         product.Name,
+		Description:  product.Description,
+		Category:     product.Category,
+		LotNumber:    product.LotNumber,
+		SerialNumber: product.SerialNumber,
+		Metadata:     metadata,
+		CreatedAt:    product.CreatedAt.UTC(),
+		UpdatedAt:    product.UpdatedAt.UTC(),
+	}
+	if product.ManufacturerID != nil {
+		id := product.ManufacturerID.String()
+		record.ManufacturerID = &id
+	}
+	return record, nil
+}
+
+func (r EventRecord) columns() []string { return eventColumns }
+
+func (r EventRecord) values() []string {
+	return []string{
+		r.ID, optional(r.ProductID), optional(r.ProductSKU), optional(r.StakeholderID), r.EventType,
+		optional(r.Location), formatTime(r.Timestamp), optional(r.Metadata), optional(r.BlockchainHash),
+		strconv.FormatBool(r.IsVerified), formatTime(r.CreatedAt),
+	}
+}
+
+func (r ProductRecord) columns() []string { return productColumns }
+
+func (r ProductRecord) values() []string {
+	return []string{
+		r.ID, r.SKU, r.Name, optional(r.Description), optional(r.Category), optional(r.ManufacturerID),
+		optional(r.LotNumber), optional(r.SerialNumber), optional(r.Metadata),
+		formatTime(r.CreatedAt), formatTime(r.UpdatedAt),
+	}
+}
+
+// metadataString renders JSONB metadata as one JSON column; empty metadata is null
+func metadataString(metadata domain.JSONB) (*string, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	s := string(b)
+	return &s, nil
+}
+
+func optional(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
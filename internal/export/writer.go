@@ -0,0 +1,133 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"github.com/goccy/go-json"
+	"github.com/parquet-go/parquet-go"
+	"io"
+)
+
+// Supported export formats
+const (
+	FormatCSV     = "csv"
+	FormatNDJSON  = "ndjson"
+	FormatParquet = "parquet"
+)
+
+// Content types of the export formats
+const (
+	ContentTypeCSV     = "text/csv"
+	ContentTypeNDJSON  = "application/x-ndjson"
+	ContentTypeParquet = "application/vnd.apache.parquet"
+)
+
+// parquetRowGroupSize caps the rows buffered in memory before a Parquet row group is written out
+const parquetRowGroupSize = 10000
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Record is a row type the writers know how to lay out
+type Record interface {
+	EventRecord | ProductRecord
+
+	columns() []string
+	values() []string
+}
+
+// Writer streams records of one type to an output in one format. Close must be called to
+// finish the file; it does not close the underlying output.
+type Writer[T Record] interface {
+	Write(records []T) error
+	Close() error
+}
+
+// ContentType is the MIME type of a supported format
+func ContentType(format string) (string, error) {
+	switch format {
+	case FormatCSV:
+		return ContentTypeCSV, nil
+	case FormatNDJSON:
+		return ContentTypeNDJSON, nil
+	case FormatParquet:
+		return ContentTypeParquet, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// NewWriter starts an export in the given format
+func NewWriter[T Record](w io.Writer, format string) (Writer[T], error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter[T](w)
+	case FormatNDJSON:
+		buffered := bufio.NewWriter(w)
+		return &ndjsonWriter[T]{buffered: buffered, encoder: json.NewEncoder(buffered)}, nil
+	case FormatParquet:
+		return &parquetWriter[T]{writer: parquet.NewGenericWriter[T](w, parquet.MaxRowsPerRowGroup(parquetRowGroupSize))}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type csvWriter[T Record] struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter[T Record](w io.Writer) (*csvWriter[T], error) {
+	var zero T
+	writer := csv.NewWriter(w)
+	if err := writer.Write(zero.columns()); err != nil {
+		return nil, err
+	}
+	return &csvWriter[T]{writer: writer}, nil
+}
+
+func (w *csvWriter[T]) Write(records []T) error {
+	for _, record := range records {
+		if err := w.writer.Write(record.values()); err != nil {
+			return err
+		}
+	}
+	// Flush per batch so memory stays bounded by the batch, not the export
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvWriter[T]) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonWriter[T Record] struct {
+	buffered *bufio.Writer
+	encoder  *json.Encoder
+}
+
+func (w *ndjsonWriter[T]) Write(records []T) error {
+	for i := range records {
+		if err := w.encoder.Encode(&records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ndjsonWriter[T]) Close() error {
+	return w.buffered.Flush()
+}
+
+type parquetWriter[T Record] struct {
+	writer *parquet.GenericWriter[T]
+}
+
+func (w *parquetWriter[T]) Write(records []T) error {
+	_, err := w.writer.Write(records)
+	return err
+}
+
+func (w *parquetWriter[T]) Close() error {
+	return w.writer.Close()
+}
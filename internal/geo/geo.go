@@ -0,0 +1,82 @@
+// Package geo measures distances on a spherical earth, the model the locations table's
+// earthdistance queries use, so in-process checks agree with radius queries in the database.
+package geo
+
+import "math"
+
+// EarthRadiusKm is the mean earth radius, the one earthdistance assumes
+const EarthRadiusKm = 6371.0
+
+// Point is a position in decimal degrees
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Valid reports whether p lies on the globe
+func (p Point) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// Distance is the great-circle distance between a and b in kilometres
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLng := radians(b.Longitude - a.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// DistanceToPath is how far p lies from the path through the points in order, in kilometres.
+// A path of one point is that point; an empty path is infinitely far away.
+func DistanceToPath(p Point, path []Point) float64 {
+	switch len(path) {
+	case 0:
+		return math.Inf(1)
+	case 1:
+		return Distance(p, path[0])
+	}
+	nearest := math.Inf(1)
+	for i := 1; i < len(path); i++ {
+		nearest = math.Min(nearest, distanceToSegment(p, path[i-1], path[i]))
+	}
+	return nearest
+}
+
+// distanceToSegment finds the point of the segment a-b closest to p on a plane tangent at p,
+// which is accurate to well under a percent for the legs of a route, and measures the great
+// circle to it
+func distanceToSegment(p, a, b Point) float64 {
+	ax, ay := project(p, a)
+	bx, by := project(p, b)
+	dx, dy := bx-ax, by-ay
+
+	t := 0.0
+	if length := dx*dx + dy*dy; length > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/length))
+	}
+	closest := Point{
+		Latitude:  a.Latitude + t*(b.Latitude-a.Latitude),
+		Longitude: a.Longitude + t*wrap(b.Longitude-a.Longitude),
+	}
+	closest.Longitude = wrap(closest.Longitude)
+	return Distance(p, closest)
+}
+
+// project maps q onto an equirectangular plane centred on origin, in kilometres
+func project(origin, q Point) (x, y float64) {
+	x = radians(wrap(q.Longitude-origin.Longitude)) * math.Cos(radians(origin.Latitude)) * EarthRadiusKm
+	y = radians(q.Latitude-origin.Latitude) * EarthRadiusKm
+	return x, y
+}
+
+// wrap brings a longitude or longitude difference into [-180, 180), so legs crossing the
+// antimeridian take the short way round
+func wrap(degrees float64) float64 {
+	return math.Mod(math.Mod(degrees+180, 360)+360, 360) - 180
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
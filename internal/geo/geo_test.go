@@ -0,0 +1,117 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// kmPerDegree is the length of one degree of a great circle on the model earth
+var kmPerDegree = EarthRadiusKm * math.Pi / 180
+
+func near(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		point Point
+		want  bool
+	}{
+		{Point{Latitude: -6.2, Longitude: 106.8}, true},
+		{Point{Latitude: 90, Longitude: 180}, true},
+		{Point{Latitude: -90, Longitude: -180}, true},
+		{Point{Latitude: 90.0001, Longitude: 0}, false},
+		{Point{Latitude: 0, Longitude: -180.0001}, false},
+		{Point{Latitude: math.NaN(), Longitude: 0}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.point.Valid(); got != tt.want {
+			t.Errorf("%+v.Valid() = %v, want %v", tt.point, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      Point
+		want      float64
+		tolerance float64
+	}{
+		{name: "same point", a: Point{-6.2, 106.8}, b: Point{-6.2, 106.8}, want: 0, tolerance: 1e-9},
+		{name: "one degree along the equator", a: Point{0, 0}, b: Point{0, 1}, want: kmPerDegree, tolerance: 1e-6},
+		{name: "one degree along a meridian", a: Point{10, 20}, b: Point{11, 20}, want: kmPerDegree, tolerance: 1e-6},
+		{name: "across the antimeridian", a: Point{0, 179.5}, b: Point{0, -179.5}, want: kmPerDegree, tolerance: 1e-6},
+		{name: "pole to pole", a: Point{90, 0}, b: Point{-90, 0}, want: math.Pi * EarthRadiusKm, tolerance: 1e-6},
+		{name: "antipodes", a: Point{0, 0}, b: Point{0, 180}, want: math.Pi * EarthRadiusKm, tolerance: 1e-6},
+		{name: "Jakarta to Surabaya", a: Point{-6.2088, 106.8456}, b: Point{-7.2575, 112.7521}, want: 663, tolerance: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); !near(got, tt.want, tt.tolerance) {
+				t.Errorf("Distance(%+v, %+v) = %.6f, want %.6f", tt.a, tt.b, got, tt.want)
+			}
+			if got, back := Distance(tt.a, tt.b), Distance(tt.b, tt.a); !near(got, back, 1e-9) {
+				t.Errorf("Distance is not symmetric: %.9f and %.9f", got, back)
+			}
+		})
+	}
+}
+
+func TestDistanceToPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		point     Point
+		path      []Point
+		want      float64
+		tolerance float64
+	}{
+		{name: "empty path", point: Point{0, 0}, path: nil, want: math.Inf(1)},
+		{name: "single point", point: Point{0, 0}, path: []Point{{0, 1}}, want: kmPerDegree, tolerance: 1e-6},
+		{name: "on a vertex", point: Point{0, 1}, path: []Point{{0, 0}, {0, 1}, {1, 1}}, want: 0, tolerance: 1e-9},
+		{name: "on a segment", point: Point{0, 0.5}, path: []Point{{0, 0}, {0, 1}}, want: 0, tolerance: 1e-9},
+		{name: "beside the middle of a segment", point: Point{1, 0}, path: []Point{{0, -1}, {0, 1}}, want: kmPerDegree, tolerance: 0.5},
+		{name: "past the end of a segment", point: Point{0, 2}, path: []Point{{0, 0}, {0, 1}}, want: kmPerDegree, tolerance: 1e-6},
+		{name: "before the start of a segment", point: Point{0, -1}, path: []Point{{0, 0}, {0, 1}}, want: kmPerDegree, tolerance: 1e-6},
+		{name: "degenerate segment", point: Point{0, 0}, path: []Point{{0, 1}, {0, 1}}, want: kmPerDegree, tolerance: 1e-6},
+		{name: "nearest of several legs", point: Point{0.5, 2}, path: []Point{{0, 0}, {0, 1}, {1, 1}, {1, 2}}, want: 0.5 * kmPerDegree, tolerance: 0.5},
+		{name: "leg crossing the antimeridian", point: Point{0, 180}, path: []Point{{0, 179}, {0, -179}}, want: 0, tolerance: 1e-6},
+		{name: "beside a leg crossing the antimeridian", point: Point{1, -180}, path: []Point{{0, 179}, {0, -179}}, want: kmPerDegree, tolerance: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceToPath(tt.point, tt.path)
+			if math.IsInf(tt.want, 1) {
+				if !math.IsInf(got, 1) {
+					t.Errorf("DistanceToPath(%+v, %v) = %.6f, want +Inf", tt.point, tt.path, got)
+				}
+				return
+			}
+			if !near(got, tt.want, tt.tolerance) {
+				t.Errorf("DistanceToPath(%+v, %v) = %.6f, want %.6f", tt.point, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		degrees float64
+		want    float64
+	}{
+		{0, 0},
+		{179, 179},
+		{180, -180},
+		{-180, -180},
+		{181, -179},
+		{-181, 179},
+		{358, -2},
+		{-358, 2},
+		{720, 0},
+	}
+	for _, tt := range tests {
+		if got := wrap(tt.degrees); !near(got, tt.want, 1e-9) {
+			t.Errorf("wrap(%v) = %v, want %v", tt.degrees, got, tt.want)
+		}
+	}
+}
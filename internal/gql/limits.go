@@ -0,0 +1,156 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"strings"
+)
+
+var (
+	ErrQueryTooDeep    = errors.New("query is nested too deeply")
+	ErrQueryTooComplex = errors.New("query is too complex")
+)
+
+// Limits bound what one operation may ask for. Depth counts nested selections; complexity counts
+// one per field, with everything below a list field multiplied by its limit argument, so it
+// estimates the number of values the response can hold.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+var DefaultLimits = Limits{MaxDepth: 8, MaxComplexity: 5000}
+
+type limitWalker struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// check measures an operation that has passed validation
+func (l Limits) check(schema *graphql.Schema, document *ast.Document, operation *ast.OperationDefinition, variables map[string]interface{}) error {
+	w := &limitWalker{schema: schema, fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			w.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	root := schema.QueryType()
+	if operation.Operation == ast.OperationTypeSubscription {
+		root = schema.SubscriptionType()
+	}
+	if root == nil {
+		return nil
+	}
+
+	depth, complexity := w.selectionSet(root, operation.SelectionSet)
+	if depth > l.MaxDepth {
+		return fmt.Errorf("%w: depth %d exceeds %d", ErrQueryTooDeep, depth, l.MaxDepth)
+	}
+	if complexity > l.MaxComplexity {
+		return fmt.Errorf("%w: complexity %d exceeds %d", ErrQueryTooComplex, complexity, l.MaxComplexity)
+	}
+	return nil
+}
+
+func (w *limitWalker) selectionSet(parent *graphql.Object, set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, c = w.field(parent, selection)
+		case *ast.InlineFragment:
+			d, c = w.selectionSet(w.typeCondition(parent, selection.TypeCondition), selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := w.fragments[selection.Name.Value]; ok {
+				d, c = w.selectionSet(w.typeCondition(parent, fragment.TypeCondition), fragment.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (w *limitWalker) field(parent *graphql.Object, field *ast.Field) (depth, complexity int) {
+	// Introspection is bounded by the schema itself
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+	definition, ok := parent.Fields()[field.Name.Value]
+	if !ok {
+		return 1, 1
+	}
+
+	child, _ := namedType(definition.Type).(*graphql.Object)
+	if child == nil || field.SelectionSet == nil {
+		return 1, 1
+	}
+
+	depth, complexity = w.selectionSet(child, field.SelectionSet)
+	return depth + 1, 1 + w.multiplier(definition, field)*complexity
+}
+
+// multiplier is the limit argument of list fields, or its default
+func (w *limitWalker) multiplier(definition *graphql.FieldDefinition, field *ast.Field) int {
+	for _, arg := range definition.Args {
+		if arg.Name() != "limit" {
+			continue
+		}
+		limit, _ := arg.DefaultValue.(int)
+		for _, given := range field.Arguments {
+			if given.Name.Value == "limit" {
+				limit = w.intValue(given.Value, limit)
+			}
+		}
+		return max(limit, 1)
+	}
+	return 1
+}
+
+func (w *limitWalker) intValue(value ast.Value, fallback int) int {
+	switch value := value.(type) {
+	case *ast.IntValue:
+		if n, ok := graphql.Int.ParseLiteral(value).(int); ok {
+			return n
+		}
+	case *ast.Variable:
+		switch n := w.variables[value.Name.Value].(type) {
+		case int:
+			return n
+		case float64:
+			return int(n)
+		}
+	}
+	return fallback
+}
+
+func (w *limitWalker) typeCondition(parent *graphql.Object, condition *ast.Named) *graphql.Object {
+	if condition == nil {
+		return parent
+	}
+	if object, ok := w.schema.Type(condition.Name.Value).(*graphql.Object); ok {
+		return object
+	}
+	return parent
+}
+
+func namedType(t graphql.Type) graphql.Type {
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			t = wrapped.OfType
+		default:
+			return t
+		}
+	}
+}
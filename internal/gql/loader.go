@@ -0,0 +1,125 @@
+package gql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"sync"
+)
+
+// Loader batches lookups into one fetch. Load only records the key and returns a thunk; the
+// executor resolves thunks breadth-first, so by the time the first one runs every key of that
+// level of the query has been recorded and they are all fetched together.
+type Loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending *batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	seen    map[K]struct{}
+	once    sync.Once
+	results map[K]V
+	err     error
+}
+
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch}
+}
+
+// Load queues key for the next fetch. Missing keys resolve to V's zero value.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (interface{}, error) {
+	l.mu.Lock()
+	b := l.pending
+	if b == nil {
+		b = &batch[K, V]{seen: make(map[K]struct{})}
+		l.pending = b
+	}
+	if _, ok := b.seen[key]; !ok {
+		b.seen[key] = struct{}{}
+		b.keys = append(b.keys, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		b.once.Do(func() {
+			// Later loads start a new batch
+			l.mu.Lock()
+			if l.pending == b {
+				l.pending = nil
+			}
+			l.mu.Unlock()
+			b.results, b.err = l.fetch(ctx, b.keys)
+		})
+		if b.err != nil {
+			return nil, b.err
+		}
+		return b.results[key], nil
+	}
+}
+
+// childKey asks for up to Limit children of a parent
+type childKey struct {
+	ParentID uuid.UUID
+	Limit    int
+}
+
+// loaders are the per-request batchers behind every relation in the schema
+type loaders struct {
+	products             *Loader[uuid.UUID, *domain.Product]
+	stakeholders         *Loader[uuid.UUID, *domain.Stakeholder]
+	events               *Loader[uuid.UUID, *domain.SupplyChainEvent]
+	productEvents        *Loader[childKey, []*domain.SupplyChainEvent]
+	stakeholderEvents    *Loader[childKey, []*domain.SupplyChainEvent]
+	manufacturerProducts *Loader[childKey, []*domain.Product]
+	eventTransactions    *Loader[childKey, []*domain.BlockchainTransaction]
+}
+
+func newLoaders(batch services.BatchService) *loaders {
+	return &loaders{
+		products:             NewLoader(batch.ProductsByIDs),
+		stakeholders:         NewLoader(batch.StakeholdersByIDs),
+		events:               NewLoader(batch.EventsByIDs),
+		productEvents:        NewLoader(children(batch.EventsByProducts)),
+		stakeholderEvents:    NewLoader(children(batch.EventsByStakeholders)),
+		manufacturerProducts: NewLoader(children(batch.ProductsByManufacturers)),
+		eventTransactions:    NewLoader(children(batch.TransactionsByEvents)),
+	}
+}
+
+// children adapts a batch lookup of up to n children per parent to childKey loaders, fetching once
+// per distinct limit
+func children[V any](fetch func(ctx context.Context, parentIDs []uuid.UUID, n int) (map[uuid.UUID][]V, error)) func(ctx context.Context, keys []childKey) (map[childKey][]V, error) {
+	return func(ctx context.Context, keys []childKey) (map[childKey][]V, error) {
+		byLimit := make(map[int][]uuid.UUID)
+		for _, key := range keys {
+			byLimit[key.Limit] = append(byLimit[key.Limit], key.ParentID)
+		}
+
+		results := make(map[childKey][]V, len(keys))
+		for limit, parentIDs := range byLimit {
+			groups, err := fetch(ctx, parentIDs, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, parentID := range parentIDs {
+				// An empty list rather than null for parents without children
+				results[childKey{ParentID: parentID, Limit: limit}] = append([]V{}, groups[parentID]...)
+			}
+		}
+		return results, nil
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, batch services.BatchService) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(batch))
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
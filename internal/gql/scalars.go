@@ -0,0 +1,79 @@
+package gql
+
+import (
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// UUID is serialized as its canonical string
+var UUID = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "UUID",
+	Description: "A UUID in its canonical 36-character form.",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case uuid.UUID:
+			return value.String()
+		case *uuid.UUID:
+			return value.String()
+		default:
+			return nil
+		}
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			if id, err := uuid.Parse(s); err == nil {
+				return id
+			}
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if s, ok := valueAST.(*ast.StringValue); ok {
+			if id, err := uuid.Parse(s.Value); err == nil {
+				return id
+			}
+		}
+		return nil
+	},
+})
+
+// JSON carries free-form metadata objects as they are stored
+var JSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "An arbitrary JSON value.",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: parseJSONLiteral,
+})
+
+func parseJSONLiteral(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.ObjectValue:
+		object := make(map[string]interface{}, len(valueAST.Fields))
+		for _, field := range valueAST.Fields {
+			object[field.Name.Value] = parseJSONLiteral(field.Value)
+		}
+		return object
+	case *ast.ListValue:
+		list := make([]interface{}, 0, len(valueAST.Values))
+		for _, value := range valueAST.Values {
+			list = append(list, parseJSONLiteral(value))
+		}
+		return list
+	case *ast.StringValue, *ast.EnumValue:
+		return valueAST.GetValue()
+	case *ast.IntValue:
+		return graphql.Int.ParseLiteral(valueAST)
+	case *ast.FloatValue:
+		return graphql.Float.ParseLiteral(valueAST)
+	case *ast.BooleanValue:
+		return valueAST.Value
+	default:
+		return nil
+	}
+}
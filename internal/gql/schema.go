@@ -0,0 +1,525 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"github.com/koriebruh/suplyChainTrack/internal/stream"
+	"slices"
+)
+
+// Page sizes for root lists (as in the REST API) and for nested relation lists
+const (
+	defaultPageSize     = 10
+	defaultRelationSize = 20
+	maxPageSize         = 100
+)
+
+// schemaBuilder holds the object types while they reference each other
+type schemaBuilder struct {
+	services *services.ServiceManager
+
+	product     *graphql.Object
+	stakeholder *graphql.Object
+	event       *graphql.Object
+	transaction *graphql.Object
+}
+
+// newSchema mirrors internal/domain: field names are the JSON names of the REST API, and relations
+// are resolved through the request's loaders
+func newSchema(services *services.ServiceManager) (graphql.Schema, error) {
+	b := &schemaBuilder{services: services}
+
+	b.stakeholder = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Stakeholder",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":             {Type: graphql.NewNonNull(UUID)},
+				"name":           {Type: graphql.NewNonNull(graphql.String)},
+				"type":           {Type: graphql.NewNonNull(graphql.String)},
+				"wallet_address": {Type: graphql.String},
+				"email":          {Type: graphql.NewNonNull(graphql.String)},
+				"phone":          {Type: graphql.String},
+				"address":        {Type: graphql.String},
+				"is_verified":    {Type: graphql.NewNonNull(graphql.Boolean)},
+				"created_at":     {Type: graphql.NewNonNull(graphql.DateTime)},
+				"updated_at":     {Type: graphql.NewNonNull(graphql.DateTime)},
+				"products": b.relationList(b.product, "Newest products it manufactured", func(p graphql.ResolveParams, limit int) func() (interface{}, error) {
+					return loadersFrom(p.Context).manufacturerProducts.Load(p.Context, childKey{ParentID: p.Source.(*domain.Stakeholder).ID, Limit: limit})
+				}),
+				"events": b.relationList(b.event, "Latest events it recorded", func(p graphql.ResolveParams, limit int) func() (interface{}, error) {
+					return loadersFrom(p.Context).stakeholderEvents.Load(p.Context, childKey{ParentID: p.Source.(*domain.Stakeholder).ID, Limit: limit})
+				}),
+			}
+		}),
+	})
+
+	b.product = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":              {Type: graphql.NewNonNull(UUID)},
+				"sku":             {Type: graphql.NewNonNull(graphql.String)},
+				"name":            {Type: graphql.NewNonNull(graphql.String)},
+				"description":     {Type: graphql.String},
+				"category":        {Type: graphql.String},
+				"manufacturer_id": {Type: UUID},
+				"lot_number":      {Type: graphql.String},
+				"serial_number":   {Type: graphql.String},
+				"metadata":        {Type: JSON},
+				"created_at":      {Type: graphql.NewNonNull(graphql.DateTime)},
+				"updated_at":      {Type: graphql.NewNonNull(graphql.DateTime)},
+				"manufacturer": {
+					Type: b.stakeholder,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						product := p.Source.(*domain.Product)
+						if product.Manufacturer != nil {
+							return product.Manufacturer, nil
+						}
+						return loadStakeholder(p, product.ManufacturerID)
+					},
+				},
+				"events": b.relationList(b.event, "First events in timestamp order", func(p graphql.ResolveParams, limit int) func() (interface{}, error) {
+					return loadersFrom(p.Context).productEvents.Load(p.Context, childKey{ParentID: p.Source.(*domain.Product).ID, Limit: limit})
+				}),
+			}
+		}),
+	})
+
+	b.event = graphql.NewObject(graphql.ObjectConfig{
+		Name: "SupplyChainEvent",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":              {Type: graphql.NewNonNull(UUID)},
+				"product_id":      {Type: UUID},
+				"stakeholder_id":  {Type: UUID},
+				"event_type":      {Type: graphql.NewNonNull(graphql.String)},
+				"location":        {Type: graphql.String},
+				"location_id":     {Type: UUID},
+				"timestamp":       {Type: graphql.NewNonNull(graphql.DateTime)},
+				"metadata":        {Type: JSON},
+				"blockchain_hash": {Type: graphql.String},
+				"is_verified":     {Type: graphql.NewNonNull(graphql.Boolean)},
+				"created_at":      {Type: graphql.NewNonNull(graphql.DateTime)},
+				"product": {
+					Type: b.product,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						event := p.Source.(*domain.SupplyChainEvent)
+						if event.Product != nil {
+							return event.Product, nil
+						}
+						if event.ProductID == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).products.Load(p.Context, *event.ProductID), nil
+					},
+				},
+				"stakeholder": {
+					Type: b.stakeholder,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						event := p.Source.(*domain.SupplyChainEvent)
+						if event.Stakeholder != nil {
+							return event.Stakeholder, nil
+						}
+						return loadStakeholder(p, event.StakeholderID)
+					},
+				},
+				"transactions": b.relationList(b.transaction, "Latest blockchain transactions", func(p graphql.ResolveParams, limit int) func() (interface{}, error) {
+					return loadersFrom(p.Context).eventTransactions.Load(p.Context, childKey{ParentID: p.Source.(*domain.SupplyChainEvent).ID, Limit: limit})
+				}),
+			}
+		}),
+	})
+
+	b.transaction = graphql.NewObject(graphql.ObjectConfig{
+		Name: "BlockchainTransaction",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":               {Type: graphql.NewNonNull(UUID)},
+				"event_id":         {Type: UUID},
+				"transaction_hash": {Type: graphql.NewNonNull(graphql.String)},
+				"block_number":     {Type: graphql.Int},
+				"gas_used":         {Type: graphql.Int},
+				"status":           {Type: graphql.NewNonNull(graphql.String)},
+				"created_at":       {Type: graphql.NewNonNull(graphql.DateTime)},
+				"event": {
+					Type: b.event,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						transaction := p.Source.(*domain.BlockchainTransaction)
+						if transaction.Event != nil {
+							return transaction.Event, nil
+						}
+						if transaction.EventID == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).events.Load(p.Context, *transaction.EventID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:        b.query(),
+		Subscription: b.subscription(),
+	})
+}
+
+func (b *schemaBuilder) query() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"product": {
+				Type: b.product,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(UUID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return b.services.Product.GetProduct(p.Context, p.Args["id"].(uuid.UUID))
+				},
+			},
+			"product_by_sku": {
+				Type: b.product,
+				Args: graphql.FieldConfigArgument{"sku": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return b.services.Product.GetProductBySKU(p.Context, p.Args["sku"].(string))
+				},
+			},
+			"products": b.rootList("Product", b.product, listquery.Products, graphql.InputObjectConfigFieldMap{
+				"category":        {Type: graphql.String},
+				"manufacturer_id": {Type: UUID},
+				"sku":             {Type: graphql.String},
+				"name":            {Type: graphql.String},
+				"lot_number":      {Type: graphql.String},
+			}, func(ctx context.Context, args map[string]interface{}, page paging.Request, query listquery.Options) (*dto.PaginatedResponse, error) {
+				filter := &dto.ProductFilter{}
+				if err := decodeFilter(args, filter); err != nil {
+					return nil, err
+				}
+				filter.Limit, filter.Offset, filter.Cursor, filter.Count = page.Limit, page.Offset, page.Cursor, page.Count
+				filter.Query = query
+				return b.services.Product.ListProducts(ctx, filter)
+			}),
+			"stakeholder": {
+				Type: b.stakeholder,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(UUID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return b.services.Stakeholder.GetStakeholder(p.Context, p.Args["id"].(uuid.UUID))
+				},
+			},
+			"stakeholders": b.rootList("Stakeholder", b.stakeholder, listquery.Stakeholders, graphql.InputObjectConfigFieldMap{
+				"type":        {Type: graphql.String},
+				"is_verified": {Type: graphql.Boolean},
+				"email":       {Type: graphql.String},
+			}, func(ctx context.Context, args map[string]interface{}, page paging.Request, query listquery.Options) (*dto.PaginatedResponse, error) {
+				filter := &dto.StakeholderFilter{}
+				if err := decodeFilter(args, filter); err != nil {
+					return nil, err
+				}
+				filter.Limit, filter.Offset, filter.Cursor, filter.Count = page.Limit, page.Offset, page.Cursor, page.Count
+				filter.Query = query
+				return b.services.Stakeholder.ListStakeholders(ctx, filter)
+			}),
+			"event": {
+				Type: b.event,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(UUID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return b.services.SupplyChain.GetEvent(p.Context, p.Args["id"].(uuid.UUID))
+				},
+			},
+			"events": b.rootList("SupplyChainEvent", b.event, listquery.Events, graphql.InputObjectConfigFieldMap{
+				"product_id":     {Type: UUID},
+				"stakeholder_id": {Type: UUID},
+				"event_type":     {Type: graphql.String},
+				"event_types":    {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				"location":       {Type: graphql.String},
+				"location_id":    {Type: UUID},
+				"near":           {Type: geoRadiusInput},
+				"is_verified":    {Type: graphql.Boolean},
+				"from_date":      {Type: graphql.DateTime},
+				"to_date":        {Type: graphql.DateTime},
+			}, func(ctx context.Context, args map[string]interface{}, page paging.Request, query listquery.Options) (*dto.PaginatedResponse, error) {
+				filter := &dto.SupplyChainEventFilter{}
+				if err := decodeFilter(args, filter); err != nil {
+					return nil, err
+				}
+				filter.Limit, filter.Offset, filter.Cursor, filter.Count = page.Limit, page.Offset, page.Cursor, page.Count
+				filter.Query = query
+				return b.services.SupplyChain.ListEvents(ctx, filter)
+			}),
+			"transaction": {
+				Type: b.transaction,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(UUID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return b.services.Blockchain.GetTransaction(p.Context, p.Args["id"].(uuid.UUID))
+				},
+			},
+			"transaction_by_hash": {
+				Type: b.transaction,
+				Args: graphql.FieldConfigArgument{"hash": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return b.services.Blockchain.GetTransactionByHash(p.Context, p.Args["hash"].(string))
+				},
+			},
+			"transactions": b.rootList("BlockchainTransaction", b.transaction, listquery.Transactions, graphql.InputObjectConfigFieldMap{
+				"event_id": {Type: UUID},
+				"status":   {Type: graphql.String},
+			}, func(ctx context.Context, args map[string]interface{}, page paging.Request, query listquery.Options) (*dto.PaginatedResponse, error) {
+				filter := &dto.BlockchainTransactionFilter{}
+				if err := decodeFilter(args, filter); err != nil {
+					return nil, err
+				}
+				filter.Limit, filter.Offset, filter.Cursor, filter.Count = page.Limit, page.Offset, page.Cursor, page.Count
+				filter.Query = query
+				return b.services.Blockchain.ListTransactions(ctx, filter)
+			}),
+		},
+	})
+}
+
+// subscription pushes events as they are recorded or verified, through the same stream as the
+// WebSocket/SSE endpoints and with the same stakeholder restriction
+func (b *schemaBuilder) subscription() *graphql.Object {
+	message := graphql.NewObject(graphql.ObjectConfig{
+		Name: "EventMessage",
+		Fields: graphql.Fields{
+			"cursor": {Type: graphql.NewNonNull(graphql.String), Description: "Pass back as cursor to resume after a reconnect"},
+			"topic":  {Type: graphql.NewNonNull(graphql.String), Description: "event.recorded or event.verified"},
+			"event":  {Type: graphql.NewNonNull(b.event)},
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"events": {
+				Type: graphql.NewNonNull(message),
+				Args: graphql.FieldConfigArgument{
+					"product_id":     {Type: UUID},
+					"lot_number":     {Type: graphql.String},
+					"stakeholder_id": {Type: UUID},
+					"event_type":     {Type: graphql.String},
+					"topics":         {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+					"cursor":         {Type: graphql.String},
+				},
+				Subscribe: b.subscribeEvents,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+}
+
+func (b *schemaBuilder) subscribeEvents(p graphql.ResolveParams) (interface{}, error) {
+	var cursor *stream.Cursor
+	if value, ok := p.Args["cursor"].(string); ok {
+		parsed, err := stream.ParseCursor(value)
+		if err != nil {
+			return nil, err
+		}
+		cursor = &parsed
+	}
+
+	var filter stream.Filter
+	if err := decodeFilter(p.Args, &filter); err != nil {
+		return nil, err
+	}
+	filter.ID = "graphql"
+	var topics []string
+	for _, topic := range asList(p.Args["topics"]) {
+		topics = append(topics, topic.(string))
+	}
+
+	conn, err := b.services.Stream.Open(p.Context, claimsFrom(p.Context), cursor)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Subscribe(filter); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	messages := make(chan interface{})
+	go func() {
+		defer close(messages)
+		defer conn.Close()
+		for message := range conn.Messages() {
+			if len(topics) > 0 && !slices.Contains(topics, message.Topic) {
+				continue
+			}
+			select {
+			case messages <- message:
+			case <-p.Context.Done():
+				return
+			}
+		}
+	}()
+	return messages, nil
+}
+
+// rootList is a paginated list field taking a filter input object mirroring a dto filter
+func (b *schemaBuilder) rootList(name string, item *graphql.Object, resource listquery.Resource, filterFields graphql.InputObjectConfigFieldMap, list func(ctx context.Context, filter map[string]interface{}, page paging.Request, query listquery.Options) (*dto.PaginatedResponse, error)) *graphql.Field {
+	page := graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Page",
+		Fields: graphql.Fields{
+			"data":            {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))},
+			"total":           {Type: graphql.Int, Description: "null when count is none"},
+			"total_estimated": {Type: graphql.NewNonNull(graphql.Boolean)},
+			"limit":           {Type: graphql.NewNonNull(graphql.Int)},
+			"offset":          {Type: graphql.NewNonNull(graphql.Int)},
+			"has_more":        {Type: graphql.NewNonNull(graphql.Boolean)},
+			"next_cursor":     {Type: graphql.String, Description: "pass as cursor to get the next page"},
+		},
+	})
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   name + "Filter",
+		Fields: filterFields,
+	})
+
+	return &graphql.Field{
+		Type: graphql.NewNonNull(page),
+		Args: graphql.FieldConfigArgument{
+			"filter": {Type: filter},
+			"limit":  {Type: graphql.Int, DefaultValue: defaultPageSize},
+			"offset": {Type: graphql.Int, DefaultValue: 0},
+			"cursor": {Type: graphql.String, Description: "next_cursor of the previous page; replaces offset"},
+			"count":  {Type: graphql.String, Description: "exact, estimated or none; exact on the first page and none after a cursor by default"},
+			"sort":   {Type: graphql.String, Description: "comma separated fields, - for descending, e.g. -timestamp,location"},
+			"where":  {Type: graphql.NewList(graphql.NewNonNull(predicateInput)), Description: "predicates on top of filter, all of which must match"},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			limit, err := limitArg(p, maxPageSize)
+			if err != nil {
+				return nil, err
+			}
+			page := paging.Request{Limit: limit}
+			page.Offset, _ = p.Args["offset"].(int)
+			if page.Offset < 0 {
+				return nil, fmt.Errorf("offset must not be negative")
+			}
+			if cursor, ok := p.Args["cursor"].(string); ok {
+				if page.Cursor, err = paging.ParseCursor(cursor); err != nil {
+					return nil, err
+				}
+			}
+			if count, ok := p.Args["count"].(string); ok {
+				if !paging.IsValidCount(count) {
+					return nil, paging.ErrInvalidCount
+				}
+				page.Count = count
+			}
+			query, err := listQueryArgs(p, resource)
+			if err != nil {
+				return nil, err
+			}
+			args, _ := p.Args["filter"].(map[string]interface{})
+			return list(p.Context, args, page, query)
+		},
+	}
+}
+
+// predicateInput is a list query predicate, as filter[field][op]=value is in the REST API
+var predicateInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "Predicate",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"field": {Type: graphql.NewNonNull(graphql.String), Description: "a field, or a key path in a JSON field such as metadata.temperature"},
+		"op":    {Type: graphql.String, DefaultValue: listquery.OpEq, Description: "eq, ne, gt, gte, lt, lte, in, like or exists"},
+		"value": {Type: graphql.NewNonNull(graphql.String), Description: "comma separated for in, true or false for exists"},
+	},
+})
+
+// geoRadiusInput selects the events recorded at registered locations within a radius
+var geoRadiusInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "GeoRadius",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"latitude":  {Type: graphql.NewNonNull(graphql.Float)},
+		"longitude": {Type: graphql.NewNonNull(graphql.Float)},
+		"radius_km": {Type: graphql.NewNonNull(graphql.Float)},
+	},
+})
+
+// listQueryArgs reads the sort and where arguments of a root list. Relations resolve through the
+// loaders and the selection set picks the fields, so include and fields have no GraphQL argument.
+func listQueryArgs(p graphql.ResolveParams, resource listquery.Resource) (listquery.Options, error) {
+	var query listquery.Options
+	var err error
+	if sort, ok := p.Args["sort"].(string); ok {
+		if query.Sort, err = listquery.ParseSort(resource, sort); err != nil {
+			return listquery.Options{}, err
+		}
+	}
+	where, _ := p.Args["where"].([]interface{})
+	if len(where) > listquery.MaxFilters {
+		return listquery.Options{}, fmt.Errorf("%w: at most %d predicates", listquery.ErrInvalidQuery, listquery.MaxFilters)
+	}
+	for _, item := range where {
+		arg := item.(map[string]interface{})
+		op, _ := arg["op"].(string)
+		predicate, err := listquery.ParsePredicate(resource, arg["field"].(string), op, arg["value"].(string))
+		if err != nil {
+			return listquery.Options{}, err
+		}
+		query.Filters = append(query.Filters, predicate)
+	}
+	return query, nil
+}
+
+// relationList is a nested list resolved through a loader, up to limit items per parent. The
+// item type is read lazily because the object types reference each other.
+func (b *schemaBuilder) relationList(item *graphql.Object, description string, load func(p graphql.ResolveParams, limit int) func() (interface{}, error)) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item))),
+		Description: description,
+		Args: graphql.FieldConfigArgument{
+			"limit": {Type: graphql.Int, DefaultValue: defaultRelationSize},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			limit, err := limitArg(p, maxPageSize)
+			if err != nil {
+				return nil, err
+			}
+			return load(p, limit), nil
+		},
+	}
+}
+
+func loadStakeholder(p graphql.ResolveParams, id *uuid.UUID) (interface{}, error) {
+	if id == nil {
+		return nil, nil
+	}
+	return loadersFrom(p.Context).stakeholders.Load(p.Context, *id), nil
+}
+
+func limitArg(p graphql.ResolveParams, max int) (int, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit < 1 || limit > max {
+		return 0, fmt.Errorf("limit must be between 1 and %d", max)
+	}
+	return limit, nil
+}
+
+// decodeFilter copies GraphQL arguments into a dto filter; both use the same JSON names
+func decodeFilter(args map[string]interface{}, filter interface{}) error {
+	if len(args) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+	if err := json.Unmarshal(data, filter); err != nil {
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+	return nil
+}
+
+func asList(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
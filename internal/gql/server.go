@@ -0,0 +1,144 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"github.com/koriebruh/suplyChainTrack/internal/stream"
+)
+
+var ErrSubscriptionOverHTTP = errors.New("subscriptions are only served over WebSocket")
+
+// Request is a GraphQL request as sent over HTTP or inside a WebSocket subscribe message
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Server runs GraphQL operations against the services
+type Server struct {
+	schema   graphql.Schema
+	services *services.ServiceManager
+	limits   Limits
+}
+
+func NewServer(services *services.ServiceManager, limits Limits) (*Server, error) {
+	schema, err := newSchema(services)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build GraphQL schema: %w", err)
+	}
+	return &Server{schema: schema, services: services, limits: limits}, nil
+}
+
+// Execute runs a query. Every request gets its own loaders, so relations are batched per request.
+func (s *Server) Execute(ctx context.Context, req *Request) *graphql.Result {
+	document, operation, result := s.prepare(req)
+	if result != nil {
+		return result
+	}
+	if operation.Operation == ast.OperationTypeSubscription {
+		return errorResult(ErrSubscriptionOverHTTP)
+	}
+	return s.execute(ctx, req, document)
+}
+
+// Subscribe runs a subscription until ctx is cancelled or its stream ends, sending one result per
+// event; claims limit which events it sees. Queries are answered with a single result.
+func (s *Server) Subscribe(ctx context.Context, req *Request, claims *stream.Claims) <-chan *graphql.Result {
+	document, operation, result := s.prepare(req)
+	if result == nil && operation.Operation != ast.OperationTypeSubscription {
+		result = s.execute(ctx, req, document)
+	}
+	if result != nil {
+		results := make(chan *graphql.Result, 1)
+		results <- result
+		close(results)
+		return results
+	}
+
+	ctx = context.WithValue(withLoaders(ctx, s.services.Batch), claimsKey{}, claims)
+	return graphql.ExecuteSubscription(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           document,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
+
+func (s *Server) execute(ctx context.Context, req *Request, document *ast.Document) *graphql.Result {
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           document,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, s.services.Batch),
+	})
+}
+
+// prepare parses, validates and measures a request; a non-nil result holds its errors
+func (s *Server) prepare(req *Request) (*ast.Document, *ast.OperationDefinition, *graphql.Result) {
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return nil, nil, errorResult(err)
+	}
+
+	validation := graphql.ValidateDocument(&s.schema, document, nil)
+	if !validation.IsValid {
+		return nil, nil, &graphql.Result{Errors: validation.Errors}
+	}
+
+	operation, err := findOperation(document, req.OperationName)
+	if err != nil {
+		return nil, nil, errorResult(err)
+	}
+	if err := s.limits.check(&s.schema, document, operation, req.Variables); err != nil {
+		return nil, nil, errorResult(err)
+	}
+
+	return document, operation, nil
+}
+
+func findOperation(document *ast.Document, name string) (*ast.OperationDefinition, error) {
+	var found *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if found != nil {
+				return nil, errors.New("operationName is required when the document has several operations")
+			}
+			found = operation
+		} else if operation.Name != nil && operation.Name.Value == name {
+			return operation, nil
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("unknown operation %q", name)
+	}
+	return found, nil
+}
+
+func errorResult(err error) *graphql.Result {
+	return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+}
+
+type claimsKey struct{}
+
+func claimsFrom(ctx context.Context) *stream.Claims {
+	if claims, ok := ctx.Value(claimsKey{}).(*stream.Claims); ok {
+		return claims
+	}
+	return &stream.Claims{}
+}
@@ -0,0 +1,109 @@
+package grpcapi
+
+import (
+	"context"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	pb "github.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1"
+)
+
+type blockchainServer struct {
+	pb.UnimplementedBlockchainServiceServer
+	service services.BlockchainService
+}
+
+func (s *blockchainServer) CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.BlockchainTransaction, error) {
+	eventID, err := parseOptionalID("event_id", req.EventId)
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	transaction, err := s.service.CreateTransaction(ctx, &dto.CreateBlockchainTransactionRequest{
+		EventID:         eventID,
+		TransactionHash: req.GetTransactionHash(),
+		BlockNumber:     req.BlockNumber,
+		GasUsed:         req.GasUsed,
+		Status:          req.GetStatus(),
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to create transaction")
+	}
+	return toTransaction(transaction), nil
+}
+
+func (s *blockchainServer) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.BlockchainTransaction, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	transaction, err := s.service.GetTransaction(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "failed to get transaction")
+	}
+	return toTransaction(transaction), nil
+}
+
+func (s *blockchainServer) GetTransactionByHash(ctx context.Context, req *pb.GetTransactionByHashRequest) (*pb.BlockchainTransaction, error) {
+	transaction, err := s.service.GetTransactionByHash(ctx, req.GetTransactionHash())
+	if err != nil {
+		return nil, toStatus(err, "failed to get transaction")
+	}
+	return toTransaction(transaction), nil
+}
+
+func (s *blockchainServer) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	page, err := pageRequest(req.GetLimit(), req.GetOffset(), req.Cursor, req.GetCount())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	query, err := listQuery(listquery.Transactions, req.GetSort(), req.GetWhere(), req.GetInclude(), req.GetFields())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	eventID, err := parseOptionalID("event_id", req.EventId)
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	result, err := s.service.ListTransactions(ctx, &dto.BlockchainTransactionFilter{
+		EventID: eventID,
+		Status:  req.Status,
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+		Cursor:  page.Cursor,
+		Count:   page.Count,
+		Query:   query,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to list transactions")
+	}
+	transactions, _ := result.Data.([]*domain.BlockchainTransaction)
+	return &pb.ListTransactionsResponse{Transactions: selectFields(toList(transactions, toTransaction), query), Page: toPage(result)}, nil
+}
+
+func (s *blockchainServer) UpdateTransactionStatus(ctx context.Context, req *pb.UpdateTransactionStatusRequest) (*pb.BlockchainTransaction, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	if err := s.service.UpdateTransactionStatus(ctx, id, req.GetStatus(), req.BlockNumber); err != nil {
+		return nil, toStatus(err, "failed to update transaction status")
+	}
+	transaction, err := s.service.GetTransaction(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "failed to get transaction")
+	}
+	return toTransaction(transaction), nil
+}
+
+func (s *blockchainServer) ListTransactionsByEvent(ctx context.Context, req *pb.ListTransactionsByEventRequest) (*pb.ListTransactionsByEventResponse, error) {
+	eventID, err := parseID("event_id", req.GetEventId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	transactions, err := s.service.GetTransactionsByEvent(ctx, eventID)
+	if err != nil {
+		return nil, toStatus(err, "failed to get transactions by event")
+	}
+	return &pb.ListTransactionsByEventResponse{Transactions: toList(transactions, toTransaction)}, nil
+}
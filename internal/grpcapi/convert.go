@@ -0,0 +1,266 @@
+package grpcapi
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	pb "github.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"time"
+)
+
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %s must be a UUID", errInvalidArgument, field)
+	}
+	return id, nil
+}
+
+func parseOptionalID(field string, value *string) (*uuid.UUID, error) {
+	if value == nil {
+		return nil, nil
+	}
+	id, err := parseID(field, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func parseIDs(field string, values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := parseID(field, value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// pageRequest reads the paging fields every list request carries
+func pageRequest(limit, offset int32, cursor *string, count string) (paging.Request, error) {
+	if offset < 0 {
+		return paging.Request{}, fmt.Errorf("%w: offset must not be negative", errInvalidArgument)
+	}
+	page := paging.Request{Limit: pageSize(limit), Offset: int(offset), Count: count}
+	if cursor != nil {
+		parsed, err := paging.ParseCursor(*cursor)
+		if err != nil {
+			return paging.Request{}, err
+		}
+		page.Cursor = parsed
+	}
+	if count != "" && !paging.IsValidCount(count) {
+		return paging.Request{}, paging.ErrInvalidCount
+	}
+	return page, nil
+}
+
+// listQuery reads the list query fields of a list request
+func listQuery(resource listquery.Resource, sort string, where []*pb.Predicate, include, fields []string) (listquery.Options, error) {
+	var query listquery.Options
+	var err error
+	if query.Sort, err = listquery.ParseSort(resource, sort); err != nil {
+		return listquery.Options{}, err
+	}
+	if query.Include, err = listquery.ParseInclude(resource, strings.Join(include, ",")); err != nil {
+		return listquery.Options{}, err
+	}
+	if query.Fields, err = listquery.ParseFields(resource, strings.Join(fields, ",")); err != nil {
+		return listquery.Options{}, err
+	}
+	if len(where) > listquery.MaxFilters {
+		return listquery.Options{}, fmt.Errorf("%w: at most %d predicates", listquery.ErrInvalidQuery, listquery.MaxFilters)
+	}
+	for _, predicate := range where {
+		op := predicate.GetOp()
+		if op == "" {
+			op = listquery.OpEq
+		}
+		parsed, err := listquery.ParsePredicate(resource, predicate.GetField(), op, predicate.GetValue())
+		if err != nil {
+			return listquery.Options{}, err
+		}
+		query.Filters = append(query.Filters, parsed)
+	}
+	return query, nil
+}
+
+// selectFields clears every field of the messages the query did not select, keeping the id and
+// the included relations. Proto field names match the REST field names.
+func selectFields[M proto.Message](messages []M, query listquery.Options) []M {
+	if len(query.Fields) == 0 {
+		return messages
+	}
+	keep := map[protoreflect.Name]bool{"id": true}
+	for _, name := range query.Fields {
+		keep[protoreflect.Name(name)] = true
+	}
+	for _, name := range query.Include {
+		keep[protoreflect.Name(name)] = true
+	}
+	for _, message := range messages {
+		m := message.ProtoReflect()
+		var clear []protoreflect.FieldDescriptor
+		m.Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if !keep[field.Name()] {
+				clear = append(clear, field)
+			}
+			return true
+		})
+		for _, field := range clear {
+			m.Clear(field)
+		}
+	}
+	return messages
+}
+
+func optionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	value := id.String()
+	return &value
+}
+
+func optionalTime(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := t.AsTime()
+	return &value
+}
+
+func geoRadius(near *pb.GeoRadius) *dto.GeoRadius {
+	if near == nil {
+		return nil
+	}
+	return &dto.GeoRadius{Latitude: near.GetLatitude(), Longitude: near.GetLongitude(), RadiusKm: near.GetRadiusKm()}
+}
+
+func toJSONB(s *structpb.Struct) domain.JSONB {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
+
+// fromJSONB drops metadata that cannot be represented as a Struct rather than failing the call
+func fromJSONB(j domain.JSONB) *structpb.Struct {
+	if j == nil {
+		return nil
+	}
+	s, err := structpb.NewStruct(j)
+	if err != nil {
+		return nil
+	}
+	return s
+}
+
+func toPage(page *dto.PaginatedResponse) *pb.Page {
+	out := &pb.Page{
+		Limit:          int32(page.Limit),
+		Offset:         int32(page.Offset),
+		HasMore:        page.HasMore,
+		TotalEstimated: page.TotalEstimated,
+		NextCursor:     page.NextCursor,
+	}
+	if page.Total != nil {
+		total := int32(*page.Total)
+		out.Total = &total
+	}
+	return out
+}
+
+func toStakeholder(s *domain.Stakeholder) *pb.Stakeholder {
+	if s == nil {
+		return nil
+	}
+	return &pb.Stakeholder{
+		Id:            s.ID.String(),
+		Name:          s.Name,
+		Type:          s.Type,
+		WalletAddress: s.WalletAddress,
+		Email:         s.Email,
+		Phone:         s.Phone,
+		Address:       s.Address,
+		IsVerified:    s.IsVerified,
+		CreatedAt:     timestamppb.New(s.CreatedAt),
+		UpdatedAt:     timestamppb.New(s.UpdatedAt),
+	}
+}
+
+func toProduct(p *domain.Product) *pb.Product {
+	if p == nil {
+		return nil
+	}
+	return &pb.Product{
+		Id:             p.ID.String(),
+		Sku:            p.SKU,
+		Name:           p.Name,
+		Description:    p.Description,
+		Category:       p.Category,
+		ManufacturerId: optionalID(p.ManufacturerID),
+		LotNumber:      p.LotNumber,
+		SerialNumber:   p.SerialNumber,
+		Metadata:       fromJSONB(p.Metadata),
+		CreatedAt:      timestamppb.New(p.CreatedAt),
+		UpdatedAt:      timestamppb.New(p.UpdatedAt),
+		Manufacturer:   toStakeholder(p.Manufacturer),
+	}
+}
+
+func toEvent(e *domain.SupplyChainEvent) *pb.SupplyChainEvent {
+	if e == nil {
+		return nil
+	}
+	return &pb.SupplyChainEvent{
+		Id:             e.ID.String(),
+		ProductId:      optionalID(e.ProductID),
+		StakeholderId:  optionalID(e.StakeholderID),
+		EventType:      e.EventType,
+		Location:       e.Location,
+		Timestamp:      timestamppb.New(e.Timestamp),
+		Metadata:       fromJSONB(e.Metadata),
+		BlockchainHash: e.BlockchainHash,
+		IsVerified:     e.IsVerified,
+		CreatedAt:      timestamppb.New(e.CreatedAt),
+		Product:        toProduct(e.Product),
+		Stakeholder:    toStakeholder(e.Stakeholder),
+		LocationId:     optionalID(e.LocationID),
+	}
+}
+
+func toTransaction(t *domain.BlockchainTransaction) *pb.BlockchainTransaction {
+	if t == nil {
+		return nil
+	}
+	return &pb.BlockchainTransaction{
+		Id:              t.ID.String(),
+		EventId:         optionalID(t.EventID),
+		TransactionHash: t.TransactionHash,
+		BlockNumber:     t.BlockNumber,
+		GasUsed:         t.GasUsed,
+		Status:          t.Status,
+		CreatedAt:       timestamppb.New(t.CreatedAt),
+	}
+}
+
+// toList converts every item of a service result
+func toList[T any, P any](items []T, convert func(T) P) []P {
+	out := make([]P, 0, len(items))
+	for _, item := range items {
+		out = append(out, convert(item))
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+package grpcapi
+
+import (
+	"context"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	pb "github.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type productServer struct {
+	pb.UnimplementedProductServiceServer
+	service services.ProductService
+}
+
+func (s *productServer) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.Product, error) {
+	manufacturerID, err := parseOptionalID("manufacturer_id", req.ManufacturerId)
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	product, err := s.service.CreateProduct(ctx, &dto.CreateProductRequest{
+		SKU:            req.GetSku(),
+		Name:           req.GetName(),
+		Description:    req.Description,
+		Category:       req.Category,
+		ManufacturerID: manufacturerID,
+		LotNumber:      req.LotNumber,
+		SerialNumber:   req.SerialNumber,
+		Metadata:       toJSONB(req.GetMetadata()),
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to create product")
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	product, err := s.service.GetProduct(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "failed to get product")
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) GetProductBySKU(ctx context.Context, req *pb.GetProductBySKURequest) (*pb.Product, error) {
+	product, err := s.service.GetProductBySKU(ctx, req.GetSku())
+	if err != nil {
+		return nil, toStatus(err, "failed to get product")
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.Product, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	manufacturerID, err := parseOptionalID("manufacturer_id", req.ManufacturerId)
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	product, err := s.service.UpdateProduct(ctx, id, &dto.UpdateProductRequest{
+		Name:           req.Name,
+		Description:    req.Description,
+		Category:       req.Category,
+		ManufacturerID: manufacturerID,
+		LotNumber:      req.LotNumber,
+		SerialNumber:   req.SerialNumber,
+		Metadata:       toJSONB(req.GetMetadata()),
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to update product")
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	if err := s.service.DeleteProduct(ctx, id); err != nil {
+		return nil, toStatus(err, "failed to delete product")
+	}
+	return &pb.DeleteProductResponse{}, nil
+}
+
+func (s *productServer) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	page, err := pageRequest(req.GetLimit(), req.GetOffset(), req.Cursor, req.GetCount())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	query, err := listQuery(listquery.Products, req.GetSort(), req.GetWhere(), req.GetInclude(), req.GetFields())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	manufacturerID, err := parseOptionalID("manufacturer_id", req.ManufacturerId)
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	result, err := s.service.ListProducts(ctx, &dto.ProductFilter{
+		Category:       req.Category,
+		ManufacturerID: manufacturerID,
+		SKU:            req.Sku,
+		Name:           req.Name,
+		LotNumber:      req.LotNumber,
+		Limit:          page.Limit,
+		Offset:         page.Offset,
+		Cursor:         page.Cursor,
+		Count:          page.Count,
+		Query:          query,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to list products")
+	}
+	products, _ := result.Data.([]*domain.Product)
+	return &pb.ListProductsResponse{Products: selectFields(toList(products, toProduct), query), Page: toPage(result)}, nil
+}
+
+func (s *productServer) GetProductStats(ctx context.Context, req *pb.GetProductStatsRequest) (*pb.ProductStats, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	stats, err := s.service.GetProductStats(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "failed to get product stats")
+	}
+	return &pb.ProductStats{
+		ProductId:       stats.ProductID.String(),
+		TotalEvents:     stats.TotalEvents,
+		VerifiedEvents:  stats.VerifiedEvents,
+		CurrentLocation: stats.CurrentLocation,
+		CurrentHolder:   optionalID(stats.CurrentHolder),
+		CurrentState:    stats.CurrentState,
+		LastStakeholder: optionalID(stats.LastStakeholder),
+		LastActivity:    timestamppb.New(stats.LastActivity),
+	}, nil
+}
+
+func (s *productServer) ListProductsByManufacturer(ctx context.Context, req *pb.ListProductsByManufacturerRequest) (*pb.ListProductsByManufacturerResponse, error) {
+	manufacturerID, err := parseID("manufacturer_id", req.GetManufacturerId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	products, err := s.service.GetProductsByManufacturer(ctx, manufacturerID)
+	if err != nil {
+		return nil, toStatus(err, "failed to get products by manufacturer")
+	}
+	return &pb.ListProductsByManufacturerResponse{Products: toList(products, toProduct)}, nil
+}
@@ -0,0 +1,190 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"github.com/koriebruh/suplyChainTrack/internal/stream"
+	pb "github.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+var errInvalidArgument = errors.New("invalid argument")
+
+// NewServer registers the four services on a gRPC server sharing the REST API's service layer.
+// Every call needs the API key in the x-api-key metadata; WatchEvents also accepts a stream token
+// as "authorization: Bearer <token>".
+func NewServer(services *services.ServiceManager, config conf.Config) *grpc.Server {
+	auth := &authenticator{apiKey: config.AppConfig.ApiKey, secret: config.StreamConfig.TokenSecret}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(recoverUnary, auth.unary),
+		grpc.ChainStreamInterceptor(recoverStream, auth.stream),
+	)
+
+	pb.RegisterStakeholderServiceServer(server, &stakeholderServer{service: services.Stakeholder})
+	pb.RegisterProductServiceServer(server, &productServer{service: services.Product})
+	pb.RegisterSupplyChainServiceServer(server, &supplyChainServer{service: services.SupplyChain, stream: services.Stream})
+	pb.RegisterBlockchainServiceServer(server, &blockchainServer{service: services.Blockchain})
+	return server
+}
+
+type authenticator struct {
+	apiKey string
+	secret string
+}
+
+func (a *authenticator) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !a.hasAPIKey(ctx) {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if a.hasAPIKey(ss.Context()) {
+		return handler(srv, &claimsStream{ServerStream: ss, claims: &stream.Claims{}})
+	}
+	if info.FullMethod != pb.SupplyChainService_WatchEvents_FullMethodName {
+		return status.Error(codes.Unauthenticated, "invalid API key")
+	}
+
+	token, ok := bearerToken(ss.Context())
+	if !ok {
+		return status.Error(codes.Unauthenticated, "invalid API key or stream token")
+	}
+	claims, err := stream.VerifyToken(a.secret, token, time.Now())
+	if err != nil {
+		return toStatus(err, "invalid stream token")
+	}
+	return handler(srv, &claimsStream{ServerStream: ss, claims: claims})
+}
+
+func (a *authenticator) hasAPIKey(ctx context.Context) bool {
+	keys := metadata.ValueFromIncomingContext(ctx, "x-api-key")
+	return a.apiKey != "" && len(keys) == 1 && subtle.ConstantTimeCompare([]byte(keys[0]), []byte(a.apiKey)) == 1
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) != 1 {
+		return "", false
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	return token, ok && token != ""
+}
+
+// claimsStream carries what an authenticated stream may see into its handler
+type claimsStream struct {
+	grpc.ServerStream
+	claims *stream.Claims
+}
+
+type claimsKey struct{}
+
+func (s *claimsStream) Context() context.Context {
+	return context.WithValue(s.ServerStream.Context(), claimsKey{}, s.claims)
+}
+
+func claimsFrom(ctx context.Context) *stream.Claims {
+	if claims, ok := ctx.Value(claimsKey{}).(*stream.Claims); ok {
+		return claims
+	}
+	return &stream.Claims{}
+}
+
+func recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("gRPC panic", "method", info.FullMethod, "panic", r)
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func recoverStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("gRPC panic", "method", info.FullMethod, "panic", r)
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// toStatus maps a service error to the gRPC code its REST counterpart would answer with;
+// unexpected errors are logged and reported as fallback
+func toStatus(err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrStakeholderNotFound),
+		errors.Is(err, services.ErrProductNotFound),
+		errors.Is(err, services.ErrEventNotFound),
+		errors.Is(err, services.ErrTransactionNotFound),
+		errors.Is(err, services.ErrLocationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrDuplicateEmail),
+		errors.Is(err, services.ErrDuplicateSKU),
+		errors.Is(err, services.ErrDuplicateWallet):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, errInvalidArgument),
+		errors.Is(err, services.ErrInvalidStakeholderType),
+		errors.Is(err, services.ErrInvalidEventType),
+		errors.Is(err, services.ErrInvalidTransactionStatus),
+		errors.Is(err, services.ErrInvalidContainment),
+		errors.Is(err, services.ErrInvalidTransformation),
+		errors.Is(err, services.ErrInvalidStreamRequest),
+		errors.Is(err, services.ErrInvalidLocation),
+		errors.Is(err, stream.ErrInvalidCursor),
+		errors.Is(err, paging.ErrInvalidCursor),
+		errors.Is(err, paging.ErrInvalidCount),
+		errors.Is(err, listquery.ErrInvalidQuery):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrInvalidEventSequence),
+		errors.Is(err, services.ErrAlreadyContained),
+		errors.Is(err, services.ErrNotContained),
+		errors.Is(err, services.ErrNotCustodyHolder):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, services.ErrUnauthorized),
+		errors.Is(err, services.ErrStreamForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, stream.ErrInvalidToken),
+		errors.Is(err, stream.ErrExpiredToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, services.ErrStreamOverflow):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		slog.Error(fallback, "error", err)
+		return status.Error(codes.Internal, fallback)
+	}
+}
+
+// pageSize applies the REST API's default and caps the page size
+func pageSize(limit int32) int {
+	switch {
+	case limit <= 0:
+		return defaultPageSize
+	case limit > maxPageSize:
+		return maxPageSize
+	default:
+		return int(limit)
+	}
+}
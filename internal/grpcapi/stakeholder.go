@@ -0,0 +1,141 @@
+package grpcapi
+
+import (
+	"context"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	pb "github.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type stakeholderServer struct {
+	pb.UnimplementedStakeholderServiceServer
+	service services.StakeholderService
+}
+
+func (s *stakeholderServer) CreateStakeholder(ctx context.Context, req *pb.CreateStakeholderRequest) (*pb.Stakeholder, error) {
+	stakeholder, err := s.service.CreateStakeholder(ctx, &dto.CreateStakeholderRequest{
+		Name:          req.GetName(),
+		Type:          req.GetType(),
+		WalletAddress: req.WalletAddress,
+		Email:         req.GetEmail(),
+		Phone:         req.Phone,
+		Address:       req.Address,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to create stakeholder")
+	}
+	return toStakeholder(stakeholder), nil
+}
+
+func (s *stakeholderServer) GetStakeholder(ctx context.Context, req *pb.GetStakeholderRequest) (*pb.Stakeholder, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	stakeholder, err := s.service.GetStakeholder(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "failed to get stakeholder")
+	}
+	return toStakeholder(stakeholder), nil
+}
+
+func (s *stakeholderServer) GetStakeholderByEmail(ctx context.Context, req *pb.GetStakeholderByEmailRequest) (*pb.Stakeholder, error) {
+	stakeholder, err := s.service.GetStakeholderByEmail(ctx, req.GetEmail())
+	if err != nil {
+		return nil, toStatus(err, "failed to get stakeholder")
+	}
+	return toStakeholder(stakeholder), nil
+}
+
+func (s *stakeholderServer) UpdateStakeholder(ctx context.Context, req *pb.UpdateStakeholderRequest) (*pb.Stakeholder, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	stakeholder, err := s.service.UpdateStakeholder(ctx, id, &dto.UpdateStakeholderRequest{
+		Name:          req.Name,
+		WalletAddress: req.WalletAddress,
+		Email:         req.Email,
+		Phone:         req.Phone,
+		Address:       req.Address,
+		IsVerified:    req.IsVerified,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to update stakeholder")
+	}
+	return toStakeholder(stakeholder), nil
+}
+
+func (s *stakeholderServer) DeleteStakeholder(ctx context.Context, req *pb.DeleteStakeholderRequest) (*pb.DeleteStakeholderResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	if err := s.service.DeleteStakeholder(ctx, id); err != nil {
+		return nil, toStatus(err, "failed to delete stakeholder")
+	}
+	return &pb.DeleteStakeholderResponse{}, nil
+}
+
+func (s *stakeholderServer) ListStakeholders(ctx context.Context, req *pb.ListStakeholdersRequest) (*pb.ListStakeholdersResponse, error) {
+	page, err := pageRequest(req.GetLimit(), req.GetOffset(), req.Cursor, req.GetCount())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	query, err := listQuery(listquery.Stakeholders, req.GetSort(), req.GetWhere(), nil, req.GetFields())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	result, err := s.service.ListStakeholders(ctx, &dto.StakeholderFilter{
+		Type:       req.Type,
+		IsVerified: req.IsVerified,
+		Email:      req.Email,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		Cursor:     page.Cursor,
+		Count:      page.Count,
+		Query:      query,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to list stakeholders")
+	}
+	stakeholders, _ := result.Data.([]*domain.Stakeholder)
+	return &pb.ListStakeholdersResponse{Stakeholders: selectFields(toList(stakeholders, toStakeholder), query), Page: toPage(result)}, nil
+}
+
+func (s *stakeholderServer) GetStakeholderStats(ctx context.Context, req *pb.GetStakeholderStatsRequest) (*pb.StakeholderStats, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	stats, err := s.service.GetStakeholderStats(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "failed to get stakeholder stats")
+	}
+	return &pb.StakeholderStats{
+		StakeholderId:  stats.StakeholderID.String(),
+		TotalProducts:  stats.TotalProducts,
+		TotalEvents:    stats.TotalEvents,
+		VerifiedEvents: stats.VerifiedEvents,
+		PendingEvents:  stats.PendingEvents,
+		LastActivity:   timestamppb.New(stats.LastActivity),
+	}, nil
+}
+
+func (s *stakeholderServer) VerifyStakeholder(ctx context.Context, req *pb.VerifyStakeholderRequest) (*pb.Stakeholder, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	if err := s.service.VerifyStakeholder(ctx, id); err != nil {
+		return nil, toStatus(err, "failed to verify stakeholder")
+	}
+	stakeholder, err := s.service.GetStakeholder(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "failed to get stakeholder")
+	}
+	return toStakeholder(stakeholder), nil
+}
@@ -0,0 +1,316 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"github.com/koriebruh/suplyChainTrack/internal/stream"
+	pb "github.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"io"
+	"slices"
+)
+
+type supplyChainServer struct {
+	pb.UnimplementedSupplyChainServiceServer
+	service services.SupplyChainService
+	stream  services.StreamService
+}
+
+func (s *supplyChainServer) RecordEvent(ctx context.Context, req *pb.RecordEventRequest) (*pb.SupplyChainEvent, error) {
+	event, err := s.record(ctx, req)
+	if err != nil {
+		return nil, toStatus(err, "failed to create event")
+	}
+	return toEvent(event), nil
+}
+
+func (s *supplyChainServer) record(ctx context.Context, req *pb.RecordEventRequest) (*domain.SupplyChainEvent, error) {
+	create, err := toCreateEventRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.service.CreateEvent(ctx, create)
+}
+
+func (s *supplyChainServer) GetEvent(ctx context.Context, req *pb.GetEventRequest) (*pb.SupplyChainEvent, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	event, err := s.service.GetEvent(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "failed to get event")
+	}
+	return toEvent(event), nil
+}
+
+func (s *supplyChainServer) DeleteEvent(ctx context.Context, req *pb.DeleteEventRequest) (*pb.DeleteEventResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	if err := s.service.DeleteEvent(ctx, id); err != nil {
+		return nil, toStatus(err, "failed to delete event")
+	}
+	return &pb.DeleteEventResponse{}, nil
+}
+
+func (s *supplyChainServer) ListEvents(ctx context.Context, req *pb.ListEventsRequest) (*pb.ListEventsResponse, error) {
+	page, err := pageRequest(req.GetLimit(), req.GetOffset(), req.Cursor, req.GetCount())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	query, err := listQuery(listquery.Events, req.GetSort(), req.GetWhere(), req.GetInclude(), req.GetFields())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	productID, err := parseOptionalID("product_id", req.ProductId)
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	stakeholderID, err := parseOptionalID("stakeholder_id", req.StakeholderId)
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	locationID, err := parseOptionalID("location_id", req.LocationId)
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	result, err := s.service.ListEvents(ctx, &dto.SupplyChainEventFilter{
+		ProductID:     productID,
+		StakeholderID: stakeholderID,
+		EventType:     req.EventType,
+		EventTypes:    req.GetEventTypes(),
+		Location:      req.Location,
+		LocationID:    locationID,
+		Near:          geoRadius(req.GetNear()),
+		IsVerified:    req.IsVerified,
+		FromDate:      optionalTime(req.GetFromDate()),
+		ToDate:        optionalTime(req.GetToDate()),
+		Limit:         page.Limit,
+		Offset:        page.Offset,
+		Cursor:        page.Cursor,
+		Count:         page.Count,
+		Query:         query,
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to list events")
+	}
+	events, _ := result.Data.([]*domain.SupplyChainEvent)
+	return &pb.ListEventsResponse{Events: selectFields(toList(events, toEvent), query), Page: toPage(result)}, nil
+}
+
+func (s *supplyChainServer) GetProductTrace(ctx context.Context, req *pb.GetProductTraceRequest) (*pb.ProductTrace, error) {
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	trace, err := s.service.GetProductTrace(ctx, productID)
+	if err != nil {
+		return nil, toStatus(err, "failed to get product trace")
+	}
+	return &pb.ProductTrace{
+		Product: toProduct(trace.Product),
+		Events:  toList(trace.Events, toEvent),
+		InheritedEvents: toList(trace.InheritedEvents, func(inherited *dto.InheritedEvent) *pb.InheritedEvent {
+			return &pb.InheritedEvent{ContainerId: inherited.ContainerID.String(), Event: toEvent(inherited.Event)}
+		}),
+	}, nil
+}
+
+func (s *supplyChainServer) VerifyEvent(ctx context.Context, req *pb.VerifyEventRequest) (*pb.SupplyChainEvent, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	if req.GetBlockchainHash() == "" {
+		return nil, toStatus(fmt.Errorf("%w: blockchain_hash is required", errInvalidArgument), "invalid request")
+	}
+	if err := s.service.VerifyEvent(ctx, id, req.GetBlockchainHash()); err != nil {
+		return nil, toStatus(err, "failed to verify event")
+	}
+	event, err := s.service.GetEvent(ctx, id)
+	if err != nil {
+		return nil, toStatus(err, "failed to get event")
+	}
+	return toEvent(event), nil
+}
+
+func (s *supplyChainServer) ListEventsByProduct(ctx context.Context, req *pb.ListEventsByProductRequest) (*pb.ListEventsByProductResponse, error) {
+	productID, err := parseID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	events, err := s.service.GetEventsByProduct(ctx, productID)
+	if err != nil {
+		return nil, toStatus(err, "failed to get events by product")
+	}
+	return &pb.ListEventsByProductResponse{Events: toList(events, toEvent)}, nil
+}
+
+func (s *supplyChainServer) ListEventsByStakeholder(ctx context.Context, req *pb.ListEventsByStakeholderRequest) (*pb.ListEventsByStakeholderResponse, error) {
+	stakeholderID, err := parseID("stakeholder_id", req.GetStakeholderId())
+	if err != nil {
+		return nil, toStatus(err, "invalid request")
+	}
+	events, err := s.service.GetEventsByStakeholder(ctx, stakeholderID)
+	if err != nil {
+		return nil, toStatus(err, "failed to get events by stakeholder")
+	}
+	return &pb.ListEventsByStakeholderResponse{Events: toList(events, toEvent)}, nil
+}
+
+// WatchEvents follows the same stream as the WebSocket and SSE endpoints, with a single filter
+func (s *supplyChainServer) WatchEvents(req *pb.WatchEventsRequest, ss grpc.ServerStreamingServer[pb.EventMessage]) error {
+	ctx := ss.Context()
+
+	var cursor *stream.Cursor
+	if req.Cursor != nil {
+		parsed, err := stream.ParseCursor(req.GetCursor())
+		if err != nil {
+			return toStatus(err, "invalid request")
+		}
+		cursor = &parsed
+	}
+	productID, err := parseOptionalID("product_id", req.ProductId)
+	if err != nil {
+		return toStatus(err, "invalid request")
+	}
+	stakeholderID, err := parseOptionalID("stakeholder_id", req.StakeholderId)
+	if err != nil {
+		return toStatus(err, "invalid request")
+	}
+
+	conn, err := s.stream.Open(ctx, claimsFrom(ctx), cursor)
+	if err != nil {
+		return toStatus(err, "failed to open event stream")
+	}
+	defer conn.Close()
+
+	err = conn.Subscribe(stream.Filter{
+		ID:            "grpc",
+		ProductID:     productID,
+		LotNumber:     req.LotNumber,
+		StakeholderID: stakeholderID,
+		EventType:     req.EventType,
+	})
+	if err != nil {
+		return toStatus(err, "failed to subscribe")
+	}
+
+	topics := req.GetTopics()
+	for message := range conn.Messages() {
+		if len(topics) > 0 && !slices.Contains(topics, message.Topic) {
+			continue
+		}
+		err := ss.Send(&pb.EventMessage{Cursor: message.Cursor, Topic: message.Topic, Event: toEvent(message.Event)})
+		if err != nil {
+			return err
+		}
+	}
+	if err := conn.Err(); err != nil {
+		return toStatus(err, "event stream failed")
+	}
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+// RecordEvents records each event as it arrives, in its own transaction like RecordEvent, and
+// answers once the client closes its side with the outcome of every event
+func (s *supplyChainServer) RecordEvents(ss grpc.ClientStreamingServer[pb.RecordEventRequest, pb.RecordEventsResponse]) error {
+	response := &pb.RecordEventsResponse{}
+	for index := int32(0); ; index++ {
+		req, err := ss.Recv()
+		if errors.Is(err, io.EOF) {
+			return ss.SendAndClose(response)
+		}
+		if err != nil {
+			return err
+		}
+
+		result := &pb.RecordEventResult{Index: index}
+		event, err := s.record(ss.Context(), req)
+		if err != nil {
+			if ctxErr := ss.Context().Err(); ctxErr != nil {
+				return status.FromContextError(ctxErr).Err()
+			}
+			message := status.Convert(toStatus(err, "failed to create event")).Message()
+			result.Error = &message
+			response.Failed++
+		} else {
+			id := event.ID.String()
+			result.EventId = &id
+			response.Recorded++
+		}
+		response.Results = append(response.Results, result)
+	}
+}
+
+func toCreateEventRequest(req *pb.RecordEventRequest) (*dto.CreateSupplyChainEventRequest, error) {
+	productID, err := parseOptionalID("product_id", req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	stakeholderID, err := parseOptionalID("stakeholder_id", req.StakeholderId)
+	if err != nil {
+		return nil, err
+	}
+	locationID, err := parseOptionalID("location_id", req.LocationId)
+	if err != nil {
+		return nil, err
+	}
+	childIDs, err := parseIDs("child_ids", req.GetChildIds())
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := toTransformationLines("inputs", req.GetInputs())
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := toTransformationLines("outputs", req.GetOutputs())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetTimestamp() == nil {
+		return nil, fmt.Errorf("%w: timestamp is required", errInvalidArgument)
+	}
+
+	return &dto.CreateSupplyChainEventRequest{
+		ProductID:      productID,
+		StakeholderID:  stakeholderID,
+		EventType:      req.GetEventType(),
+		Location:       req.Location,
+		LocationID:     locationID,
+		Timestamp:      req.GetTimestamp().AsTime(),
+		Metadata:       toJSONB(req.GetMetadata()),
+		BlockchainHash: req.BlockchainHash,
+		ChildIDs:       childIDs,
+		Inputs:         inputs,
+		Outputs:        outputs,
+	}, nil
+}
+
+func toTransformationLines(field string, lines []*pb.TransformationLine) ([]dto.TransformationLineRequest, error) {
+	out := make([]dto.TransformationLineRequest, 0, len(lines))
+	for _, line := range lines {
+		productID, err := parseID(field+".product_id", line.GetProductId())
+		if err != nil {
+			return nil, err
+		}
+		if line.GetQuantity() <= 0 {
+			return nil, fmt.Errorf("%w: %s.quantity must be positive", errInvalidArgument, field)
+		}
+		out = append(out, dto.TransformationLineRequest{
+			ProductID: productID,
+			Quantity:  line.GetQuantity(),
+			Unit:      line.Unit,
+			LotNumber: line.LotNumber,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,110 @@
+package gs1
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// DefaultResolver is the canonical GS1 Digital Link domain
+const DefaultResolver = "https://id.gs1.org"
+
+// Application identifiers used in Digital Link paths
+const (
+	AIGTIN   = "01"
+	AILot    = "10"
+	AISerial = "21"
+)
+
+var ErrInvalidDigitalLink = errors.New("invalid GS1 Digital Link")
+
+// DigitalLink is the product part of a GS1 Digital Link URI: a GTIN optionally qualified by lot or serial
+type DigitalLink struct {
+	GTIN   string // 14 digits
+	Lot    string
+	Serial string
+
+	// Attributes are the data attributes carried in the query string (e.g. 17 expiry date), keyed by AI
+	Attributes map[string]string
+}
+
+// ParseDigitalLink extracts the GTIN, lot and serial from a Digital Link URI or path, and the
+// AI-keyed data attributes from its query string. Any domain and path prefix is accepted;
+// unknown qualifiers and non-AI query parameters (such as linkType) are ignored.
+func ParseDigitalLink(raw string) (*DigitalLink, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, ErrInvalidDigitalLink
+	}
+
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	start := -1
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == AIGTIN {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, ErrInvalidDigitalLink
+	}
+
+	link := &DigitalLink{}
+	for i := start; i+1 < len(segments); i += 2 {
+		value, err := url.PathUnescape(segments[i+1])
+		if err != nil {
+			return nil, ErrInvalidDigitalLink
+		}
+		switch segments[i] {
+		case AIGTIN:
+			gtin, err := NormalizeGTIN(value)
+			if err != nil {
+				return nil, err
+			}
+			link.GTIN = gtin
+		case AILot:
+			link.Lot = value
+		case AISerial:
+			link.Serial = value
+		}
+	}
+
+	for key, values := range u.Query() {
+		if !isAI(key) || len(values) == 0 {
+			continue
+		}
+		if link.Attributes == nil {
+			link.Attributes = make(map[string]string)
+		}
+		link.Attributes[key] = values[0]
+	}
+
+	return link, nil
+}
+
+// URI renders the link under the given resolver domain, qualifiers in the order GS1 prescribes
+func (l *DigitalLink) URI(resolver string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(resolver, "/"))
+	b.WriteString("/" + AIGTIN + "/" + l.GTIN)
+	if l.Lot != "" {
+		b.WriteString("/" + AILot + "/" + url.PathEscape(l.Lot))
+	}
+	if l.Serial != "" {
+		b.WriteString("/" + AISerial + "/" + url.PathEscape(l.Serial))
+	}
+	return b.String()
+}
+
+// isAI reports whether key looks like a GS1 application identifier: two to four digits
+func isAI(key string) bool {
+	if len(key) < 2 || len(key) > 4 {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < '0' || key[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
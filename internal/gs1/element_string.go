@@ -0,0 +1,121 @@
+package gs1
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Symbology identifiers that mark a barcode's data as GS1 element strings
+const (
+	SymbologyGS1128        = "]C1"
+	SymbologyGS1DataMatrix = "]d2"
+)
+
+// GroupSeparator is the FNC1 that terminates a variable-length element when another follows
+const GroupSeparator = "\x1d"
+
+// maxVariableLength is the longest lot or serial number GS1 allows
+const maxVariableLength = 20
+
+var ErrInvalidElement = errors.New("invalid GS1 element value")
+
+// predefinedLengths are the AIs, by two-digit prefix, whose elements have a fixed length and
+// therefore need no separator
+var predefinedLengths = map[string]bool{
+	"00": true, "01": true, "02": true, "03": true, "04": true,
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"20": true, "31": true, "32": true, "33": true, "34": true, "35": true, "36": true, "41": true,
+}
+
+// element is one AI and its value
+type element struct {
+	ai    string
+	value string
+}
+
+// elements lists the link's AIs in encoding order: GTIN, then fixed-length attributes, then the
+// variable-length lot, serial and attributes, so as few separators as possible are needed
+func (l *DigitalLink) elements() ([]element, error) {
+	if !ValidGTIN(l.GTIN) {
+		return nil, ErrInvalidGTIN
+	}
+
+	fixed := []element{{AIGTIN, l.GTIN}}
+	var variable []element
+	if l.Lot != "" {
+		variable = append(variable, element{AILot, l.Lot})
+	}
+	if l.Serial != "" {
+		variable = append(variable, element{AISerial, l.Serial})
+	}
+
+	ais := make([]string, 0, len(l.Attributes))
+	for ai := range l.Attributes {
+		ais = append(ais, ai)
+	}
+	sort.Strings(ais)
+	for _, ai := range ais {
+		if !isAI(ai) {
+			return nil, ErrInvalidElement
+		}
+		if predefinedLengths[ai[:2]] {
+			fixed = append(fixed, element{ai, l.Attributes[ai]})
+		} else {
+			variable = append(variable, element{ai, l.Attributes[ai]})
+		}
+	}
+
+	for _, e := range variable {
+		if len(e.value) > maxVariableLength || !validCharacters(e.value) {
+			return nil, ErrInvalidElement
+		}
+	}
+	return append(fixed, variable...), nil
+}
+
+// ElementString renders the link in the human-readable form printed under a barcode,
+// e.g. (01)04006381333931(10)L123(21)S1
+func (l *DigitalLink) ElementString() (string, error) {
+	elements, err := l.elements()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, e := range elements {
+		b.WriteString("(" + e.ai + ")" + e.value)
+	}
+	return b.String(), nil
+}
+
+// Payload renders the data a GS1-128 or GS1 DataMatrix symbol encodes: the symbology identifier,
+// then the elements with a group separator after every variable-length element but the last
+func (l *DigitalLink) Payload(symbology string) (string, error) {
+	elements, err := l.elements()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(symbology)
+	for i, e := range elements {
+		b.WriteString(e.ai + e.value)
+		if !predefinedLengths[e.ai[:2]] && i < len(elements)-1 {
+			b.WriteString(GroupSeparator)
+		}
+	}
+	return b.String(), nil
+}
+
+// validCharacters reports whether s only uses GS1 AI encodable character set 82
+func validCharacters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9', c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		case strings.IndexByte("!\"%&'()*+,-./:;<=>?_", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package gs1
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// EPC pure identity URN prefixes this package understands
+const (
+	URNPrefixSGTIN = "urn:epc:id:sgtin:"
+	URNPrefixLGTIN = "urn:epc:class:lgtin:"
+	URNPrefixGTIN  = "urn:epc:idpat:sgtin:"
+)
+
+var ErrInvalidEPCURN = errors.New("invalid EPC URN")
+
+// ParseEPCURN converts an SGTIN, LGTIN or SGTIN pattern URN into its Digital Link form.
+// The URN splits the GTIN into company prefix and item reference, whose first digit is the indicator.
+func ParseEPCURN(urn string) (*DigitalLink, error) {
+	var body string
+	var qualifier func(link *DigitalLink, value string)
+	switch {
+	case strings.HasPrefix(urn, URNPrefixSGTIN):
+		body = strings.TrimPrefix(urn, URNPrefixSGTIN)
+		qualifier = func(link *DigitalLink, value string) { link.Serial = value }
+	case strings.HasPrefix(urn, URNPrefixLGTIN):
+		body = strings.TrimPrefix(urn, URNPrefixLGTIN)
+		qualifier = func(link *DigitalLink, value string) { link.Lot = value }
+	case strings.HasPrefix(urn, URNPrefixGTIN):
+		body = strings.TrimPrefix(urn, URNPrefixGTIN)
+		qualifier = func(link *DigitalLink, value string) {}
+	default:
+		return nil, ErrInvalidEPCURN
+	}
+
+	parts := strings.SplitN(body, ".", 3)
+	if len(parts) != 3 || len(parts[1]) == 0 || len(parts[0])+len(parts[1]) != 13 {
+		return nil, ErrInvalidEPCURN
+	}
+	companyPrefix, itemRef := parts[0], parts[1]
+
+	data := itemRef[:1] + companyPrefix + itemRef[1:]
+	check, err := CheckDigit(data)
+	if err != nil {
+		return nil, ErrInvalidEPCURN
+	}
+
+	link := &DigitalLink{GTIN: data + string(check)}
+	if parts[2] != "*" {
+		qualifier(link, unescapeURN(parts[2]))
+	}
+	return link, nil
+}
+
+// unescapeURN decodes the %-escapes EPC URNs use for reserved characters in serials and lots
+func unescapeURN(s string) string {
+	if value, err := url.PathUnescape(s); err == nil {
+		return value
+	}
+	return s
+}
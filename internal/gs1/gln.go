@@ -0,0 +1,39 @@
+package gs1
+
+import (
+	"errors"
+	"strings"
+)
+
+// URNPrefixSGLN is the EPC pure identity URN of a location: company prefix, location reference and extension
+const URNPrefixSGLN = "urn:epc:id:sgln:"
+
+var ErrInvalidGLN = errors.New("invalid GLN")
+
+// ValidGLN reports whether s is a 13-digit Global Location Number with a correct check digit
+func ValidGLN(s string) bool {
+	if len(s) != 13 {
+		return false
+	}
+	check, err := CheckDigit(s[:12])
+	return err == nil && check == s[12]
+}
+
+// ParseSGLN returns the GLN an SGLN URN identifies. The extension, which names a sub-location
+// such as a dock door, is dropped.
+func ParseSGLN(urn string) (string, error) {
+	body, ok := strings.CutPrefix(urn, URNPrefixSGLN)
+	if !ok {
+		return "", ErrInvalidGLN
+	}
+	parts := strings.SplitN(body, ".", 3)
+	if len(parts) != 3 || len(parts[0])+len(parts[1]) != 12 {
+		return "", ErrInvalidGLN
+	}
+	data := parts[0] + parts[1]
+	check, err := CheckDigit(data)
+	if err != nil {
+		return "", ErrInvalidGLN
+	}
+	return data + string(check), nil
+}
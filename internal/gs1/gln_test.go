@@ -0,0 +1,64 @@
+package gs1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidGLN(t *testing.T) {
+	tests := []struct {
+		gln  string
+		want bool
+	}{
+		{"0614141000005", true},
+		{"5412345000013", true},
+		{"0614141000006", false}, // wrong check digit
+		{"614141000005", false},  // 12 digits
+		{"06141410000050", false},
+		{"061414100000A", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidGLN(tt.gln); got != tt.want {
+			t.Errorf("ValidGLN(%q) = %v, want %v", tt.gln, got, tt.want)
+		}
+	}
+}
+
+func TestParseSGLN(t *testing.T) {
+	tests := []struct {
+		name    string
+		urn     string
+		want    string
+		wantErr bool
+	}{
+		{name: "no extension", urn: "urn:epc:id:sgln:0614141.00000.0", want: "0614141000005"},
+		{name: "extension is dropped", urn: "urn:epc:id:sgln:0614141.00000.dock-3", want: "0614141000005"},
+		{name: "longer company prefix", urn: "urn:epc:id:sgln:541234500.001.0", want: "5412345000013"},
+		{name: "other EPC scheme", urn: "urn:epc:id:sgtin:0614141.00000.0", wantErr: true},
+		{name: "missing extension", urn: "urn:epc:id:sgln:0614141.00000", wantErr: true},
+		{name: "too few digits", urn: "urn:epc:id:sgln:0614141.0000.0", wantErr: true},
+		{name: "too many digits", urn: "urn:epc:id:sgln:0614141.000000.0", wantErr: true},
+		{name: "not digits", urn: "urn:epc:id:sgln:061414A.00000.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSGLN(tt.urn)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidGLN) {
+					t.Fatalf("ParseSGLN(%q) error = %v, want ErrInvalidGLN", tt.urn, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSGLN(%q) unexpected error: %v", tt.urn, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSGLN(%q) = %q, want %q", tt.urn, got, tt.want)
+			}
+			if !ValidGLN(got) {
+				t.Errorf("ParseSGLN(%q) = %q, which is not a valid GLN", tt.urn, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package gs1
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidGTIN = errors.New("invalid GTIN")
+
+// CheckDigit computes the GS1 mod-10 check digit for the data digits of a GTIN (all digits but the last)
+func CheckDigit(data string) (byte, error) {
+	sum := 0
+	for i := len(data) - 1; i >= 0; i-- {
+		c := data[i]
+		if c < '0' || c > '9' {
+			return 0, ErrInvalidGTIN
+		}
+		digit := int(c - '0')
+		// Weights alternate 3, 1, 3, ... starting from the rightmost data digit
+		if (len(data)-1-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10), nil
+}
+
+// ValidGTIN reports whether s is a GTIN-8, -12, -13 or -14 with a correct check digit
+func ValidGTIN(s string) bool {
+	switch len(s) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+	check, err := CheckDigit(s[:len(s)-1])
+	return err == nil && check == s[len(s)-1]
+}
+
+// NormalizeGTIN validates s and left-pads it to the 14-digit form used in Digital Link URIs
+func NormalizeGTIN(s string) (string, error) {
+	if !ValidGTIN(s) {
+		return "", ErrInvalidGTIN
+	}
+	return strings.Repeat("0", 14-len(s)) + s, nil
+}
+
+// GTINCandidates returns the shorter GTIN forms a 14-digit GTIN may have been stored as,
+// longest first, so a lookup matches whichever length a product's SKU was recorded with
+func GTINCandidates(gtin14 string) []string {
+	candidates := []string{gtin14}
+	for _, length := range []int{13, 12, 8} {
+		prefix := gtin14[:14-length]
+		if strings.Trim(prefix, "0") == "" {
+			candidates = append(candidates, gtin14[14-length:])
+		}
+	}
+	return candidates
+}
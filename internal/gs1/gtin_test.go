@@ -0,0 +1,97 @@
+package gs1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    byte
+		wantErr bool
+	}{
+		{name: "GTIN-13", data: "400638133393", want: '1'},
+		{name: "GTIN-8", data: "9638507", want: '4'},
+		{name: "GTIN-12", data: "03600029145", want: '2'},
+		{name: "sum already a multiple of ten", data: "000000000000", want: '0'},
+		{name: "letter", data: "40063813339A", wantErr: true},
+		{name: "space", data: "4006381 3393", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckDigit(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckDigit(%q) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("CheckDigit(%q) = %c, want %c", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidGTIN(t *testing.T) {
+	tests := []struct {
+		gtin string
+		want bool
+	}{
+		{"96385074", true},
+		{"036000291452", true},
+		{"4006381333931", true},
+		{"04006381333931", true},
+		{"4006381333932", false}, // wrong check digit
+		{"96385075", false},
+		{"400638133393", false}, // 12 digits, but not a valid GTIN-12
+		{"123456789", false},    // no GTIN has 9 digits
+		{"", false},
+		{"400638133393X", false},
+		{"+4006381333931", false},
+	}
+	for _, tt := range tests {
+		if got := ValidGTIN(tt.gtin); got != tt.want {
+			t.Errorf("ValidGTIN(%q) = %v, want %v", tt.gtin, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeGTIN(t *testing.T) {
+	tests := []struct {
+		gtin    string
+		want    string
+		wantErr bool
+	}{
+		{gtin: "96385074", want: "00000096385074"},
+		{gtin: "036000291452", want: "00036000291452"},
+		{gtin: "4006381333931", want: "04006381333931"},
+		{gtin: "04006381333931", want: "04006381333931"},
+		{gtin: "4006381333932", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeGTIN(tt.gtin)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("NormalizeGTIN(%q) error = %v, wantErr %v", tt.gtin, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeGTIN(%q) = %q, want %q", tt.gtin, got, tt.want)
+		}
+	}
+}
+
+func TestGTINCandidates(t *testing.T) {
+	tests := []struct {
+		gtin14 string
+		want   []string
+	}{
+		{"00000096385074", []string{"00000096385074", "0000096385074", "000096385074", "96385074"}},
+		{"00036000291452", []string{"00036000291452", "0036000291452", "036000291452"}},
+		{"04006381333931", []string{"04006381333931", "4006381333931"}},
+		{"14006381333938", []string{"14006381333938"}},
+	}
+	for _, tt := range tests {
+		if got := GTINCandidates(tt.gtin14); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GTINCandidates(%q) = %v, want %v", tt.gtin14, got, tt.want)
+		}
+	}
+}
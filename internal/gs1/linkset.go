@@ -0,0 +1,47 @@
+package gs1
+
+import "strings"
+
+// ContentTypeLinkset is the media type of an RFC 9264 linkset
+const ContentTypeLinkset = "application/linkset+json"
+
+// VocabularyPrefix is the IRI the compact gs1: link types expand to
+const VocabularyPrefix = "https://gs1.org/voc/"
+
+// GS1 link types served by the resolver, in compact form
+const (
+	LinkTypeAll          = "all"
+	LinkTypeDefault      = "gs1:defaultLink"
+	LinkTypePIP          = "gs1:pip"
+	LinkTypeTraceability = "gs1:traceability"
+	LinkTypeEPCIS        = "gs1:epcis"
+)
+
+// LinkTypeURI expands a compact gs1: link type to its vocabulary IRI; other values pass through
+func LinkTypeURI(linkType string) string {
+	if strings.HasPrefix(linkType, "gs1:") {
+		return VocabularyPrefix + strings.TrimPrefix(linkType, "gs1:")
+	}
+	return linkType
+}
+
+// Linkset is the application/linkset+json document: one context object per anchor
+type Linkset struct {
+	Linkset []map[string]interface{} `json:"linkset"`
+}
+
+// Link is one target of a link relation
+type Link struct {
+	Href  string `json:"href"`
+	Title string `json:"title,omitempty"`
+	Type  string `json:"type,omitempty"`
+}
+
+// NewLinkset builds the linkset of a single anchor, keyed by expanded link type
+func NewLinkset(anchor string, links map[string][]Link) *Linkset {
+	context := map[string]interface{}{"anchor": anchor}
+	for linkType, targets := range links {
+		context[LinkTypeURI(linkType)] = targets
+	}
+	return &Linkset{Linkset: []map[string]interface{}{context}}
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type analyticsHandler struct {
+	service services.AnalyticsService
+}
+
+func NewAnalyticsHandler(service services.AnalyticsService) *analyticsHandler {
+	return &analyticsHandler{service: service}
+}
+
+func (h *analyticsHandler) GetDashboard(c *fiber.Ctx) error {
+	query, err := parseAnalyticsQuery(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+
+	dashboard, err := h.service.GetDashboard(c.Context(), query)
+	if err != nil {
+		return h.sendAnalyticsError(c, err, "Failed to get analytics dashboard")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, dashboard, "Analytics dashboard retrieved successfully")
+}
+
+// GetTransitReport summarises transit times by sender_id, receiver_id, product_id, location_id,
+// from, to and group_by
+func (h *analyticsHandler) GetTransitReport(c *fiber.Ctx) error {
+	query, err := parseAnalyticsQuery(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+
+	report, err := h.service.GetTransitReport(c.Context(), query)
+	if err != nil {
+		return h.sendAnalyticsError(c, err, "Failed to get transit times")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, report, "Transit times retrieved successfully")
+}
+
+// GetDwellReport summarises dwell times by stakeholder_id, location_id, product_id, from, to and
+// group_by
+func (h *analyticsHandler) GetDwellReport(c *fiber.Ctx) error {
+	query, err := parseAnalyticsQuery(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+
+	report, err := h.service.GetDwellReport(c.Context(), query)
+	if err != nil {
+		return h.sendAnalyticsError(c, err, "Failed to get dwell times")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, report, "Dwell times retrieved successfully")
+}
+
+// GetThroughput counts events per stakeholder by stakeholder_id, from, to and period
+func (h *analyticsHandler) GetThroughput(c *fiber.Ctx) error {
+	query, err := parseAnalyticsQuery(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+
+	report, err := h.service.GetThroughput(c.Context(), query)
+	if err != nil {
+		return h.sendAnalyticsError(c, err, "Failed to get throughput")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, report, "Throughput retrieved successfully")
+}
+
+// ListLegs lists transit legs, latest received first, by product_id, sender_id, receiver_id,
+// custody_transfer_id, from and to
+func (h *analyticsHandler) ListLegs(c *fiber.Ctx) error {
+	filter := &dto.TransitLegFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+
+	if filter.ProductID, err = optionalUUID(c.Query("product_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid product_id: %w", err), "Invalid query parameters")
+	}
+	if filter.SenderID, err = optionalUUID(c.Query("sender_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid sender_id: %w", err), "Invalid query parameters")
+	}
+	if filter.ReceiverID, err = optionalUUID(c.Query("receiver_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid receiver_id: %w", err), "Invalid query parameters")
+	}
+	if filter.CustodyTransferID, err = optionalUUID(c.Query("custody_transfer_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid custody_transfer_id: %w", err), "Invalid query parameters")
+	}
+	if filter.From, err = optionalTime(c.Query("from")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid from, expected RFC3339: %w", err), "Invalid query parameters")
+	}
+	if filter.To, err = optionalTime(c.Query("to")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid to, expected RFC3339: %w", err), "Invalid query parameters")
+	}
+
+	response, err := h.service.ListLegs(c.Context(), filter)
+	if err != nil {
+		return h.sendAnalyticsError(c, err, "Failed to list transit legs")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Transit legs retrieved successfully")
+}
+
+// ListDwells lists stays, latest arrival first, by product_id, stakeholder_id, location_id, open,
+// from and to
+func (h *analyticsHandler) ListDwells(c *fiber.Ctx) error {
+	filter := &dto.DwellFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+
+	if filter.ProductID, err = optionalUUID(c.Query("product_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid product_id: %w", err), "Invalid query parameters")
+	}
+	if filter.StakeholderID, err = optionalUUID(c.Query("stakeholder_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid stakeholder_id: %w", err), "Invalid query parameters")
+	}
+	if filter.LocationID, err = optionalUUID(c.Query("location_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid location_id: %w", err), "Invalid query parameters")
+	}
+	if open := c.Query("open"); open != "" {
+		value, err := strconv.ParseBool(open)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid open: %w", err), "Invalid query parameters")
+		}
+		filter.Open = &value
+	}
+	if filter.From, err = optionalTime(c.Query("from")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid from, expected RFC3339: %w", err), "Invalid query parameters")
+	}
+	if filter.To, err = optionalTime(c.Query("to")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid to, expected RFC3339: %w", err), "Invalid query parameters")
+	}
+
+	response, err := h.service.ListDwells(c.Context(), filter)
+	if err != nil {
+		return h.sendAnalyticsError(c, err, "Failed to list dwells")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Dwells retrieved successfully")
+}
+
+// Refresh rebuilds stale analytics right away instead of waiting for the refresher
+func (h *analyticsHandler) Refresh(c *fiber.Ctx) error {
+	result, err := h.service.Refresh(c.Context())
+	if err != nil {
+		return h.sendAnalyticsError(c, err, "Failed to refresh analytics")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, result, "Analytics refreshed successfully")
+}
+
+func parseAnalyticsQuery(c *fiber.Ctx) (*dto.AnalyticsQuery, error) {
+	query := &dto.AnalyticsQuery{GroupBy: c.Query("group_by"), Period: c.Query("period")}
+	var err error
+	if query.ProductID, err = optionalUUID(c.Query("product_id")); err != nil {
+		return nil, fmt.Errorf("invalid product_id: %w", err)
+	}
+	if query.SenderID, err = optionalUUID(c.Query("sender_id")); err != nil {
+		return nil, fmt.Errorf("invalid sender_id: %w", err)
+	}
+	if query.ReceiverID, err = optionalUUID(c.Query("receiver_id")); err != nil {
+		return nil, fmt.Errorf("invalid receiver_id: %w", err)
+	}
+	if query.StakeholderID, err = optionalUUID(c.Query("stakeholder_id")); err != nil {
+		return nil, fmt.Errorf("invalid stakeholder_id: %w", err)
+	}
+	if query.LocationID, err = optionalUUID(c.Query("location_id")); err != nil {
+		return nil, fmt.Errorf("invalid location_id: %w", err)
+	}
+	if query.From, err = optionalTime(c.Query("from")); err != nil {
+		return nil, fmt.Errorf("invalid from, expected RFC3339: %w", err)
+	}
+	if query.To, err = optionalTime(c.Query("to")); err != nil {
+		return nil, fmt.Errorf("invalid to, expected RFC3339: %w", err)
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			return nil, fmt.Errorf("invalid limit: %w", err)
+		}
+	}
+	return query, nil
+}
+
+func (h *analyticsHandler) sendAnalyticsError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidAnalyticsQuery):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
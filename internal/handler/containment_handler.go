@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"time"
+)
+
+type containmentHandler struct {
+	service services.SupplyChainService
+}
+
+func NewContainmentHandler(service services.SupplyChainService) *containmentHandler {
+	return &containmentHandler{service: service}
+}
+
+func (h *containmentHandler) GetContainment(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+
+	// Default to the current packing state
+	at := time.Now()
+	if atParam := c.Query("at"); atParam != "" {
+		if at, err = time.Parse(time.RFC3339, atParam); err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid at parameter, expected RFC3339")
+		}
+	}
+
+	snapshot, err := h.service.GetContainmentAt(c.Context(), id, at)
+	if err != nil {
+		if err == services.ErrProductNotFound {
+			return SendError(c, fiber.StatusNotFound, err, "Product not found")
+		}
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to get containment")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, snapshot, "Containment retrieved successfully")
+}
+
+func (h *containmentHandler) GetContainmentHistory(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+
+	history, err := h.service.GetContainmentHistory(c.Context(), id)
+	if err != nil {
+		if err == services.ErrProductNotFound {
+			return SendError(c, fiber.StatusNotFound, err, "Product not found")
+		}
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to get containment history")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, history, "Containment history retrieved successfully")
+}
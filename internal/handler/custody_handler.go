@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type custodyHandler struct {
+	service services.CustodyService
+}
+
+func NewCustodyHandler(service services.CustodyService) *custodyHandler {
+	return &custodyHandler{service: service}
+}
+
+func (h *custodyHandler) CreateTransfer(c *fiber.Ctx) error {
+	var req dto.CreateCustodyTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	transfer, err := h.service.CreateTransfer(c.Context(), &req)
+	if err != nil {
+		return h.sendTransferError(c, err, "Failed to create custody transfer")
+	}
+
+	return SendSuccess(c, fiber.StatusCreated, transfer, "Custody transfer created successfully")
+}
+
+func (h *custodyHandler) GetTransfer(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid custody transfer ID")
+	}
+
+	transfer, err := h.service.GetTransfer(c.Context(), id)
+	if err != nil {
+		return h.sendTransferError(c, err, "Failed to get custody transfer")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, transfer, "Custody transfer retrieved successfully")
+}
+
+func (h *custodyHandler) AcceptTransfer(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid custody transfer ID")
+	}
+
+	var req dto.AcceptCustodyTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	transfer, err := h.service.AcceptTransfer(c.Context(), id, &req)
+	if err != nil {
+		return h.sendTransferError(c, err, "Failed to accept custody transfer")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, transfer, "Custody transfer accepted successfully")
+}
+
+func (h *custodyHandler) RejectTransfer(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid custody transfer ID")
+	}
+
+	var req dto.RejectCustodyTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	transfer, err := h.service.RejectTransfer(c.Context(), id, &req)
+	if err != nil {
+		return h.sendTransferError(c, err, "Failed to reject custody transfer")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, transfer, "Custody transfer rejected successfully")
+}
+
+func (h *custodyHandler) ListTransfers(c *fiber.Ctx) error {
+	filter, err := h.parseFilter(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+	if stakeholderID := c.Query("stakeholder_id"); stakeholderID != "" {
+		if id, err := uuid.Parse(stakeholderID); err == nil {
+			filter.StakeholderID = &id
+		}
+	}
+	if productID := c.Query("product_id"); productID != "" {
+		if id, err := uuid.Parse(productID); err == nil {
+			filter.ProductID = &id
+		}
+	}
+
+	response, err := h.service.ListTransfers(c.Context(), filter)
+	if err != nil {
+		return h.sendTransferError(c, err, "Failed to list custody transfers")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Custody transfers retrieved successfully")
+}
+
+func (h *custodyHandler) ListPendingTransfers(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid stakeholder ID")
+	}
+
+	filter, err := h.parseFilter(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+
+	response, err := h.service.ListPendingTransfers(c.Context(), id, filter)
+	if err != nil {
+		return h.sendTransferError(c, err, "Failed to list pending custody transfers")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Pending custody transfers retrieved successfully")
+}
+
+func (h *custodyHandler) parseFilter(c *fiber.Ctx) (*dto.CustodyTransferFilter, error) {
+	filter := &dto.CustodyTransferFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return nil, err
+	}
+	filter.Cursor, filter.Count = cursor, count
+	if direction := c.Query("direction"); direction != "" {
+		filter.Direction = &direction
+	}
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	return filter, nil
+}
+
+func (h *custodyHandler) sendTransferError(c *fiber.Ctx, err error, fallback string) error {
+	switch err {
+	case services.ErrCustodyTransferNotFound:
+		return SendError(c, fiber.StatusNotFound, err, "Custody transfer not found")
+	case services.ErrProductNotFound:
+		return SendError(c, fiber.StatusNotFound, err, "Product not found")
+	case services.ErrStakeholderNotFound:
+		return SendError(c, fiber.StatusNotFound, err, "Stakeholder not found")
+	case services.ErrUnauthorized:
+		return SendError(c, fiber.StatusForbidden, err, "Only the receiving stakeholder can respond to this transfer")
+	case services.ErrNotCustodyHolder:
+		return SendError(c, fiber.StatusForbidden, err, "Sender does not hold custody of the product")
+	case services.ErrPendingTransferExists, services.ErrCustodyTransferNotPending:
+		return SendError(c, fiber.StatusConflict, err, err.Error())
+	case services.ErrCustodyTransferExpired:
+		return SendError(c, fiber.StatusGone, err, "Custody transfer has expired")
+	case services.ErrInvalidCustodyTransfer, services.ErrInvalidEventSequence:
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/epcis"
+	"github.com/koriebruh/suplyChainTrack/internal/gs1"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"net/url"
+	"strings"
+)
+
+type digitalLinkHandler struct {
+	service services.DigitalLinkService
+	config  conf.DigitalLinkConfig
+}
+
+func NewDigitalLinkHandler(service services.DigitalLinkService, config conf.DigitalLinkConfig) *digitalLinkHandler {
+	return &digitalLinkHandler{service: service, config: config}
+}
+
+// Resolve serves a GS1 Digital Link URI (/01/{gtin}[/10/{lot}][/21/{serial}]). Machines asking for
+// JSON get the product and its trace, linkset clients (or linkType=all) get the GS1 linkset, a
+// specific linkType redirects to that link and browsers are redirected to the consumer page.
+func (h *digitalLinkHandler) Resolve(c *fiber.Ctx) error {
+	link, err := gs1.ParseDigitalLink(c.OriginalURL())
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid GS1 Digital Link")
+	}
+
+	base := publicBaseURL(c, h.config)
+	resolution, err := h.service.Resolve(c.Context(), link, base)
+	if err != nil {
+		switch {
+		case errors.Is(err, gs1.ErrInvalidGTIN):
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid GTIN")
+		case errors.Is(err, services.ErrProductNotFound), errors.Is(err, services.ErrDigitalLinkMismatch):
+			return SendError(c, fiber.StatusNotFound, err, "Product not found")
+		default:
+			return SendError(c, fiber.StatusInternalServerError, err, "Failed to resolve Digital Link")
+		}
+	}
+
+	links := h.links(base, resolution)
+	linkType := c.Query("linkType")
+	c.Set(fiber.HeaderLink, "<"+resolution.Link+"?linkType="+gs1.LinkTypeAll+">; rel=\"linkset\"; type=\""+gs1.ContentTypeLinkset+"\"")
+
+	if linkType == gs1.LinkTypeAll {
+		return c.Status(fiber.StatusOK).JSON(gs1.NewLinkset(resolution.Link, links), gs1.ContentTypeLinkset)
+	}
+	if linkType != "" {
+		// A link type the product does not have falls back to the default link, as GS1 resolvers do
+		if targets, ok := links[linkType]; ok {
+			return c.Redirect(targets[0].Href, fiber.StatusTemporaryRedirect)
+		}
+		return c.Redirect(links[gs1.LinkTypeDefault][0].Href, fiber.StatusTemporaryRedirect)
+	}
+
+	switch c.Accepts(fiber.MIMETextHTML, fiber.MIMEApplicationJSON, epcis.ContentTypeJSON, gs1.ContentTypeLinkset) {
+	case gs1.ContentTypeLinkset:
+		return c.Status(fiber.StatusOK).JSON(gs1.NewLinkset(resolution.Link, links), gs1.ContentTypeLinkset)
+	case fiber.MIMETextHTML:
+		if _, ok := links[gs1.LinkTypePIP]; ok {
+			return c.Redirect(links[gs1.LinkTypePIP][0].Href, fiber.StatusTemporaryRedirect)
+		}
+	}
+
+	return SendSuccess(c, fiber.StatusOK, resolution, "Digital Link resolved successfully")
+}
+
+// links lists the targets of each GS1 link type: the consumer page when one is configured,
+// the EPCIS trace and the EPCIS event query for the product
+func (h *digitalLinkHandler) links(base string, resolution *dto.DigitalLinkResolution) map[string][]gs1.Link {
+	product := resolution.Product
+	traceability := gs1.Link{
+		Href:  base + "/api/v1/epcis/products/" + product.ID.String() + "/trace",
+		Title: product.Name + " traceability",
+		Type:  epcis.ContentTypeJSON,
+	}
+	links := map[string][]gs1.Link{
+		gs1.LinkTypeTraceability: {traceability},
+		gs1.LinkTypeEPCIS: {{
+			Href:  base + "/api/v1/epcis/events?MATCH_epc=" + url.QueryEscape(epcis.ProductEPC(product)),
+			Title: product.Name + " EPCIS events",
+			Type:  epcis.ContentTypeJSON,
+		}},
+		gs1.LinkTypeDefault: {traceability},
+	}
+
+	if h.config.ConsumerPageURL != "" {
+		page := gs1.Link{
+			Href: strings.NewReplacer(
+				"{gtin}", resolution.GTIN,
+				"{lot}", url.PathEscape(resolution.Lot),
+				"{serial}", url.PathEscape(resolution.Serial),
+				"{sku}", url.PathEscape(product.SKU),
+				"{product_id}", product.ID.String(),
+			).Replace(h.config.ConsumerPageURL),
+			Title: product.Name,
+			Type:  fiber.MIMETextHTML,
+		}
+		links[gs1.LinkTypePIP] = []gs1.Link{page}
+		links[gs1.LinkTypeDefault] = []gs1.Link{page}
+	}
+
+	return links
+}
+
+// publicBaseURL is the configured public origin, else the origin the request arrived on
+func publicBaseURL(c *fiber.Ctx, config conf.DigitalLinkConfig) string {
+	if config.BaseURL != "" {
+		return strings.TrimRight(config.BaseURL, "/")
+	}
+	return c.BaseURL()
+}
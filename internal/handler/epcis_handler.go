@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/epcis"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"io"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type epcisHandler struct {
+	service services.EPCISService
+}
+
+func NewEPCISHandler(service services.EPCISService) *epcisHandler {
+	return &epcisHandler{service: service}
+}
+
+// Capture accepts an EPCIS 2.0 JSON-LD EPCISDocument. Events without the tracer:stakeholder
+// extension are attributed to the stakeholder_id query parameter.
+func (h *epcisHandler) Capture(c *fiber.Ctx) error {
+	stakeholderID, err := optionalUUID(c.Query("stakeholder_id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid stakeholder ID")
+	}
+
+	events, err := epcis.DecodeDocument(bytes.NewReader(c.Body()))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid EPCIS document")
+	}
+
+	result, err := h.service.Capture(c.Context(), events, stakeholderID)
+	if err != nil {
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to capture EPCIS events")
+	}
+
+	return SendSuccess(c, captureStatus(result), result, "EPCIS events captured")
+}
+
+// QueryEvents answers a SimpleEventQuery with an EPCISQueryDocument. Further pages are linked
+// through the Link header, as in the EPCIS 2.0 REST binding.
+func (h *epcisHandler) QueryEvents(c *fiber.Ctx) error {
+	query, err := parseEPCISQuery(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid EPCIS query")
+	}
+
+	result, err := h.service.Query(c.Context(), query)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidEPCISQuery) {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid EPCIS query")
+		}
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to query EPCIS events")
+	}
+
+	if result.NextPageToken != "" {
+		if next, err := url.Parse(c.OriginalURL()); err == nil {
+			params := next.Query()
+			params.Set("nextPageToken", result.NextPageToken)
+			next.RawQuery = params.Encode()
+			c.Set(fiber.HeaderLink, fmt.Sprintf("<%s>; rel=\"next\"", next.String()))
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(epcis.NewQueryDocument(result.Events), epcis.ContentTypeJSON)
+}
+
+// CaptureXML accepts an EPCIS 1.2 XML EPCISDocument. The body is read as a stream, so events are
+// captured in document order as they arrive.
+func (h *epcisHandler) CaptureXML(c *fiber.Ctx) error {
+	stakeholderID, err := optionalUUID(c.Query("stakeholder_id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid stakeholder ID")
+	}
+
+	var body io.Reader = bytes.NewReader(c.Body())
+	if stream := c.Context().RequestBodyStream(); stream != nil {
+		body = stream
+	}
+
+	result, err := h.service.ImportXML(c.Context(), body, stakeholderID)
+	if err != nil {
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to import EPCIS XML")
+	}
+
+	return SendSuccess(c, captureStatus(result), result, "EPCIS XML imported")
+}
+
+// GetProductTrace renders a product's trace as an EPCIS 2.0 JSON-LD document
+func (h *epcisHandler) GetProductTrace(c *fiber.Ctx) error {
+	events, err := h.productTrace(c)
+	if err != nil {
+		return err
+	}
+	if events == nil {
+		return nil
+	}
+
+	return c.Status(fiber.StatusOK).JSON(epcis.NewDocument(events), epcis.ContentTypeJSON)
+}
+
+// GetProductTraceXML renders a product's trace as an EPCIS 1.2 XML document
+func (h *epcisHandler) GetProductTraceXML(c *fiber.Ctx) error {
+	events, err := h.productTrace(c)
+	if err != nil {
+		return err
+	}
+	if events == nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := epcis.EncodeXML(&buf, events); err != nil {
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to encode EPCIS XML")
+	}
+
+	c.Set(fiber.HeaderContentType, epcis.ContentTypeXML)
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+// ExportEventsXML streams every event with GE_eventTime <= eventTime < LT_eventTime as an EPCIS 1.2
+// XML document. The response is written while events are read, so a failure part way through can
+// only be logged and leaves the document truncated.
+func (h *epcisHandler) ExportEventsXML(c *fiber.Ctx) error {
+	from, err := optionalTime(c.Query("GE_eventTime"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid GE_eventTime")
+	}
+	to, err := optionalTime(c.Query("LT_eventTime"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid LT_eventTime")
+	}
+
+	c.Set(fiber.HeaderContentType, epcis.ContentTypeXML)
+	c.Status(fiber.StatusOK)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// The request context ends once the handler returns; the stream outlives it
+		ctx := context.Background()
+		writer, err := epcis.NewXMLWriter(w)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to start EPCIS XML export", "error", err)
+			return
+		}
+		if err := h.service.ExportEvents(ctx, from, to, writer.WriteEvent); err != nil {
+			slog.ErrorContext(ctx, "failed to export EPCIS XML", "error", err)
+			return
+		}
+		if err := writer.Close(); err != nil {
+			slog.ErrorContext(ctx, "failed to finish EPCIS XML export", "error", err)
+		}
+	})
+
+	return nil
+}
+
+// productTrace loads the rendered trace, sending the error response itself; nil events mean it did
+func (h *epcisHandler) productTrace(c *fiber.Ctx) ([]*epcis.Event, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+
+	events, err := h.service.ExportProductTrace(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			return nil, SendError(c, fiber.StatusNotFound, err, "Product not found")
+		}
+		return nil, SendError(c, fiber.StatusInternalServerError, err, "Failed to get product trace")
+	}
+
+	return events, nil
+}
+
+func parseEPCISQuery(c *fiber.Ctx) (*dto.EPCISQuery, error) {
+	query := &dto.EPCISQuery{NextPageToken: c.Query("nextPageToken")}
+
+	if eventTypes := c.Query("eventType"); eventTypes != "" {
+		query.EventTypes = strings.Split(eventTypes, ",")
+	}
+	if bizStep := c.Query("EQ_bizStep"); bizStep != "" {
+		query.BizStep = &bizStep
+	}
+	if epc := c.Query("MATCH_epc"); epc != "" {
+		query.EPC = &epc
+	}
+	if bizLocation := c.Query("EQ_bizLocation"); bizLocation != "" {
+		query.BizLocation = &bizLocation
+	}
+	from, err := optionalTime(c.Query("GE_eventTime"))
+	if err != nil {
+		return nil, err
+	}
+	query.FromTime = from
+	to, err := optionalTime(c.Query("LT_eventTime"))
+	if err != nil {
+		return nil, err
+	}
+	query.ToTime = to
+	if perPage := c.Query("perPage"); perPage != "" {
+		n, err := strconv.Atoi(perPage)
+		if err != nil {
+			return nil, err
+		}
+		query.PerPage = n
+	}
+
+	return query, nil
+}
+
+// captureStatus is 201 when every event was captured or already known, 207 when some failed
+// and 422 when none could be captured
+func captureStatus(result *dto.EPCISCaptureResult) int {
+	switch {
+	case result.Failed == 0:
+		return fiber.StatusCreated
+	case result.Captured+result.Duplicates > 0:
+		return fiber.StatusMultiStatus
+	default:
+		return fiber.StatusUnprocessableEntity
+	}
+}
+
+func optionalUUID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func optionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
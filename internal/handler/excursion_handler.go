@@ -0,0 +1,306 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type excursionHandler struct {
+	service services.ExcursionService
+}
+
+func NewExcursionHandler(service services.ExcursionService) *excursionHandler {
+	return &excursionHandler{service: service}
+}
+
+func (h *excursionHandler) CreateRule(c *fiber.Ctx) error {
+	var req dto.CreateExcursionRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	rule, err := h.service.CreateRule(c.Context(), &req)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to create excursion rule")
+	}
+
+	return SendSuccess(c, fiber.StatusCreated, rule, "Excursion rule created successfully")
+}
+
+func (h *excursionHandler) GetRule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid excursion rule ID")
+	}
+
+	rule, err := h.service.GetRule(c.Context(), id)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to get excursion rule")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, rule, "Excursion rule retrieved successfully")
+}
+
+func (h *excursionHandler) UpdateRule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid excursion rule ID")
+	}
+
+	var req dto.UpdateExcursionRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	rule, err := h.service.UpdateRule(c.Context(), id, &req)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to update excursion rule")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, rule, "Excursion rule updated successfully")
+}
+
+func (h *excursionHandler) DeleteRule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid excursion rule ID")
+	}
+
+	if err := h.service.DeleteRule(c.Context(), id); err != nil {
+		return h.sendExcursionError(c, err, "Failed to delete excursion rule")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, nil, "Excursion rule deleted successfully")
+}
+
+// ListRules lists excursion rules, newest first, by category, metric and is_active
+func (h *excursionHandler) ListRules(c *fiber.Ctx) error {
+	filter := &dto.ExcursionRuleFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+
+	if category := c.Query("category"); category != "" {
+		filter.Category = &category
+	}
+	if metric := c.Query("metric"); metric != "" {
+		filter.Metric = &metric
+	}
+	if isActive := c.Query("is_active"); isActive != "" {
+		active, err := strconv.ParseBool(isActive)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid is_active: %w", err), "Invalid query parameters")
+		}
+		filter.IsActive = &active
+	}
+
+	response, err := h.service.ListRules(c.Context(), filter)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to list excursion rules")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Excursion rules retrieved successfully")
+}
+
+func (h *excursionHandler) GetIncident(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid excursion incident ID")
+	}
+
+	incident, err := h.service.GetIncident(c.Context(), id)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to get excursion incident")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, incident, "Excursion incident retrieved successfully")
+}
+
+// ListIncidents lists excursion incidents, newest first, by rule_id, product_id,
+// custody_transfer_id, status and breached. Under /custody-transfers/:id/excursions the transfer
+// comes from the path.
+func (h *excursionHandler) ListIncidents(c *fiber.Ctx) error {
+	filter := &dto.ExcursionIncidentFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+
+	if filter.RuleID, err = optionalUUID(c.Query("rule_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid rule_id: %w", err), "Invalid query parameters")
+	}
+	if filter.ProductID, err = optionalUUID(c.Query("product_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid product_id: %w", err), "Invalid query parameters")
+	}
+	if filter.CustodyTransferID, err = optionalUUID(c.Params("id", c.Query("custody_transfer_id"))); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid custody transfer ID")
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+	if breached := c.Query("breached"); breached != "" {
+		value, err := strconv.ParseBool(breached)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid breached: %w", err), "Invalid query parameters")
+		}
+		filter.Breached = &value
+	}
+
+	response, err := h.service.ListIncidents(c.Context(), filter)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to list excursion incidents")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Excursion incidents retrieved successfully")
+}
+
+func (h *excursionHandler) AcknowledgeIncident(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid excursion incident ID")
+	}
+
+	var req dto.AcknowledgeExcursionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+		}
+	}
+
+	incident, err := h.service.AcknowledgeIncident(c.Context(), id, &req)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to acknowledge excursion incident")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, incident, "Excursion incident acknowledged successfully")
+}
+
+func (h *excursionHandler) GetQuarantine(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid quarantine ID")
+	}
+
+	quarantine, err := h.service.GetQuarantine(c.Context(), id)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to get quarantine")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, quarantine, "Quarantine retrieved successfully")
+}
+
+// ListQuarantines lists quarantines, newest first, by product_id, incident_id, lot_number and
+// status. Under /products/:id/quarantines the product comes from the path.
+func (h *excursionHandler) ListQuarantines(c *fiber.Ctx) error {
+	filter := &dto.QuarantineFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+
+	if filter.ProductID, err = optionalUUID(c.Params("id", c.Query("product_id"))); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+	if filter.IncidentID, err = optionalUUID(c.Query("incident_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid incident_id: %w", err), "Invalid query parameters")
+	}
+	if lotNumber := c.Query("lot_number"); lotNumber != "" {
+		filter.LotNumber = &lotNumber
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+
+	response, err := h.service.ListQuarantines(c.Context(), filter)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to list quarantines")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Quarantines retrieved successfully")
+}
+
+func (h *excursionHandler) ReleaseQuarantine(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid quarantine ID")
+	}
+
+	var req dto.ReleaseQuarantineRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+		}
+	}
+
+	quarantine, err := h.service.ReleaseQuarantine(c.Context(), id, &req)
+	if err != nil {
+		return h.sendExcursionError(c, err, "Failed to release quarantine")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, quarantine, "Quarantine released successfully")
+}
+
+func (h *excursionHandler) sendExcursionError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrExcursionRuleNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Excursion rule not found")
+	case errors.Is(err, services.ErrExcursionIncidentNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Excursion incident not found")
+	case errors.Is(err, services.ErrQuarantineNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Quarantine not found")
+	case errors.Is(err, services.ErrExcursionAlreadyAcknowledged):
+		return SendError(c, fiber.StatusConflict, err, "Excursion incident has already been acknowledged")
+	case errors.Is(err, services.ErrQuarantineNotActive):
+		return SendError(c, fiber.StatusConflict, err, "Quarantine is not active")
+	case errors.Is(err, services.ErrInvalidExcursionRule),
+		errors.Is(err, services.ErrInvalidExcursionFilter):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/export"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type exportHandler struct {
+	service services.ExportService
+}
+
+func NewExportHandler(service services.ExportService) *exportHandler {
+	return &exportHandler{service: service}
+}
+
+// ExportEvents streams every event matching the query filters (product_id, stakeholder_id,
+// event_type, event_types, location, location_id, is_verified, from_date, to_date and filter[...]
+// predicates) as format=csv, ndjson or parquet
+func (h *exportHandler) ExportEvents(c *fiber.Ctx) error {
+	filter := &dto.SupplyChainEventFilter{}
+
+	if productID := c.Query("product_id"); productID != "" {
+		id, err := uuid.Parse(productID)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+		}
+		filter.ProductID = &id
+	}
+	if stakeholderID := c.Query("stakeholder_id"); stakeholderID != "" {
+		id, err := uuid.Parse(stakeholderID)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid stakeholder ID")
+		}
+		filter.StakeholderID = &id
+	}
+	if eventType := c.Query("event_type"); eventType != "" {
+		filter.EventType = &eventType
+	}
+	if eventTypes := c.Query("event_types"); eventTypes != "" {
+		filter.EventTypes = strings.Split(eventTypes, ",")
+	}
+	if location := c.Query("location"); location != "" {
+		filter.Location = &location
+	}
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := uuid.Parse(locationID)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid location ID")
+		}
+		filter.LocationID = &id
+	}
+	if isVerified := c.Query("is_verified"); isVerified != "" {
+		verified, err := strconv.ParseBool(isVerified)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid is_verified")
+		}
+		filter.IsVerified = &verified
+	}
+	var err error
+	if filter.FromDate, err = optionalTime(c.Query("from_date")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid from_date")
+	}
+	if filter.ToDate, err = optionalTime(c.Query("to_date")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid to_date")
+	}
+	if filter.Query.Filters, err = exportPredicates(c, listquery.Events); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid list query")
+	}
+
+	return h.stream(c, "events", func(ctx context.Context, format string, w io.Writer) error {
+		return h.service.ExportEvents(ctx, filter, format, w)
+	})
+}
+
+// ExportProducts streams every product matching the query filters (category, manufacturer_id,
+// sku, name, lot_number and filter[...] predicates) as format=csv, ndjson or parquet
+func (h *exportHandler) ExportProducts(c *fiber.Ctx) error {
+	filter := &dto.ProductFilter{}
+
+	if category := c.Query("category"); category != "" {
+		filter.Category = &category
+	}
+	if manufacturerID := c.Query("manufacturer_id"); manufacturerID != "" {
+		id, err := uuid.Parse(manufacturerID)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid manufacturer ID")
+		}
+		filter.ManufacturerID = &id
+	}
+	if sku := c.Query("sku"); sku != "" {
+		filter.SKU = &sku
+	}
+	if name := c.Query("name"); name != "" {
+		filter.Name = &name
+	}
+	if lotNumber := c.Query("lot_number"); lotNumber != "" {
+		filter.LotNumber = &lotNumber
+	}
+	var err error
+	if filter.Query.Filters, err = exportPredicates(c, listquery.Products); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid list query")
+	}
+
+	return h.stream(c, "products", func(ctx context.Context, format string, w io.Writer) error {
+		return h.service.ExportProducts(ctx, filter, format, w)
+	})
+}
+
+// exportPredicates reads the list query's filter[...] predicates. Exports keep their own column
+// set and id order, so sort, fields and include do not apply.
+func exportPredicates(c *fiber.Ctx, resource listquery.Resource) ([]listquery.Predicate, error) {
+	options, err := listquery.Parse(resource, c.Queries())
+	if err != nil {
+		return nil, err
+	}
+	return options.Filters, nil
+}
+
+// stream checks the format up front, then writes the export as the response body while rows are
+// read, so a failure part way through can only be logged and leaves the file truncated
+func (h *exportHandler) stream(c *fiber.Ctx, name string, write func(ctx context.Context, format string, w io.Writer) error) error {
+	format := strings.ToLower(c.Query("format", export.FormatCSV))
+	if err := h.service.ValidateFormat(format); err != nil {
+		return h.sendExportError(c, err, "Invalid export format")
+	}
+	contentType, _ := export.ContentType(format)
+
+	filename := name + "-" + time.Now().UTC().Format("20060102T150405Z") + "." + format
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, "attachment; filename=\""+filename+"\"")
+	c.Status(fiber.StatusOK)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// The request context ends once the handler returns; the stream outlives it
+		ctx := context.Background()
+		if err := write(ctx, format, w); err != nil {
+			slog.ErrorContext(ctx, "failed to stream export", "export", name, "format", format, "error", err)
+		}
+	})
+
+	return nil
+}
+
+func (h *exportHandler) sendExportError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidExport):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"net/url"
+	"strconv"
+)
+
+type genealogyHandler struct {
+	service services.SupplyChainService
+}
+
+func NewGenealogyHandler(service services.SupplyChainService) *genealogyHandler {
+	return &genealogyHandler{service: service}
+}
+
+func (h *genealogyHandler) GetUpstream(c *fiber.Ctx) error {
+	return h.walk(c, h.service.GetUpstreamGenealogy)
+}
+
+func (h *genealogyHandler) GetDownstream(c *fiber.Ctx) error {
+	return h.walk(c, h.service.GetDownstreamGenealogy)
+}
+
+// GetLotUpstream walks up from the transformations that produced a lot, by product_id and depth
+func (h *genealogyHandler) GetLotUpstream(c *fiber.Ctx) error {
+	return h.walkLot(c, h.service.GetLotUpstreamGenealogy)
+}
+
+// GetLotDownstream walks down from the transformations that consumed a lot, such as the finished
+// goods made with a contaminated ingredient lot, by product_id and depth
+func (h *genealogyHandler) GetLotDownstream(c *fiber.Ctx) error {
+	return h.walkLot(c, h.service.GetLotDownstreamGenealogy)
+}
+
+func (h *genealogyHandler) GetTransformationLines(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid event ID")
+	}
+
+	lines, err := h.service.GetTransformationLines(c.Context(), id)
+	if err != nil {
+		if err == services.ErrEventNotFound {
+			return SendError(c, fiber.StatusNotFound, err, "Event not found")
+		}
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to get transformation lines")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, lines, "Transformation lines retrieved successfully")
+}
+
+func (h *genealogyHandler) walk(c *fiber.Ctx, fn func(ctx context.Context, productID uuid.UUID, maxDepth int) (*dto.Genealogy, error)) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+
+	depth, err := parseDepth(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+
+	genealogy, err := fn(c.Context(), id, depth)
+	if err != nil {
+		if err == services.ErrProductNotFound {
+			return SendError(c, fiber.StatusNotFound, err, "Product not found")
+		}
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to get genealogy")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, genealogy, "Genealogy retrieved successfully")
+}
+
+func (h *genealogyHandler) walkLot(c *fiber.Ctx, fn func(ctx context.Context, lot string, productID *uuid.UUID, maxDepth int) (*dto.Genealogy, error)) error {
+	lot, err := url.PathUnescape(c.Params("lot"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid lot")
+	}
+
+	productID, err := optionalUUID(c.Query("product_id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid product_id: %w", err), "Invalid query parameters")
+	}
+	depth, err := parseDepth(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+
+	genealogy, err := fn(c.Context(), lot, productID, depth)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrProductNotFound):
+			return SendError(c, fiber.StatusNotFound, err, "Product not found")
+		case errors.Is(err, services.ErrInvalidGenealogyQuery):
+			return SendError(c, fiber.StatusBadRequest, err, err.Error())
+		}
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to get genealogy")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, genealogy, "Genealogy retrieved successfully")
+}
+
+// parseDepth reads depth; 0 (default) walks every level up to the service limit
+func parseDepth(c *fiber.Ctx) (int, error) {
+	depthParam := c.Query("depth")
+	if depthParam == "" {
+		return 0, nil
+	}
+	depth, err := strconv.Atoi(depthParam)
+	if err != nil {
+		return 0, fmt.Errorf("invalid depth: %w", err)
+	}
+	if depth < 0 {
+		return 0, errors.New("invalid depth: must not be negative")
+	}
+	return depth, nil
+}
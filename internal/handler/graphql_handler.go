@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/gql"
+	"github.com/koriebruh/suplyChainTrack/internal/stream"
+	"sync"
+	"time"
+)
+
+// graphql-transport-ws (https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md)
+const (
+	graphqlWSProtocol = "graphql-transport-ws"
+
+	gqlConnectionInit = "connection_init"
+	gqlConnectionAck  = "connection_ack"
+	gqlPing           = "ping"
+	gqlPong           = "pong"
+	gqlSubscribe      = "subscribe"
+	gqlNext           = "next"
+	gqlError          = "error"
+	gqlComplete       = "complete"
+
+	// Close codes defined by the protocol
+	gqlCloseBadRequest       = 4400
+	gqlCloseUnauthorized     = 4401
+	gqlCloseForbidden        = 4403
+	gqlCloseSubscriberExists = 4409
+	gqlCloseTooManyInits     = 4429
+
+	// connectionInitWait is how long a client has to send connection_init
+	connectionInitWait = 10 * time.Second
+
+	// maxSubscriptions bounds the operations running on one connection
+	maxSubscriptions = 20
+)
+
+type graphqlHandler struct {
+	server *gql.Server
+	apiKey string
+	secret string
+	ws     fiber.Handler
+}
+
+func NewGraphQLHandler(server *gql.Server, config conf.Config) *graphqlHandler {
+	h := &graphqlHandler{server: server, apiKey: config.AppConfig.ApiKey, secret: config.StreamConfig.TokenSecret}
+	h.ws = websocket.New(h.serveWebSocket, websocket.Config{
+		Origins:      config.AppConfig.AllowedOrigins,
+		Subprotocols: []string{graphqlWSProtocol},
+	})
+	return h
+}
+
+// Query answers a GraphQL query: POST with a JSON body {"query", "operationName", "variables"}, or
+// GET with the same fields as query parameters (variables JSON-encoded). The response is the plain
+// GraphQL result, {"data", "errors"}.
+func (h *graphqlHandler) Query(c *fiber.Ctx) error {
+	var req gql.Request
+	if c.Method() == fiber.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return SendError(c, fiber.StatusBadRequest, err, "Invalid variables")
+			}
+		}
+	} else if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+	if req.Query == "" {
+		return SendError(c, fiber.StatusBadRequest, errors.New("query is required"), "Query is required")
+	}
+
+	result := h.server.Execute(c.Context(), &req)
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// Upgrade only lets WebSocket requests through; authentication happens in connection_init
+func (h *graphqlHandler) Upgrade(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return SendError(c, fiber.StatusUpgradeRequired, fiber.ErrUpgradeRequired, "WebSocket upgrade required")
+	}
+	return c.Next()
+}
+
+// WebSocket serves subscriptions (and queries) over the graphql-transport-ws protocol. The
+// connection_init payload carries {"token": "..."} or {"X-API-Key": "..."}.
+func (h *graphqlHandler) WebSocket(c *fiber.Ctx) error {
+	return h.ws(c)
+}
+
+// graphqlWSMessage is a graphql-transport-ws frame
+type graphqlWSMessage struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// graphqlWSFrame is what the writer sends: a message or, with CloseCode set, a close frame
+type graphqlWSFrame struct {
+	Message   graphqlWSMessage
+	CloseCode int
+	CloseText string
+}
+
+type graphqlWSConn struct {
+	out  chan graphqlWSFrame
+	stop chan struct{} // closed when the read loop ends
+	done chan struct{} // closed when the writer has finished with the socket
+
+	mu            sync.Mutex
+	claims        *stream.Claims
+	subscriptions map[string]context.CancelFunc
+}
+
+func (h *graphqlHandler) serveWebSocket(ws *websocket.Conn) {
+	conn := &graphqlWSConn{
+		out:           make(chan graphqlWSFrame, 16),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+		subscriptions: map[string]context.CancelFunc{},
+	}
+	go h.writeWebSocket(ws, conn)
+	defer func() {
+		// The socket is released when this returns, so the writer must be finished with it
+		close(conn.stop)
+		<-conn.done
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel() // ends every subscription
+
+	ws.SetReadLimit(maxClientMessage * 16)
+	ws.SetReadDeadline(time.Now().Add(connectionInitWait))
+
+	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			return // closed, timed out, or closed by the writer
+		}
+
+		var message graphqlWSMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			conn.close(gqlCloseBadRequest, "Invalid message received")
+			return
+		}
+
+		switch message.Type {
+		case gqlConnectionInit:
+			if conn.initialized() {
+				conn.close(gqlCloseTooManyInits, "Too many initialisation requests")
+				return
+			}
+			claims, err := h.connectionClaims(message.Payload)
+			if err != nil {
+				conn.close(gqlCloseForbidden, "Forbidden")
+				return
+			}
+			conn.mu.Lock()
+			conn.claims = claims
+			conn.mu.Unlock()
+
+			ws.SetReadDeadline(time.Now().Add(pongWait))
+			ws.SetPongHandler(func(string) error {
+				return ws.SetReadDeadline(time.Now().Add(pongWait))
+			})
+			conn.send(graphqlWSMessage{Type: gqlConnectionAck})
+		case gqlPing:
+			conn.send(graphqlWSMessage{Type: gqlPong})
+		case gqlPong:
+		case gqlSubscribe:
+			if !conn.initialized() {
+				conn.close(gqlCloseUnauthorized, "Unauthorized")
+				return
+			}
+			var req gql.Request
+			if message.ID == "" || json.Unmarshal(message.Payload, &req) != nil {
+				conn.close(gqlCloseBadRequest, "Invalid subscribe message")
+				return
+			}
+			if !h.subscribe(ctx, conn, message.ID, &req) {
+				return
+			}
+		case gqlComplete:
+			conn.mu.Lock()
+			if stop, ok := conn.subscriptions[message.ID]; ok {
+				stop()
+				delete(conn.subscriptions, message.ID)
+			}
+			conn.mu.Unlock()
+		default:
+			conn.close(gqlCloseBadRequest, "Unknown message type")
+			return
+		}
+	}
+}
+
+// subscribe starts an operation; false means the connection was closed
+func (h *graphqlHandler) subscribe(ctx context.Context, conn *graphqlWSConn, id string, req *gql.Request) bool {
+	conn.mu.Lock()
+	if _, exists := conn.subscriptions[id]; exists {
+		conn.mu.Unlock()
+		conn.close(gqlCloseSubscriberExists, "Subscriber for "+id+" already exists")
+		return false
+	}
+	if len(conn.subscriptions) >= maxSubscriptions {
+		conn.mu.Unlock()
+		conn.sendErrors(id, gqlerrors.FormatErrors(errors.New("too many subscriptions on this connection")))
+		return true
+	}
+	ctx, stop := context.WithCancel(ctx)
+	conn.subscriptions[id] = stop
+	claims := conn.claims
+	conn.mu.Unlock()
+
+	results := h.server.Subscribe(ctx, req, claims)
+	go func() {
+		defer func() {
+			conn.mu.Lock()
+			delete(conn.subscriptions, id)
+			conn.mu.Unlock()
+			stop()
+		}()
+
+		first := true
+		for result := range results {
+			// Errors before anything was executed end the operation with an error message
+			if first && result.Data == nil && result.HasErrors() {
+				conn.sendErrors(id, result.Errors)
+				drain(results)
+				return
+			}
+			first = false
+
+			payload, err := json.Marshal(result)
+			if err != nil {
+				continue
+			}
+			if !conn.send(graphqlWSMessage{Type: gqlNext, ID: id, Payload: payload}) {
+				drain(results)
+				return
+			}
+		}
+
+		// Not after the client completed it itself
+		if ctx.Err() == nil {
+			conn.send(graphqlWSMessage{Type: gqlComplete, ID: id})
+		}
+	}()
+	return true
+}
+
+// connectionClaims reads the credentials of connection_init
+func (h *graphqlHandler) connectionClaims(payload json.RawMessage) (*stream.Claims, error) {
+	var credentials struct {
+		Token  string `json:"token"`
+		APIKey string `json:"X-API-Key"`
+	}
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &credentials); err != nil {
+			return nil, err
+		}
+	}
+	return streamClaims(h.apiKey, h.secret, credentials.APIKey, credentials.Token)
+}
+
+func (h *graphqlHandler) writeWebSocket(ws *websocket.Conn, conn *graphqlWSConn) {
+	defer close(conn.done)
+	// Closing the socket also ends the read loop
+	defer ws.Close()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		var err error
+		select {
+		case <-conn.stop:
+			return
+		case frame := <-conn.out:
+			if frame.CloseCode != 0 {
+				ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(frame.CloseCode, frame.CloseText), time.Now().Add(writeWait))
+				return
+			}
+			ws.SetWriteDeadline(time.Now().Add(writeWait))
+			err = ws.WriteJSON(frame.Message)
+		case <-ping.C:
+			err = ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (c *graphqlWSConn) initialized() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.claims != nil
+}
+
+// send queues a message; false means the connection is gone
+func (c *graphqlWSConn) send(message graphqlWSMessage) bool {
+	select {
+	case c.out <- graphqlWSFrame{Message: message}:
+		return true
+	case <-c.done:
+		return false
+	}
+}
+
+func (c *graphqlWSConn) sendErrors(id string, errs []gqlerrors.FormattedError) {
+	payload, _ := json.Marshal(errs)
+	c.send(graphqlWSMessage{Type: gqlError, ID: id, Payload: payload})
+}
+
+// close has the writer send a close frame and waits until it is written
+func (c *graphqlWSConn) close(code int, text string) {
+	select {
+	case c.out <- graphqlWSFrame{CloseCode: code, CloseText: text}:
+		<-c.done
+	case <-c.done:
+	}
+}
+
+func drain(results <-chan *graphql.Result) {
+	for range results {
+	}
+}
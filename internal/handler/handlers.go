@@ -3,6 +3,7 @@ package handler
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
 )
 
 type Handler struct {
@@ -24,6 +25,23 @@ func SendSuccess(c *fiber.Ctx, statusCode int, data interface{}, message string)
 	})
 }
 
+// parsePage reads the cursor and count query parameters shared by the list endpoints
+func parsePage(c *fiber.Ctx) (*paging.Cursor, string, error) {
+	var cursor *paging.Cursor
+	if value := c.Query("cursor"); value != "" {
+		parsed, err := paging.ParseCursor(value)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor = parsed
+	}
+	count := c.Query("count")
+	if count != "" && !paging.IsValidCount(count) {
+		return nil, "", paging.ErrInvalidCount
+	}
+	return cursor, count, nil
+}
+
 type StakeholderHandler interface {
 	CreateStakeholder(c *fiber.Ctx) error
 	GetStakeholderByEmail(c *fiber.Ctx) error
@@ -68,3 +86,169 @@ type BlockchainHandler interface {
 	UpdateTransactionStatus(c *fiber.Ctx) error
 	GetTransactionByEvent(c *fiber.Ctx) error
 }
+
+type ContainmentHandler interface {
+	GetContainment(c *fiber.Ctx) error
+	GetContainmentHistory(c *fiber.Ctx) error
+}
+
+type GenealogyHandler interface {
+	GetUpstream(c *fiber.Ctx) error
+	GetDownstream(c *fiber.Ctx) error
+	GetLotUpstream(c *fiber.Ctx) error
+	GetLotDownstream(c *fiber.Ctx) error
+	GetTransformationLines(c *fiber.Ctx) error
+}
+
+type CustodyHandler interface {
+	CreateTransfer(c *fiber.Ctx) error
+	GetTransfer(c *fiber.Ctx) error
+	AcceptTransfer(c *fiber.Ctx) error
+	RejectTransfer(c *fiber.Ctx) error
+	ListTransfers(c *fiber.Ctx) error
+	ListPendingTransfers(c *fiber.Ctx) error
+}
+
+type InventoryHandler interface {
+	GetCurrentCustody(c *fiber.Ctx) error
+	ListInventory(c *fiber.Ctx) error
+	ListStakeholderInventory(c *fiber.Ctx) error
+}
+
+type RecallHandler interface {
+	CreateRecall(c *fiber.Ctx) error
+	GetRecall(c *fiber.Ctx) error
+	ListRecalls(c *fiber.Ctx) error
+	ActivateRecall(c *fiber.Ctx) error
+	NotifyStakeholders(c *fiber.Ctx) error
+	AcknowledgeRecall(c *fiber.Ctx) error
+	CloseRecall(c *fiber.Ctx) error
+	ListAffectedUnits(c *fiber.Ctx) error
+	GetRecallReport(c *fiber.Ctx) error
+	ListStakeholderRecalls(c *fiber.Ctx) error
+}
+
+type EPCISHandler interface {
+	Capture(c *fiber.Ctx) error
+	QueryEvents(c *fiber.Ctx) error
+	CaptureXML(c *fiber.Ctx) error
+	GetProductTrace(c *fiber.Ctx) error
+	GetProductTraceXML(c *fiber.Ctx) error
+	ExportEventsXML(c *fiber.Ctx) error
+}
+
+type DigitalLinkHandler interface {
+	Resolve(c *fiber.Ctx) error
+}
+
+type LabelHandler interface {
+	GetProductLabel(c *fiber.Ctx) error
+	GetProductQR(c *fiber.Ctx) error
+	GetLotLabel(c *fiber.Ctx) error
+	GetLotQR(c *fiber.Ctx) error
+	CreateLabelSheet(c *fiber.Ctx) error
+	GetShipmentLabelSheet(c *fiber.Ctx) error
+}
+
+type ImportHandler interface {
+	StartImport(c *fiber.Ctx) error
+	GetImportJob(c *fiber.Ctx) error
+	ListImportJobs(c *fiber.Ctx) error
+	ListImportErrors(c *fiber.Ctx) error
+}
+
+type WebhookHandler interface {
+	CreateSubscription(c *fiber.Ctx) error
+	GetSubscription(c *fiber.Ctx) error
+	UpdateSubscription(c *fiber.Ctx) error
+	RotateSecret(c *fiber.Ctx) error
+	DeleteSubscription(c *fiber.Ctx) error
+	ListSubscriptions(c *fiber.Ctx) error
+	ListDeliveries(c *fiber.Ctx) error
+	GetDelivery(c *fiber.Ctx) error
+	ReplayDelivery(c *fiber.Ctx) error
+}
+
+type ExportHandler interface {
+	ExportEvents(c *fiber.Ctx) error
+	ExportProducts(c *fiber.Ctx) error
+}
+
+type StreamHandler interface {
+	CreateToken(c *fiber.Ctx) error
+	Authorize(c *fiber.Ctx) error
+	WebSocket(c *fiber.Ctx) error
+	SSE(c *fiber.Ctx) error
+}
+
+type GraphQLHandler interface {
+	Query(c *fiber.Ctx) error
+	Upgrade(c *fiber.Ctx) error
+	WebSocket(c *fiber.Ctx) error
+}
+
+type SearchHandler interface {
+	Search(c *fiber.Ctx) error
+}
+
+type LocationHandler interface {
+	CreateLocation(c *fiber.Ctx) error
+	GetLocation(c *fiber.Ctx) error
+	UpdateLocation(c *fiber.Ctx) error
+	DeleteLocation(c *fiber.Ctx) error
+	ListLocations(c *fiber.Ctx) error
+	ListNearby(c *fiber.Ctx) error
+	ListEvents(c *fiber.Ctx) error
+}
+
+type RouteHandler interface {
+	SetRoutePlan(c *fiber.Ctx) error
+	GetRoutePlan(c *fiber.Ctx) error
+	DeleteRoutePlan(c *fiber.Ctx) error
+	ReportPosition(c *fiber.Ctx) error
+	GetAlert(c *fiber.Ctx) error
+	ListAlerts(c *fiber.Ctx) error
+	AcknowledgeAlert(c *fiber.Ctx) error
+}
+
+type TelemetryHandler interface {
+	RegisterDevice(c *fiber.Ctx) error
+	GetDevice(c *fiber.Ctx) error
+	UpdateDevice(c *fiber.Ctx) error
+	DeleteDevice(c *fiber.Ctx) error
+	ListDevices(c *fiber.Ctx) error
+	AttachDevice(c *fiber.Ctx) error
+	DetachDevice(c *fiber.Ctx) error
+	Ingest(c *fiber.Ctx) error
+	ListReadings(c *fiber.Ctx) error
+	GetSeries(c *fiber.Ctx) error
+}
+
+type ExcursionHandler interface {
+	CreateRule(c *fiber.Ctx) error
+	GetRule(c *fiber.Ctx) error
+	UpdateRule(c *fiber.Ctx) error
+	DeleteRule(c *fiber.Ctx) error
+	ListRules(c *fiber.Ctx) error
+	GetIncident(c *fiber.Ctx) error
+	ListIncidents(c *fiber.Ctx) error
+	AcknowledgeIncident(c *fiber.Ctx) error
+	GetQuarantine(c *fiber.Ctx) error
+	ListQuarantines(c *fiber.Ctx) error
+	ReleaseQuarantine(c *fiber.Ctx) error
+}
+
+type AnalyticsHandler interface {
+	GetDashboard(c *fiber.Ctx) error
+	GetTransitReport(c *fiber.Ctx) error
+	GetDwellReport(c *fiber.Ctx) error
+	GetThroughput(c *fiber.Ctx) error
+	ListLegs(c *fiber.Ctx) error
+	ListDwells(c *fiber.Ctx) error
+	Refresh(c *fiber.Ctx) error
+}
+
+type ScorecardHandler interface {
+	GetScorecard(c *fiber.Ctx) error
+	RankStakeholders(c *fiber.Ctx) error
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type importHandler struct {
+	service services.ImportService
+}
+
+func NewImportHandler(service services.ImportService) *importHandler {
+	return &importHandler{service: service}
+}
+
+// StartImport accepts a multipart upload (field "file", .csv or .xlsx) and answers 202 with the
+// job to poll. dry_run=true validates every row without writing anything.
+func (h *importHandler) StartImport(c *fiber.Ctx) error {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "File is required")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid file")
+	}
+	defer file.Close()
+
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run", c.FormValue("dry_run")))
+	req := &dto.StartImportRequest{
+		Kind:     c.Params("kind"),
+		FileName: header.Filename,
+		DryRun:   dryRun,
+	}
+
+	job, err := h.service.StartImport(c.Context(), req, file)
+	if err != nil {
+		return h.sendImportError(c, err, "Failed to start import")
+	}
+
+	return SendSuccess(c, fiber.StatusAccepted, job, "Import started")
+}
+
+func (h *importHandler) GetImportJob(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid import job ID")
+	}
+
+	job, err := h.service.GetImportJob(c.Context(), id)
+	if err != nil {
+		return h.sendImportError(c, err, "Failed to get import job")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, job, "Import job retrieved successfully")
+}
+
+func (h *importHandler) ListImportJobs(c *fiber.Ctx) error {
+	filter := &dto.ImportJobFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+	if kind := c.Query("kind"); kind != "" {
+		filter.Kind = &kind
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	response, err := h.service.ListImportJobs(c.Context(), filter)
+	if err != nil {
+		return h.sendImportError(c, err, "Failed to list import jobs")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Import jobs retrieved successfully")
+}
+
+// ListImportErrors pages through a job's row errors; format=csv downloads them all as a report
+func (h *importHandler) ListImportErrors(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid import job ID")
+	}
+
+	if c.Query("format") == "csv" {
+		var buf bytes.Buffer
+		if err := h.service.WriteErrorReport(c.Context(), id, &buf); err != nil {
+			return h.sendImportError(c, err, "Failed to build error report")
+		}
+		c.Set(fiber.HeaderContentType, "text/csv")
+		c.Set(fiber.HeaderContentDisposition, "attachment; filename=\"import-"+id.String()+"-errors.csv\"")
+		return c.Status(fiber.StatusOK).Send(buf.Bytes())
+	}
+
+	limit, offset := 10, 0
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	if o, err := strconv.Atoi(c.Query("offset")); err == nil && o > 0 {
+		offset = o
+	}
+
+	response, err := h.service.ListImportErrors(c.Context(), id, limit, offset)
+	if err != nil {
+		return h.sendImportError(c, err, "Failed to list import errors")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Import errors retrieved successfully")
+}
+
+func (h *importHandler) sendImportError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrImportJobNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Import job not found")
+	case errors.Is(err, services.ErrInvalidImport):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type inventoryHandler struct {
+	service services.CustodyService
+}
+
+func NewInventoryHandler(service services.CustodyService) *inventoryHandler {
+	return &inventoryHandler{service: service}
+}
+
+func (h *inventoryHandler) GetCurrentCustody(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+
+	custody, err := h.service.GetCurrentCustody(c.Context(), id)
+	if err != nil {
+		switch err {
+		case services.ErrProductNotFound:
+			return SendError(c, fiber.StatusNotFound, err, "Product not found")
+		case services.ErrCustodyNotFound:
+			return SendError(c, fiber.StatusNotFound, err, "No custody recorded for product")
+		default:
+			return SendError(c, fiber.StatusInternalServerError, err, "Failed to get current custody")
+		}
+	}
+
+	return SendSuccess(c, fiber.StatusOK, custody, "Current custody retrieved successfully")
+}
+
+func (h *inventoryHandler) ListInventory(c *fiber.Ctx) error {
+	filter, err := h.parseFilter(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	if holderID := c.Query("holder_id"); holderID != "" {
+		if id, err := uuid.Parse(holderID); err == nil {
+			filter.HolderID = &id
+		}
+	}
+
+	return h.list(c, filter)
+}
+
+func (h *inventoryHandler) ListStakeholderInventory(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid stakeholder ID")
+	}
+
+	filter, err := h.parseFilter(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.HolderID = &id
+
+	return h.list(c, filter)
+}
+
+func (h *inventoryHandler) list(c *fiber.Ctx, filter *dto.InventoryFilter) error {
+	response, err := h.service.ListInventory(c.Context(), filter)
+	if err != nil {
+		switch err {
+		case services.ErrStakeholderNotFound:
+			return SendError(c, fiber.StatusNotFound, err, "Stakeholder not found")
+		case services.ErrInvalidCustodyState:
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid custody state")
+		default:
+			return SendError(c, fiber.StatusInternalServerError, err, "Failed to list inventory")
+		}
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Inventory retrieved successfully")
+}
+
+func (h *inventoryHandler) parseFilter(c *fiber.Ctx) (*dto.InventoryFilter, error) {
+	filter := &dto.InventoryFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return nil, err
+	}
+	filter.Cursor, filter.Count = cursor, count
+	if location := c.Query("location"); location != "" {
+		filter.Location = &location
+	}
+	if state := c.Query("state"); state != "" {
+		filter.State = &state
+	}
+	if containerID := c.Query("container_id"); containerID != "" {
+		if id, err := uuid.Parse(containerID); err == nil {
+			filter.ContainerID = &id
+		}
+	}
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/label"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"net/url"
+	"strconv"
+)
+
+type labelHandler struct {
+	service services.LabelService
+	config  conf.DigitalLinkConfig
+}
+
+func NewLabelHandler(service services.LabelService, config conf.DigitalLinkConfig) *labelHandler {
+	return &labelHandler{service: service, config: config}
+}
+
+func (h *labelHandler) GetProductLabel(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+
+	productLabel, err := h.service.ProductLabel(c.Context(), id, publicBaseURL(c, h.config))
+	if err != nil {
+		return h.sendLabelError(c, err, "Failed to get product label")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, productLabel, "Product label retrieved successfully")
+}
+
+// GetProductQR renders the QR code of a product label; ?format=png|svg, ?size=pixels
+func (h *labelHandler) GetProductQR(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+
+	productLabel, err := h.service.ProductLabel(c.Context(), id, publicBaseURL(c, h.config))
+	if err != nil {
+		return h.sendLabelError(c, err, "Failed to get product label")
+	}
+
+	return h.sendQR(c, productLabel.URL)
+}
+
+func (h *labelHandler) GetLotLabel(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+
+	lot, err := url.PathUnescape(c.Params("lot"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid lot")
+	}
+
+	lotLabel, err := h.service.LotLabel(c.Context(), id, lot, publicBaseURL(c, h.config))
+	if err != nil {
+		return h.sendLabelError(c, err, "Failed to get lot label")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, lotLabel, "Lot label retrieved successfully")
+}
+
+func (h *labelHandler) GetLotQR(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid product ID")
+	}
+
+	lot, err := url.PathUnescape(c.Params("lot"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid lot")
+	}
+
+	lotLabel, err := h.service.LotLabel(c.Context(), id, lot, publicBaseURL(c, h.config))
+	if err != nil {
+		return h.sendLabelError(c, err, "Failed to get lot label")
+	}
+
+	return h.sendQR(c, lotLabel.URL)
+}
+
+// CreateLabelSheet renders a printable PDF sheet of labels for the requested products
+func (h *labelHandler) CreateLabelSheet(c *fiber.Ctx) error {
+	var req dto.CreateLabelSheetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	labels, err := h.service.SheetLabels(c.Context(), &req, publicBaseURL(c, h.config))
+	if err != nil {
+		return h.sendLabelError(c, err, "Failed to create label sheet")
+	}
+
+	return h.sendSheet(c, labels, "labels.pdf")
+}
+
+// GetShipmentLabelSheet renders the label sheet of a custody transfer: the shipped unit and its contents
+func (h *labelHandler) GetShipmentLabelSheet(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid custody transfer ID")
+	}
+
+	labels, err := h.service.ShipmentLabels(c.Context(), id, publicBaseURL(c, h.config))
+	if err != nil {
+		return h.sendLabelError(c, err, "Failed to create shipment labels")
+	}
+
+	return h.sendSheet(c, labels, "shipment-"+id.String()+".pdf")
+}
+
+func (h *labelHandler) sendQR(c *fiber.Ctx, content string) error {
+	format := c.Query("format", label.FormatPNG)
+	size := label.DefaultQRSize
+	if s := c.Query("size"); s != "" {
+		if n, err := strconv.Atoi(s); err == nil {
+			size = n
+		}
+	}
+
+	image, err := label.QR(content, format, size)
+	if err != nil {
+		if errors.Is(err, label.ErrInvalidFormat) {
+			return SendError(c, fiber.StatusBadRequest, err, "Format must be png or svg")
+		}
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to render QR code")
+	}
+
+	c.Set(fiber.HeaderContentType, label.ContentType(format))
+	return c.Status(fiber.StatusOK).Send(image)
+}
+
+func (h *labelHandler) sendSheet(c *fiber.Ctx, labels []*dto.ProductLabel, filename string) error {
+	sheet := make([]label.Label, 0, len(labels))
+	for _, productLabel := range labels {
+		lines := []string{"SKU " + productLabel.SKU}
+		if productLabel.ElementString != "" {
+			lines = append(lines, productLabel.ElementString)
+		} else {
+			if productLabel.Lot != "" {
+				lines = append(lines, "Lot "+productLabel.Lot)
+			}
+			if productLabel.Serial != "" {
+				lines = append(lines, "S/N "+productLabel.Serial)
+			}
+		}
+		sheet = append(sheet, label.Label{Title: productLabel.Name, Lines: lines, QRContent: productLabel.URL})
+	}
+
+	var buf bytes.Buffer
+	if err := label.WriteSheet(&buf, sheet); err != nil {
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to render label sheet")
+	}
+
+	c.Set(fiber.HeaderContentType, label.ContentTypePDF)
+	c.Set(fiber.HeaderContentDisposition, "inline; filename=\""+filename+"\"")
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+func (h *labelHandler) sendLabelError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrProductNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Product not found")
+	case errors.Is(err, services.ErrCustodyTransferNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Custody transfer not found")
+	case errors.Is(err, services.ErrInvalidLabelRequest):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
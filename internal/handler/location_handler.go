@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type locationHandler struct {
+	service services.LocationService
+}
+
+func NewLocationHandler(service services.LocationService) *locationHandler {
+	return &locationHandler{service: service}
+}
+
+func (h *locationHandler) CreateLocation(c *fiber.Ctx) error {
+	var req dto.CreateLocationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	location, err := h.service.CreateLocation(c.Context(), &req)
+	if err != nil {
+		return h.sendLocationError(c, err, "Failed to create location")
+	}
+
+	return SendSuccess(c, fiber.StatusCreated, location, "Location created successfully")
+}
+
+func (h *locationHandler) GetLocation(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid location ID")
+	}
+
+	location, err := h.service.GetLocation(c.Context(), id)
+	if err != nil {
+		return h.sendLocationError(c, err, "Failed to get location")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, location, "Location retrieved successfully")
+}
+
+func (h *locationHandler) UpdateLocation(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid location ID")
+	}
+
+	var req dto.UpdateLocationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	location, err := h.service.UpdateLocation(c.Context(), id, &req)
+	if err != nil {
+		return h.sendLocationError(c, err, "Failed to update location")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, location, "Location updated successfully")
+}
+
+func (h *locationHandler) DeleteLocation(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid location ID")
+	}
+
+	if err := h.service.DeleteLocation(c.Context(), id); err != nil {
+		return h.sendLocationError(c, err, "Failed to delete location")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, nil, "Location deleted successfully")
+}
+
+func (h *locationHandler) ListLocations(c *fiber.Ctx) error {
+	filter, err := locationFilter(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	response, err := h.service.ListLocations(c.Context(), filter)
+	if err != nil {
+		return h.sendLocationError(c, err, "Failed to list locations")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Locations retrieved successfully")
+}
+
+// ListNearby lists the locations within radius_km of lat,lng, nearest first, with the same
+// filters as ListLocations; limit defaults to and is capped at 100
+func (h *locationHandler) ListNearby(c *fiber.Ctx) error {
+	near, err := parseGeoRadius(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid radius query")
+	}
+	if near == nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("lat, lng and radius_km are required"), "Invalid radius query")
+	}
+	filter, err := locationFilter(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+
+	locations, err := h.service.ListNearby(c.Context(), *near, filter)
+	if err != nil {
+		return h.sendLocationError(c, err, "Failed to list nearby locations")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, locations, "Nearby locations retrieved successfully")
+}
+
+// ListEvents lists the events recorded at a location, newest first. Without an id in the path it
+// lists the events at every location within radius_km of lat,lng.
+func (h *locationHandler) ListEvents(c *fiber.Ctx) error {
+	filter := &dto.SupplyChainEventFilter{}
+
+	if idParam := c.Params("id"); idParam != "" {
+		id, err := uuid.Parse(idParam)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid location ID")
+		}
+		filter.LocationID = &id
+	} else {
+		near, err := parseGeoRadius(c)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid radius query")
+		}
+		if near == nil {
+			return SendError(c, fiber.StatusBadRequest, fmt.Errorf("lat, lng and radius_km are required"), "Invalid radius query")
+		}
+		filter.Near = near
+	}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+	if eventType := c.Query("event_type"); eventType != "" {
+		filter.EventType = &eventType
+	}
+	if filter.FromDate, err = optionalTime(c.Query("from_date")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid from_date")
+	}
+	if filter.ToDate, err = optionalTime(c.Query("to_date")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid to_date")
+	}
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	response, err := h.service.ListEvents(c.Context(), filter)
+	if err != nil {
+		return h.sendLocationError(c, err, "Failed to list location events")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Location events retrieved successfully")
+}
+
+func (h *locationHandler) sendLocationError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrLocationNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Location not found")
+	case errors.Is(err, services.ErrStakeholderNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Stakeholder not found")
+	case errors.Is(err, services.ErrDuplicateLocation):
+		return SendError(c, fiber.StatusConflict, err, "Location name already exists")
+	case errors.Is(err, services.ErrDuplicateGLN):
+		return SendError(c, fiber.StatusConflict, err, "GLN already exists")
+	case errors.Is(err, services.ErrInvalidLocation):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
+
+// locationFilter reads the type, stakeholder_id, name, limit and offset query parameters
+func locationFilter(c *fiber.Ctx) (*dto.LocationFilter, error) {
+	filter := &dto.LocationFilter{}
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	if locationType := c.Query("type"); locationType != "" {
+		filter.Type = &locationType
+	}
+	if stakeholderID := c.Query("stakeholder_id"); stakeholderID != "" {
+		id, err := uuid.Parse(stakeholderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stakeholder_id: %w", err)
+		}
+		filter.StakeholderID = &id
+	}
+	if name := c.Query("name"); name != "" {
+		filter.Name = &name
+	}
+	return filter, nil
+}
+
+// parseGeoRadius reads lat, lng and radius_km, which are given together or not at all; nil when absent
+func parseGeoRadius(c *fiber.Ctx) (*dto.GeoRadius, error) {
+	lat, lng, radius := c.Query("lat"), c.Query("lng"), c.Query("radius_km")
+	if lat == "" && lng == "" && radius == "" {
+		return nil, nil
+	}
+	if lat == "" || lng == "" || radius == "" {
+		return nil, fmt.Errorf("lat, lng and radius_km are given together")
+	}
+	var near dto.GeoRadius
+	var err error
+	if near.Latitude, err = strconv.ParseFloat(lat, 64); err != nil {
+		return nil, fmt.Errorf("invalid lat: %w", err)
+	}
+	if near.Longitude, err = strconv.ParseFloat(lng, 64); err != nil {
+		return nil, fmt.Errorf("invalid lng: %w", err)
+	}
+	if near.RadiusKm, err = strconv.ParseFloat(radius, 64); err != nil {
+		return nil, fmt.Errorf("invalid radius_km: %w", err)
+	}
+	return &near, nil
+}
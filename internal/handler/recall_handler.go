@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type recallHandler struct {
+	service services.RecallService
+}
+
+func NewRecallHandler(service services.RecallService) *recallHandler {
+	return &recallHandler{service: service}
+}
+
+func (h *recallHandler) CreateRecall(c *fiber.Ctx) error {
+	var req dto.CreateRecallRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	recall, err := h.service.CreateRecall(c.Context(), &req)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to create recall")
+	}
+
+	return SendSuccess(c, fiber.StatusCreated, recall, "Recall created successfully")
+}
+
+func (h *recallHandler) GetRecall(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid recall ID")
+	}
+
+	recall, err := h.service.GetRecall(c.Context(), id)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to get recall")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, recall, "Recall retrieved successfully")
+}
+
+func (h *recallHandler) ListRecalls(c *fiber.Ctx) error {
+	filter := &dto.RecallFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+	if severity := c.Query("severity"); severity != "" {
+		filter.Severity = &severity
+	}
+	if productID := c.Query("product_id"); productID != "" {
+		if id, err := uuid.Parse(productID); err == nil {
+			filter.ProductID = &id
+		}
+	}
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	response, err := h.service.ListRecalls(c.Context(), filter)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to list recalls")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Recalls retrieved successfully")
+}
+
+func (h *recallHandler) ActivateRecall(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid recall ID")
+	}
+
+	recall, err := h.service.ActivateRecall(c.Context(), id)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to activate recall")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, recall, "Recall activated successfully")
+}
+
+func (h *recallHandler) NotifyStakeholders(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid recall ID")
+	}
+
+	sent, err := h.service.NotifyStakeholders(c.Context(), id)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to notify stakeholders")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, fiber.Map{"sent": sent}, "Recall notices sent successfully")
+}
+
+func (h *recallHandler) AcknowledgeRecall(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid recall ID")
+	}
+
+	var req dto.AcknowledgeRecallRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	notification, err := h.service.AcknowledgeRecall(c.Context(), id, &req)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to acknowledge recall")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, notification, "Recall acknowledged successfully")
+}
+
+func (h *recallHandler) CloseRecall(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid recall ID")
+	}
+
+	recall, err := h.service.CloseRecall(c.Context(), id)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to close recall")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, recall, "Recall closed successfully")
+}
+
+func (h *recallHandler) ListAffectedUnits(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid recall ID")
+	}
+
+	filter := &dto.RecallUnitFilter{}
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+	if holderID := c.Query("holder_id"); holderID != "" {
+		if holder, err := uuid.Parse(holderID); err == nil {
+			filter.HolderID = &holder
+		}
+	}
+	if source := c.Query("source"); source != "" {
+		filter.Source = &source
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	response, err := h.service.ListAffectedUnits(c.Context(), id, filter)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to list affected units")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Affected units retrieved successfully")
+}
+
+func (h *recallHandler) GetRecallReport(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid recall ID")
+	}
+
+	report, err := h.service.GetRecallReport(c.Context(), id)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to get recall report")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, report, "Recall report retrieved successfully")
+}
+
+func (h *recallHandler) ListStakeholderRecalls(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid stakeholder ID")
+	}
+
+	var status *string
+	if s := c.Query("status"); s != "" {
+		status = &s
+	}
+
+	notifications, err := h.service.ListStakeholderRecalls(c.Context(), id, status)
+	if err != nil {
+		return h.sendRecallError(c, err, "Failed to list stakeholder recalls")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, notifications, "Stakeholder recalls retrieved successfully")
+}
+
+func (h *recallHandler) sendRecallError(c *fiber.Ctx, err error, fallback string) error {
+	switch err {
+	case services.ErrRecallNotFound:
+		return SendError(c, fiber.StatusNotFound, err, "Recall not found")
+	case services.ErrProductNotFound:
+		return SendError(c, fiber.StatusNotFound, err, "Product not found")
+	case services.ErrStakeholderNotFound:
+		return SendError(c, fiber.StatusNotFound, err, "Stakeholder not found")
+	case services.ErrRecallNotificationNotFound:
+		return SendError(c, fiber.StatusForbidden, err, err.Error())
+	case services.ErrRecallNotDraft, services.ErrRecallNotActive:
+		return SendError(c, fiber.StatusConflict, err, err.Error())
+	case services.ErrInvalidRecall, services.ErrRecallNoUnits:
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
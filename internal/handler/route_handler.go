@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type routeHandler struct {
+	service services.RouteService
+}
+
+func NewRouteHandler(service services.RouteService) *routeHandler {
+	return &routeHandler{service: service}
+}
+
+// SetRoutePlan gives the custody transfer in the path its route plan, replacing the one it had
+func (h *routeHandler) SetRoutePlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid custody transfer ID")
+	}
+
+	var req dto.SetRoutePlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	plan, err := h.service.SetRoutePlan(c.Context(), id, &req)
+	if err != nil {
+		return h.sendRouteError(c, err, "Failed to set route plan")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, plan, "Route plan set successfully")
+}
+
+func (h *routeHandler) GetRoutePlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid custody transfer ID")
+	}
+
+	plan, err := h.service.GetRoutePlan(c.Context(), id)
+	if err != nil {
+		return h.sendRouteError(c, err, "Failed to get route plan")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, plan, "Route plan retrieved successfully")
+}
+
+func (h *routeHandler) DeleteRoutePlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid custody transfer ID")
+	}
+
+	if err := h.service.DeleteRoutePlan(c.Context(), id); err != nil {
+		return h.sendRouteError(c, err, "Failed to delete route plan")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, nil, "Route plan deleted successfully")
+}
+
+// ReportPosition checks a product's reported position against its route plans
+func (h *routeHandler) ReportPosition(c *fiber.Ctx) error {
+	var req dto.PositionReport
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	result, err := h.service.ReportPosition(c.Context(), &req)
+	if err != nil {
+		return h.sendRouteError(c, err, "Failed to report position")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, result, "Position checked successfully")
+}
+
+func (h *routeHandler) GetAlert(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid route alert ID")
+	}
+
+	alert, err := h.service.GetAlert(c.Context(), id)
+	if err != nil {
+		return h.sendRouteError(c, err, "Failed to get route alert")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, alert, "Route alert retrieved successfully")
+}
+
+// ListAlerts lists route alerts, newest first, by custody_transfer_id, product_id, type and status.
+// Under /custody-transfers/:id/alerts the transfer comes from the path.
+func (h *routeHandler) ListAlerts(c *fiber.Ctx) error {
+	filter := &dto.RouteAlertFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+
+	transferID := c.Params("id", c.Query("custody_transfer_id"))
+	if transferID != "" {
+		id, err := uuid.Parse(transferID)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid custody transfer ID")
+		}
+		filter.CustodyTransferID = &id
+	}
+	if productID := c.Query("product_id"); productID != "" {
+		id, err := uuid.Parse(productID)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid product_id: %w", err), "Invalid query parameters")
+		}
+		filter.ProductID = &id
+	}
+	if alertType := c.Query("type"); alertType != "" {
+		filter.Type = &alertType
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	response, err := h.service.ListAlerts(c.Context(), filter)
+	if err != nil {
+		return h.sendRouteError(c, err, "Failed to list route alerts")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Route alerts retrieved successfully")
+}
+
+func (h *routeHandler) AcknowledgeAlert(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid route alert ID")
+	}
+
+	var req dto.AcknowledgeRouteAlertRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+		}
+	}
+
+	alert, err := h.service.AcknowledgeAlert(c.Context(), id, &req)
+	if err != nil {
+		return h.sendRouteError(c, err, "Failed to acknowledge route alert")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, alert, "Route alert acknowledged successfully")
+}
+
+func (h *routeHandler) sendRouteError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrCustodyTransferNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Custody transfer not found")
+	case errors.Is(err, services.ErrRoutePlanNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Route plan not found")
+	case errors.Is(err, services.ErrRouteAlertNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Route alert not found")
+	case errors.Is(err, services.ErrProductNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Product not found")
+	case errors.Is(err, services.ErrLocationNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Location not found")
+	case errors.Is(err, services.ErrCustodyTransferNotPending):
+		return SendError(c, fiber.StatusConflict, err, "Custody transfer is not pending")
+	case errors.Is(err, services.ErrRouteAlertNotOpen):
+		return SendError(c, fiber.StatusConflict, err, "Route alert is not open")
+	case errors.Is(err, services.ErrInvalidRoutePlan),
+		errors.Is(err, services.ErrInvalidPosition),
+		errors.Is(err, services.ErrInvalidRouteAlertFilter):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type scorecardHandler struct {
+	service services.ScorecardService
+}
+
+func NewScorecardHandler(service services.ScorecardService) *scorecardHandler {
+	return &scorecardHandler{service: service}
+}
+
+// GetScorecard scores a stakeholder against its peers by from, to and period
+func (h *scorecardHandler) GetScorecard(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid stakeholder ID")
+	}
+
+	query, err := parseScorecardQuery(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+
+	scorecard, err := h.service.GetScorecard(c.Context(), id, query)
+	if err != nil {
+		return h.sendScorecardError(c, err, "Failed to get scorecard")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, scorecard, "Scorecard retrieved successfully")
+}
+
+// RankStakeholders ranks the stakeholders of a type by type, from, to and limit
+func (h *scorecardHandler) RankStakeholders(c *fiber.Ctx) error {
+	query, err := parseScorecardQuery(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+	if stakeholderType := c.Query("type"); stakeholderType != "" {
+		query.Type = &stakeholderType
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid limit: %w", err), "Invalid query parameters")
+		}
+	}
+
+	ranking, err := h.service.RankStakeholders(c.Context(), query)
+	if err != nil {
+		return h.sendScorecardError(c, err, "Failed to rank stakeholders")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, ranking, "Scorecards retrieved successfully")
+}
+
+func parseScorecardQuery(c *fiber.Ctx) (*dto.ScorecardQuery, error) {
+	query := &dto.ScorecardQuery{Period: c.Query("period")}
+	var err error
+	if query.From, err = optionalTime(c.Query("from")); err != nil {
+		return nil, fmt.Errorf("invalid from, expected RFC3339: %w", err)
+	}
+	if query.To, err = optionalTime(c.Query("to")); err != nil {
+		return nil, fmt.Errorf("invalid to, expected RFC3339: %w", err)
+	}
+	return query, nil
+}
+
+func (h *scorecardHandler) sendScorecardError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrStakeholderNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Stakeholder not found")
+	case errors.Is(err, services.ErrInvalidScorecardQuery):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+	"strings"
+)
+
+type searchHandler struct {
+	service services.SearchService
+}
+
+func NewSearchHandler(service services.SearchService) *searchHandler {
+	return &searchHandler{service: service}
+}
+
+// Search ranks products, stakeholders and event locations against q; types narrows the result
+// types and limit caps each of them
+func (h *searchHandler) Search(c *fiber.Ctx) error {
+	req := &dto.SearchRequest{Query: c.Query("q")}
+	if types := c.Query("types"); types != "" {
+		req.Types = strings.Split(types, ",")
+	}
+	if limit := c.Query("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid limit")
+		}
+		req.Limit = l
+	}
+
+	results, err := h.service.Search(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			return SendError(c, fiber.StatusBadRequest, err, err.Error())
+		}
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to search")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, results, "Search results retrieved successfully")
+}
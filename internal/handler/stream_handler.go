@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"github.com/koriebruh/suplyChainTrack/internal/stream"
+	"log/slog"
+	"time"
+)
+
+const (
+	// streamHeartbeat keeps idle SSE connections and proxies from timing out
+	streamHeartbeat = 15 * time.Second
+
+	// WebSocket keepalive: the server pings, the client must answer within pongWait
+	pingInterval = 30 * time.Second
+	pongWait     = 60 * time.Second
+	writeWait    = 10 * time.Second
+
+	// maxClientMessage bounds subscribe/unsubscribe frames
+	maxClientMessage = 4096
+)
+
+// Locals keys set by Authorize for the WebSocket handler
+const (
+	localStreamClaims = "stream_claims"
+	localStreamCursor = "stream_cursor"
+)
+
+type streamHandler struct {
+	service services.StreamService
+	apiKey  string
+	secret  string
+	ws      fiber.Handler
+}
+
+func NewStreamHandler(service services.StreamService, config conf.Config) *streamHandler {
+	h := &streamHandler{service: service, apiKey: config.AppConfig.ApiKey, secret: config.StreamConfig.TokenSecret}
+	h.ws = websocket.New(h.serveWebSocket, websocket.Config{Origins: config.AppConfig.AllowedOrigins})
+	return h
+}
+
+// CreateToken issues a stream token; behind the API key, for a backend to hand to browsers and partners
+func (h *streamHandler) CreateToken(c *fiber.Ctx) error {
+	var req dto.CreateStreamTokenRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+		}
+	}
+
+	token, err := h.service.IssueToken(c.Context(), &req, h.secret)
+	if err != nil {
+		return h.sendStreamError(c, err, "Failed to issue stream token")
+	}
+
+	return SendSuccess(c, fiber.StatusCreated, token, "Stream token issued successfully")
+}
+
+// Authorize checks the X-API-Key header or a token query parameter and the optional cursor, and
+// only lets WebSocket upgrades through
+func (h *streamHandler) Authorize(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return SendError(c, fiber.StatusUpgradeRequired, fiber.ErrUpgradeRequired, "WebSocket upgrade required")
+	}
+
+	claims, cursor, err := h.authorize(c)
+	if err != nil {
+		return h.sendStreamError(c, err, "Failed to open stream")
+	}
+
+	c.Locals(localStreamClaims, claims)
+	c.Locals(localStreamCursor, cursor)
+	return c.Next()
+}
+
+// WebSocket serves the bidirectional stream. Clients send
+//
+//	{"type":"subscribe","id":"a","product_id":"...","lot_number":"...","stakeholder_id":"...","event_type":"..."}
+//	{"type":"unsubscribe","id":"a"}
+//
+// and receive subscribed/unsubscribed/error acknowledgements and event messages carrying a cursor
+// to resume from with ?cursor= after a reconnect.
+func (h *streamHandler) WebSocket(c *fiber.Ctx) error {
+	return h.ws(c)
+}
+
+// SSE streams the events matching the query filters (product_id, lot_number, stakeholder_id,
+// event_type). EventSource resumes on its own through Last-Event-ID; ?cursor= does the same.
+func (h *streamHandler) SSE(c *fiber.Ctx) error {
+	claims, cursor, err := h.authorize(c)
+	if err != nil {
+		return h.sendStreamError(c, err, "Failed to open stream")
+	}
+	if cursor == nil && c.Get("Last-Event-ID") != "" {
+		parsed, err := stream.ParseCursor(c.Get("Last-Event-ID"))
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid Last-Event-ID")
+		}
+		cursor = &parsed
+	}
+
+	filter := stream.Filter{ID: "sse"}
+	for name, target := range map[string]**uuid.UUID{"product_id": &filter.ProductID, "stakeholder_id": &filter.StakeholderID} {
+		if value := c.Query(name); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return SendError(c, fiber.StatusBadRequest, err, "Invalid "+name)
+			}
+			*target = &id
+		}
+	}
+	if lotNumber := c.Query("lot_number"); lotNumber != "" {
+		filter.LotNumber = &lotNumber
+	}
+	if eventType := c.Query("event_type"); eventType != "" {
+		filter.EventType = &eventType
+	}
+
+	// Not tied to the request context: the body is written after the handler returns
+	conn, err := h.service.Open(context.Background(), claims, cursor)
+	if err != nil {
+		return h.sendStreamError(c, err, "Failed to open stream")
+	}
+	if err := conn.Subscribe(filter); err != nil {
+		conn.Close()
+		return h.sendStreamError(c, err, "Failed to open stream")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer conn.Close()
+
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+
+		fmt.Fprint(w, "retry: 3000\n\n")
+		if w.Flush() != nil {
+			return
+		}
+		for {
+			select {
+			case message, ok := <-conn.Messages():
+				if !ok {
+					if errors.Is(conn.Err(), services.ErrStreamOverflow) {
+						fmt.Fprintf(w, "event: error\ndata: %s\n\n", conn.Err())
+						w.Flush()
+					}
+					return
+				}
+				data, err := json.Marshal(message)
+				if err != nil {
+					slog.Error("failed to encode stream message", "error", err)
+					continue
+				}
+				fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", message.Cursor, message.Topic, data)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+			// A failed flush means the client is gone
+			if w.Flush() != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// streamClientMessage is a frame sent by a WebSocket client
+type streamClientMessage struct {
+	Type string `json:"type"` // subscribe or unsubscribe
+	stream.Filter
+}
+
+// streamServerMessage is a frame sent to a WebSocket client
+type streamServerMessage struct {
+	Type    string          `json:"type"` // subscribed, unsubscribed, error or event
+	ID      string          `json:"id,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Event   *stream.Message `json:"event,omitempty"`
+}
+
+func (h *streamHandler) serveWebSocket(ws *websocket.Conn) {
+	claims := ws.Locals(localStreamClaims).(*stream.Claims)
+	cursor, _ := ws.Locals(localStreamCursor).(*stream.Cursor)
+
+	conn, err := h.service.Open(context.Background(), claims, cursor)
+	if err != nil {
+		slog.Error("failed to open stream", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	// The writer owns the socket; the read loop below only queues replies
+	replies := make(chan streamServerMessage, 16)
+	done := make(chan struct{})
+	go h.writeWebSocket(ws, conn, replies, done)
+	defer func() {
+		// The socket is released when this returns, so the writer must be finished with it
+		close(replies)
+		<-done
+	}()
+
+	ws.SetReadLimit(maxClientMessage)
+	ws.SetReadDeadline(time.Now().Add(pongWait))
+	ws.SetPongHandler(func(string) error {
+		return ws.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			return // closed or timed out
+		}
+		var message streamClientMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			message = streamClientMessage{}
+		}
+
+		var reply streamServerMessage
+		switch message.Type {
+		case "subscribe":
+			if err := conn.Subscribe(message.Filter); err != nil {
+				reply = streamServerMessage{Type: "error", ID: message.ID, Message: err.Error()}
+			} else {
+				reply = streamServerMessage{Type: "subscribed", ID: message.ID}
+			}
+		case "unsubscribe":
+			conn.Unsubscribe(message.ID)
+			reply = streamServerMessage{Type: "unsubscribed", ID: message.ID}
+		default:
+			reply = streamServerMessage{Type: "error", ID: message.ID, Message: "unknown message type"}
+		}
+
+		select {
+		case replies <- reply:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (h *streamHandler) writeWebSocket(ws *websocket.Conn, conn *services.StreamConnection, replies <-chan streamServerMessage, done chan<- struct{}) {
+	defer close(done)
+	// Closing the socket also ends the read loop
+	defer ws.Close()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		var err error
+		select {
+		case reply, ok := <-replies:
+			if !ok {
+				return
+			}
+			ws.SetWriteDeadline(time.Now().Add(writeWait))
+			err = ws.WriteJSON(reply)
+		case message, ok := <-conn.Messages():
+			if !ok {
+				if errors.Is(conn.Err(), services.ErrStreamOverflow) {
+					ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, conn.Err().Error()), time.Now().Add(writeWait))
+				}
+				return
+			}
+			ws.SetWriteDeadline(time.Now().Add(writeWait))
+			err = ws.WriteJSON(streamServerMessage{Type: "event", Event: &message})
+		case <-ping.C:
+			err = ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// authorize accepts the API key, which sees every event, or a stream token in ?token=, which may
+// be limited to one stakeholder. Browsers cannot set headers on WebSocket or EventSource requests.
+func (h *streamHandler) authorize(c *fiber.Ctx) (*stream.Claims, *stream.Cursor, error) {
+	claims, err := streamClaims(h.apiKey, h.secret, c.Get("X-API-Key"), c.Query("token"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.Query("cursor") == "" {
+		return claims, nil, nil
+	}
+	cursor, err := stream.ParseCursor(c.Query("cursor"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return claims, &cursor, nil
+}
+
+// streamClaims resolves what a real-time client may see from the API key it presents or a stream token
+func streamClaims(apiKey, secret, presentedKey, token string) (*stream.Claims, error) {
+	switch {
+	case apiKey != "" && presentedKey == apiKey:
+		return &stream.Claims{}, nil
+	case token != "":
+		return stream.VerifyToken(secret, token, time.Now())
+	default:
+		return nil, stream.ErrInvalidToken
+	}
+}
+
+func (h *streamHandler) sendStreamError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, stream.ErrInvalidToken), errors.Is(err, stream.ErrExpiredToken):
+		return SendError(c, fiber.StatusUnauthorized, err, "Unauthorized - invalid stream token")
+	case errors.Is(err, services.ErrStreamForbidden):
+		return SendError(c, fiber.StatusForbidden, err, err.Error())
+	case errors.Is(err, services.ErrStakeholderNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Stakeholder not found")
+	case errors.Is(err, stream.ErrInvalidCursor), errors.Is(err, services.ErrInvalidStreamRequest):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
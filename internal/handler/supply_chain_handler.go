@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
 	"github.com/koriebruh/suplyChainTrack/internal/services"
 	"strconv"
 )
@@ -132,6 +134,11 @@ func (h *stakeHolderHandler) ListStakeholders(c *fiber.Ctx) error {
 			filter.Offset = o
 		}
 	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
 	if stakeholderType := c.Query("type"); stakeholderType != "" {
 		filter.Type = &stakeholderType
 	}
@@ -143,6 +150,9 @@ func (h *stakeHolderHandler) ListStakeholders(c *fiber.Ctx) error {
 	if email := c.Query("email"); email != "" {
 		filter.Email = &email
 	}
+	if filter.Query, err = listquery.Parse(listquery.Stakeholders, c.Queries()); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid list query")
+	}
 
 	// Set default values
 	if filter.Limit == 0 {
@@ -151,6 +161,12 @@ func (h *stakeHolderHandler) ListStakeholders(c *fiber.Ctx) error {
 
 	response, err := h.service.ListStakeholders(c.Context(), filter)
 	if err != nil {
+		if errors.Is(err, listquery.ErrInvalidQuery) {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid list query")
+		}
+		return SendError(c, fiber.StatusInternalServerError, err, "Failed to list stakeholders")
+	}
+	if response.Data, err = filter.Query.Project(response.Data); err != nil {
 		return SendError(c, fiber.StatusInternalServerError, err, "Failed to list stakeholders")
 	}
 
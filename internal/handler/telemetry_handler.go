@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+	"time"
+)
+
+type telemetryHandler struct {
+	service services.TelemetryService
+}
+
+func NewTelemetryHandler(service services.TelemetryService) *telemetryHandler {
+	return &telemetryHandler{service: service}
+}
+
+func (h *telemetryHandler) RegisterDevice(c *fiber.Ctx) error {
+	var req dto.CreateSensorDeviceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	device, err := h.service.RegisterDevice(c.Context(), &req)
+	if err != nil {
+		return h.sendTelemetryError(c, err, "Failed to register sensor device")
+	}
+
+	return SendSuccess(c, fiber.StatusCreated, device, "Sensor device registered successfully")
+}
+
+func (h *telemetryHandler) GetDevice(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid sensor device ID")
+	}
+
+	device, err := h.service.GetDevice(c.Context(), id)
+	if err != nil {
+		return h.sendTelemetryError(c, err, "Failed to get sensor device")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, device, "Sensor device retrieved successfully")
+}
+
+func (h *telemetryHandler) UpdateDevice(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid sensor device ID")
+	}
+
+	var req dto.UpdateSensorDeviceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	device, err := h.service.UpdateDevice(c.Context(), id, &req)
+	if err != nil {
+		return h.sendTelemetryError(c, err, "Failed to update sensor device")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, device, "Sensor device updated successfully")
+}
+
+func (h *telemetryHandler) DeleteDevice(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid sensor device ID")
+	}
+
+	if err := h.service.DeleteDevice(c.Context(), id); err != nil {
+		return h.sendTelemetryError(c, err, "Failed to delete sensor device")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, nil, "Sensor device deleted successfully")
+}
+
+// ListDevices lists sensor devices, newest first, by stakeholder_id and product_id
+func (h *telemetryHandler) ListDevices(c *fiber.Ctx) error {
+	filter := &dto.SensorDeviceFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+
+	if filter.StakeholderID, err = optionalUUID(c.Query("stakeholder_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid stakeholder_id: %w", err), "Invalid query parameters")
+	}
+	if filter.ProductID, err = optionalUUID(c.Query("product_id")); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid product_id: %w", err), "Invalid query parameters")
+	}
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	response, err := h.service.ListDevices(c.Context(), filter)
+	if err != nil {
+		return h.sendTelemetryError(c, err, "Failed to list sensor devices")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Sensor devices retrieved successfully")
+}
+
+func (h *telemetryHandler) AttachDevice(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid sensor device ID")
+	}
+
+	var req dto.AttachSensorDeviceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	device, err := h.service.AttachDevice(c.Context(), id, &req)
+	if err != nil {
+		return h.sendTelemetryError(c, err, "Failed to attach sensor device")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, device, "Sensor device attached successfully")
+}
+
+func (h *telemetryHandler) DetachDevice(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid sensor device ID")
+	}
+
+	device, err := h.service.DetachDevice(c.Context(), id)
+	if err != nil {
+		return h.sendTelemetryError(c, err, "Failed to detach sensor device")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, device, "Sensor device detached successfully")
+}
+
+// Ingest stores a batch of readings: 201 when all of them were accepted, 207 when some were
+// rejected and 422 when none were
+func (h *telemetryHandler) Ingest(c *fiber.Ctx) error {
+	var batch dto.TelemetryBatch
+	if err := c.BodyParser(&batch); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	result, err := h.service.Ingest(c.Context(), &batch)
+	if err != nil {
+		return h.sendTelemetryError(c, err, "Failed to ingest telemetry")
+	}
+
+	status := fiber.StatusCreated
+	switch {
+	case len(result.Rejected) == result.Received:
+		status = fiber.StatusUnprocessableEntity
+	case len(result.Rejected) > 0:
+		status = fiber.StatusMultiStatus
+	}
+	return SendSuccess(c, status, result, "Telemetry ingested")
+}
+
+// ListReadings lists the raw readings of a device_id, product_id or custody_transfer_id between
+// from and to, oldest first
+func (h *telemetryHandler) ListReadings(c *fiber.Ctx) error {
+	query, err := parseTelemetryQuery(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+
+	readings, err := h.service.ListReadings(c.Context(), query)
+	if err != nil {
+		return h.sendTelemetryError(c, err, "Failed to list telemetry")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, readings, "Telemetry retrieved successfully")
+}
+
+// GetSeries downsamples the readings the query selects into bucket intervals such as 15m or 1h
+func (h *telemetryHandler) GetSeries(c *fiber.Ctx) error {
+	query, err := parseTelemetryQuery(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid query parameters")
+	}
+	bucket := c.Query("bucket")
+	if bucket == "" {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("bucket is required"), "Invalid query parameters")
+	}
+	if query.Bucket, err = time.ParseDuration(bucket); err != nil {
+		return SendError(c, fiber.StatusBadRequest, fmt.Errorf("invalid bucket: %w", err), "Invalid query parameters")
+	}
+
+	series, err := h.service.GetSeries(c.Context(), query)
+	if err != nil {
+		return h.sendTelemetryError(c, err, "Failed to get telemetry series")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, series, "Telemetry series retrieved successfully")
+}
+
+func parseTelemetryQuery(c *fiber.Ctx) (*dto.TelemetryQuery, error) {
+	query := &dto.TelemetryQuery{}
+	var err error
+	if query.DeviceID, err = optionalUUID(c.Query("device_id")); err != nil {
+		return nil, fmt.Errorf("invalid device_id: %w", err)
+	}
+	if query.ProductID, err = optionalUUID(c.Query("product_id")); err != nil {
+		return nil, fmt.Errorf("invalid product_id: %w", err)
+	}
+	if query.CustodyTransferID, err = optionalUUID(c.Query("custody_transfer_id")); err != nil {
+		return nil, fmt.Errorf("invalid custody_transfer_id: %w", err)
+	}
+	if query.From, err = optionalTime(c.Query("from")); err != nil {
+		return nil, fmt.Errorf("invalid from, expected RFC3339: %w", err)
+	}
+	if query.To, err = optionalTime(c.Query("to")); err != nil {
+		return nil, fmt.Errorf("invalid to, expected RFC3339: %w", err)
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			return nil, fmt.Errorf("invalid limit: %w", err)
+		}
+	}
+	return query, nil
+}
+
+func (h *telemetryHandler) sendTelemetryError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrSensorDeviceNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Sensor device not found")
+	case errors.Is(err, services.ErrProductNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Product not found")
+	case errors.Is(err, services.ErrStakeholderNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Stakeholder not found")
+	case errors.Is(err, services.ErrDuplicateDeviceSerial):
+		return SendError(c, fiber.StatusConflict, err, "Device serial already exists")
+	case errors.Is(err, services.ErrInvalidSensorDevice),
+		errors.Is(err, services.ErrInvalidTelemetry),
+		errors.Is(err, services.ErrInvalidTelemetryQuery):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"strconv"
+)
+
+type webhookHandler struct {
+	service services.WebhookService
+}
+
+func NewWebhookHandler(service services.WebhookService) *webhookHandler {
+	return &webhookHandler{service: service}
+}
+
+// CreateSubscription registers an endpoint; the response carries the signing secret, which is
+// not shown again
+func (h *webhookHandler) CreateSubscription(c *fiber.Ctx) error {
+	var req dto.CreateWebhookSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	subscription, err := h.service.CreateSubscription(c.Context(), &req)
+	if err != nil {
+		return h.sendWebhookError(c, err, "Failed to create webhook subscription")
+	}
+
+	return SendSuccess(c, fiber.StatusCreated, subscription, "Webhook subscription created successfully")
+}
+
+func (h *webhookHandler) GetSubscription(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid webhook subscription ID")
+	}
+
+	subscription, err := h.service.GetSubscription(c.Context(), id)
+	if err != nil {
+		return h.sendWebhookError(c, err, "Failed to get webhook subscription")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, subscription, "Webhook subscription retrieved successfully")
+}
+
+func (h *webhookHandler) UpdateSubscription(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid webhook subscription ID")
+	}
+
+	var req dto.UpdateWebhookSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid request body")
+	}
+
+	subscription, err := h.service.UpdateSubscription(c.Context(), id, &req)
+	if err != nil {
+		return h.sendWebhookError(c, err, "Failed to update webhook subscription")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, subscription, "Webhook subscription updated successfully")
+}
+
+func (h *webhookHandler) RotateSecret(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid webhook subscription ID")
+	}
+
+	subscription, err := h.service.RotateSecret(c.Context(), id)
+	if err != nil {
+		return h.sendWebhookError(c, err, "Failed to rotate webhook secret")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, subscription, "Webhook secret rotated successfully")
+}
+
+func (h *webhookHandler) DeleteSubscription(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid webhook subscription ID")
+	}
+
+	if err := h.service.DeleteSubscription(c.Context(), id); err != nil {
+		return h.sendWebhookError(c, err, "Failed to delete webhook subscription")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, nil, "Webhook subscription deleted successfully")
+}
+
+func (h *webhookHandler) ListSubscriptions(c *fiber.Ctx) error {
+	filter := &dto.WebhookSubscriptionFilter{}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+	if stakeholderID := c.Query("stakeholder_id"); stakeholderID != "" {
+		if id, err := uuid.Parse(stakeholderID); err == nil {
+			filter.StakeholderID = &id
+		}
+	}
+	if isActive := c.Query("is_active"); isActive != "" {
+		if active, err := strconv.ParseBool(isActive); err == nil {
+			filter.IsActive = &active
+		}
+	}
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	response, err := h.service.ListSubscriptions(c.Context(), filter)
+	if err != nil {
+		return h.sendWebhookError(c, err, "Failed to list webhook subscriptions")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Webhook subscriptions retrieved successfully")
+}
+
+// ListDeliveries is the delivery log, newest first; under /webhooks/:id/deliveries it is limited
+// to one subscription
+func (h *webhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	filter := &dto.WebhookDeliveryFilter{}
+
+	if subscriptionID := c.Params("id", c.Query("subscription_id")); subscriptionID != "" {
+		id, err := uuid.Parse(subscriptionID)
+		if err != nil {
+			return SendError(c, fiber.StatusBadRequest, err, "Invalid webhook subscription ID")
+		}
+		filter.SubscriptionID = &id
+	}
+
+	// Parse query parameters
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filter.Offset = o
+		}
+	}
+	cursor, count, err := parsePage(c)
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid pagination parameters")
+	}
+	filter.Cursor, filter.Count = cursor, count
+	if eventID := c.Query("event_id"); eventID != "" {
+		if id, err := uuid.Parse(eventID); err == nil {
+			filter.EventID = &id
+		}
+	}
+	if topic := c.Query("topic"); topic != "" {
+		filter.Topic = &topic
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+
+	// Set default values
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+
+	response, err := h.service.ListDeliveries(c.Context(), filter)
+	if err != nil {
+		return h.sendWebhookError(c, err, "Failed to list webhook deliveries")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, response, "Webhook deliveries retrieved successfully")
+}
+
+func (h *webhookHandler) GetDelivery(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid webhook delivery ID")
+	}
+
+	delivery, err := h.service.GetDelivery(c.Context(), id)
+	if err != nil {
+		return h.sendWebhookError(c, err, "Failed to get webhook delivery")
+	}
+
+	return SendSuccess(c, fiber.StatusOK, delivery, "Webhook delivery retrieved successfully")
+}
+
+// ReplayDelivery queues an earlier delivery's payload again and answers 202 with the new delivery
+func (h *webhookHandler) ReplayDelivery(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return SendError(c, fiber.StatusBadRequest, err, "Invalid webhook delivery ID")
+	}
+
+	delivery, err := h.service.ReplayDelivery(c.Context(), id)
+	if err != nil {
+		return h.sendWebhookError(c, err, "Failed to replay webhook delivery")
+	}
+
+	return SendSuccess(c, fiber.StatusAccepted, delivery, "Webhook delivery queued for replay")
+}
+
+func (h *webhookHandler) sendWebhookError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrWebhookNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Webhook subscription not found")
+	case errors.Is(err, services.ErrWebhookDeliveryNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Webhook delivery not found")
+	case errors.Is(err, services.ErrStakeholderNotFound):
+		return SendError(c, fiber.StatusNotFound, err, "Stakeholder not found")
+	case errors.Is(err, services.ErrWebhookDisabled):
+		return SendError(c, fiber.StatusConflict, err, err.Error())
+	case errors.Is(err, services.ErrInvalidWebhook):
+		return SendError(c, fiber.StatusBadRequest, err, err.Error())
+	default:
+		return SendError(c, fiber.StatusInternalServerError, err, fallback)
+	}
+}
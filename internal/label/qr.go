@@ -0,0 +1,74 @@
+package label
+
+import (
+	"errors"
+	"fmt"
+	"github.com/skip2/go-qrcode"
+	"strings"
+)
+
+// Formats a QR code can be rendered in
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+const (
+	ContentTypePNG = "image/png"
+	ContentTypeSVG = "image/svg+xml"
+	ContentTypePDF = "application/pdf"
+)
+
+// Bounds on the rendered size of a QR code, in pixels (PNG) or user units (SVG)
+const (
+	DefaultQRSize = 256
+	MinQRSize     = 64
+	MaxQRSize     = 2048
+)
+
+var ErrInvalidFormat = errors.New("unsupported label format")
+
+// QR renders content as a QR code in the given format. Medium error correction keeps
+// codes scannable with some print damage without growing them much.
+func QR(content, format string, size int) ([]byte, error) {
+	size = min(max(size, MinQRSize), MaxQRSize)
+
+	switch format {
+	case FormatPNG:
+		return qrcode.Encode(content, qrcode.Medium, size)
+	case FormatSVG:
+		code, err := qrcode.New(content, qrcode.Medium)
+		if err != nil {
+			return nil, err
+		}
+		return svg(code.Bitmap(), size), nil
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// ContentType is the media type a QR code format is served with
+func ContentType(format string) string {
+	if format == FormatSVG {
+		return ContentTypeSVG
+	}
+	return ContentTypePNG
+}
+
+// svg draws the module bitmap (quiet zone included) as a single path, one unit per module
+func svg(bitmap [][]bool, size int) []byte {
+	modules := len(bitmap)
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	return []byte(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+			`<rect width="100%%" height="100%%" fill="#fff"/><path fill="#000" d="%s"/></svg>`,
+		size, size, modules, modules, path.String()))
+}
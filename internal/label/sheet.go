@@ -0,0 +1,69 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/go-pdf/fpdf"
+	"io"
+)
+
+// Label is one printed label: a QR code with a few lines of text beside it
+type Label struct {
+	Title     string
+	Lines     []string
+	QRContent string
+}
+
+// Sheet geometry for A4 sheets of 3 x 8 labels of 70 x 37 mm, the common address-label stock
+const (
+	sheetColumns   = 3
+	sheetRows      = 8
+	labelWidth     = 70.0
+	labelHeight    = 37.0
+	sheetTopMargin = 0.5
+	labelPadding   = 2.5
+	qrSize         = labelHeight - 2*labelPadding
+	qrPixels       = 384
+)
+
+// WriteSheet lays labels out on as many A4 pages as they need and writes the PDF to w
+func WriteSheet(w io.Writer, labels []Label) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+
+	perPage := sheetColumns * sheetRows
+	for i, label := range labels {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+		slot := i % perPage
+		x := float64(slot%sheetColumns) * labelWidth
+		y := sheetTopMargin + float64(slot/sheetColumns)*labelHeight
+
+		png, err := QR(label.QRContent, FormatPNG, qrPixels)
+		if err != nil {
+			return fmt.Errorf("failed to render QR code for label %d: %w", i, err)
+		}
+		name := fmt.Sprintf("qr-%d", i)
+		pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+		pdf.ImageOptions(name, x+labelPadding, y+labelPadding, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+		textX := x + labelPadding + qrSize + labelPadding
+		textWidth := labelWidth - (textX - x) - labelPadding
+		pdf.SetXY(textX, y+labelPadding+1)
+		pdf.SetFont("Helvetica", "B", 8)
+		pdf.MultiCell(textWidth, 3.5, translate(label.Title), "", "L", false)
+		pdf.SetFont("Helvetica", "", 6.5)
+		for _, line := range label.Lines {
+			pdf.SetX(textX)
+			pdf.MultiCell(textWidth, 3, translate(line), "", "L", false)
+		}
+	}
+
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+	return pdf.Output(w)
+}
@@ -0,0 +1,322 @@
+package listquery
+
+import (
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidQuery = errors.New("invalid list query")
+
+// MaxFilters bounds the predicates of one list query
+const MaxFilters = 20
+
+const (
+	maxInValues = 100
+	maxPath     = 5
+)
+
+// Predicate operators
+const (
+	OpEq     = "eq"
+	OpNe     = "ne"
+	OpGt     = "gt"
+	OpGte    = "gte"
+	OpLt     = "lt"
+	OpLte    = "lte"
+	OpIn     = "in"     // comma separated values
+	OpLike   = "like"   // case-insensitive substring
+	OpExists = "exists" // true or false: whether the field (or JSON key) is set
+)
+
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindNumber
+	KindBool
+	KindTime
+	KindUUID
+	KindJSON // JSONB; filtered by key path, e.g. metadata.temperature
+)
+
+type Field struct {
+	Kind     Kind
+	Sortable bool
+}
+
+// Resource describes what a list may be sorted, filtered, included and projected on. Field names
+// are the JSON names, which are also the column names.
+type Resource struct {
+	Fields   map[string]Field
+	Includes map[string]string // include name → relation it preloads
+}
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Predicate is a validated filter. Value holds the parsed value: a string, float64, bool,
+// time.Time or uuid.UUID, a slice of them for in, and a bool for exists.
+type Predicate struct {
+	Field string
+	Path  []string // keys inside a JSON field
+	Op    string
+	Value interface{}
+}
+
+// Options is a parsed list query. Every name in it has been checked against the resource, so
+// it is safe to build SQL from.
+type Options struct {
+	Sort    []Sort
+	Fields  []string // sparse fieldset; every field when empty
+	Include []string
+	Filters []Predicate
+}
+
+var pathKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Parse reads sort, fields, include and filter[field][op]=value (or filter[field]=value for eq)
+// from query parameters. JSON fields take a key path: filter[metadata.temperature][gt]=8.
+func Parse(r Resource, params map[string]string) (Options, error) {
+	var options Options
+	var err error
+	if options.Sort, err = ParseSort(r, params["sort"]); err != nil {
+		return Options{}, err
+	}
+	if options.Fields, err = ParseFields(r, params["fields"]); err != nil {
+		return Options{}, err
+	}
+	if options.Include, err = ParseInclude(r, params["include"]); err != nil {
+		return Options{}, err
+	}
+
+	// Keys in order, so the same query always builds the same SQL
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := params[key]
+		rest, ok := strings.CutPrefix(key, "filter[")
+		if !ok {
+			continue
+		}
+		field, rest, ok := strings.Cut(rest, "]")
+		if !ok {
+			return Options{}, fmt.Errorf("%w: malformed filter %q", ErrInvalidQuery, key)
+		}
+		op := OpEq
+		if rest != "" {
+			inner, ok := strings.CutPrefix(rest, "[")
+			if !ok || !strings.HasSuffix(inner, "]") {
+				return Options{}, fmt.Errorf("%w: malformed filter %q", ErrInvalidQuery, key)
+			}
+			op = strings.TrimSuffix(inner, "]")
+		}
+		predicate, err := ParsePredicate(r, field, op, value)
+		if err != nil {
+			return Options{}, err
+		}
+		options.Filters = append(options.Filters, predicate)
+	}
+	if len(options.Filters) > MaxFilters {
+		return Options{}, fmt.Errorf("%w: at most %d filters", ErrInvalidQuery, MaxFilters)
+	}
+	return options, nil
+}
+
+// ParseSort reads a comma separated list of fields, each descending when prefixed with -
+func ParseSort(r Resource, s string) ([]Sort, error) {
+	var sorts []Sort
+	seen := map[string]bool{}
+	for _, name := range splitList(s) {
+		by := Sort{Field: name}
+		if rest, ok := strings.CutPrefix(name, "-"); ok {
+			by = Sort{Field: rest, Desc: true}
+		}
+		field, ok := r.Fields[by.Field]
+		if !ok || !field.Sortable {
+			return nil, fmt.Errorf("%w: cannot sort by %q", ErrInvalidQuery, by.Field)
+		}
+		if seen[by.Field] {
+			return nil, fmt.Errorf("%w: %q sorted twice", ErrInvalidQuery, by.Field)
+		}
+		seen[by.Field] = true
+		sorts = append(sorts, by)
+	}
+	return sorts, nil
+}
+
+// ParseFields reads a comma separated sparse fieldset; relations count as fields
+func ParseFields(r Resource, s string) ([]string, error) {
+	fields := splitList(s)
+	for _, name := range fields {
+		_, isField := r.Fields[name]
+		_, isRelation := r.Includes[name]
+		if !isField && !isRelation {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidQuery, name)
+		}
+	}
+	return fields, nil
+}
+
+// ParseInclude reads a comma separated list of relations to load with each row
+func ParseInclude(r Resource, s string) ([]string, error) {
+	include := splitList(s)
+	for _, name := range include {
+		if _, ok := r.Includes[name]; !ok {
+			return nil, fmt.Errorf("%w: cannot include %q", ErrInvalidQuery, name)
+		}
+	}
+	return include, nil
+}
+
+// ParsePredicate checks a filter against the resource and parses its value for the field's kind
+func ParsePredicate(r Resource, name, op, value string) (Predicate, error) {
+	fieldName, path, _ := strings.Cut(name, ".")
+	field, ok := r.Fields[fieldName]
+	if !ok {
+		return Predicate{}, fmt.Errorf("%w: cannot filter on %q", ErrInvalidQuery, fieldName)
+	}
+
+	predicate := Predicate{Field: fieldName, Op: op}
+	if field.Kind == KindJSON {
+		if path == "" {
+			return Predicate{}, fmt.Errorf("%w: filter on %q needs a key, e.g. %s.key", ErrInvalidQuery, fieldName, fieldName)
+		}
+		predicate.Path = strings.Split(path, ".")
+		if len(predicate.Path) > maxPath {
+			return Predicate{}, fmt.Errorf("%w: key path %q is deeper than %d", ErrInvalidQuery, name, maxPath)
+		}
+		for _, key := range predicate.Path {
+			if !pathKey.MatchString(key) {
+				return Predicate{}, fmt.Errorf("%w: invalid key %q in %q", ErrInvalidQuery, key, name)
+			}
+		}
+	} else if path != "" {
+		return Predicate{}, fmt.Errorf("%w: %q has no keys", ErrInvalidQuery, fieldName)
+	}
+
+	if !allows(field.Kind, op) {
+		return Predicate{}, fmt.Errorf("%w: operator %q does not apply to %q", ErrInvalidQuery, op, name)
+	}
+
+	var err error
+	switch op {
+	case OpExists:
+		predicate.Value, err = strconv.ParseBool(value)
+	case OpLike:
+		predicate.Value = value
+	case OpIn:
+		values := splitList(value)
+		if len(values) == 0 || len(values) > maxInValues {
+			return Predicate{}, fmt.Errorf("%w: in takes 1 to %d values", ErrInvalidQuery, maxInValues)
+		}
+		parsed := make([]interface{}, len(values))
+		for i, v := range values {
+			if parsed[i], err = parseValue(field.Kind, v); err != nil {
+				break
+			}
+		}
+		predicate.Value = parsed
+	default:
+		predicate.Value, err = parseValue(field.Kind, value)
+		if _, isBool := predicate.Value.(bool); err == nil && isBool && op != OpEq && op != OpNe {
+			return Predicate{}, fmt.Errorf("%w: operator %q does not apply to true or false", ErrInvalidQuery, op)
+		}
+	}
+	if err != nil {
+		return Predicate{}, fmt.Errorf("%w: invalid value %q for %q", ErrInvalidQuery, value, name)
+	}
+	return predicate, nil
+}
+
+// Project keeps only the selected fields of each item of a list, plus its id and the included
+// relations. The list is returned unchanged when no fields were selected.
+func (o Options) Project(data interface{}) (interface{}, error) {
+	if len(o.Fields) == 0 {
+		return data, nil
+	}
+	keep := map[string]bool{"id": true}
+	for _, name := range o.Fields {
+		keep[name] = true
+	}
+	for _, name := range o.Include {
+		keep[name] = true
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &items); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		for name := range item {
+			if !keep[name] {
+				delete(item, name)
+			}
+		}
+	}
+	return items, nil
+}
+
+func allows(kind Kind, op string) bool {
+	switch op {
+	case OpEq, OpNe, OpExists:
+		return true
+	case OpGt, OpGte, OpLt, OpLte:
+		return kind == KindString || kind == KindNumber || kind == KindTime || kind == KindJSON
+	case OpIn:
+		return kind != KindBool
+	case OpLike:
+		return kind == KindString || kind == KindJSON
+	default:
+		return false
+	}
+}
+
+func parseValue(kind Kind, value string) (interface{}, error) {
+	switch kind {
+	case KindNumber:
+		return strconv.ParseFloat(value, 64)
+	case KindBool:
+		return strconv.ParseBool(value)
+	case KindTime:
+		return time.Parse(time.RFC3339, value)
+	case KindUUID:
+		return uuid.Parse(value)
+	case KindJSON:
+		// JSON values are typed by their text: numbers, true and false, anything else a string
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number, nil
+		}
+		if value == "true" || value == "false" {
+			return value == "true", nil
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
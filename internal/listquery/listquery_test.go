@@ -0,0 +1,221 @@
+package listquery
+
+import (
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	id := uuid.MustParse("0b6c3a52-8f1d-4b9e-9a55-2f1c0e7d4a10")
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   Options
+	}{
+		{
+			name:   "empty",
+			params: map[string]string{},
+			want:   Options{},
+		},
+		{
+			name:   "sort, fields and include",
+			params: map[string]string{"sort": "-timestamp, event_type", "fields": "event_type,product", "include": "product"},
+			want: Options{
+				Sort:    []Sort{{Field: "timestamp", Desc: true}, {Field: "event_type"}},
+				Fields:  []string{"event_type", "product"},
+				Include: []string{"product"},
+			},
+		},
+		{
+			name: "filters in key order, eq by default",
+			params: map[string]string{
+				"filter[product_id]":                  id.String(),
+				"filter[metadata.temperature][gt]":    "8",
+				"filter[event_type][in]":              "shipped,received",
+				"filter[timestamp][gte]":              "2026-10-01T00:00:00Z",
+				"filter[is_verified]":                 "true",
+				"filter[metadata.carrier.name][eq]":   "ACME",
+				"filter[blockchain_hash][exists]":     "false",
+				"filter[location][like]":              "Jakarta",
+				"filter[metadata.sealed][ne]":         "true",
+				"filter[metadata.batch-code][exists]": "true",
+				"limit":                               "10", // not a filter
+			},
+			want: Options{Filters: []Predicate{
+				{Field: "blockchain_hash", Op: OpExists, Value: false},
+				{Field: "event_type", Op: OpIn, Value: []interface{}{"shipped", "received"}},
+				{Field: "is_verified", Op: OpEq, Value: true},
+				{Field: "location", Op: OpLike, Value: "Jakarta"},
+				{Field: "metadata", Path: []string{"batch-code"}, Op: OpExists, Value: true},
+				{Field: "metadata", Path: []string{"carrier", "name"}, Op: OpEq, Value: "ACME"},
+				{Field: "metadata", Path: []string{"sealed"}, Op: OpNe, Value: true},
+				{Field: "metadata", Path: []string{"temperature"}, Op: OpGt, Value: 8.0},
+				{Field: "product_id", Op: OpEq, Value: id},
+				{Field: "timestamp", Op: OpGte, Value: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+			}},
+		},
+		{
+			name:   "SQL in a value stays a bound value",
+			params: map[string]string{"filter[location]": "x' OR '1'='1"},
+			want:   Options{Filters: []Predicate{{Field: "location", Op: OpEq, Value: "x' OR '1'='1"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(Events, tt.params)
+			if err != nil {
+				t.Fatalf("Parse(%v) unexpected error: %v", tt.params, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%v) =\n%+v\nwant\n%+v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i <= MaxFilters; i++ {
+		tooMany[fmt.Sprintf("filter[metadata.k%d]", i)] = "v"
+	}
+
+	tests := []struct {
+		name     string
+		resource Resource
+		params   map[string]string
+	}{
+		// Field names end up in SQL, so anything but a known field is refused
+		{name: "unknown filter field", resource: Events, params: map[string]string{"filter[password]": "x"}},
+		{name: "SQL as filter field", resource: Events, params: map[string]string{"filter[event_type = event_type OR 1]": "1"}},
+		{name: "quoted filter field", resource: Events, params: map[string]string{`filter["event_type"]`: "shipped"}},
+		{name: "unknown sort field", resource: Events, params: map[string]string{"sort": "password"}},
+		{name: "SQL as sort field", resource: Events, params: map[string]string{"sort": "timestamp; DROP TABLE supply_chain_events"}},
+		{name: "sort on a field that is not sortable", resource: Events, params: map[string]string{"sort": "metadata"}},
+		{name: "field sorted twice", resource: Events, params: map[string]string{"sort": "timestamp,-timestamp"}},
+		{name: "unknown sparse field", resource: Events, params: map[string]string{"fields": "event_type,secret"}},
+		{name: "unknown include", resource: Events, params: map[string]string{"include": "manufacturer"}},
+		{name: "include on a resource without relations", resource: Stakeholders, params: map[string]string{"include": "products"}},
+
+		// JSON key paths are quoted into SQL, so keys are limited to a safe alphabet
+		{name: "quote in a path key", resource: Events, params: map[string]string{"filter[metadata.a'b]": "1"}},
+		{name: "JSON operator in a path key", resource: Events, params: map[string]string{"filter[metadata.a->>b]": "1"}},
+		{name: "space in a path key", resource: Events, params: map[string]string{"filter[metadata.a b]": "1"}},
+		{name: "empty path key", resource: Events, params: map[string]string{"filter[metadata..a]": "1"}},
+		{name: "path deeper than allowed", resource: Events, params: map[string]string{"filter[metadata.a.b.c.d.e.f]": "1"}},
+		{name: "JSON field without a path", resource: Events, params: map[string]string{"filter[metadata]": "1"}},
+		{name: "path on a plain field", resource: Events, params: map[string]string{"filter[location.city]": "Jakarta"}},
+
+		// Operators select SQL fragments from a fixed set
+		{name: "unknown operator", resource: Events, params: map[string]string{"filter[location][regex]": ".*"}},
+		{name: "SQL as operator", resource: Events, params: map[string]string{"filter[location][= 'x' OR 1=1 --]": "x"}},
+		{name: "uppercase operator", resource: Events, params: map[string]string{"filter[location][EQ]": "x"}},
+		{name: "like on a time", resource: Events, params: map[string]string{"filter[timestamp][like]": "2026"}},
+		{name: "ordering on a UUID", resource: Events, params: map[string]string{"filter[product_id][gt]": "0b6c3a52-8f1d-4b9e-9a55-2f1c0e7d4a10"}},
+		{name: "ordering on a bool", resource: Events, params: map[string]string{"filter[is_verified][gt]": "false"}},
+		{name: "in on a bool", resource: Events, params: map[string]string{"filter[is_verified][in]": "true,false"}},
+		{name: "ordering on a JSON bool", resource: Events, params: map[string]string{"filter[metadata.sealed][lt]": "true"}},
+
+		// Malformed keys
+		{name: "unclosed field", resource: Events, params: map[string]string{"filter[location": "x"}},
+		{name: "text after the field", resource: Events, params: map[string]string{"filter[location]x": "x"}},
+		{name: "unclosed operator", resource: Events, params: map[string]string{"filter[location][eq": "x"}},
+
+		// Values must parse as the field's kind
+		{name: "number that is not a number", resource: Transactions, params: map[string]string{"filter[block_number][gt]": "1; DELETE"}},
+		{name: "time that is not RFC3339", resource: Events, params: map[string]string{"filter[timestamp][gte]": "yesterday"}},
+		{name: "UUID that is not a UUID", resource: Events, params: map[string]string{"filter[product_id]": "1 OR 1=1"}},
+		{name: "bool that is not a bool", resource: Events, params: map[string]string{"filter[is_verified]": "yes"}},
+		{name: "exists that is not a bool", resource: Events, params: map[string]string{"filter[location][exists]": "maybe"}},
+		{name: "in with a bad value", resource: Events, params: map[string]string{"filter[product_id][in]": "0b6c3a52-8f1d-4b9e-9a55-2f1c0e7d4a10,nope"}},
+		{name: "in without values", resource: Events, params: map[string]string{"filter[event_type][in]": " , "}},
+		{name: "in with too many values", resource: Events, params: map[string]string{"filter[event_type][in]": strings.Repeat("a,", maxInValues+1)}},
+		{name: "too many filters", resource: Events, params: tooMany},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.resource, tt.params); !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("Parse(%v) error = %v, want ErrInvalidQuery", tt.params, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONValueKinds(t *testing.T) {
+	tests := []struct {
+		value string
+		want  interface{}
+	}{
+		{"8", 8.0},
+		{"-2.5", -2.5},
+		{"1e3", 1000.0},
+		{"true", true},
+		{"false", false},
+		{"True", "True"},
+		{"ACME", "ACME"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		predicate, err := ParsePredicate(Products, "metadata.key", OpEq, tt.value)
+		if err != nil {
+			t.Fatalf("ParsePredicate(%q) unexpected error: %v", tt.value, err)
+		}
+		if !reflect.DeepEqual(predicate.Value, tt.want) {
+			t.Errorf("ParsePredicate(%q).Value = %#v, want %#v", tt.value, predicate.Value, tt.want)
+		}
+	}
+}
+
+func TestProject(t *testing.T) {
+	type item struct {
+		ID        string `json:"id"`
+		EventType string `json:"event_type"`
+		Location  string `json:"location"`
+		Product   *struct {
+			Name string `json:"name"`
+		} `json:"product"`
+	}
+	data := []item{{ID: "1", EventType: "shipped", Location: "Jakarta"}}
+
+	tests := []struct {
+		name    string
+		options Options
+		want    []string // keys kept
+	}{
+		{name: "no fields keeps everything", options: Options{}, want: nil},
+		{name: "id is always kept", options: Options{Fields: []string{"event_type"}}, want: []string{"event_type", "id"}},
+		{name: "included relations are kept", options: Options{Fields: []string{"location"}, Include: []string{"product"}}, want: []string{"id", "location", "product"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projected, err := tt.options.Project(data)
+			if err != nil {
+				t.Fatalf("Project unexpected error: %v", err)
+			}
+			if tt.want == nil {
+				if !reflect.DeepEqual(projected, data) {
+					t.Errorf("Project = %v, want the list unchanged", projected)
+				}
+				return
+			}
+			items, ok := projected.([]map[string]json.RawMessage)
+			if !ok || len(items) != 1 {
+				t.Fatalf("Project = %#v, want one projected item", projected)
+			}
+			var keys []string
+			for key := range items[0] {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			if !reflect.DeepEqual(keys, tt.want) {
+				t.Errorf("Project kept %v, want %v", keys, tt.want)
+			}
+		})
+	}
+}
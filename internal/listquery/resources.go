@@ -0,0 +1,65 @@
+package listquery
+
+// The resources whose lists take the query language
+
+var Products = Resource{
+	Fields: map[string]Field{
+		"id":              {Kind: KindUUID},
+		"sku":             {Kind: KindString, Sortable: true},
+		"name":            {Kind: KindString, Sortable: true},
+		"description":     {Kind: KindString},
+		"category":        {Kind: KindString, Sortable: true},
+		"manufacturer_id": {Kind: KindUUID},
+		"lot_number":      {Kind: KindString, Sortable: true},
+		"serial_number":   {Kind: KindString, Sortable: true},
+		"metadata":        {Kind: KindJSON},
+		"created_at":      {Kind: KindTime, Sortable: true},
+		"updated_at":      {Kind: KindTime, Sortable: true},
+	},
+	Includes: map[string]string{"manufacturer": "Manufacturer"},
+}
+
+var Stakeholders = Resource{
+	Fields: map[string]Field{
+		"id":             {Kind: KindUUID},
+		"name":           {Kind: KindString, Sortable: true},
+		"type":           {Kind: KindString, Sortable: true},
+		"wallet_address": {Kind: KindString},
+		"email":          {Kind: KindString, Sortable: true},
+		"phone":          {Kind: KindString},
+		"address":        {Kind: KindString},
+		"is_verified":    {Kind: KindBool, Sortable: true},
+		"created_at":     {Kind: KindTime, Sortable: true},
+		"updated_at":     {Kind: KindTime, Sortable: true},
+	},
+}
+
+var Events = Resource{
+	Fields: map[string]Field{
+		"id":              {Kind: KindUUID},
+		"product_id":      {Kind: KindUUID},
+		"stakeholder_id":  {Kind: KindUUID},
+		"event_type":      {Kind: KindString, Sortable: true},
+		"location":        {Kind: KindString, Sortable: true},
+		"location_id":     {Kind: KindUUID},
+		"timestamp":       {Kind: KindTime, Sortable: true},
+		"metadata":        {Kind: KindJSON},
+		"blockchain_hash": {Kind: KindString},
+		"is_verified":     {Kind: KindBool, Sortable: true},
+		"created_at":      {Kind: KindTime, Sortable: true},
+	},
+	Includes: map[string]string{"product": "Product", "stakeholder": "Stakeholder"},
+}
+
+var Transactions = Resource{
+	Fields: map[string]Field{
+		"id":               {Kind: KindUUID},
+		"event_id":         {Kind: KindUUID},
+		"transaction_hash": {Kind: KindString},
+		"block_number":     {Kind: KindNumber, Sortable: true},
+		"gas_used":         {Kind: KindNumber, Sortable: true},
+		"status":           {Kind: KindString, Sortable: true},
+		"created_at":       {Kind: KindTime, Sortable: true},
+	},
+	Includes: map[string]string{"event": "Event"},
+}
@@ -16,6 +16,8 @@ type AppMetricsExporter struct {
 	httpRequestsTotal   *prometheus.CounterVec   // HTTP metrics
 	httpRequestDuration *prometheus.HistogramVec // HTTP metrics
 	businessEvents      *prometheus.CounterVec   // Business metrics
+	routeEvaluations    *prometheus.CounterVec   // Route monitoring metrics
+	routeAlerts         *prometheus.CounterVec   // Route monitoring metrics
 	// System metrics
 	memoryUsage     prometheus.Gauge
 	goroutinesCount prometheus.Gauge
@@ -69,6 +71,26 @@ func NewAppMetricsExporter() *AppMetricsExporter {
 			[]string{"event_type", "user_id"},
 		),
 
+		// Route monitoring metrics
+		routeEvaluations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "app",
+				Subsystem: "route",
+				Name:      "evaluations_total",
+				Help:      "Total count of shipment positions checked against a route plan by source",
+			},
+			[]string{"source"},
+		),
+		routeAlerts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "app",
+				Subsystem: "route",
+				Name:      "alerts_total",
+				Help:      "Total count of route alerts raised by type",
+			},
+			[]string{"type"},
+		),
+
 		// System metrics
 		memoryUsage: prometheus.NewGauge(
 			prometheus.GaugeOpts{
@@ -113,6 +135,8 @@ func NewAppMetricsExporter() *AppMetricsExporter {
 		exporter.httpRequestsTotal,
 		exporter.httpRequestDuration,
 		exporter.businessEvents,
+		exporter.routeEvaluations,
+		exporter.routeAlerts,
 		exporter.memoryUsage,
 		exporter.goroutinesCount,
 		exporter.uptime,
@@ -162,6 +186,16 @@ func (e *AppMetricsExporter) RecordBusinessEvent(eventType, userID string) {
 	e.businessEvents.WithLabelValues(eventType, userID).Inc()
 }
 
+// RecordRouteEvaluation mencatat posisi pengiriman yang diperiksa terhadap rencana rute
+func (e *AppMetricsExporter) RecordRouteEvaluation(source string) {
+	e.routeEvaluations.WithLabelValues(source).Inc()
+}
+
+// RecordRouteAlert mencatat alert rute yang dibuat
+func (e *AppMetricsExporter) RecordRouteAlert(alertType string) {
+	e.routeAlerts.WithLabelValues(alertType).Inc()
+}
+
 // FiberMetricMiddleware menyediakan middleware Gin untuk merekam metrik HTTP
 func (e *AppMetricsExporter) FiberMetricMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
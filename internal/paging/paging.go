@@ -0,0 +1,107 @@
+package paging
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid page cursor")
+	ErrInvalidCount  = errors.New("count must be exact, estimated or none")
+)
+
+// Count modes: how a list reports its total
+const (
+	CountExact     = "exact"     // COUNT(*) over the filter
+	CountEstimated = "estimated" // the query planner's row estimate; cheap on large tables
+	CountNone      = "none"      // no total
+)
+
+func IsValidCount(mode string) bool {
+	switch mode {
+	case CountExact, CountEstimated, CountNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// Cursor is the sort key of the last row of a page: its timestamp (created_at for most tables)
+// and ID. Clients treat it as opaque and pass it back to get the rows that follow.
+type Cursor struct {
+	At time.Time
+	ID uuid.UUID
+}
+
+func (c Cursor) String() string {
+	raw := strconv.FormatInt(c.At.UnixMicro(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	unix, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{At: time.UnixMicro(unix).UTC(), ID: parsed}, nil
+}
+
+// MarshalText and UnmarshalText let filters carry a cursor in JSON, as GraphQL filter inputs do
+func (c Cursor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Cursor) UnmarshalText(text []byte) error {
+	parsed, err := ParseCursor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// Request is the page a list asks for. With a cursor the page starts after it and the offset is
+// ignored.
+type Request struct {
+	Limit  int
+	Offset int
+	Cursor *Cursor
+	Count  string
+}
+
+// CountMode is the requested count, defaulting to exact on the first page and none after a
+// cursor, since the first page already reported the total
+func (r Request) CountMode() string {
+	switch {
+	case r.Count != "":
+		return r.Count
+	case r.Cursor != nil:
+		return CountNone
+	default:
+		return CountExact
+	}
+}
+
+// Page describes a page a list returned
+type Page struct {
+	Total          *int64 // nil when not counted
+	TotalEstimated bool
+	HasMore        bool
+	NextCursor     *Cursor // set when HasMore
+}
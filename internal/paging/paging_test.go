@@ -0,0 +1,135 @@
+package paging
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := uuid.MustParse("0b6c3a52-8f1d-4b9e-9a55-2f1c0e7d4a10")
+	tests := []struct {
+		name   string
+		cursor Cursor
+		want   Cursor // what parsing the encoded cursor returns
+	}{
+		{
+			name:   "microseconds",
+			cursor: Cursor{At: time.Date(2026, 10, 19, 8, 30, 15, 123456000, time.UTC), ID: id},
+			want:   Cursor{At: time.Date(2026, 10, 19, 8, 30, 15, 123456000, time.UTC), ID: id},
+		},
+		{
+			name:   "nanoseconds are truncated to what the database stores",
+			cursor: Cursor{At: time.Date(2026, 10, 19, 8, 30, 15, 123456789, time.UTC), ID: id},
+			want:   Cursor{At: time.Date(2026, 10, 19, 8, 30, 15, 123456000, time.UTC), ID: id},
+		},
+		{
+			name:   "other zones come back as UTC",
+			cursor: Cursor{At: time.Date(2026, 10, 19, 15, 30, 0, 0, time.FixedZone("WIB", 7*3600)), ID: id},
+			want:   Cursor{At: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), ID: id},
+		},
+		{
+			name:   "before the epoch",
+			cursor: Cursor{At: time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC), ID: id},
+			want:   Cursor{At: time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC), ID: id},
+		},
+		{
+			name:   "zero values",
+			cursor: Cursor{At: time.UnixMicro(0).UTC()},
+			want:   Cursor{At: time.UnixMicro(0).UTC()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseCursor(tt.cursor.String())
+			if err != nil {
+				t.Fatalf("ParseCursor(%q) unexpected error: %v", tt.cursor.String(), err)
+			}
+			if !parsed.At.Equal(tt.want.At) || parsed.At.Location() != time.UTC || parsed.ID != tt.want.ID {
+				t.Errorf("round trip = %+v, want %+v", *parsed, tt.want)
+			}
+
+			// The JSON form GraphQL filters use goes through the same encoding
+			text, err := tt.cursor.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText unexpected error: %v", err)
+			}
+			var decoded Cursor
+			if err := decoded.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText(%q) unexpected error: %v", text, err)
+			}
+			if decoded != *parsed {
+				t.Errorf("UnmarshalText = %+v, want %+v", decoded, *parsed)
+			}
+		})
+	}
+}
+
+func TestParseCursorRejects(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "empty", cursor: ""},
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "padded base64", cursor: base64.URLEncoding.EncodeToString([]byte("1:0b6c3a52-8f1d-4b9e-9a55-2f1c0e7d4a10"))},
+		{name: "no separator", cursor: encode("1760862615000000")},
+		{name: "time not a number", cursor: encode("yesterday:0b6c3a52-8f1d-4b9e-9a55-2f1c0e7d4a10")},
+		{name: "time out of range", cursor: encode("99999999999999999999:0b6c3a52-8f1d-4b9e-9a55-2f1c0e7d4a10")},
+		{name: "bad id", cursor: encode("1760862615000000:42")},
+		{name: "sql in id", cursor: encode("1760862615000000:' OR 1=1 --")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCursor(tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("ParseCursor(%q) error = %v, want ErrInvalidCursor", tt.cursor, err)
+			}
+			var c Cursor
+			if err := c.UnmarshalText([]byte(tt.cursor)); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("UnmarshalText(%q) error = %v, want ErrInvalidCursor", tt.cursor, err)
+			}
+		})
+	}
+}
+
+func TestCountMode(t *testing.T) {
+	cursor := &Cursor{At: time.Now(), ID: uuid.New()}
+	tests := []struct {
+		name    string
+		request Request
+		want    string
+	}{
+		{name: "first page", request: Request{}, want: CountExact},
+		{name: "after a cursor", request: Request{Cursor: cursor}, want: CountNone},
+		{name: "explicit on the first page", request: Request{Count: CountEstimated}, want: CountEstimated},
+		{name: "explicit after a cursor", request: Request{Cursor: cursor, Count: CountExact}, want: CountExact},
+		{name: "offset pages count", request: Request{Offset: 20}, want: CountExact},
+	}
+	for _, tt := range tests {
+		if got := tt.request.CountMode(); got != tt.want {
+			t.Errorf("%s: CountMode() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsValidCount(t *testing.T) {
+	tests := []struct {
+		mode string
+		want bool
+	}{
+		{CountExact, true},
+		{CountEstimated, true},
+		{CountNone, true},
+		{"", false},
+		{"EXACT", false},
+		{"all", false},
+	}
+	for _, tt := range tests {
+		if got := IsValidCount(tt.mode); got != tt.want {
+			t.Errorf("IsValidCount(%q) = %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+}
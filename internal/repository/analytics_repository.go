@@ -0,0 +1,418 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// durationColumns summarises the seconds in column as dto.DurationStats, in hours
+func durationColumns(column string) string {
+	hours := func(expr string) string { return "(" + expr + ") / 3600" }
+	percentile := func(p string) string {
+		return hours("percentile_cont(" + p + ") WITHIN GROUP (ORDER BY " + column + ")")
+	}
+	return strings.Join([]string{
+		"COUNT(" + column + ") AS count",
+		hours("AVG("+column+")") + " AS mean_hours",
+		hours("MIN("+column+")") + " AS min_hours",
+		percentile("0.5") + " AS p50_hours",
+		percentile("0.75") + " AS p75_hours",
+		percentile("0.9") + " AS p90_hours",
+		percentile("0.95") + " AS p95_hours",
+		percentile("0.99") + " AS p99_hours",
+		hours("MAX("+column+")") + " AS max_hours",
+	}, ", ")
+}
+
+// transitGroups and dwellGroups are the columns each group_by groups on
+var (
+	transitGroups = map[string][]string{
+		"route":    {"sender_id", "receiver_id"},
+		"lane":     {"origin_location_id", "destination_location_id"},
+		"sender":   {"sender_id"},
+		"receiver": {"receiver_id"},
+	}
+	dwellGroups = map[string][]string{
+		"stakeholder": {"stakeholder_id"},
+		"location":    {"location_id"},
+	}
+)
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) *analyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// MarkStale queues the product and the stakeholder's day of a stored event for the next refresh
+func (r *analyticsRepository) MarkStale(ctx context.Context, eventID uuid.UUID) error {
+	db := r.db.WithContext(ctx)
+	err := db.Exec(`
+		INSERT INTO analytics_stale_products (product_id)
+		SELECT product_id FROM supply_chain_events WHERE id = ? AND product_id IS NOT NULL
+		ON CONFLICT (product_id) DO NOTHING`, eventID).Error
+	if err != nil {
+		return err
+	}
+	return db.Exec(`
+		INSERT INTO analytics_stale_days (stakeholder_id, day)
+		SELECT stakeholder_id, timestamp::date FROM supply_chain_events WHERE id = ? AND stakeholder_id IS NOT NULL
+		ON CONFLICT (stakeholder_id, day) DO NOTHING`, eventID).Error
+}
+
+// ClaimStaleProducts takes up to limit stale products, oldest first, off the queue. Within a
+// transaction they return to it if the transaction rolls back; products another refresh claimed
+// are skipped.
+func (r *analyticsRepository) ClaimStaleProducts(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	var productIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Raw(`
+		DELETE FROM analytics_stale_products WHERE product_id IN (
+			SELECT product_id FROM analytics_stale_products ORDER BY marked_at LIMIT ? FOR UPDATE SKIP LOCKED
+		)
+		RETURNING product_id`, limit).Scan(&productIDs).Error
+	return productIDs, err
+}
+
+// ClaimStaleDays takes up to limit stale stakeholder days off the queue, like ClaimStaleProducts
+func (r *analyticsRepository) ClaimStaleDays(ctx context.Context, limit int) ([]*dto.StakeholderDay, error) {
+	var days []*dto.StakeholderDay
+	err := r.db.WithContext(ctx).Raw(`
+		DELETE FROM analytics_stale_days WHERE (stakeholder_id, day) IN (
+			SELECT stakeholder_id, day FROM analytics_stale_days ORDER BY marked_at LIMIT ? FOR UPDATE SKIP LOCKED
+		)
+		RETURNING stakeholder_id, day`, limit).Scan(&days).Error
+	return days, err
+}
+
+// RebuildProducts derives the transit legs and dwells of the products from their events again.
+// Events are taken in timestamp order: a leg runs from a shipped event to the first received event
+// before the next one shipped, and a stay from a manufactured or received event to the first
+// shipped or sold event before the next arrival.
+func (r *analyticsRepository) RebuildProducts(ctx context.Context, productIDs []uuid.UUID) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	db := r.db.WithContext(ctx)
+
+	if err := db.Where("product_id IN ?", productIDs).Delete(&domain.TransitLeg{}).Error; err != nil {
+		return err
+	}
+	err := db.Exec(`
+		WITH moves AS (
+			SELECT id, product_id, stakeholder_id, location_id, event_type, timestamp, created_at,
+				COUNT(*) FILTER (WHERE event_type = 'shipped') OVER (PARTITION BY product_id ORDER BY timestamp, created_at, id) AS leg
+			FROM supply_chain_events
+			WHERE product_id IN ? AND event_type IN ('shipped', 'received')
+		)
+		INSERT INTO transit_legs (ship_event_id, receive_event_id, product_id, custody_transfer_id, sender_id, receiver_id,
+			origin_location_id, destination_location_id, shipped_at, received_at)
+		SELECT DISTINCT ON (s.id) s.id, r.id, s.product_id, ct.id, s.stakeholder_id, r.stakeholder_id,
+			s.location_id, r.location_id, s.timestamp, r.timestamp
+		FROM moves s
+		JOIN moves r ON r.product_id = s.product_id AND r.leg = s.leg AND r.event_type = 'received'
+		LEFT JOIN custody_transfers ct ON ct.ship_event_id = s.id
+		WHERE s.event_type = 'shipped'
+		ORDER BY s.id, r.timestamp, r.created_at, r.id`, productIDs).Error
+	if err != nil {
+		return err
+	}
+
+	if err := db.Where("product_id IN ?", productIDs).Delete(&domain.ProductDwell{}).Error; err != nil {
+		return err
+	}
+	return db.Exec(`
+		WITH moves AS (
+			SELECT id, product_id, stakeholder_id, location_id, location, event_type, timestamp, created_at,
+				COUNT(*) FILTER (WHERE event_type IN ('manufactured', 'received')) OVER (PARTITION BY product_id ORDER BY timestamp, created_at, id) AS stay
+			FROM supply_chain_events
+			WHERE product_id IN ? AND event_type IN ('manufactured', 'received', 'shipped', 'sold')
+		)
+		INSERT INTO product_dwells (arrival_event_id, departure_event_id, product_id, stakeholder_id, location_id, location,
+			arrived_at, departed_at)
+		SELECT DISTINCT ON (a.id) a.id, d.id, a.product_id, a.stakeholder_id, a.location_id, a.location, a.timestamp, d.timestamp
+		FROM moves a
+		LEFT JOIN moves d ON d.product_id = a.product_id AND d.stay = a.stay AND d.event_type IN ('shipped', 'sold')
+		WHERE a.event_type IN ('manufactured', 'received')
+		ORDER BY a.id, d.timestamp, d.created_at, d.id`, productIDs).Error
+}
+
+// RebuildThroughput counts the events of the stakeholder days again
+func (r *analyticsRepository) RebuildThroughput(ctx context.Context, days []*dto.StakeholderDay) error {
+	if len(days) == 0 {
+		return nil
+	}
+	var stakeholderIDs []uuid.UUID
+	tuples := make([]string, 0, len(days))
+	vars := make([]interface{}, 0, 2*len(days))
+	for _, day := range days {
+		stakeholderIDs = append(stakeholderIDs, day.StakeholderID)
+		tuples = append(tuples, "(CAST(? AS uuid), CAST(? AS date))")
+		vars = append(vars, day.StakeholderID, day.Day.Format(time.DateOnly))
+	}
+	pairs := "(" + strings.Join(tuples, ", ") + ")"
+	db := r.db.WithContext(ctx)
+
+	err := db.Exec("DELETE FROM stakeholder_throughput WHERE (stakeholder_id, day) IN "+pairs, vars...).Error
+	if err != nil {
+		return err
+	}
+	return db.Exec(`
+		INSERT INTO stakeholder_throughput (stakeholder_id, day, event_type, events)
+		SELECT stakeholder_id, timestamp::date, event_type, COUNT(*)
+		FROM supply_chain_events
+		WHERE stakeholder_id IN ? AND (stakeholder_id, timestamp::date) IN `+pairs+`
+		GROUP BY stakeholder_id, timestamp::date, event_type`, append([]interface{}{stakeholderIDs}, vars...)...).Error
+}
+
+func (r *analyticsRepository) GetBacklog(ctx context.Context) (*dto.AnalyticsBacklog, error) {
+	var backlog dto.AnalyticsBacklog
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT (SELECT COUNT(*) FROM analytics_stale_products) AS products,
+			(SELECT COUNT(*) FROM analytics_stale_days) AS days`).Scan(&backlog).Error
+	if err != nil {
+		return nil, err
+	}
+	return &backlog, nil
+}
+
+func (r *analyticsRepository) ListLegs(ctx context.Context, filter *dto.TransitLegFilter) ([]*domain.TransitLeg, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.TransitLeg{})
+
+	// Apply filters
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.SenderID != nil {
+		query = query.Where("sender_id = ?", *filter.SenderID)
+	}
+	if filter.ReceiverID != nil {
+		query = query.Where("receiver_id = ?", *filter.ReceiverID)
+	}
+	if filter.CustodyTransferID != nil {
+		query = query.Where("custody_transfer_id = ?", *filter.CustodyTransferID)
+	}
+	if filter.From != nil {
+		query = query.Where("received_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("received_at < ?", *filter.To)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "received_at", id: "ship_event_id"}, req, func(item *domain.TransitLeg) paging.Cursor {
+		return paging.Cursor{At: item.ReceivedAt, ID: item.ShipEventID}
+	})
+}
+
+func (r *analyticsRepository) ListDwells(ctx context.Context, filter *dto.DwellFilter) ([]*domain.ProductDwell, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.ProductDwell{})
+
+	// Apply filters
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.StakeholderID != nil {
+		query = query.Where("stakeholder_id = ?", *filter.StakeholderID)
+	}
+	if filter.LocationID != nil {
+		query = query.Where("location_id = ?", *filter.LocationID)
+	}
+	if filter.Open != nil {
+		if *filter.Open {
+			query = query.Where("departed_at IS NULL")
+		} else {
+			query = query.Where("departed_at IS NOT NULL")
+		}
+	}
+	if filter.From != nil {
+		query = query.Where("arrived_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("arrived_at < ?", *filter.To)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "arrived_at", id: "arrival_event_id"}, req, func(item *domain.ProductDwell) paging.Cursor {
+		return paging.Cursor{At: item.ArrivedAt, ID: item.ArrivalEventID}
+	})
+}
+
+// TransitStats summarises the transit times of the legs received in the query's range, one row
+// per group, busiest first. Without a known group_by it returns one row for all of them.
+func (r *analyticsRepository) TransitStats(ctx context.Context, query *dto.AnalyticsQuery) ([]*dto.TransitStats, error) {
+	db := r.db.WithContext(ctx).Model(&domain.TransitLeg{})
+	if query.ProductID != nil {
+		db = db.Where("product_id = ?", *query.ProductID)
+	}
+	if query.SenderID != nil {
+		db = db.Where("sender_id = ?", *query.SenderID)
+	}
+	if query.ReceiverID != nil {
+		db = db.Where("receiver_id = ?", *query.ReceiverID)
+	}
+	if query.LocationID != nil {
+		db = db.Where("? IN (origin_location_id, destination_location_id)", *query.LocationID)
+	}
+	if query.From != nil {
+		db = db.Where("received_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("received_at < ?", *query.To)
+	}
+
+	var stats []*dto.TransitStats
+	err := groupedStats(db, transitGroups[query.GroupBy], durationColumns("transit_seconds"), query.Limit).Scan(&stats).Error
+	return stats, err
+}
+
+// DwellStats summarises the stays that ended in the query's range like TransitStats
+func (r *analyticsRepository) DwellStats(ctx context.Context, query *dto.AnalyticsQuery) ([]*dto.DwellStats, error) {
+	db := applyDwellQuery(r.db.WithContext(ctx).Model(&domain.ProductDwell{}), query)
+	if query.From != nil {
+		db = db.Where("departed_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("departed_at < ?", *query.To)
+	}
+	db = db.Where("departed_at IS NOT NULL")
+
+	var stats []*dto.DwellStats
+	err := groupedStats(db, dwellGroups[query.GroupBy], durationColumns("dwell_seconds"), query.Limit).Scan(&stats).Error
+	return stats, err
+}
+
+// CountOpenDwells counts the stays of the query's products, stakeholder or location that have not
+// ended
+func (r *analyticsRepository) CountOpenDwells(ctx context.Context, query *dto.AnalyticsQuery) (int64, error) {
+	var count int64
+	err := applyDwellQuery(r.db.WithContext(ctx).Model(&domain.ProductDwell{}), query).
+		Where("departed_at IS NULL").
+		Count(&count).Error
+	return count, err
+}
+
+// Throughput counts the events of stakeholders per period over the days from the query's From to
+// its To, by period and then stakeholder
+func (r *analyticsRepository) Throughput(ctx context.Context, query *dto.AnalyticsQuery) ([]*dto.ThroughputPoint, error) {
+	db := r.db.WithContext(ctx).Table("stakeholder_throughput").
+		Select(`stakeholder_id, date_trunc(?, day::timestamp) AS period_start,
+			(SUM(events) FILTER (WHERE event_type = 'manufactured'))::bigint AS manufactured,
+			(SUM(events) FILTER (WHERE event_type = 'shipped'))::bigint AS shipped,
+			(SUM(events) FILTER (WHERE event_type = 'received'))::bigint AS received,
+			(SUM(events) FILTER (WHERE event_type = 'sold'))::bigint AS sold,
+			SUM(events)::bigint AS total`, query.Period)
+	if query.StakeholderID != nil {
+		db = db.Where("stakeholder_id = ?", *query.StakeholderID)
+	}
+	if query.From != nil {
+		db = db.Where("day >= CAST(? AS date)", query.From.Format(time.DateOnly))
+	}
+	if query.To != nil {
+		db = db.Where("day <= CAST(? AS date)", query.To.Format(time.DateOnly))
+	}
+
+	var rows []struct {
+		StakeholderID uuid.UUID
+		PeriodStart   time.Time
+		Manufactured  *int64
+		Shipped       *int64
+		Received      *int64
+		Sold          *int64
+		Total         int64
+	}
+	err := db.Group("stakeholder_id, period_start").Order("period_start, stakeholder_id").Limit(query.Limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	count := func(n *int64) int64 {
+		if n == nil {
+			return 0
+		}
+		return *n
+	}
+	points := make([]*dto.ThroughputPoint, 0, len(rows))
+	for _, row := range rows {
+		points = append(points, &dto.ThroughputPoint{
+			StakeholderID: row.StakeholderID,
+			PeriodStart:   row.PeriodStart,
+			Manufactured:  count(row.Manufactured),
+			Shipped:       count(row.Shipped),
+			Received:      count(row.Received),
+			Sold:          count(row.Sold),
+			Total:         row.Total,
+		})
+	}
+	return points, nil
+}
+
+// ThroughputTotals counts the events of all stakeholders on the days from from to to by type
+func (r *analyticsRepository) ThroughputTotals(ctx context.Context, from, to time.Time) (map[string]int64, error) {
+	var rows []struct {
+		EventType string
+		Events    int64
+	}
+	err := r.db.WithContext(ctx).Table("stakeholder_throughput").
+		Select("event_type, SUM(events)::bigint AS events").
+		Where("day BETWEEN CAST(? AS date) AND CAST(? AS date)", from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Group("event_type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		totals[row.EventType] = row.Events
+	}
+	return totals, nil
+}
+
+// GetOverview counts the products and stakeholders, the events recorded from from up to to and
+// the products in transit now
+func (r *analyticsRepository) GetOverview(ctx context.Context, from, to time.Time) (*dto.AnalyticsDashboard, error) {
+	var dashboard dto.AnalyticsDashboard
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT (SELECT COUNT(*) FROM products) AS products,
+			(SELECT COUNT(*) FROM stakeholders) AS stakeholders,
+			(SELECT COUNT(*) FROM supply_chain_events WHERE timestamp >= ? AND timestamp < ?) AS events,
+			(SELECT COUNT(*) FROM product_custodies WHERE state = ?) AS in_transit`,
+		from, to, domain.CustodyStateInTransit).Scan(&dashboard).Error
+	if err != nil {
+		return nil, err
+	}
+	dashboard.From, dashboard.To = from, to
+	return &dashboard, nil
+}
+
+func applyDwellQuery(db *gorm.DB, query *dto.AnalyticsQuery) *gorm.DB {
+	if query.ProductID != nil {
+		db = db.Where("product_id = ?", *query.ProductID)
+	}
+	if query.StakeholderID != nil {
+		db = db.Where("stakeholder_id = ?", *query.StakeholderID)
+	}
+	if query.LocationID != nil {
+		db = db.Where("location_id = ?", *query.LocationID)
+	}
+	return db
+}
+
+// groupedStats selects stats per group of columns, busiest groups first and at most limit of them
+func groupedStats(db *gorm.DB, columns []string, stats string, limit int) *gorm.DB {
+	if len(columns) == 0 {
+		return db.Select(stats)
+	}
+	group := strings.Join(columns, ", ")
+	return db.Select(group + ", " + stats).Group(group).Order("count DESC, " + group).Limit(limit)
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
 	"gorm.io/gorm"
 )
 
@@ -46,11 +48,9 @@ func (r *blockchainTransactionRepository) Delete(ctx context.Context, id uuid.UU
 	return r.db.WithContext(ctx).Delete(&domain.BlockchainTransaction{}, id).Error
 }
 
-func (r *blockchainTransactionRepository) List(ctx context.Context, filter *dto.BlockchainTransactionFilter) ([]*domain.BlockchainTransaction, int64, error) {
-	var transactions []*domain.BlockchainTransaction
-	var total int64
-
-	query := r.db.WithContext(ctx).Model(&domain.BlockchainTransaction{}).Preload("Event")
+func (r *blockchainTransactionRepository) List(ctx context.Context, filter *dto.BlockchainTransactionFilter) ([]*domain.BlockchainTransaction, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.BlockchainTransaction{})
+	query = applyIncludes(query, listquery.Transactions, filter.Query.Include)
 
 	// Apply filters
 	if filter.EventID != nil {
@@ -59,23 +59,13 @@ func (r *blockchainTransactionRepository) List(ctx context.Context, filter *dto.
 	if filter.Status != nil {
 		query = query.Where("status = ?", *filter.Status)
 	}
-
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
+	query = applyPredicates(query, filter.Query.Filters)
 
 	// Apply pagination and ordering
-	query = query.Order("created_at DESC")
-	if filter.Limit > 0 {
-		query = query.Limit(filter.Limit)
-	}
-	if filter.Offset > 0 {
-		query = query.Offset(filter.Offset)
-	}
-
-	err := query.Find(&transactions).Error
-	return transactions, total, err
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}.sortedBy(filter.Query.Sort), req, func(item *domain.BlockchainTransaction) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
 }
 
 func (r *blockchainTransactionRepository) GetByEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.BlockchainTransaction, error) {
@@ -84,6 +74,14 @@ func (r *blockchainTransactionRepository) GetByEvent(ctx context.Context, eventI
 	return transactions, err
 }
 
+// GetByEvents loads up to perEvent of each event's latest transactions, without relations
+func (r *blockchainTransactionRepository) GetByEvents(ctx context.Context, eventIDs []uuid.UUID, perEvent int) ([]*domain.BlockchainTransaction, error) {
+	var transactions []*domain.BlockchainTransaction
+	err := firstPerParent(r.db.WithContext(ctx), &domain.BlockchainTransaction{}, "event_id", eventIDs, "created_at DESC, id ASC", perEvent).
+		Find(&transactions).Error
+	return transactions, err
+}
+
 func (r *blockchainTransactionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string, blockNumber *int64) error {
 	updates := map[string]interface{}{
 		"status": status,
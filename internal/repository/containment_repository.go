@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+type containmentRepository struct {
+	db *gorm.DB
+}
+
+func NewContainmentRepository(db *gorm.DB) *containmentRepository {
+	return &containmentRepository{db: db}
+}
+
+func (r *containmentRepository) Create(ctx context.Context, containment *domain.ProductContainment) error {
+	return r.db.WithContext(ctx).Create(containment).Error
+}
+
+func (r *containmentRepository) Unpack(ctx context.Context, parentID uuid.UUID, childIDs []uuid.UUID, eventID uuid.UUID, at time.Time) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.ProductContainment{}).
+		Where("parent_id = ? AND unpacked_at IS NULL", parentID)
+	if len(childIDs) > 0 {
+		query = query.Where("child_id IN ?", childIDs)
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"unpacked_at":     at,
+		"unpack_event_id": eventID,
+	})
+	return result.RowsAffected, result.Error
+}
+
+func (r *containmentRepository) GetActiveByChild(ctx context.Context, childID uuid.UUID) (*domain.ProductContainment, error) {
+	var containment domain.ProductContainment
+	err := r.db.WithContext(ctx).Where("child_id = ? AND unpacked_at IS NULL", childID).First(&containment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &containment, nil
+}
+
+func (r *containmentRepository) GetActiveChildren(ctx context.Context, parentID uuid.UUID) ([]*domain.ProductContainment, error) {
+	var containments []*domain.ProductContainment
+	err := r.db.WithContext(ctx).Preload("Child").Where("parent_id = ? AND unpacked_at IS NULL", parentID).Order("packed_at ASC").Find(&containments).Error
+	return containments, err
+}
+
+func (r *containmentRepository) GetByChild(ctx context.Context, childID uuid.UUID) ([]*domain.ProductContainment, error) {
+	var containments []*domain.ProductContainment
+	err := r.db.WithContext(ctx).Where("child_id = ?", childID).Order("packed_at ASC").Find(&containments).Error
+	return containments, err
+}
+
+func (r *containmentRepository) GetContainerAt(ctx context.Context, childID uuid.UUID, at time.Time) (*domain.ProductContainment, error) {
+	var containment domain.ProductContainment
+	err := r.db.WithContext(ctx).Preload("Parent").
+		Where("child_id = ? AND packed_at <= ? AND (unpacked_at IS NULL OR unpacked_at > ?)", childID, at, at).
+		Order("packed_at DESC").
+		First(&containment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &containment, nil
+}
+
+func (r *containmentRepository) GetContentsAt(ctx context.Context, parentID uuid.UUID, at time.Time) ([]*domain.ProductContainment, error) {
+	var containments []*domain.ProductContainment
+	err := r.db.WithContext(ctx).Preload("Child").
+		Where("parent_id = ? AND packed_at <= ? AND (unpacked_at IS NULL OR unpacked_at > ?)", parentID, at, at).
+		Order("packed_at ASC").
+		Find(&containments).Error
+	return containments, err
+}
+
+func (r *containmentRepository) GetHistory(ctx context.Context, productID uuid.UUID) ([]*domain.ProductContainment, error) {
+	var containments []*domain.ProductContainment
+	err := r.db.WithContext(ctx).Preload("Parent").Preload("Child").
+		Where("parent_id = ? OR child_id = ?", productID, productID).
+		Order("packed_at ASC").
+		Find(&containments).Error
+	return containments, err
+}
+
+func (r *containmentRepository) GetByUnpackEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.ProductContainment, error) {
+	var containments []*domain.ProductContainment
+	err := r.db.WithContext(ctx).Preload("Child").Where("unpack_event_id = ?", eventID).Find(&containments).Error
+	return containments, err
+}
+
+func (r *containmentRepository) GetByPackEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.ProductContainment, error) {
+	var containments []*domain.ProductContainment
+	err := r.db.WithContext(ctx).Preload("Child").Where("pack_event_id = ?", eventID).Find(&containments).Error
+	return containments, err
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type custodyProjectionRepository struct {
+	db *gorm.DB
+}
+
+func NewCustodyProjectionRepository(db *gorm.DB) *custodyProjectionRepository {
+	return &custodyProjectionRepository{db: db}
+}
+
+func (r *custodyProjectionRepository) Upsert(ctx context.Context, custody *domain.ProductCustody) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}},
+		UpdateAll: true,
+	}).Create(custody).Error
+}
+
+func (r *custodyProjectionRepository) GetByProduct(ctx context.Context, productID uuid.UUID) (*domain.ProductCustody, error) {
+	var custody domain.ProductCustody
+	err := r.db.WithContext(ctx).Where("product_id = ?", productID).First(&custody).Error
+	if err != nil {
+		return nil, err
+	}
+	return &custody, nil
+}
+
+func (r *custodyProjectionRepository) GetByProducts(ctx context.Context, productIDs []uuid.UUID) ([]*domain.ProductCustody, error) {
+	var items []*domain.ProductCustody
+	if len(productIDs) == 0 {
+		return items, nil
+	}
+	err := r.db.WithContext(ctx).Where("product_id IN ?", productIDs).Find(&items).Error
+	return items, err
+}
+
+func (r *custodyProjectionRepository) List(ctx context.Context, filter *dto.InventoryFilter) ([]*domain.ProductCustody, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.ProductCustody{}).Preload("Product").Preload("Holder")
+
+	// Apply filters
+	if filter.HolderID != nil {
+		query = query.Where("holder_id = ?", *filter.HolderID)
+	}
+	if filter.Location != nil {
+		query = query.Where("location = ?", *filter.Location)
+	}
+	if filter.State != nil {
+		query = query.Where("state = ?", *filter.State)
+	}
+	if filter.ContainerID != nil {
+		query = query.Where("container_id = ?", *filter.ContainerID)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "last_event_at", id: "product_id"}, req, func(item *domain.ProductCustody) paging.Cursor {
+		return paging.Cursor{At: item.LastEventAt, ID: item.ProductID}
+	})
+}
+
+func (r *custodyProjectionRepository) DeleteAll(ctx context.Context) error {
+	return r.db.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&domain.ProductCustody{}).Error
+}
+
+func (r *custodyProjectionRepository) DeleteByProducts(ctx context.Context, productIDs []uuid.UUID) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("product_id IN ?", productIDs).Delete(&domain.ProductCustody{}).Error
+}
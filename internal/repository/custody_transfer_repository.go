@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+	"time"
+)
+
+type custodyTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewCustodyTransferRepository(db *gorm.DB) *custodyTransferRepository {
+	return &custodyTransferRepository{db: db}
+}
+
+func (r *custodyTransferRepository) Create(ctx context.Context, transfer *domain.CustodyTransfer) error {
+	return r.db.WithContext(ctx).Create(transfer).Error
+}
+
+func (r *custodyTransferRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CustodyTransfer, error) {
+	var transfer domain.CustodyTransfer
+	err := r.db.WithContext(ctx).Preload("Product").Preload("Sender").Preload("Receiver").Where("id = ?", id).First(&transfer).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// GetPendingByProduct returns the product's transfer still awaiting a response; one past its
+// expires_at is treated as expired even before the expirer marks it
+func (r *custodyTransferRepository) GetPendingByProduct(ctx context.Context, productID uuid.UUID) (*domain.CustodyTransfer, error) {
+	var transfer domain.CustodyTransfer
+	err := r.db.WithContext(ctx).
+		Where("product_id = ? AND status = ? AND expires_at > ?", productID, domain.CustodyTransferStatusPending, time.Now()).
+		First(&transfer).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// UpdateIfPending applies updates only while the transfer is still pending, so concurrent
+// accept/reject/expire calls cannot both win. It reports whether the row was updated.
+func (r *custodyTransferRepository) UpdateIfPending(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.CustodyTransfer{}).
+		Where("id = ? AND status = ?", id, domain.CustodyTransferStatusPending).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *custodyTransferRepository) List(ctx context.Context, filter *dto.CustodyTransferFilter) ([]*domain.CustodyTransfer, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.CustodyTransfer{}).Preload("Product").Preload("Sender").Preload("Receiver")
+
+	// Apply filters
+	if filter.StakeholderID != nil {
+		switch {
+		case filter.Direction != nil && *filter.Direction == "incoming":
+			query = query.Where("receiver_id = ?", *filter.StakeholderID)
+		case filter.Direction != nil && *filter.Direction == "outgoing":
+			query = query.Where("sender_id = ?", *filter.StakeholderID)
+		default:
+			query = query.Where("sender_id = ? OR receiver_id = ?", *filter.StakeholderID, *filter.StakeholderID)
+		}
+	}
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.Status != nil {
+		// Overdue pending transfers list as expired before the expirer marks them
+		now := time.Now()
+		switch *filter.Status {
+		case domain.CustodyTransferStatusPending:
+			query = query.Where("status = ? AND expires_at > ?", domain.CustodyTransferStatusPending, now)
+		case domain.CustodyTransferStatusExpired:
+			query = query.Where("(status = ? OR (status = ? AND expires_at <= ?))",
+				domain.CustodyTransferStatusExpired, domain.CustodyTransferStatusPending, now)
+		default:
+			query = query.Where("status = ?", *filter.Status)
+		}
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.CustodyTransfer) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+// ExpirePending marks every pending transfer past its expires_at as expired
+func (r *custodyTransferRepository) ExpirePending(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&domain.CustodyTransfer{}).
+		Where("status = ? AND expires_at <= ?", domain.CustodyTransferStatusPending, now).
+		Updates(map[string]interface{}{
+			"status":       domain.CustodyTransferStatusExpired,
+			"responded_at": now,
+			"updated_at":   now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// ExpirePendingByProduct expires the product's pending transfer if it is past its expires_at
+func (r *custodyTransferRepository) ExpirePendingByProduct(ctx context.Context, productID uuid.UUID, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.CustodyTransfer{}).
+		Where("product_id = ? AND status = ? AND expires_at <= ?", productID, domain.CustodyTransferStatusPending, now).
+		Updates(map[string]interface{}{
+			"status":       domain.CustodyTransferStatusExpired,
+			"responded_at": now,
+			"updated_at":   now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type excursionRepository struct {
+	db *gorm.DB
+}
+
+func NewExcursionRepository(db *gorm.DB) *excursionRepository {
+	return &excursionRepository{db: db}
+}
+
+func (r *excursionRepository) CreateRule(ctx context.Context, rule *domain.ExcursionRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *excursionRepository) GetRule(ctx context.Context, id uuid.UUID) (*domain.ExcursionRule, error) {
+	var rule domain.ExcursionRule
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *excursionRepository) UpdateRule(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.ExcursionRule{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeleteRule removes a rule and its incidents; quarantines they caused stay in place
+func (r *excursionRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.ExcursionRule{}, id).Error
+}
+
+func (r *excursionRepository) ListRules(ctx context.Context, filter *dto.ExcursionRuleFilter) ([]*domain.ExcursionRule, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.ExcursionRule{})
+
+	// Apply filters
+	if filter.Category != nil {
+		query = query.Where("LOWER(category) = LOWER(?)", *filter.Category)
+	}
+	if filter.Metric != nil {
+		query = query.Where("metric = ?", *filter.Metric)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.ExcursionRule) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+// GetActiveRules reads the active rules of the categories, which must be given in lower case
+func (r *excursionRepository) GetActiveRules(ctx context.Context, categories []string) ([]*domain.ExcursionRule, error) {
+	var rules []*domain.ExcursionRule
+	if len(categories) == 0 {
+		return rules, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("LOWER(category) IN ? AND is_active", categories).
+		Order("created_at").
+		Find(&rules).Error
+	return rules, err
+}
+
+// OpenIncident writes an incident unless the product already has an open one for the rule. It
+// reports whether the incident was written.
+func (r *excursionRepository) OpenIncident(ctx context.Context, incident *domain.ExcursionIncident) (bool, error) {
+	// The conflict target names the partial unique index idx_excursion_incidents_open, whose
+	// predicate must be spelled out for Postgres to match it
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "rule_id"}, {Name: "product_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "status = 'open'"}}},
+		DoNothing:   true,
+	}).Create(incident)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetOpenIncidents reads the open incidents of the products, locking them against concurrent
+// evaluations until the transaction ends
+func (r *excursionRepository) GetOpenIncidents(ctx context.Context, productIDs []uuid.UUID) ([]*domain.ExcursionIncident, error) {
+	var incidents []*domain.ExcursionIncident
+	if len(productIDs) == 0 {
+		return incidents, nil
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id IN ? AND status = ?", productIDs, domain.ExcursionIncidentStatusOpen).
+		Find(&incidents).Error
+	return incidents, err
+}
+
+func (r *excursionRepository) GetOpenIncident(ctx context.Context, ruleID, productID uuid.UUID) (*domain.ExcursionIncident, error) {
+	var incident domain.ExcursionIncident
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("rule_id = ? AND product_id = ? AND status = ?", ruleID, productID, domain.ExcursionIncidentStatusOpen).
+		First(&incident).Error
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+// SaveIncident writes back every column of an incident read earlier
+func (r *excursionRepository) SaveIncident(ctx context.Context, incident *domain.ExcursionIncident) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(incident).Error
+}
+
+func (r *excursionRepository) GetIncident(ctx context.Context, id uuid.UUID) (*domain.ExcursionIncident, error) {
+	var incident domain.ExcursionIncident
+	err := r.db.WithContext(ctx).Preload("Rule").Preload("Product").Where("id = ?", id).First(&incident).Error
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+// UpdateIncidentIfUnacknowledged applies updates only while nobody acknowledged the incident and
+// reports whether it did
+func (r *excursionRepository) UpdateIncidentIfUnacknowledged(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.ExcursionIncident{}).
+		Where("id = ? AND acknowledged_at IS NULL", id).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *excursionRepository) ListIncidents(ctx context.Context, filter *dto.ExcursionIncidentFilter) ([]*domain.ExcursionIncident, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.ExcursionIncident{})
+
+	// Apply filters
+	if filter.RuleID != nil {
+		query = query.Where("rule_id = ?", *filter.RuleID)
+	}
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.CustodyTransferID != nil {
+		query = query.Where("custody_transfer_id = ?", *filter.CustodyTransferID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.Breached != nil {
+		if *filter.Breached {
+			query = query.Where("breached_at IS NOT NULL")
+		} else {
+			query = query.Where("breached_at IS NULL")
+		}
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.ExcursionIncident) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+// GetLotProductIDs reads the IDs of the products carrying the lot number that the manufacturer
+// made under one of the SKUs. Lot numbers repeat across manufacturers and items, so the lot
+// number alone does not identify a lot.
+func (r *excursionRepository) GetLotProductIDs(ctx context.Context, lotNumber string, manufacturerID *uuid.UUID, skus []string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	query := r.db.WithContext(ctx).Model(&domain.Product{}).
+		Where("lot_number = ? AND sku IN ?", lotNumber, skus)
+	if manufacturerID != nil {
+		query = query.Where("manufacturer_id = ?", *manufacturerID)
+	} else {
+		query = query.Where("manufacturer_id IS NULL")
+	}
+	err := query.Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// Quarantine writes quarantines, skipping products that already have an active one, and returns
+// how many were written
+func (r *excursionRepository) Quarantine(ctx context.Context, quarantines []*domain.ProductQuarantine) (int64, error) {
+	if len(quarantines) == 0 {
+		return 0, nil
+	}
+	// Spelled-out predicate of idx_product_quarantines_active, as in OpenIncident
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "product_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "status = 'active'"}}},
+		DoNothing:   true,
+	}).CreateInBatches(quarantines, 500)
+	return result.RowsAffected, result.Error
+}
+
+func (r *excursionRepository) GetQuarantine(ctx context.Context, id uuid.UUID) (*domain.ProductQuarantine, error) {
+	var quarantine domain.ProductQuarantine
+	err := r.db.WithContext(ctx).Preload("Product").Where("id = ?", id).First(&quarantine).Error
+	if err != nil {
+		return nil, err
+	}
+	return &quarantine, nil
+}
+
+// UpdateQuarantineIfActive applies updates only while the quarantine is active and reports
+// whether it did
+func (r *excursionRepository) UpdateQuarantineIfActive(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.ProductQuarantine{}).
+		Where("id = ? AND status = ?", id, domain.QuarantineStatusActive).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *excursionRepository) ListQuarantines(ctx context.Context, filter *dto.QuarantineFilter) ([]*domain.ProductQuarantine, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.ProductQuarantine{})
+
+	// Apply filters
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.IncidentID != nil {
+		query = query.Where("incident_id = ?", *filter.IncidentID)
+	}
+	if filter.LotNumber != nil {
+		query = query.Where("product_id IN (?)", r.db.Model(&domain.Product{}).Select("id").Where("lot_number = ?", *filter.LotNumber))
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.ProductQuarantine) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+	"time"
+)
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) *importJobRepository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) Create(ctx context.Context, job *domain.ImportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *importJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *importJobRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.ImportJob{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// FailStale fails pending and running jobs without a heartbeat since before, whose process
+// stopped before finishing them
+func (r *importJobRepository) FailStale(ctx context.Context, before time.Time, reason string) (int64, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.ImportJob{}).
+		Where("status IN ? AND COALESCE(heartbeat_at, created_at) < ?",
+			[]string{domain.ImportJobStatusPending, domain.ImportJobStatusRunning}, before).
+		Updates(map[string]interface{}{
+			"status":      domain.ImportJobStatusFailed,
+			"error":       reason,
+			"finished_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *importJobRepository) List(ctx context.Context, filter *dto.ImportJobFilter) ([]*domain.ImportJob, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.ImportJob{})
+
+	// Apply filters
+	if filter.Kind != nil {
+		query = query.Where("kind = ?", *filter.Kind)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.ImportJob) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+func (r *importJobRepository) CreateErrors(ctx context.Context, rowErrors []*domain.ImportRowError) error {
+	if len(rowErrors) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(rowErrors, 500).Error
+}
+
+func (r *importJobRepository) ListErrors(ctx context.Context, jobID uuid.UUID, limit, offset int) ([]*domain.ImportRowError, int64, error) {
+	var rowErrors []*domain.ImportRowError
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.ImportRowError{}).Where("job_id = ?", jobID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("row_number ASC, created_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	err := query.Find(&rowErrors).Error
+	return rowErrors, total, err
+}
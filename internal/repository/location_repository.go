@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+	"time"
+)
+
+// withinRadius matches locations whose coordinates lie within a distance in metres of a point
+// (latitude, longitude, metres, given twice). earth_box lets the GiST index on ll_to_earth narrow
+// the rows; the box is larger than the circle, so the distance is checked as well.
+const withinRadius = "earth_box(ll_to_earth(?, ?), ?) @> ll_to_earth(latitude, longitude) AND " +
+	"earth_distance(ll_to_earth(?, ?), ll_to_earth(latitude, longitude)) <= ?"
+
+type locationRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationRepository(db *gorm.DB) *locationRepository {
+	return &locationRepository{db: db}
+}
+
+func (r *locationRepository) Create(ctx context.Context, location *domain.Location) error {
+	return r.db.WithContext(ctx).Create(location).Error
+}
+
+func (r *locationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Location, error) {
+	var location domain.Location
+	err := r.db.WithContext(ctx).Preload("Stakeholder").Where("id = ?", id).First(&location).Error
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (r *locationRepository) GetByGLN(ctx context.Context, gln string) (*domain.Location, error) {
+	var location domain.Location
+	err := r.db.WithContext(ctx).Where("gln = ?", gln).First(&location).Error
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// GetByName matches the name regardless of case
+func (r *locationRepository) GetByName(ctx context.Context, name string) (*domain.Location, error) {
+	var location domain.Location
+	err := r.db.WithContext(ctx).Where("lower(name) = lower(?)", name).First(&location).Error
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// Register returns the location called name, creating it with type other when there is none.
+// Concurrent registrations of one name end up with the same location.
+func (r *locationRepository) Register(ctx context.Context, name string) (*domain.Location, error) {
+	now := time.Now()
+	err := r.db.WithContext(ctx).Exec(`
+		INSERT INTO locations (id, name, type, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (lower(name)) DO NOTHING`, uuid.New(), name, domain.LocationTypeOther, now, now).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByName(ctx, name)
+}
+
+func (r *locationRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.Location{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *locationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.Location{}, id).Error
+}
+
+func (r *locationRepository) List(ctx context.Context, filter *dto.LocationFilter) ([]*domain.Location, *paging.Page, error) {
+	query := applyLocationFilter(r.db.WithContext(ctx).Model(&domain.Location{}), filter)
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.Location) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+// ListNearby reads the locations within near, nearest first, up to filter.Limit of them.
+// Locations without coordinates are never near anything.
+func (r *locationRepository) ListNearby(ctx context.Context, near dto.GeoRadius, filter *dto.LocationFilter) ([]*dto.NearbyLocation, error) {
+	var rows []struct {
+		domain.Location
+		DistanceKm float64
+	}
+	query := r.db.WithContext(ctx).Model(&domain.Location{}).
+		Select("locations.*, earth_distance(ll_to_earth(?, ?), ll_to_earth(latitude, longitude)) / 1000 AS distance_km", near.Latitude, near.Longitude).
+		Where(withinRadius, radiusArgs(near)...)
+	query = applyLocationFilter(query, filter)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if err := query.Order("distance_km, id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	nearby := make([]*dto.NearbyLocation, 0, len(rows))
+	for _, row := range rows {
+		location := row.Location
+		nearby = append(nearby, &dto.NearbyLocation{Location: &location, DistanceKm: row.DistanceKm})
+	}
+	return nearby, nil
+}
+
+func applyLocationFilter(query *gorm.DB, filter *dto.LocationFilter) *gorm.DB {
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+	if filter.StakeholderID != nil {
+		query = query.Where("stakeholder_id = ?", *filter.StakeholderID)
+	}
+	if filter.Name != nil {
+		query = query.Where("name ILIKE ?", "%"+*filter.Name+"%")
+	}
+	return query
+}
+
+// radiusArgs binds withinRadius for near
+func radiusArgs(near dto.GeoRadius) []interface{} {
+	metres := near.RadiusKm * 1000
+	return []interface{}{near.Latitude, near.Longitude, metres, near.Latitude, near.Longitude, metres}
+}
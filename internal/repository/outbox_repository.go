@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// outboxSequenceLock is the advisory lock that serialises numbering the outbox
+const outboxSequenceLock = 0x6f7574626f78
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *outboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Add(ctx context.Context, messages []*domain.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(messages).Error
+}
+
+// Sequence numbers up to limit committed messages that have no sequence yet and reports how many it
+// numbered. Numbering holds a lock until it commits, so a sequence only becomes visible once every
+// lower one has: a reader that has seen a number will never find a lower one appear later.
+func (r *outboxRepository) Sequence(ctx context.Context, limit int) (int, error) {
+	var numbered int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", outboxSequenceLock).Error; err != nil {
+			return err
+		}
+		result := tx.Exec(`
+			UPDATE outbox_messages m
+			SET sequence = n.sequence
+			FROM (SELECT id, nextval('outbox_messages_sequence') AS sequence
+			      FROM (SELECT id FROM outbox_messages WHERE sequence IS NULL ORDER BY created_at, id LIMIT ?) unsequenced) n
+			WHERE m.id = n.id`, limit)
+		numbered = result.RowsAffected
+		return result.Error
+	})
+	return int(numbered), err
+}
+
+// ProcessPending locks up to limit numbered, unpublished messages in sequence order and hands them to publish.
+// They are marked published when it succeeds; otherwise the failure is recorded on them and its
+// error returned. Rows stay locked while publish runs, so concurrent relays skip them, and a relay
+// that dies mid-publish leaves them to be published again.
+func (r *outboxRepository) ProcessPending(ctx context.Context, limit int, publish func(messages []*domain.OutboxMessage) error) (int, error) {
+	var processed int
+	var publishErr error
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var messages []*domain.OutboxMessage
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND sequence IS NOT NULL").
+			Order("sequence ASC").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]interface{}, 0, len(messages))
+		for _, message := range messages {
+			ids = append(ids, message.ID)
+		}
+
+		if publishErr = publish(messages); publishErr != nil {
+			return tx.Model(&domain.OutboxMessage{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": publishErr.Error(),
+			}).Error
+		}
+
+		processed = len(messages)
+		return tx.Model(&domain.OutboxMessage{}).Where("id IN ?", ids).Update("published_at", time.Now()).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return processed, publishErr
+}
+
+// ListSequenced reads messages with the given names numbered after the sequence after, in order
+func (r *outboxRepository) ListSequenced(ctx context.Context, names []string, after int64, limit int) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+	err := r.db.WithContext(ctx).
+		Where("name IN ?", names).
+		Where("sequence > ?", after).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// LastSequence is the highest sequence number handed out so far, 0 when none has been
+func (r *outboxRepository) LastSequence(ctx context.Context) (int64, error) {
+	var last int64
+	err := r.db.WithContext(ctx).Model(&domain.OutboxMessage{}).Select("COALESCE(MAX(sequence), 0)").Scan(&last).Error
+	return last, err
+}
+
+// LockConsumer returns a consumer's position, starting it at the end of the log when it is new, and
+// locks it until the transaction ends so one instance of the consumer reads at a time
+func (r *outboxRepository) LockConsumer(ctx context.Context, name string) (int64, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Exec(`
+		INSERT INTO outbox_consumers (name, position)
+		SELECT ?, COALESCE(MAX(sequence), 0) FROM outbox_messages
+		ON CONFLICT (name) DO NOTHING`, name).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var consumer domain.OutboxConsumer
+	err = db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(&consumer).Error
+	return consumer.Position, err
+}
+
+func (r *outboxRepository) SetConsumerPosition(ctx context.Context, name string, position int64) error {
+	return r.db.WithContext(ctx).Model(&domain.OutboxConsumer{}).Where("name = ?", name).Updates(map[string]interface{}{
+		"position":   position,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// DeletePublishedBefore prunes messages published before cutoff
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("published_at < ?", cutoff).Delete(&domain.OutboxMessage{})
+	return result.RowsAffected, result.Error
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
 	"gorm.io/gorm"
 )
 
@@ -29,6 +31,12 @@ func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return &product, nil
 }
 
+func (r *productRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Product, error) {
+	var products []*domain.Product
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&products).Error
+	return products, err
+}
+
 func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*domain.Product, error) {
 	var product domain.Product
 	err := r.db.WithContext(ctx).Preload("Manufacturer").Where("sku = ?", sku).First(&product).Error
@@ -46,13 +54,21 @@ func (r *productRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&domain.Product{}, id).Error
 }
 
-func (r *productRepository) List(ctx context.Context, filter *dto.ProductFilter) ([]*domain.Product, int64, error) {
-	var products []*domain.Product
-	var total int64
-
-	query := r.db.WithContext(ctx).Model(&domain.Product{}).Preload("Manufacturer")
+func (r *productRepository) List(ctx context.Context, filter *dto.ProductFilter) ([]*domain.Product, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Product{})
+	query = applyIncludes(query, listquery.Products, filter.Query.Include)
 
 	// Apply filters
+	query = applyProductFilter(query, filter)
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}.sortedBy(filter.Query.Sort), req, func(item *domain.Product) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+func applyProductFilter(query *gorm.DB, filter *dto.ProductFilter) *gorm.DB {
 	if filter.Category != nil {
 		query = query.Where("category = ?", *filter.Category)
 	}
@@ -65,22 +81,38 @@ func (r *productRepository) List(ctx context.Context, filter *dto.ProductFilter)
 	if filter.Name != nil {
 		query = query.Where("name ILIKE ?", "%"+*filter.Name+"%")
 	}
-
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if filter.LotNumber != nil {
+		query = query.Where("lot_number = ?", *filter.LotNumber)
 	}
+	return applyPredicates(query, filter.Query.Filters)
+}
 
-	// Apply pagination
-	if filter.Limit > 0 {
-		query = query.Limit(filter.Limit)
-	}
-	if filter.Offset > 0 {
-		query = query.Offset(filter.Offset)
+// EachFiltered walks every product matching filter in id order, batchSize at a time, ignoring Limit and Offset
+func (r *productRepository) EachFiltered(ctx context.Context, filter *dto.ProductFilter, batchSize int, fn func(products []*domain.Product) error) error {
+	var lastID *uuid.UUID
+
+	for {
+		var products []*domain.Product
+		query := applyProductFilter(r.db.WithContext(ctx).Model(&domain.Product{}), filter)
+		if lastID != nil {
+			query = query.Where("id > ?", *lastID)
+		}
+		if err := query.Order("id ASC").Limit(batchSize).Find(&products).Error; err != nil {
+			return err
+		}
+		if len(products) == 0 {
+			return nil
+		}
+
+		if err := fn(products); err != nil {
+			return err
+		}
+
+		lastID = &products[len(products)-1].ID
+		if len(products) < batchSize {
+			return nil
+		}
 	}
-
-	err := query.Find(&products).Error
-	return products, total, err
 }
 
 func (r *productRepository) GetStats(ctx context.Context, id uuid.UUID) (*dto.ProductStats, error) {
@@ -93,12 +125,22 @@ func (r *productRepository) GetStats(ctx context.Context, id uuid.UUID) (*dto.Pr
 	// Get verified events
 	r.db.WithContext(ctx).Model(&domain.SupplyChainEvent{}).Where("product_id = ? AND is_verified = ?", id, true).Count(&stats.VerifiedEvents)
 
-	// Get last event for location and stakeholder
+	// Current location, holder and state come from the maintained custody projection
+	var custody domain.ProductCustody
+	if err := r.db.WithContext(ctx).Where("product_id = ?", id).First(&custody).Error; err == nil {
+		stats.CurrentLocation = custody.Location
+		stats.CurrentHolder = custody.HolderID
+		stats.CurrentState = &custody.State
+		stats.LastActivity = custody.LastEventAt
+	}
+
+	// Get last stakeholder that recorded an event on the product itself
 	var lastEvent domain.SupplyChainEvent
-	if err := r.db.WithContext(ctx).Where("product_id = ?", id).Order("timestamp DESC").First(&lastEvent).Error; err == nil {
-		stats.CurrentLocation = lastEvent.Location
+	if err := r.db.WithContext(ctx).Select("stakeholder_id", "timestamp").Where("product_id = ?", id).Order("timestamp DESC").First(&lastEvent).Error; err == nil {
 		stats.LastStakeholder = lastEvent.StakeholderID
-		stats.LastActivity = lastEvent.Timestamp
+		if lastEvent.Timestamp.After(stats.LastActivity) {
+			stats.LastActivity = lastEvent.Timestamp
+		}
 	}
 
 	return &stats, nil
@@ -109,3 +151,11 @@ func (r *productRepository) GetByManufacturer(ctx context.Context, manufacturerI
 	err := r.db.WithContext(ctx).Preload("Manufacturer").Where("manufacturer_id = ?", manufacturerID).Find(&products).Error
 	return products, err
 }
+
+// GetByManufacturers loads up to perManufacturer of each manufacturer's newest products, without relations
+func (r *productRepository) GetByManufacturers(ctx context.Context, manufacturerIDs []uuid.UUID, perManufacturer int) ([]*domain.Product, error) {
+	var products []*domain.Product
+	err := firstPerParent(r.db.WithContext(ctx), &domain.Product{}, "manufacturer_id", manufacturerIDs, "created_at DESC, id ASC", perManufacturer).
+		Find(&products).Error
+	return products, err
+}
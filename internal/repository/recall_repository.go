@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+)
+
+type recallRepository struct {
+	db *gorm.DB
+}
+
+func NewRecallRepository(db *gorm.DB) *recallRepository {
+	return &recallRepository{db: db}
+}
+
+func (r *recallRepository) Create(ctx context.Context, recall *domain.Recall) error {
+	return r.db.WithContext(ctx).Create(recall).Error
+}
+
+func (r *recallRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Recall, error) {
+	var recall domain.Recall
+	err := r.db.WithContext(ctx).Preload("Initiator").Preload("Product").Where("id = ?", id).First(&recall).Error
+	if err != nil {
+		return nil, err
+	}
+	return &recall, nil
+}
+
+func (r *recallRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.Recall{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// UpdateIfStatus applies updates only while the recall is still in the given status,
+// so two concurrent activations cannot both win. It reports whether the row was updated.
+func (r *recallRepository) UpdateIfStatus(ctx context.Context, id uuid.UUID, status string, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.Recall{}).
+		Where("id = ? AND status = ?", id, status).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *recallRepository) List(ctx context.Context, filter *dto.RecallFilter) ([]*domain.Recall, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Recall{}).Preload("Initiator").Preload("Product")
+
+	// Apply filters
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.Severity != nil {
+		query = query.Where("severity = ?", *filter.Severity)
+	}
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ? OR id IN (SELECT recall_id FROM recall_units WHERE product_id = ?)", *filter.ProductID, *filter.ProductID)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.Recall) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+// FindScopedProducts returns the products matching every scope criterion set on the recall.
+// A lot matches the product's own lot or a transformation output recorded with that lot; the
+// manufacture window matches manufactured events and transformations that produced the product.
+func (r *recallRepository) FindScopedProducts(ctx context.Context, recall *domain.Recall) ([]uuid.UUID, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Product{})
+
+	if recall.ProductID != nil {
+		query = query.Where("id = ?", *recall.ProductID)
+	}
+	if recall.LotNumber != nil {
+		query = query.Where("lot_number = ? OR id IN (SELECT product_id FROM transformation_lines WHERE direction = ? AND lot_number = ?)",
+			*recall.LotNumber, domain.TransformationDirectionOutput, *recall.LotNumber)
+	}
+
+	// Serials are compared by length first so unpadded numeric serials order naturally ("9" < "10")
+	if recall.SerialFrom != nil {
+		query = query.Where("serial_number IS NOT NULL AND (LENGTH(serial_number), serial_number) >= (LENGTH(?), ?)", *recall.SerialFrom, *recall.SerialFrom)
+	}
+	if recall.SerialTo != nil {
+		query = query.Where("serial_number IS NOT NULL AND (LENGTH(serial_number), serial_number) <= (LENGTH(?), ?)", *recall.SerialTo, *recall.SerialTo)
+	}
+
+	if recall.ManufacturedFrom != nil || recall.ManufacturedTo != nil {
+		made := r.db.Table("supply_chain_events").Select("product_id").
+			Where("event_type = ? AND product_id IS NOT NULL", domain.EventTypeManufactured)
+		transformed := r.db.Table("transformation_lines").Select("transformation_lines.product_id").
+			Joins("JOIN supply_chain_events ON supply_chain_events.id = transformation_lines.event_id").
+			Where("transformation_lines.direction = ?", domain.TransformationDirectionOutput)
+		if recall.ManufacturedFrom != nil {
+			made = made.Where("timestamp >= ?", *recall.ManufacturedFrom)
+			transformed = transformed.Where("supply_chain_events.timestamp >= ?", *recall.ManufacturedFrom)
+		}
+		if recall.ManufacturedTo != nil {
+			made = made.Where("timestamp <= ?", *recall.ManufacturedTo)
+			transformed = transformed.Where("supply_chain_events.timestamp <= ?", *recall.ManufacturedTo)
+		}
+		query = query.Where("id IN (?) OR id IN (?)", made, transformed)
+	}
+
+	var ids []uuid.UUID
+	err := query.Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *recallRepository) CreateUnits(ctx context.Context, units []*domain.RecallUnit) error {
+	if len(units) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(units, 500).Error
+}
+
+func (r *recallRepository) ListUnits(ctx context.Context, recallID uuid.UUID, filter *dto.RecallUnitFilter) ([]*domain.RecallUnit, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.RecallUnit{}).Preload("Product").Preload("Holder").Where("recall_id = ?", recallID)
+
+	// Apply filters
+	if filter.HolderID != nil {
+		query = query.Where("holder_id = ?", *filter.HolderID)
+	}
+	if filter.Source != nil {
+		query = query.Where("source = ?", *filter.Source)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id", ascending: true}, req, func(item *domain.RecallUnit) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+func (r *recallRepository) CountUnits(ctx context.Context, recallID uuid.UUID) ([]*dto.RecallUnitCount, error) {
+	var counts []*dto.RecallUnitCount
+	err := r.db.WithContext(ctx).Model(&domain.RecallUnit{}).
+		Select("source, COALESCE(state, '') AS state, holder_id IS NOT NULL AS has_holder, COUNT(*) AS count").
+		Where("recall_id = ?", recallID).
+		Group("source, COALESCE(state, ''), holder_id IS NOT NULL").
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *recallRepository) CreateNotifications(ctx context.Context, notifications []*domain.RecallNotification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(notifications).Error
+}
+
+func (r *recallRepository) UpdateNotification(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.RecallNotification{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *recallRepository) GetNotification(ctx context.Context, recallID, stakeholderID uuid.UUID) (*domain.RecallNotification, error) {
+	var notification domain.RecallNotification
+	err := r.db.WithContext(ctx).Preload("Stakeholder").
+		Where("recall_id = ? AND stakeholder_id = ?", recallID, stakeholderID).
+		First(&notification).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *recallRepository) ListNotifications(ctx context.Context, recallID uuid.UUID) ([]*domain.RecallNotification, error) {
+	var notifications []*domain.RecallNotification
+	err := r.db.WithContext(ctx).Preload("Stakeholder").Where("recall_id = ?", recallID).Order("unit_count DESC, created_at ASC").Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *recallRepository) ListNotificationsByStakeholder(ctx context.Context, stakeholderID uuid.UUID, status *string) ([]*domain.RecallNotification, error) {
+	var notifications []*domain.RecallNotification
+	query := r.db.WithContext(ctx).Preload("Recall").Where("stakeholder_id = ?", stakeholderID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	err := query.Order("created_at DESC").Find(&notifications).Error
+	return notifications, err
+}
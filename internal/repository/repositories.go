@@ -2,48 +2,128 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
 )
 
 type RepositoriesManagers struct {
+	db                    *gorm.DB
+	afterCommit           *[]func() // set inside a transaction, see AfterCommit
 	Stakeholder           StakeholderRepository
 	Product               ProductRepository
 	SupplyChainEvent      SupplyChainEventRepository
 	BlockchainTransaction BlockchainTransactionRepository
+	Containment           ContainmentRepository
+	Transformation        TransformationRepository
+	CustodyTransfer       CustodyTransferRepository
+	CustodyProjection     CustodyProjectionRepository
+	Recall                RecallRepository
+	ImportJob             ImportJobRepository
+	Webhook               WebhookRepository
+	Outbox                OutboxRepository
+	Search                SearchRepository
+	Location              LocationRepository
+	Route                 RouteRepository
+	Telemetry             TelemetryRepository
+	Excursion             ExcursionRepository
+	Analytics             AnalyticsRepository
+	Scorecard             ScorecardRepository
 }
 
 func NewRepositories(db *gorm.DB) *RepositoriesManagers {
 	return &RepositoriesManagers{
+		db:                    db,
 		Stakeholder:           NewStakeholderRepository(db),
 		Product:               NewProductRepository(db),
 		SupplyChainEvent:      NewSupplyChainEventRepository(db),
 		BlockchainTransaction: NewBlockchainTransactionRepository(db),
+		Containment:           NewContainmentRepository(db),
+		Transformation:        NewTransformationRepository(db),
+		CustodyTransfer:       NewCustodyTransferRepository(db),
+		CustodyProjection:     NewCustodyProjectionRepository(db),
+		Recall:                NewRecallRepository(db),
+		ImportJob:             NewImportJobRepository(db),
+		Webhook:               NewWebhookRepository(db),
+		Outbox:                NewOutboxRepository(db),
+		Search:                NewSearchRepository(db),
+		Location:              NewLocationRepository(db),
+		Route:                 NewRouteRepository(db),
+		Telemetry:             NewTelemetryRepository(db),
+		Excursion:             NewExcursionRepository(db),
+		Analytics:             NewAnalyticsRepository(db),
+		Scorecard:             NewScorecardRepository(db),
 	}
 }
 
+// Transactor runs fn with repositories bound to a single database transaction.
+// The transaction is committed when fn returns nil and rolled back otherwise.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(repos *RepositoriesManagers) error) error
+}
+
+func (m *RepositoriesManagers) WithinTransaction(ctx context.Context, fn func(repos *RepositoriesManagers) error) error {
+	// A nested transaction is a savepoint: its callbacks wait for the outermost commit
+	pending, outermost := m.afterCommit, m.afterCommit == nil
+	if outermost {
+		pending = &[]func(){}
+	}
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repos := NewRepositories(tx)
+		repos.afterCommit = pending
+		return fn(repos)
+	})
+	if err == nil && outermost {
+		for _, callback := range *pending {
+			callback()
+		}
+	}
+	return err
+}
+
+// AfterCommit runs fn once the transaction the repositories are bound to commits, and never if it
+// rolls back. Outside a transaction fn runs right away.
+func (m *RepositoriesManagers) AfterCommit(fn func()) {
+	if m.afterCommit == nil {
+		fn()
+		return
+	}
+	*m.afterCommit = append(*m.afterCommit, fn)
+}
+
 type StakeholderRepository interface {
 	Create(ctx context.Context, stakeholder *domain.Stakeholder) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Stakeholder, error)
 	GetByEmail(ctx context.Context, email string) (*domain.Stakeholder, error)
 	GetByWalletAddress(ctx context.Context, address string) (*domain.Stakeholder, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Stakeholder, error)
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, filter *dto.StakeholderFilter) ([]*domain.Stakeholder, int64, error)
+	List(ctx context.Context, filter *dto.StakeholderFilter) ([]*domain.Stakeholder, *paging.Page, error)
 	GetStats(ctx context.Context, id uuid.UUID) (*dto.StakeholderStats, error)
 }
 
 type ProductRepository interface {
 	Create(ctx context.Context, product *domain.Product) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Product, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Product, error)
 	GetBySKU(ctx context.Context, sku string) (*domain.Product, error)
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, filter *dto.ProductFilter) ([]*domain.Product, int64, error)
+	List(ctx context.Context, filter *dto.ProductFilter) ([]*domain.Product, *paging.Page, error)
 	GetStats(ctx context.Context, id uuid.UUID) (*dto.ProductStats, error)
 	GetByManufacturer(ctx context.Context, manufacturerID uuid.UUID) ([]*domain.Product, error)
+	GetByManufacturers(ctx context.Context, manufacturerIDs []uuid.UUID, perManufacturer int) ([]*domain.Product, error)
+	EachFiltered(ctx context.Context, filter *dto.ProductFilter, batchSize int, fn func(products []*domain.Product) error) error
 }
 
 type SupplyChainEventRepository interface {
@@ -51,11 +131,20 @@ type SupplyChainEventRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.SupplyChainEvent, error)
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, filter *dto.SupplyChainEventFilter) ([]*domain.SupplyChainEvent, int64, error)
+	List(ctx context.Context, filter *dto.SupplyChainEventFilter) ([]*domain.SupplyChainEvent, *paging.Page, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.SupplyChainEvent, error)
 	GetByProduct(ctx context.Context, productID uuid.UUID) ([]*domain.SupplyChainEvent, error)
+	GetByProducts(ctx context.Context, productIDs []uuid.UUID, perProduct int) ([]*domain.SupplyChainEvent, error)
+	GetByProductBetween(ctx context.Context, productID uuid.UUID, from time.Time, to *time.Time) ([]*domain.SupplyChainEvent, error)
 	GetByStakeholder(ctx context.Context, stakeholderID uuid.UUID) ([]*domain.SupplyChainEvent, error)
+	GetByStakeholders(ctx context.Context, stakeholderIDs []uuid.UUID, perStakeholder int) ([]*domain.SupplyChainEvent, error)
+	GetByMetadataValue(ctx context.Context, key, value string) ([]*domain.SupplyChainEvent, error)
 	GetTrace(ctx context.Context, productID uuid.UUID) (*dto.SupplyChainTrace, error)
 	VerifyEvent(ctx context.Context, id uuid.UUID, blockchainHash string) error
+	EachInOrder(ctx context.Context, batchSize int, fn func(events []*domain.SupplyChainEvent) error) error
+	EachInRange(ctx context.Context, from, to *time.Time, batchSize int, fn func(events []*domain.SupplyChainEvent) error) error
+	EachForProducts(ctx context.Context, productIDs []uuid.UUID, batchSize int, fn func(events []*domain.SupplyChainEvent) error) error
+	EachFiltered(ctx context.Context, filter *dto.SupplyChainEventFilter, batchSize int, fn func(events []*domain.SupplyChainEvent) error) error
 }
 
 type BlockchainTransactionRepository interface {
@@ -64,7 +153,410 @@ type BlockchainTransactionRepository interface {
 	GetByTransactionHash(ctx context.Context, hash string) (*domain.BlockchainTransaction, error)
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, filter *dto.BlockchainTransactionFilter) ([]*domain.BlockchainTransaction, int64, error)
+	List(ctx context.Context, filter *dto.BlockchainTransactionFilter) ([]*domain.BlockchainTransaction, *paging.Page, error)
 	GetByEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.BlockchainTransaction, error)
+	GetByEvents(ctx context.Context, eventIDs []uuid.UUID, perEvent int) ([]*domain.BlockchainTransaction, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string, blockNumber *int64) error
 }
+
+type ContainmentRepository interface {
+	Create(ctx context.Context, containment *domain.ProductContainment) error
+	Unpack(ctx context.Context, parentID uuid.UUID, childIDs []uuid.UUID, eventID uuid.UUID, at time.Time) (int64, error)
+	GetActiveByChild(ctx context.Context, childID uuid.UUID) (*domain.ProductContainment, error)
+	GetActiveChildren(ctx context.Context, parentID uuid.UUID) ([]*domain.ProductContainment, error)
+	GetByChild(ctx context.Context, childID uuid.UUID) ([]*domain.ProductContainment, error)
+	GetContainerAt(ctx context.Context, childID uuid.UUID, at time.Time) (*domain.ProductContainment, error)
+	GetContentsAt(ctx context.Context, parentID uuid.UUID, at time.Time) ([]*domain.ProductContainment, error)
+	GetHistory(ctx context.Context, productID uuid.UUID) ([]*domain.ProductContainment, error)
+	GetByUnpackEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.ProductContainment, error)
+	GetByPackEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.ProductContainment, error)
+}
+
+type TransformationRepository interface {
+	CreateLines(ctx context.Context, lines []*domain.TransformationLine) error
+	GetByEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.TransformationLine, error)
+	GetProducingEvents(ctx context.Context, productID uuid.UUID) ([]*domain.SupplyChainEvent, error)
+	GetUpstream(ctx context.Context, productIDs []uuid.UUID) ([]*dto.GenealogyEdge, error)
+	GetDownstream(ctx context.Context, productIDs []uuid.UUID) ([]*dto.GenealogyEdge, error)
+	GetUpstreamOfLot(ctx context.Context, lot string, productID *uuid.UUID) ([]*dto.GenealogyEdge, error)
+	GetDownstreamOfLot(ctx context.Context, lot string, productID *uuid.UUID) ([]*dto.GenealogyEdge, error)
+	GetUpstreamOfLots(ctx context.Context, lots []dto.ProductLot) ([]*dto.GenealogyEdge, error)
+	GetDownstreamOfLots(ctx context.Context, lots []dto.ProductLot) ([]*dto.GenealogyEdge, error)
+}
+
+type CustodyTransferRepository interface {
+	Create(ctx context.Context, transfer *domain.CustodyTransfer) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.CustodyTransfer, error)
+	GetPendingByProduct(ctx context.Context, productID uuid.UUID) (*domain.CustodyTransfer, error)
+	UpdateIfPending(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (bool, error)
+	List(ctx context.Context, filter *dto.CustodyTransferFilter) ([]*domain.CustodyTransfer, *paging.Page, error)
+	ExpirePending(ctx context.Context, now time.Time) (int64, error)
+	ExpirePendingByProduct(ctx context.Context, productID uuid.UUID, now time.Time) (bool, error)
+}
+
+type CustodyProjectionRepository interface {
+	Upsert(ctx context.Context, custody *domain.ProductCustody) error
+	GetByProduct(ctx context.Context, productID uuid.UUID) (*domain.ProductCustody, error)
+	GetByProducts(ctx context.Context, productIDs []uuid.UUID) ([]*domain.ProductCustody, error)
+	List(ctx context.Context, filter *dto.InventoryFilter) ([]*domain.ProductCustody, *paging.Page, error)
+	DeleteAll(ctx context.Context) error
+	DeleteByProducts(ctx context.Context, productIDs []uuid.UUID) error
+}
+
+type RecallRepository interface {
+	Create(ctx context.Context, recall *domain.Recall) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Recall, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	UpdateIfStatus(ctx context.Context, id uuid.UUID, status string, updates map[string]interface{}) (bool, error)
+	List(ctx context.Context, filter *dto.RecallFilter) ([]*domain.Recall, *paging.Page, error)
+	FindScopedProducts(ctx context.Context, recall *domain.Recall) ([]uuid.UUID, error)
+	CreateUnits(ctx context.Context, units []*domain.RecallUnit) error
+	ListUnits(ctx context.Context, recallID uuid.UUID, filter *dto.RecallUnitFilter) ([]*domain.RecallUnit, *paging.Page, error)
+	CountUnits(ctx context.Context, recallID uuid.UUID) ([]*dto.RecallUnitCount, error)
+	CreateNotifications(ctx context.Context, notifications []*domain.RecallNotification) error
+	UpdateNotification(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	GetNotification(ctx context.Context, recallID, stakeholderID uuid.UUID) (*domain.RecallNotification, error)
+	ListNotifications(ctx context.Context, recallID uuid.UUID) ([]*domain.RecallNotification, error)
+	ListNotificationsByStakeholder(ctx context.Context, stakeholderID uuid.UUID, status *string) ([]*domain.RecallNotification, error)
+}
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ImportJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ImportJob, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	FailStale(ctx context.Context, before time.Time, reason string) (int64, error)
+	List(ctx context.Context, filter *dto.ImportJobFilter) ([]*domain.ImportJob, *paging.Page, error)
+	CreateErrors(ctx context.Context, rowErrors []*domain.ImportRowError) error
+	ListErrors(ctx context.Context, jobID uuid.UUID, limit, offset int) ([]*domain.ImportRowError, int64, error)
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	ListSubscriptions(ctx context.Context, filter *dto.WebhookSubscriptionFilter) ([]*domain.WebhookSubscription, *paging.Page, error)
+	GetActiveByStakeholders(ctx context.Context, stakeholderIDs []uuid.UUID) ([]*domain.WebhookSubscription, error)
+	RecordFailure(ctx context.Context, id uuid.UUID) (int, error)
+	CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	ListDeliveries(ctx context.Context, filter *dto.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, *paging.Page, error)
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error)
+}
+
+type OutboxRepository interface {
+	Add(ctx context.Context, messages []*domain.OutboxMessage) error
+	Sequence(ctx context.Context, limit int) (int, error)
+	ProcessPending(ctx context.Context, limit int, publish func(messages []*domain.OutboxMessage) error) (int, error)
+	ListSequenced(ctx context.Context, names []string, after int64, limit int) ([]*domain.OutboxMessage, error)
+	LastSequence(ctx context.Context) (int64, error)
+	LockConsumer(ctx context.Context, name string) (int64, error)
+	SetConsumerPosition(ctx context.Context, name string, position int64) error
+	DeletePublishedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type SearchRepository interface {
+	SearchProducts(ctx context.Context, query string, limit int) ([]*dto.ProductHit, error)
+	SearchStakeholders(ctx context.Context, query string, limit int) ([]*dto.StakeholderHit, error)
+	SearchLocations(ctx context.Context, query string, limit int) ([]*dto.LocationHit, error)
+}
+
+type LocationRepository interface {
+	Create(ctx context.Context, location *domain.Location) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Location, error)
+	GetByGLN(ctx context.Context, gln string) (*domain.Location, error)
+	GetByName(ctx context.Context, name string) (*domain.Location, error)
+	Register(ctx context.Context, name string) (*domain.Location, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter *dto.LocationFilter) ([]*domain.Location, *paging.Page, error)
+	ListNearby(ctx context.Context, near dto.GeoRadius, filter *dto.LocationFilter) ([]*dto.NearbyLocation, error)
+}
+
+// RouteRepository keeps the route plans of shipments and the alerts raised against them
+type RouteRepository interface {
+	SavePlan(ctx context.Context, plan *domain.RoutePlan) error
+	GetPlanByTransfer(ctx context.Context, transferID uuid.UUID) (*domain.RoutePlan, error)
+	DeletePlan(ctx context.Context, transferID uuid.UUID) error
+	GetActivePlans(ctx context.Context, productID uuid.UUID, receiveEventID *uuid.UUID) ([]*domain.RoutePlan, error)
+	RaiseAlert(ctx context.Context, alert *domain.RouteAlert) (bool, error)
+	GetAlert(ctx context.Context, id uuid.UUID) (*domain.RouteAlert, error)
+	UpdateAlertIfOpen(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (bool, error)
+	ListAlerts(ctx context.Context, filter *dto.RouteAlertFilter) ([]*domain.RouteAlert, *paging.Page, error)
+}
+
+// TelemetryRepository keeps sensor devices and the time series of their readings
+type TelemetryRepository interface {
+	CreateDevice(ctx context.Context, device *domain.SensorDevice) error
+	GetDeviceByID(ctx context.Context, id uuid.UUID) (*domain.SensorDevice, error)
+	GetDeviceBySerial(ctx context.Context, serial string) (*domain.SensorDevice, error)
+	GetDevices(ctx context.Context, ids []uuid.UUID, serials []string) ([]*domain.SensorDevice, error)
+	UpdateDevice(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteDevice(ctx context.Context, id uuid.UUID) error
+	ListDevices(ctx context.Context, filter *dto.SensorDeviceFilter) ([]*domain.SensorDevice, *paging.Page, error)
+	InsertReadings(ctx context.Context, readings []*domain.TelemetryReading) ([]*domain.TelemetryReading, error)
+	TouchDevice(ctx context.Context, id uuid.UUID, at time.Time) error
+	ListReadings(ctx context.Context, query *dto.TelemetryQuery) ([]*domain.TelemetryReading, error)
+	Downsample(ctx context.Context, query *dto.TelemetryQuery) ([]*dto.TelemetryBucket, error)
+	GetProductSpan(ctx context.Context, productID uuid.UUID) (*time.Time, *time.Time, error)
+}
+
+// ExcursionRepository keeps the excursion rules of product categories, the incidents they raise
+// and the quarantines breached rules put products in
+type ExcursionRepository interface {
+	CreateRule(ctx context.Context, rule *domain.ExcursionRule) error
+	GetRule(ctx context.Context, id uuid.UUID) (*domain.ExcursionRule, error)
+	UpdateRule(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	ListRules(ctx context.Context, filter *dto.ExcursionRuleFilter) ([]*domain.ExcursionRule, *paging.Page, error)
+	GetActiveRules(ctx context.Context, categories []string) ([]*domain.ExcursionRule, error)
+	OpenIncident(ctx context.Context, incident *domain.ExcursionIncident) (bool, error)
+	GetOpenIncidents(ctx context.Context, productIDs []uuid.UUID) ([]*domain.ExcursionIncident, error)
+	GetOpenIncident(ctx context.Context, ruleID, productID uuid.UUID) (*domain.ExcursionIncident, error)
+	SaveIncident(ctx context.Context, incident *domain.ExcursionIncident) error
+	GetIncident(ctx context.Context, id uuid.UUID) (*domain.ExcursionIncident, error)
+	UpdateIncidentIfUnacknowledged(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (bool, error)
+	ListIncidents(ctx context.Context, filter *dto.ExcursionIncidentFilter) ([]*domain.ExcursionIncident, *paging.Page, error)
+	GetLotProductIDs(ctx context.Context, lotNumber string, manufacturerID *uuid.UUID, skus []string) ([]uuid.UUID, error)
+	Quarantine(ctx context.Context, quarantines []*domain.ProductQuarantine) (int64, error)
+	GetQuarantine(ctx context.Context, id uuid.UUID) (*domain.ProductQuarantine, error)
+	UpdateQuarantineIfActive(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (bool, error)
+	ListQuarantines(ctx context.Context, filter *dto.QuarantineFilter) ([]*domain.ProductQuarantine, *paging.Page, error)
+}
+
+// AnalyticsRepository keeps the transit legs, dwells and throughput derived from events, and the
+// queue of products and stakeholder days whose figures are stale
+type AnalyticsRepository interface {
+	MarkStale(ctx context.Context, eventID uuid.UUID) error
+	ClaimStaleProducts(ctx context.Context, limit int) ([]uuid.UUID, error)
+	ClaimStaleDays(ctx context.Context, limit int) ([]*dto.StakeholderDay, error)
+	RebuildProducts(ctx context.Context, productIDs []uuid.UUID) error
+	RebuildThroughput(ctx context.Context, days []*dto.StakeholderDay) error
+	GetBacklog(ctx context.Context) (*dto.AnalyticsBacklog, error)
+	ListLegs(ctx context.Context, filter *dto.TransitLegFilter) ([]*domain.TransitLeg, *paging.Page, error)
+	ListDwells(ctx context.Context, filter *dto.DwellFilter) ([]*domain.ProductDwell, *paging.Page, error)
+	TransitStats(ctx context.Context, query *dto.AnalyticsQuery) ([]*dto.TransitStats, error)
+	DwellStats(ctx context.Context, query *dto.AnalyticsQuery) ([]*dto.DwellStats, error)
+	CountOpenDwells(ctx context.Context, query *dto.AnalyticsQuery) (int64, error)
+	Throughput(ctx context.Context, query *dto.AnalyticsQuery) ([]*dto.ThroughputPoint, error)
+	ThroughputTotals(ctx context.Context, from, to time.Time) (map[string]int64, error)
+	GetOverview(ctx context.Context, from, to time.Time) (*dto.AnalyticsDashboard, error)
+}
+
+// ScorecardRepository measures stakeholder performance from their transfers, events and dwells
+type ScorecardRepository interface {
+	Metrics(ctx context.Context, query *dto.ScorecardQuery) ([]*dto.ScorecardMetrics, error)
+}
+
+// firstPerParent limits a batched child query to the first n rows of each parent, in order, so
+// one query can serve many parents without loading their whole history
+func firstPerParent(db *gorm.DB, model interface{}, parentColumn string, parentIDs []uuid.UUID, order string, n int) *gorm.DB {
+	ranked := db.Model(model).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY "+parentColumn+" ORDER BY "+order+") AS parent_rank").
+		Where(parentColumn+" IN ?", parentIDs)
+	return db.Table("(?) AS ranked", ranked).Where("parent_rank <= ?", n).Order(parentColumn + ", " + order)
+}
+
+// keyset is the sort key a list pages on: a timestamp column followed by a unique column
+type keyset struct {
+	at, id    string
+	ascending bool             // newest first unless set
+	sort      []listquery.Sort // a requested order other than the keyset's; pages by offset only
+}
+
+// sortedBy orders the list as requested. Sorting on the keyset's own column only changes its
+// direction, so cursors keep working; any other order pages by offset.
+func (k keyset) sortedBy(sort []listquery.Sort) keyset {
+	switch {
+	case len(sort) == 1 && sort[0].Field == k.at:
+		k.ascending = !sort[0].Desc
+	case len(sort) > 0:
+		k.sort = sort
+	}
+	return k
+}
+
+// listPage reads a page of query ordered by key, after req.Cursor or else from req.Offset, counting
+// the rows beforehand as req asks. It reads one row past the limit to tell whether more follow.
+func listPage[T any](query *gorm.DB, key keyset, req paging.Request, cursorOf func(T) paging.Cursor) ([]T, *paging.Page, error) {
+	page := &paging.Page{}
+	switch req.CountMode() {
+	case paging.CountExact:
+		// Count on its own session: it rewrites the statement's SELECT, which the page read shares
+		var total int64
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, nil, err
+		}
+		page.Total = &total
+	case paging.CountEstimated:
+		total, err := estimateCount(query)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Total, page.TotalEstimated = &total, true
+	}
+
+	direction, after := "DESC", "<"
+	if key.ascending {
+		direction, after = "ASC", ">"
+	}
+	if len(key.sort) > 0 {
+		if req.Cursor != nil {
+			return nil, nil, fmt.Errorf("%w: a cursor follows the default order; page a sorted list by offset", listquery.ErrInvalidQuery)
+		}
+		for _, by := range key.sort {
+			query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: by.Field}, Desc: by.Desc})
+		}
+		query = query.Order(key.id + " " + direction)
+	} else {
+		query = query.Order(key.at + " " + direction + ", " + key.id + " " + direction)
+	}
+	if req.Cursor != nil {
+		query = query.Where("("+key.at+", "+key.id+") "+after+" (?, ?)", req.Cursor.At, req.Cursor.ID)
+	} else if req.Offset > 0 {
+		query = query.Offset(req.Offset)
+	}
+	if req.Limit > 0 {
+		query = query.Limit(req.Limit + 1)
+	}
+
+	var items []T
+	if err := query.Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+	if req.Limit > 0 && len(items) > req.Limit {
+		items = items[:req.Limit]
+		page.HasMore = true
+		if len(key.sort) == 0 {
+			next := cursorOf(items[len(items)-1])
+			page.NextCursor = &next
+		}
+	}
+	return items, page, nil
+}
+
+// applyPredicates adds the list query's filters. Field names and JSON keys were checked by
+// listquery; values are always bound.
+func applyPredicates(query *gorm.DB, predicates []listquery.Predicate) *gorm.DB {
+	for _, p := range predicates {
+		if p.Path != nil {
+			query = applyJSONPredicate(query, p)
+		} else {
+			query = applyColumnPredicate(query, p)
+		}
+	}
+	return query
+}
+
+func applyColumnPredicate(query *gorm.DB, p listquery.Predicate) *gorm.DB {
+	column := p.Field
+	switch p.Op {
+	case listquery.OpNe:
+		return query.Where(column+" IS DISTINCT FROM ?", p.Value)
+	case listquery.OpGt:
+		return query.Where(column+" > ?", p.Value)
+	case listquery.OpGte:
+		return query.Where(column+" >= ?", p.Value)
+	case listquery.OpLt:
+		return query.Where(column+" < ?", p.Value)
+	case listquery.OpLte:
+		return query.Where(column+" <= ?", p.Value)
+	case listquery.OpIn:
+		return query.Where(column+" IN ?", p.Value)
+	case listquery.OpLike:
+		return query.Where(column+" ILIKE ?", "%"+p.Value.(string)+"%")
+	case listquery.OpExists:
+		if p.Value.(bool) {
+			return query.Where(column + " IS NOT NULL")
+		}
+		return query.Where(column + " IS NULL")
+	default:
+		return query.Where(column+" = ?", p.Value)
+	}
+}
+
+// applyJSONPredicate filters on a key inside a JSONB column. Equality uses containment (@>) so a
+// GIN index can serve it; ordering compares only values of the same JSON type, since JSONB orders
+// numbers below booleans.
+func applyJSONPredicate(query *gorm.DB, p listquery.Predicate) *gorm.DB {
+	column := p.Field
+	path := "{" + strings.Join(p.Path, ",") + "}"
+
+	switch p.Op {
+	case listquery.OpEq, listquery.OpNe, listquery.OpIn:
+		values := []interface{}{p.Value}
+		if p.Op == listquery.OpIn {
+			values = p.Value.([]interface{})
+		}
+		conditions := make([]string, len(values))
+		args := make([]interface{}, len(values))
+		for i, value := range values {
+			conditions[i], args[i] = column+" @> ?::jsonb", jsonContaining(p.Path, value)
+		}
+		if p.Op == listquery.OpNe {
+			return query.Where("NOT COALESCE("+column+" @> ?::jsonb, false)", args...)
+		}
+		return query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	case listquery.OpGt, listquery.OpGte, listquery.OpLt, listquery.OpLte:
+		operator := map[string]string{listquery.OpGt: ">", listquery.OpGte: ">=", listquery.OpLt: "<", listquery.OpLte: "<="}[p.Op]
+		jsonType := "string"
+		if _, ok := p.Value.(float64); ok {
+			jsonType = "number"
+		}
+		value, _ := json.Marshal(p.Value)
+		return query.Where("jsonb_typeof("+column+" #> ?::text[]) = ? AND "+column+" #> ?::text[] "+operator+" ?::jsonb",
+			path, jsonType, path, string(value))
+	case listquery.OpLike:
+		return query.Where(column+" #>> ?::text[] ILIKE ?", path, "%"+p.Value.(string)+"%")
+	case listquery.OpExists:
+		if p.Value.(bool) {
+			return query.Where(column+" #> ?::text[] IS NOT NULL", path)
+		}
+		return query.Where(column+" #> ?::text[] IS NULL", path)
+	default:
+		query.AddError(fmt.Errorf("%w: unknown operator %q", listquery.ErrInvalidQuery, p.Op))
+		return query
+	}
+}
+
+// jsonContaining builds the JSON object holding value at path, e.g. {"a":{"b":value}}
+func jsonContaining(path []string, value interface{}) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		value = map[string]interface{}{path[i]: value}
+	}
+	encoded, _ := json.Marshal(value) // strings, numbers and booleans always encode
+	return string(encoded)
+}
+
+// applyIncludes preloads the relations a list query asked for
+func applyIncludes(query *gorm.DB, resource listquery.Resource, include []string) *gorm.DB {
+	for _, name := range include {
+		query = query.Preload(resource.Includes[name])
+	}
+	return query
+}
+
+// estimateCount reads the planner's row estimate for query instead of counting the rows
+func estimateCount(query *gorm.DB) (int64, error) {
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(&[]map[string]interface{}{}).Statement
+
+	var raw string
+	err := query.Session(&gorm.Session{NewDB: true}).Raw("EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).Row().Scan(&raw)
+	if err != nil {
+		return 0, err
+	}
+
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, errors.New("query plan is empty")
+	}
+	return int64(plans[0].Plan.Rows), nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type routeRepository struct {
+	db *gorm.DB
+}
+
+func NewRouteRepository(db *gorm.DB) *routeRepository {
+	return &routeRepository{db: db}
+}
+
+// SavePlan creates the route plan of a shipment or replaces the one it has
+func (r *routeRepository) SavePlan(ctx context.Context, plan *domain.RoutePlan) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "custody_transfer_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"waypoints", "corridor_km", "geofences", "expected_location_ids", "updated_at"}),
+	}).Create(plan).Error
+}
+
+func (r *routeRepository) GetPlanByTransfer(ctx context.Context, transferID uuid.UUID) (*domain.RoutePlan, error) {
+	var plan domain.RoutePlan
+	err := r.db.WithContext(ctx).Where("custody_transfer_id = ?", transferID).First(&plan).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *routeRepository) DeletePlan(ctx context.Context, transferID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("custody_transfer_id = ?", transferID).Delete(&domain.RoutePlan{}).Error
+}
+
+// GetActivePlans reads the plans a position of the product is checked against: those of its
+// pending transfers, and that of the transfer receiveEventID completed, if any
+func (r *routeRepository) GetActivePlans(ctx context.Context, productID uuid.UUID, receiveEventID *uuid.UUID) ([]*domain.RoutePlan, error) {
+	var plans []*domain.RoutePlan
+	query := r.db.WithContext(ctx).Model(&domain.RoutePlan{}).
+		Joins("JOIN custody_transfers ON custody_transfers.id = route_plans.custody_transfer_id").
+		Where("route_plans.product_id = ?", productID)
+	if receiveEventID != nil {
+		query = query.Where("custody_transfers.status = ? OR custody_transfers.receive_event_id = ?", domain.CustodyTransferStatusPending, *receiveEventID)
+	} else {
+		query = query.Where("custody_transfers.status = ?", domain.CustodyTransferStatusPending)
+	}
+	err := query.Order("route_plans.created_at").Find(&plans).Error
+	return plans, err
+}
+
+// RaiseAlert writes an alert unless its plan already has an open one of the same type and
+// subject. It reports whether the alert was written.
+func (r *routeRepository) RaiseAlert(ctx context.Context, alert *domain.RouteAlert) (bool, error) {
+	// The conflict target names the partial unique index idx_route_alerts_open; its predicate is
+	// spelled out rather than bound, as Postgres matches it against the index at plan time
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "route_plan_id"}, {Name: "type"}, {Name: "subject"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "status = 'open'"}}},
+		DoNothing:   true,
+	}).Create(alert)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *routeRepository) GetAlert(ctx context.Context, id uuid.UUID) (*domain.RouteAlert, error) {
+	var alert domain.RouteAlert
+	err := r.db.WithContext(ctx).Preload("Product").Where("id = ?", id).First(&alert).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// UpdateAlertIfOpen applies updates only while the alert is open and reports whether it did
+func (r *routeRepository) UpdateAlertIfOpen(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.RouteAlert{}).
+		Where("id = ? AND status = ?", id, domain.RouteAlertStatusOpen).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *routeRepository) ListAlerts(ctx context.Context, filter *dto.RouteAlertFilter) ([]*domain.RouteAlert, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.RouteAlert{})
+
+	// Apply filters
+	if filter.CustodyTransferID != nil {
+		query = query.Where("custody_transfer_id = ?", *filter.CustodyTransferID)
+	}
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.RouteAlert) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"gorm.io/gorm"
+	"strings"
+)
+
+type scorecardRepository struct {
+	db *gorm.DB
+}
+
+func NewScorecardRepository(db *gorm.DB) *scorecardRepository {
+	return &scorecardRepository{db: db}
+}
+
+// Metrics measures each stakeholder the query selects over its range: the custody transfers it
+// sent, those it received by their expected arrival, the events it recorded and how long products
+// stayed with it. Every selected stakeholder
+// gets a row, or with a period one row per period of the range, even without any activity.
+func (r *scorecardRepository) Metrics(ctx context.Context, query *dto.ScorecardQuery) ([]*dto.ScorecardMetrics, error) {
+	var peers []string
+	var args []interface{}
+	if query.Type != nil {
+		peers = append(peers, "type = ?")
+		args = append(args, *query.Type)
+	}
+	if query.StakeholderID != nil {
+		peers = append(peers, "id = ?")
+		args = append(args, *query.StakeholderID)
+	}
+	if len(peers) == 0 {
+		peers = append(peers, "TRUE")
+	}
+
+	// Without a period the whole range is one bucket
+	buckets := "SELECT CAST(NULL AS timestamp) AS period_start"
+	bucket := func(string) string { return "CAST(NULL AS timestamp)" }
+	var bucketArgs []interface{}
+	if query.Period != "" {
+		buckets = `SELECT generate_series(date_trunc(?, CAST(? AS timestamp)),
+			CAST(? AS timestamp) - interval '1 microsecond', CAST('1 ' || CAST(? AS text) AS interval)) AS period_start`
+		args = append(args, query.Period, *query.From, *query.To, query.Period)
+		bucket = func(column string) string { return "date_trunc(?, " + column + ")" }
+		bucketArgs = []interface{}{query.Period}
+	}
+
+	args = append(args, bucketArgs...)
+	args = append(args,
+		domain.CustodyTransferStatusAccepted, domain.CustodyTransferStatusRejected, domain.CustodyTransferStatusExpired,
+		domain.CustodyTransferStatusAccepted,
+		*query.From, *query.To)
+	args = append(args, bucketArgs...)
+	args = append(args, domain.CustodyTransferStatusAccepted, *query.From, *query.To)
+	args = append(args, bucketArgs...)
+	args = append(args, *query.From, *query.To)
+	args = append(args, bucketArgs...)
+	args = append(args, *query.From, *query.To)
+
+	var metrics []*dto.ScorecardMetrics
+	err := r.db.WithContext(ctx).Raw(`
+		WITH peers AS (SELECT id FROM stakeholders WHERE `+strings.Join(peers, " AND ")+`),
+		buckets AS (`+buckets+`),
+		sent AS (
+			SELECT sender_id AS stakeholder_id, `+bucket("created_at")+` AS period_start,
+				COUNT(*) AS transfers_sent,
+				COUNT(*) FILTER (WHERE status = ?) AS accepted,
+				COUNT(*) FILTER (WHERE status = ?) AS rejected,
+				COUNT(*) FILTER (WHERE status = ?) AS expired,
+				COUNT(*) FILTER (WHERE status = ? AND has_discrepancy) AS discrepancies
+			FROM custody_transfers
+			WHERE sender_id IN (SELECT id FROM peers) AND created_at >= ? AND created_at < ?
+			GROUP BY 1, 2
+		),
+		received AS (
+			SELECT t.receiver_id AS stakeholder_id, `+bucket("t.created_at")+` AS period_start,
+				COUNT(*) AS receipts_due,
+				COUNT(*) FILTER (WHERE e.timestamp <= t.expected_arrival_at) AS on_time_receipts
+			FROM custody_transfers t
+			JOIN supply_chain_events e ON e.id = t.receive_event_id
+			WHERE t.receiver_id IN (SELECT id FROM peers) AND t.status = ? AND t.expected_arrival_at IS NOT NULL
+				AND t.created_at >= ? AND t.created_at < ?
+			GROUP BY 1, 2
+		),
+		recorded AS (
+			SELECT stakeholder_id, `+bucket("timestamp")+` AS period_start,
+				COUNT(*) AS events,
+				COUNT(*) FILTER (WHERE is_verified) AS verified_events
+			FROM supply_chain_events
+			WHERE stakeholder_id IN (SELECT id FROM peers) AND timestamp >= ? AND timestamp < ?
+			GROUP BY 1, 2
+		),
+		handled AS (
+			SELECT stakeholder_id, `+bucket("departed_at")+` AS period_start,
+				COUNT(*) AS stays,
+				AVG(dwell_seconds) / 3600 AS avg_handling_hours
+			FROM product_dwells
+			WHERE stakeholder_id IN (SELECT id FROM peers) AND departed_at >= ? AND departed_at < ?
+			GROUP BY 1, 2
+		)
+		SELECT p.id AS stakeholder_id, b.period_start,
+			COALESCE(s.transfers_sent, 0) AS transfers_sent,
+			COALESCE(s.accepted, 0) AS accepted,
+			COALESCE(s.rejected, 0) AS rejected,
+			COALESCE(s.expired, 0) AS expired,
+			COALESCE(s.discrepancies, 0) AS discrepancies,
+			COALESCE(r.receipts_due, 0) AS receipts_due,
+			COALESCE(r.on_time_receipts, 0) AS on_time_receipts,
+			COALESCE(e.events, 0) AS events,
+			COALESCE(e.verified_events, 0) AS verified_events,
+			COALESCE(h.stays, 0) AS stays,
+			h.avg_handling_hours
+		FROM peers p
+		CROSS JOIN buckets b
+		LEFT JOIN sent s ON s.stakeholder_id = p.id AND s.period_start IS NOT DISTINCT FROM b.period_start
+		LEFT JOIN received r ON r.stakeholder_id = p.id AND r.period_start IS NOT DISTINCT FROM b.period_start
+		LEFT JOIN recorded e ON e.stakeholder_id = p.id AND e.period_start IS NOT DISTINCT FROM b.period_start
+		LEFT JOIN handled h ON h.stakeholder_id = p.id AND h.period_start IS NOT DISTINCT FROM b.period_start
+		ORDER BY b.period_start, p.id`, args...).Scan(&metrics).Error
+	if err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
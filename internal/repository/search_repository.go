@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Search documents. They must stay identical to the expressions of the indexes in
+// 20261019111100 to 20261019111300, or Postgres cannot use them.
+const (
+	productDocument = "(setweight(to_tsvector('english', name), 'A') || setweight(to_tsvector('english', sku), 'A') || " +
+		"setweight(to_tsvector('english', coalesce(category, '')), 'B') || setweight(to_tsvector('english', coalesce(description, '')), 'C'))"
+	stakeholderDocument = "(setweight(to_tsvector('english', name), 'A') || setweight(to_tsvector('english', coalesce(address, '')), 'B'))"
+	locationDocument    = "to_tsvector('english', coalesce(location, ''))"
+)
+
+// ts_headline options: short fields are returned whole, long ones as fragments around the matches
+const (
+	headlineWhole     = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	headlineFragments = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=8, FragmentDelimiter=\" ... \""
+)
+
+// escapeHTML wraps a text column so ts_headline highlights the HTML-escaped text: ts_headline
+// copies its input as is, and highlights are returned as markup. The parser reads the entities
+// as entities, not words, so escaping does not change what matches.
+func escapeHTML(column string) string {
+	return "replace(replace(replace(replace(" + column + ", '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '\"', '&quot;')"
+}
+
+// searchRepository matches the full-text query or, for typos, a trigram word similarity (<%) on
+// the names, and ranks by both. Headlines are only built for the rows returned.
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *searchRepository {
+	return &searchRepository{db: db}
+}
+
+func (r *searchRepository) SearchProducts(ctx context.Context, query string, limit int) ([]*dto.ProductHit, error) {
+	var rows []struct {
+		domain.Product
+		Score                float64
+		NameHighlight        string
+		DescriptionHighlight *string
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		WITH search AS (SELECT websearch_to_tsquery('english', @q) AS query),
+		hits AS (
+			SELECT products.id, ts_rank_cd(`+productDocument+`, search.query) + word_similarity(@q, products.name) AS score
+			FROM products, search
+			WHERE `+productDocument+` @@ search.query OR @q <% products.name OR @q <% products.sku
+			ORDER BY score DESC, products.id
+			LIMIT @limit
+		)
+		SELECT products.*, hits.score,
+			ts_headline('english', `+escapeHTML("products.name")+`, search.query, @whole) AS name_highlight,
+			ts_headline('english', `+escapeHTML("products.description")+`, search.query, @fragments) AS description_highlight
+		FROM hits JOIN products ON products.id = hits.id, search
+		ORDER BY hits.score DESC, products.id`, searchArgs(query, limit)).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*dto.ProductHit, 0, len(rows))
+	for _, row := range rows {
+		product := row.Product
+		hit := &dto.ProductHit{Product: &product, Score: row.Score, Highlights: map[string]string{"name": row.NameHighlight}}
+		if row.DescriptionHighlight != nil {
+			hit.Highlights["description"] = *row.DescriptionHighlight
+		}
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
+func (r *searchRepository) SearchStakeholders(ctx context.Context, query string, limit int) ([]*dto.StakeholderHit, error) {
+	var rows []struct {
+		domain.Stakeholder
+		Score            float64
+		NameHighlight    string
+		AddressHighlight *string
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		WITH search AS (SELECT websearch_to_tsquery('english', @q) AS query),
+		hits AS (
+			SELECT stakeholders.id, ts_rank_cd(`+stakeholderDocument+`, search.query) + word_similarity(@q, stakeholders.name) AS score
+			FROM stakeholders, search
+			WHERE `+stakeholderDocument+` @@ search.query OR @q <% stakeholders.name
+			ORDER BY score DESC, stakeholders.id
+			LIMIT @limit
+		)
+		SELECT stakeholders.*, hits.score,
+			ts_headline('english', `+escapeHTML("stakeholders.name")+`, search.query, @whole) AS name_highlight,
+			ts_headline('english', `+escapeHTML("stakeholders.address")+`, search.query, @fragments) AS address_highlight
+		FROM hits JOIN stakeholders ON stakeholders.id = hits.id, search
+		ORDER BY hits.score DESC, stakeholders.id`, searchArgs(query, limit)).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*dto.StakeholderHit, 0, len(rows))
+	for _, row := range rows {
+		stakeholder := row.Stakeholder
+		hit := &dto.StakeholderHit{Stakeholder: &stakeholder, Score: row.Score, Highlights: map[string]string{"name": row.NameHighlight}}
+		if row.AddressHighlight != nil {
+			hit.Highlights["address"] = *row.AddressHighlight
+		}
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
+// SearchLocations matches the distinct locations events were recorded at
+func (r *searchRepository) SearchLocations(ctx context.Context, query string, limit int) ([]*dto.LocationHit, error) {
+	var rows []struct {
+		Location  string
+		Highlight string
+		Score     float64
+		Events    int64
+		LastSeen  time.Time
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		WITH search AS (SELECT websearch_to_tsquery('english', @q) AS query),
+		locations AS (
+			SELECT location, count(*) AS events, max(timestamp) AS last_seen
+			FROM supply_chain_events, search
+			WHERE `+locationDocument+` @@ search.query OR @q <% location
+			GROUP BY location
+		),
+		hits AS (
+			SELECT locations.*, ts_rank_cd(`+locationDocument+`, search.query) + word_similarity(@q, location) AS score
+			FROM locations, search
+			ORDER BY score DESC, events DESC, location
+			LIMIT @limit
+		)
+		SELECT hits.*, ts_headline('english', `+escapeHTML("location")+`, search.query, @whole) AS highlight
+		FROM hits, search
+		ORDER BY score DESC, events DESC, location`, searchArgs(query, limit)).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*dto.LocationHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, &dto.LocationHit{
+			Location:  row.Location,
+			Highlight: row.Highlight,
+			Score:     row.Score,
+			Events:    row.Events,
+			LastSeen:  row.LastSeen,
+		})
+	}
+	return hits, nil
+}
+
+func searchArgs(query string, limit int) map[string]interface{} {
+	return map[string]interface{}{
+		"q":         query,
+		"limit":     limit,
+		"whole":     headlineWhole,
+		"fragments": headlineFragments,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
 	"gorm.io/gorm"
 )
 
@@ -29,6 +30,12 @@ func (r stakeholderRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 	return &stakeholder, nil
 }
 
+func (r stakeholderRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Stakeholder, error) {
+	var stakeholders []*domain.Stakeholder
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&stakeholders).Error
+	return stakeholders, err
+}
+
 func (r stakeholderRepository) GetByEmail(ctx context.Context, email string) (*domain.Stakeholder, error) {
 	var stakeholder domain.Stakeholder
 	err := r.db.WithContext(ctx).Where("email = ?", email).First(&stakeholder).Error
@@ -55,10 +62,7 @@ func (r stakeholderRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&domain.Stakeholder{}, id).Error
 }
 
-func (r stakeholderRepository) List(ctx context.Context, filter *dto.StakeholderFilter) ([]*domain.Stakeholder, int64, error) {
-	var stakeholders []*domain.Stakeholder
-	var total int64
-
+func (r stakeholderRepository) List(ctx context.Context, filter *dto.StakeholderFilter) ([]*domain.Stakeholder, *paging.Page, error) {
 	query := r.db.WithContext(ctx).Model(&domain.Stakeholder{})
 
 	// Apply filters
@@ -71,22 +75,13 @@ func (r stakeholderRepository) List(ctx context.Context, filter *dto.Stakeholder
 	if filter.Email != nil {
 		query = query.Where("email ILIKE ?", "%"+*filter.Email+"%")
 	}
+	query = applyPredicates(query, filter.Query.Filters)
 
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Apply pagination
-	if filter.Limit > 0 {
-		query = query.Limit(filter.Limit)
-	}
-	if filter.Offset > 0 {
-		query = query.Offset(filter.Offset)
-	}
-
-	err := query.Find(&stakeholders).Error
-	return stakeholders, total, err
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}.sortedBy(filter.Query.Sort), req, func(item *domain.Stakeholder) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
 }
 
 func (r stakeholderRepository) GetStats(ctx context.Context, id uuid.UUID) (*dto.StakeholderStats, error) {
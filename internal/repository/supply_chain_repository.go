@@ -5,7 +5,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/listquery"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
 	"gorm.io/gorm"
+	"time"
 )
 
 type supplyChainEventRepository struct {
@@ -22,13 +25,20 @@ func (r *supplyChainEventRepository) Create(ctx context.Context, event *domain.S
 
 func (r *supplyChainEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.SupplyChainEvent, error) {
 	var event domain.SupplyChainEvent
-	err := r.db.WithContext(ctx).Preload("Product").Preload("Stakeholder").Where("id = ?", id).First(&event).Error
+	err := r.db.WithContext(ctx).Preload("Product").Preload("Stakeholder").Preload("Site").Where("id = ?", id).First(&event).Error
 	if err != nil {
 		return nil, err
 	}
 	return &event, nil
 }
 
+// GetByIDs loads events without relations
+func (r *supplyChainEventRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.SupplyChainEvent, error) {
+	var events []*domain.SupplyChainEvent
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&events).Error
+	return events, err
+}
+
 func (r *supplyChainEventRepository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
 	return r.db.WithContext(ctx).Model(&domain.SupplyChainEvent{}).Where("id = ?", id).Updates(updates).Error
 }
@@ -37,13 +47,21 @@ func (r *supplyChainEventRepository) Delete(ctx context.Context, id uuid.UUID) e
 	return r.db.WithContext(ctx).Delete(&domain.SupplyChainEvent{}, id).Error
 }
 
-func (r *supplyChainEventRepository) List(ctx context.Context, filter *dto.SupplyChainEventFilter) ([]*domain.SupplyChainEvent, int64, error) {
-	var events []*domain.SupplyChainEvent
-	var total int64
-
-	query := r.db.WithContext(ctx).Model(&domain.SupplyChainEvent{}).Preload("Product").Preload("Stakeholder")
+func (r *supplyChainEventRepository) List(ctx context.Context, filter *dto.SupplyChainEventFilter) ([]*domain.SupplyChainEvent, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.SupplyChainEvent{})
+	query = applyIncludes(query, listquery.Events, filter.Query.Include)
 
 	// Apply filters
+	query = applyEventFilter(query, filter)
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "timestamp", id: "id"}.sortedBy(filter.Query.Sort), req, func(item *domain.SupplyChainEvent) paging.Cursor {
+		return paging.Cursor{At: item.Timestamp, ID: item.ID}
+	})
+}
+
+func applyEventFilter(query *gorm.DB, filter *dto.SupplyChainEventFilter) *gorm.DB {
 	if filter.ProductID != nil {
 		query = query.Where("product_id = ?", *filter.ProductID)
 	}
@@ -53,9 +71,18 @@ func (r *supplyChainEventRepository) List(ctx context.Context, filter *dto.Suppl
 	if filter.EventType != nil {
 		query = query.Where("event_type = ?", *filter.EventType)
 	}
+	if len(filter.EventTypes) > 0 {
+		query = query.Where("event_type IN ?", filter.EventTypes)
+	}
 	if filter.Location != nil {
 		query = query.Where("location ILIKE ?", "%"+*filter.Location+"%")
 	}
+	if filter.LocationID != nil {
+		query = query.Where("location_id = ?", *filter.LocationID)
+	}
+	if filter.Near != nil {
+		query = query.Where("location_id IN (SELECT id FROM locations WHERE "+withinRadius+")", radiusArgs(*filter.Near)...)
+	}
 	if filter.IsVerified != nil {
 		query = query.Where("is_verified = ?", *filter.IsVerified)
 	}
@@ -65,34 +92,45 @@ func (r *supplyChainEventRepository) List(ctx context.Context, filter *dto.Suppl
 	if filter.ToDate != nil {
 		query = query.Where("timestamp <= ?", *filter.ToDate)
 	}
+	return applyPredicates(query, filter.Query.Filters)
+}
 
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
+func (r *supplyChainEventRepository) GetByProduct(ctx context.Context, productID uuid.UUID) ([]*domain.SupplyChainEvent, error) {
+	var events []*domain.SupplyChainEvent
+	err := r.db.WithContext(ctx).Preload("Product").Preload("Stakeholder").Preload("Site").Where("product_id = ?", productID).Order("timestamp ASC").Find(&events).Error
+	return events, err
+}
 
-	// Apply pagination and ordering
-	query = query.Order("timestamp DESC")
-	if filter.Limit > 0 {
-		query = query.Limit(filter.Limit)
-	}
-	if filter.Offset > 0 {
-		query = query.Offset(filter.Offset)
+func (r *supplyChainEventRepository) GetByProductBetween(ctx context.Context, productID uuid.UUID, from time.Time, to *time.Time) ([]*domain.SupplyChainEvent, error) {
+	var events []*domain.SupplyChainEvent
+	query := r.db.WithContext(ctx).Preload("Product").Preload("Stakeholder").
+		Where("product_id = ? AND timestamp >= ?", productID, from)
+	if to != nil {
+		query = query.Where("timestamp <= ?", *to)
 	}
+	err := query.Order("timestamp ASC").Find(&events).Error
+	return events, err
+}
 
-	err := query.Find(&events).Error
-	return events, total, err
+func (r *supplyChainEventRepository) GetByStakeholder(ctx context.Context, stakeholderID uuid.UUID) ([]*domain.SupplyChainEvent, error) {
+	var events []*domain.SupplyChainEvent
+	err := r.db.WithContext(ctx).Preload("Product").Preload("Stakeholder").Where("stakeholder_id = ?", stakeholderID).Order("timestamp DESC").Find(&events).Error
+	return events, err
 }
 
-func (r *supplyChainEventRepository) GetByProduct(ctx context.Context, productID uuid.UUID) ([]*domain.SupplyChainEvent, error) {
+// GetByProducts loads up to perProduct of each product's first events in timestamp order, without relations
+func (r *supplyChainEventRepository) GetByProducts(ctx context.Context, productIDs []uuid.UUID, perProduct int) ([]*domain.SupplyChainEvent, error) {
 	var events []*domain.SupplyChainEvent
-	err := r.db.WithContext(ctx).Preload("Product").Preload("Stakeholder").Where("product_id = ?", productID).Order("timestamp ASC").Find(&events).Error
+	err := firstPerParent(r.db.WithContext(ctx), &domain.SupplyChainEvent{}, "product_id", productIDs, "timestamp ASC, id ASC", perProduct).
+		Find(&events).Error
 	return events, err
 }
 
-func (r *supplyChainEventRepository) GetByStakeholder(ctx context.Context, stakeholderID uuid.UUID) ([]*domain.SupplyChainEvent, error) {
+// GetByStakeholders loads up to perStakeholder of each stakeholder's latest events, without relations
+func (r *supplyChainEventRepository) GetByStakeholders(ctx context.Context, stakeholderIDs []uuid.UUID, perStakeholder int) ([]*domain.SupplyChainEvent, error) {
 	var events []*domain.SupplyChainEvent
-	err := r.db.WithContext(ctx).Preload("Product").Preload("Stakeholder").Where("stakeholder_id = ?", stakeholderID).Order("timestamp DESC").Find(&events).Error
+	err := firstPerParent(r.db.WithContext(ctx), &domain.SupplyChainEvent{}, "stakeholder_id", stakeholderIDs, "timestamp DESC, id ASC", perStakeholder).
+		Find(&events).Error
 	return events, err
 }
 
@@ -122,3 +160,100 @@ func (r *supplyChainEventRepository) VerifyEvent(ctx context.Context, id uuid.UU
 	}
 	return r.db.WithContext(ctx).Model(&domain.SupplyChainEvent{}).Where("id = ?", id).Updates(updates).Error
 }
+
+// EachInOrder replays every event in (timestamp, id) order, batchSize at a time, using keyset pagination
+func (r *supplyChainEventRepository) EachInOrder(ctx context.Context, batchSize int, fn func(events []*domain.SupplyChainEvent) error) error {
+	return r.EachInRange(ctx, nil, nil, batchSize, fn)
+}
+
+// EachInRange is EachInOrder restricted to events with from <= timestamp < to; nil bounds are open
+func (r *supplyChainEventRepository) EachInRange(ctx context.Context, from, to *time.Time, batchSize int, fn func(events []*domain.SupplyChainEvent) error) error {
+	return r.eachInOrder(ctx, func(query *gorm.DB) *gorm.DB {
+		if from != nil {
+			query = query.Where("timestamp >= ?", *from)
+		}
+		if to != nil {
+			query = query.Where("timestamp < ?", *to)
+		}
+		return query
+	}, batchSize, fn)
+}
+
+// EachForProducts is EachInOrder restricted to the events of the given products and the
+// transformations that consumed or produced them
+func (r *supplyChainEventRepository) EachForProducts(ctx context.Context, productIDs []uuid.UUID, batchSize int, fn func(events []*domain.SupplyChainEvent) error) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	return r.eachInOrder(ctx, func(query *gorm.DB) *gorm.DB {
+		transformations := r.db.Model(&domain.TransformationLine{}).Select("event_id").Where("product_id IN ?", productIDs)
+		return query.Where("product_id IN ? OR id IN (?)", productIDs, transformations)
+	}, batchSize, fn)
+}
+
+func (r *supplyChainEventRepository) eachInOrder(ctx context.Context, scope func(query *gorm.DB) *gorm.DB, batchSize int, fn func(events []*domain.SupplyChainEvent) error) error {
+	var lastTimestamp time.Time
+	var lastID *uuid.UUID
+
+	for {
+		var events []*domain.SupplyChainEvent
+		query := scope(r.db.WithContext(ctx).Model(&domain.SupplyChainEvent{}))
+		if lastID != nil {
+			query = query.Where("(timestamp, id) > (?, ?)", lastTimestamp, *lastID)
+		}
+		if err := query.Order("timestamp ASC, id ASC").Limit(batchSize).Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		if err := fn(events); err != nil {
+			return err
+		}
+
+		last := events[len(events)-1]
+		lastTimestamp, lastID = last.Timestamp, &last.ID
+		if len(events) < batchSize {
+			return nil
+		}
+	}
+}
+
+// EachFiltered walks every event matching filter in (timestamp, id) order, batchSize at a time.
+// Limit and Offset are ignored; keyset pagination keeps each batch query cheap however deep it goes.
+func (r *supplyChainEventRepository) EachFiltered(ctx context.Context, filter *dto.SupplyChainEventFilter, batchSize int, fn func(events []*domain.SupplyChainEvent) error) error {
+	var lastTimestamp time.Time
+	var lastID *uuid.UUID
+
+	for {
+		var events []*domain.SupplyChainEvent
+		query := applyEventFilter(r.db.WithContext(ctx).Model(&domain.SupplyChainEvent{}).Preload("Product"), filter)
+		if lastID != nil {
+			query = query.Where("(timestamp, id) > (?, ?)", lastTimestamp, *lastID)
+		}
+		if err := query.Order("timestamp ASC, id ASC").Limit(batchSize).Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		if err := fn(events); err != nil {
+			return err
+		}
+
+		last := events[len(events)-1]
+		lastTimestamp, lastID = last.Timestamp, &last.ID
+		if len(events) < batchSize {
+			return nil
+		}
+	}
+}
+
+// GetByMetadataValue finds events whose metadata holds value under the given top-level key
+func (r *supplyChainEventRepository) GetByMetadataValue(ctx context.Context, key, value string) ([]*domain.SupplyChainEvent, error) {
+	var events []*domain.SupplyChainEvent
+	err := r.db.WithContext(ctx).Where("metadata ->> ? = ?", key, value).Order("timestamp ASC").Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// telemetryInsertBatch is how many readings one INSERT statement writes
+const telemetryInsertBatch = 1000
+
+type telemetryRepository struct {
+	db *gorm.DB
+}
+
+func NewTelemetryRepository(db *gorm.DB) *telemetryRepository {
+	return &telemetryRepository{db: db}
+}
+
+func (r *telemetryRepository) CreateDevice(ctx context.Context, device *domain.SensorDevice) error {
+	return r.db.WithContext(ctx).Create(device).Error
+}
+
+func (r *telemetryRepository) GetDeviceByID(ctx context.Context, id uuid.UUID) (*domain.SensorDevice, error) {
+	var device domain.SensorDevice
+	err := r.db.WithContext(ctx).Preload("Stakeholder").Preload("Product").Where("id = ?", id).First(&device).Error
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+func (r *telemetryRepository) GetDeviceBySerial(ctx context.Context, serial string) (*domain.SensorDevice, error) {
+	var device domain.SensorDevice
+	err := r.db.WithContext(ctx).Where("serial = ?", serial).First(&device).Error
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+// GetDevices reads the devices with any of the IDs or serials, in no particular order
+func (r *telemetryRepository) GetDevices(ctx context.Context, ids []uuid.UUID, serials []string) ([]*domain.SensorDevice, error) {
+	var devices []*domain.SensorDevice
+	if len(ids) == 0 && len(serials) == 0 {
+		return devices, nil
+	}
+	query := r.db.WithContext(ctx)
+	switch {
+	case len(ids) > 0 && len(serials) > 0:
+		query = query.Where("id IN ? OR serial IN ?", ids, serials)
+	case len(ids) > 0:
+		query = query.Where("id IN ?", ids)
+	default:
+		query = query.Where("serial IN ?", serials)
+	}
+	err := query.Find(&devices).Error
+	return devices, err
+}
+
+func (r *telemetryRepository) UpdateDevice(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.SensorDevice{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeleteDevice removes a device and its readings
+func (r *telemetryRepository) DeleteDevice(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.SensorDevice{}, id).Error
+}
+
+func (r *telemetryRepository) ListDevices(ctx context.Context, filter *dto.SensorDeviceFilter) ([]*domain.SensorDevice, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.SensorDevice{})
+
+	// Apply filters
+	if filter.StakeholderID != nil {
+		query = query.Where("stakeholder_id = ?", *filter.StakeholderID)
+	}
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.SensorDevice) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+// InsertReadings writes readings, skipping any a device already reported for the same instant,
+// and returns the readings it wrote as stored
+func (r *telemetryRepository) InsertReadings(ctx context.Context, readings []*domain.TelemetryReading) ([]*domain.TelemetryReading, error) {
+	stored := make([]*domain.TelemetryReading, 0, len(readings))
+	for start := 0; start < len(readings); start += telemetryInsertBatch {
+		batch := readings[start:min(start+telemetryInsertBatch, len(readings))]
+		values := make([]string, 0, len(batch))
+		args := make([]interface{}, 0, len(batch)*9)
+		for _, reading := range batch {
+			values = append(values, "(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+			args = append(args, reading.DeviceID, reading.ProductID, reading.CustodyTransferID, reading.RecordedAt,
+				reading.Temperature, reading.Humidity, reading.Latitude, reading.Longitude, reading.ReceivedAt)
+		}
+
+		// RETURNING only yields the rows that were inserted, unlike a Create whose returned IDs
+		// would be assigned to the readings in order
+		var inserted []*domain.TelemetryReading
+		err := r.db.WithContext(ctx).Raw(`
+			INSERT INTO telemetry_readings (device_id, product_id, custody_transfer_id, recorded_at,
+				temperature, humidity, latitude, longitude, received_at)
+			VALUES `+strings.Join(values, ", ")+`
+			ON CONFLICT (device_id, recorded_at) DO NOTHING
+			RETURNING *`, args...).Scan(&inserted).Error
+		if err != nil {
+			return nil, err
+		}
+		stored = append(stored, inserted...)
+	}
+	return stored, nil
+}
+
+// TouchDevice moves a device's last_seen_at forward to at; it never moves back
+func (r *telemetryRepository) TouchDevice(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.SensorDevice{}).
+		Where("id = ? AND (last_seen_at IS NULL OR last_seen_at < ?)", id, at).
+		UpdateColumn("last_seen_at", at).Error
+}
+
+// ListReadings reads the readings query selects, oldest first, up to query.Limit of them
+func (r *telemetryRepository) ListReadings(ctx context.Context, query *dto.TelemetryQuery) ([]*domain.TelemetryReading, error) {
+	var readings []*domain.TelemetryReading
+	db := applyTelemetryQuery(r.db.WithContext(ctx).Model(&domain.TelemetryReading{}), query)
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+	err := db.Order("recorded_at, id").Find(&readings).Error
+	return readings, err
+}
+
+// Downsample summarises the readings query selects per query.Bucket, oldest bucket first.
+// Buckets are aligned to whole intervals since 2000-01-01, so neighbouring ranges line up.
+func (r *telemetryRepository) Downsample(ctx context.Context, query *dto.TelemetryQuery) ([]*dto.TelemetryBucket, error) {
+	var buckets []*dto.TelemetryBucket
+	interval := fmt.Sprintf("%d seconds", int64(query.Bucket.Seconds()))
+	db := r.db.WithContext(ctx).Model(&domain.TelemetryReading{}).
+		Select(`date_bin(?::interval, recorded_at, TIMESTAMP '2000-01-01') AS start,
+			COUNT(*) AS readings,
+			MIN(temperature) AS min_temperature, MAX(temperature) AS max_temperature, AVG(temperature) AS avg_temperature,
+			MIN(humidity) AS min_humidity, MAX(humidity) AS max_humidity, AVG(humidity) AS avg_humidity,
+			(array_agg(latitude ORDER BY recorded_at DESC) FILTER (WHERE latitude IS NOT NULL))[1] AS latitude,
+			(array_agg(longitude ORDER BY recorded_at DESC) FILTER (WHERE longitude IS NOT NULL))[1] AS longitude`, interval)
+	db = applyTelemetryQuery(db, query)
+	err := db.Group("start").Order("start").Scan(&buckets).Error
+	return buckets, err
+}
+
+// GetProductSpan reads when the first and last readings of a product were recorded; nil when it
+// has none
+func (r *telemetryRepository) GetProductSpan(ctx context.Context, productID uuid.UUID) (*time.Time, *time.Time, error) {
+	var span struct {
+		First *time.Time
+		Last  *time.Time
+	}
+	err := r.db.WithContext(ctx).Model(&domain.TelemetryReading{}).
+		Select("MIN(recorded_at) AS first, MAX(recorded_at) AS last").
+		Where("product_id = ?", productID).
+		Scan(&span).Error
+	return span.First, span.Last, err
+}
+
+func applyTelemetryQuery(db *gorm.DB, query *dto.TelemetryQuery) *gorm.DB {
+	if query.DeviceID != nil {
+		db = db.Where("device_id = ?", *query.DeviceID)
+	}
+	if query.ProductID != nil {
+		db = db.Where("product_id = ?", *query.ProductID)
+	}
+	if query.CustodyTransferID != nil {
+		db = db.Where("custody_transfer_id = ?", *query.CustodyTransferID)
+	}
+	if query.From != nil {
+		db = db.Where("recorded_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("recorded_at < ?", *query.To)
+	}
+	return db
+}
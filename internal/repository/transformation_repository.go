@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"gorm.io/gorm"
+)
+
+type transformationRepository struct {
+	db *gorm.DB
+}
+
+func NewTransformationRepository(db *gorm.DB) *transformationRepository {
+	return &transformationRepository{db: db}
+}
+
+func (r *transformationRepository) CreateLines(ctx context.Context, lines []*domain.TransformationLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&lines).Error
+}
+
+func (r *transformationRepository) GetByEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.TransformationLine, error) {
+	var lines []*domain.TransformationLine
+	err := r.db.WithContext(ctx).Preload("Product").Where("event_id = ?", eventID).Order("direction ASC, created_at ASC").Find(&lines).Error
+	return lines, err
+}
+
+func (r *transformationRepository) GetProducingEvents(ctx context.Context, productID uuid.UUID) ([]*domain.SupplyChainEvent, error) {
+	var events []*domain.SupplyChainEvent
+	err := r.db.WithContext(ctx).
+		Where("id IN (?)", r.db.Model(&domain.TransformationLine{}).Select("event_id").Where("product_id = ? AND direction = ?", productID, domain.TransformationDirectionOutput)).
+		Order("timestamp ASC").
+		Find(&events).Error
+	return events, err
+}
+
+func (r *transformationRepository) GetUpstream(ctx context.Context, productIDs []uuid.UUID) ([]*dto.GenealogyEdge, error) {
+	return r.edges(ctx, productIDs, domain.TransformationDirectionOutput, domain.TransformationDirectionInput)
+}
+
+func (r *transformationRepository) GetDownstream(ctx context.Context, productIDs []uuid.UUID) ([]*dto.GenealogyEdge, error) {
+	return r.edges(ctx, productIDs, domain.TransformationDirectionInput, domain.TransformationDirectionOutput)
+}
+
+// GetUpstreamOfLot and GetDownstreamOfLot find the lines on the other side of the transformations
+// that produced or consumed a lot, optionally only the lot of one product
+func (r *transformationRepository) GetUpstreamOfLot(ctx context.Context, lot string, productID *uuid.UUID) ([]*dto.GenealogyEdge, error) {
+	return r.lotEdges(ctx, lot, productID, domain.TransformationDirectionOutput, domain.TransformationDirectionInput)
+}
+
+func (r *transformationRepository) GetDownstreamOfLot(ctx context.Context, lot string, productID *uuid.UUID) ([]*dto.GenealogyEdge, error) {
+	return r.lotEdges(ctx, lot, productID, domain.TransformationDirectionInput, domain.TransformationDirectionOutput)
+}
+
+// GetUpstreamOfLots and GetDownstreamOfLots are GetUpstream and GetDownstream for given lots of
+// the products, so a walk that started at a lot stays on that lot's lines
+func (r *transformationRepository) GetUpstreamOfLots(ctx context.Context, lots []dto.ProductLot) ([]*dto.GenealogyEdge, error) {
+	return r.productLotEdges(ctx, lots, domain.TransformationDirectionOutput, domain.TransformationDirectionInput)
+}
+
+func (r *transformationRepository) GetDownstreamOfLots(ctx context.Context, lots []dto.ProductLot) ([]*dto.GenealogyEdge, error) {
+	return r.productLotEdges(ctx, lots, domain.TransformationDirectionInput, domain.TransformationDirectionOutput)
+}
+
+// edges finds, for every transformation where the given products appear on the known side,
+// the lines on the other side of the same transformation
+func (r *transformationRepository) edges(ctx context.Context, productIDs []uuid.UUID, knownDirection, otherDirection string) ([]*dto.GenealogyEdge, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	var known []*domain.TransformationLine
+	if err := r.db.WithContext(ctx).Where("product_id IN ? AND direction = ?", productIDs, knownDirection).Find(&known).Error; err != nil {
+		return nil, err
+	}
+	return r.otherSide(ctx, known, otherDirection)
+}
+
+func (r *transformationRepository) lotEdges(ctx context.Context, lot string, productID *uuid.UUID, knownDirection, otherDirection string) ([]*dto.GenealogyEdge, error) {
+	db := r.db.WithContext(ctx).Where("lot_number = ? AND direction = ?", lot, knownDirection)
+	if productID != nil {
+		db = db.Where("product_id = ?", *productID)
+	}
+
+	var known []*domain.TransformationLine
+	if err := db.Find(&known).Error; err != nil {
+		return nil, err
+	}
+	return r.otherSide(ctx, known, otherDirection)
+}
+
+func (r *transformationRepository) productLotEdges(ctx context.Context, lots []dto.ProductLot, knownDirection, otherDirection string) ([]*dto.GenealogyEdge, error) {
+	if len(lots) == 0 {
+		return nil, nil
+	}
+
+	pairs := make([][]interface{}, 0, len(lots))
+	for _, lot := range lots {
+		pairs = append(pairs, []interface{}{lot.ProductID, lot.LotNumber})
+	}
+	var known []*domain.TransformationLine
+	if err := r.db.WithContext(ctx).Where("(product_id, COALESCE(lot_number, '')) IN ? AND direction = ?", pairs, knownDirection).Find(&known).Error; err != nil {
+		return nil, err
+	}
+	return r.otherSide(ctx, known, otherDirection)
+}
+
+// otherSide links each known line to the lines on the other side of its transformation
+func (r *transformationRepository) otherSide(ctx context.Context, known []*domain.TransformationLine, otherDirection string) ([]*dto.GenealogyEdge, error) {
+	if len(known) == 0 {
+		return nil, nil
+	}
+
+	knownByEvent := make(map[uuid.UUID][]*domain.TransformationLine)
+	eventIDs := make([]uuid.UUID, 0, len(known))
+	for _, line := range known {
+		if _, ok := knownByEvent[line.EventID]; !ok {
+			eventIDs = append(eventIDs, line.EventID)
+		}
+		knownByEvent[line.EventID] = append(knownByEvent[line.EventID], line)
+	}
+
+	var other []*domain.TransformationLine
+	if err := r.db.WithContext(ctx).Preload("Product").Where("event_id IN ? AND direction = ?", eventIDs, otherDirection).Order("created_at ASC").Find(&other).Error; err != nil {
+		return nil, err
+	}
+
+	edges := make([]*dto.GenealogyEdge, 0, len(other))
+	for _, line := range other {
+		for _, knownLine := range knownByEvent[line.EventID] {
+			edges = append(edges, &dto.GenealogyEdge{ProductID: knownLine.ProductID, LotNumber: knownLine.LotNumber, Line: line})
+		}
+	}
+	return edges, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *webhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *webhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.WebhookSubscription{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&domain.WebhookSubscription{}).Error
+}
+
+func (r *webhookRepository) ListSubscriptions(ctx context.Context, filter *dto.WebhookSubscriptionFilter) ([]*domain.WebhookSubscription, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.WebhookSubscription{})
+
+	// Apply filters
+	if filter.StakeholderID != nil {
+		query = query.Where("stakeholder_id = ?", *filter.StakeholderID)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.WebhookSubscription) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+func (r *webhookRepository) GetActiveByStakeholders(ctx context.Context, stakeholderIDs []uuid.UUID) ([]*domain.WebhookSubscription, error) {
+	var subscriptions []*domain.WebhookSubscription
+	if len(stakeholderIDs) == 0 {
+		return subscriptions, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("stakeholder_id IN ? AND is_active = ?", stakeholderIDs, true).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// RecordFailure counts one more failed attempt in a row and returns the new count
+func (r *webhookRepository) RecordFailure(ctx context.Context, id uuid.UUID) (int, error) {
+	var subscription domain.WebhookSubscription
+	err := r.db.WithContext(ctx).Model(&subscription).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "consecutive_failures"}}}).
+		Where("id = ?", id).
+		Update("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error
+	return subscription.ConsecutiveFailures, err
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(deliveries, 100).Error
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := r.db.WithContext(ctx).Preload("Subscription").Where("id = ?", id).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.WebhookDelivery{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, filter *dto.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, *paging.Page, error) {
+	query := r.db.WithContext(ctx).Model(&domain.WebhookDelivery{})
+
+	// Apply filters
+	if filter.SubscriptionID != nil {
+		query = query.Where("subscription_id = ?", *filter.SubscriptionID)
+	}
+	if filter.EventID != nil {
+		query = query.Where("event_id = ?", *filter.EventID)
+	}
+	if filter.Topic != nil {
+		query = query.Where("topic = ?", *filter.Topic)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	// Apply pagination and ordering
+	req := paging.Request{Limit: filter.Limit, Offset: filter.Offset, Cursor: filter.Cursor, Count: filter.Count}
+	return listPage(query, keyset{at: "created_at", id: "id"}, req, func(item *domain.WebhookDelivery) paging.Cursor {
+		return paging.Cursor{At: item.CreatedAt, ID: item.ID}
+	})
+}
+
+// ClaimDueDeliveries takes up to limit pending deliveries whose next attempt is due and pushes
+// their next attempt lease into the future, so concurrent dispatchers never pick the same one.
+// A dispatcher that dies mid-attempt leaves the delivery to be retried once the lease runs out.
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.WebhookDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryStatusPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Model(&domain.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var deliveries []*domain.WebhookDelivery
+	err = r.db.WithContext(ctx).Preload("Subscription").Where("id IN ?", ids).Order("next_attempt_at ASC").Find(&deliveries).Error
+	return deliveries, err
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"log/slog"
+	"time"
+)
+
+const (
+	// analyticsRefreshBatch is how many stale products, and stakeholder days, one refresh
+	// transaction rebuilds
+	analyticsRefreshBatch = 200
+	// analyticsRefreshMaxBatches bounds the batches an on-demand refresh runs
+	analyticsRefreshMaxBatches = 25
+	// analyticsRefreshInterval is how often the refresher looks for stale figures
+	analyticsRefreshInterval = 30 * time.Second
+	// analyticsDefaultRange is the period reports cover unless from is given
+	analyticsDefaultRange = 30 * 24 * time.Hour
+	// analyticsDefaultGroups and analyticsMaxGroups bound the groups of a transit or dwell report
+	analyticsDefaultGroups = 100
+	analyticsMaxGroups     = 1000
+	// analyticsDefaultPoints and analyticsMaxPoints bound the points of a throughput report
+	analyticsDefaultPoints = 1000
+	analyticsMaxPoints     = 10000
+	// analyticsBusiestRoutes is how many routes the dashboard lists
+	analyticsBusiestRoutes = 5
+)
+
+type analyticsService struct {
+	repo repository.AnalyticsRepository
+	tx   repository.Transactor
+}
+
+func NewAnalyticsService(repo repository.AnalyticsRepository, tx repository.Transactor) *analyticsService {
+	return &analyticsService{repo: repo, tx: tx}
+}
+
+// Run refreshes stale analytics until ctx is cancelled. Several refreshers may run against the
+// same database; each stale product or day is rebuilt by one of them.
+func (s *analyticsService) Run(ctx context.Context) {
+	ticker := time.NewTicker(analyticsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain full batches before waiting again
+		for {
+			products, days, err := s.refreshBatch(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to refresh analytics", "error", err)
+			}
+			if err != nil || (products < analyticsRefreshBatch && days < analyticsRefreshBatch) {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh rebuilds stale analytics now, up to analyticsRefreshMaxBatches batches, and reports
+// what it rebuilt and what is left for the refresher
+func (s *analyticsService) Refresh(ctx context.Context) (*dto.AnalyticsRefresh, error) {
+	result := &dto.AnalyticsRefresh{}
+	for i := 0; i < analyticsRefreshMaxBatches; i++ {
+		products, days, err := s.refreshBatch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result.Products += products
+		result.Days += days
+		if products < analyticsRefreshBatch && days < analyticsRefreshBatch {
+			break
+		}
+	}
+
+	backlog, err := s.repo.GetBacklog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get analytics backlog: %w", err)
+	}
+	result.Remaining = backlog
+	return result, nil
+}
+
+// refreshBatch claims one batch of stale products and stakeholder days and rebuilds them in one
+// transaction, so a failed rebuild leaves them queued
+func (s *analyticsService) refreshBatch(ctx context.Context) (int, int, error) {
+	var products, days int
+	err := s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		productIDs, err := repos.Analytics.ClaimStaleProducts(ctx, analyticsRefreshBatch)
+		if err != nil {
+			return fmt.Errorf("failed to claim stale products: %w", err)
+		}
+		if err := repos.Analytics.RebuildProducts(ctx, productIDs); err != nil {
+			return fmt.Errorf("failed to rebuild transit legs and dwells: %w", err)
+		}
+
+		stale, err := repos.Analytics.ClaimStaleDays(ctx, analyticsRefreshBatch)
+		if err != nil {
+			return fmt.Errorf("failed to claim stale days: %w", err)
+		}
+		if err := repos.Analytics.RebuildThroughput(ctx, stale); err != nil {
+			return fmt.Errorf("failed to rebuild throughput: %w", err)
+		}
+
+		products, days = len(productIDs), len(stale)
+		return nil
+	})
+	return products, days, err
+}
+
+func (s *analyticsService) ListLegs(ctx context.Context, filter *dto.TransitLegFilter) (*dto.PaginatedResponse, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidAnalyticsQuery)
+	}
+
+	legs, page, err := s.repo.ListLegs(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transit legs: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(legs, page, filter.Limit, filter.Offset), nil
+}
+
+func (s *analyticsService) ListDwells(ctx context.Context, filter *dto.DwellFilter) (*dto.PaginatedResponse, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidAnalyticsQuery)
+	}
+
+	dwells, page, err := s.repo.ListDwells(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dwells: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(dwells, page, filter.Limit, filter.Offset), nil
+}
+
+// GetTransitReport summarises the transit times of the legs received in the query's range, over
+// all of them and per group (by route unless group_by says otherwise)
+func (s *analyticsService) GetTransitReport(ctx context.Context, query *dto.AnalyticsQuery) (*dto.TransitReport, error) {
+	if query.GroupBy == "" {
+		query.GroupBy = "route"
+	}
+	switch query.GroupBy {
+	case "route", "lane", "sender", "receiver", "none":
+	default:
+		return nil, fmt.Errorf("%w: group_by must be route, lane, sender, receiver or none", ErrInvalidAnalyticsQuery)
+	}
+	if err := analyticsRange(query, analyticsDefaultGroups, analyticsMaxGroups); err != nil {
+		return nil, err
+	}
+
+	overall, err := s.repo.TransitStats(ctx, ungrouped(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transit times: %w", err)
+	}
+	report := &dto.TransitReport{From: *query.From, To: *query.To, GroupBy: query.GroupBy, Groups: []*dto.TransitStats{}}
+	if len(overall) > 0 {
+		report.Overall = &overall[0].DurationStats
+	}
+	if query.GroupBy != "none" {
+		if report.Groups, err = s.repo.TransitStats(ctx, query); err != nil {
+			return nil, fmt.Errorf("failed to get transit times: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// GetDwellReport summarises the stays that ended in the query's range, over all of them and per
+// group (by stakeholder unless group_by says otherwise), and counts those still open
+func (s *analyticsService) GetDwellReport(ctx context.Context, query *dto.AnalyticsQuery) (*dto.DwellReport, error) {
+	if query.GroupBy == "" {
+		query.GroupBy = "stakeholder"
+	}
+	switch query.GroupBy {
+	case "stakeholder", "location", "none":
+	default:
+		return nil, fmt.Errorf("%w: group_by must be stakeholder, location or none", ErrInvalidAnalyticsQuery)
+	}
+	if err := analyticsRange(query, analyticsDefaultGroups, analyticsMaxGroups); err != nil {
+		return nil, err
+	}
+
+	overall, err := s.repo.DwellStats(ctx, ungrouped(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dwell times: %w", err)
+	}
+	report := &dto.DwellReport{From: *query.From, To: *query.To, GroupBy: query.GroupBy, Groups: []*dto.DwellStats{}}
+	if len(overall) > 0 {
+		report.Overall = &overall[0].DurationStats
+	}
+	if query.GroupBy != "none" {
+		if report.Groups, err = s.repo.DwellStats(ctx, query); err != nil {
+			return nil, fmt.Errorf("failed to get dwell times: %w", err)
+		}
+	}
+	if report.Open, err = s.repo.CountOpenDwells(ctx, query); err != nil {
+		return nil, fmt.Errorf("failed to count open dwells: %w", err)
+	}
+	return report, nil
+}
+
+// GetThroughput counts the events of each stakeholder per day, week or month over the days of the
+// query's range
+func (s *analyticsService) GetThroughput(ctx context.Context, query *dto.AnalyticsQuery) (*dto.ThroughputReport, error) {
+	if query.Period == "" {
+		query.Period = "day"
+	}
+	switch query.Period {
+	case "day", "week", "month":
+	default:
+		return nil, fmt.Errorf("%w: period must be day, week or month", ErrInvalidAnalyticsQuery)
+	}
+	if err := analyticsRange(query, analyticsDefaultPoints, analyticsMaxPoints); err != nil {
+		return nil, err
+	}
+
+	points, err := s.repo.Throughput(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get throughput: %w", err)
+	}
+	return &dto.ThroughputReport{From: *query.From, To: *query.To, Period: query.Period, Points: points}, nil
+}
+
+// GetDashboard sums up the query's range: counts, throughput, transit and dwell times and the
+// busiest routes, with the backlog the figures do not reflect yet
+func (s *analyticsService) GetDashboard(ctx context.Context, query *dto.AnalyticsQuery) (*dto.AnalyticsDashboard, error) {
+	if err := analyticsRange(query, analyticsBusiestRoutes, analyticsBusiestRoutes); err != nil {
+		return nil, err
+	}
+	query.GroupBy = "route"
+
+	dashboard, err := s.repo.GetOverview(ctx, *query.From, *query.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overview: %w", err)
+	}
+	if dashboard.Throughput, err = s.repo.ThroughputTotals(ctx, *query.From, *query.To); err != nil {
+		return nil, fmt.Errorf("failed to get throughput: %w", err)
+	}
+
+	transit, err := s.repo.TransitStats(ctx, ungrouped(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transit times: %w", err)
+	}
+	if len(transit) > 0 {
+		dashboard.Transit = &transit[0].DurationStats
+	}
+	dwell, err := s.repo.DwellStats(ctx, ungrouped(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dwell times: %w", err)
+	}
+	if len(dwell) > 0 {
+		dashboard.Dwell = &dwell[0].DurationStats
+	}
+	if dashboard.BusiestRoutes, err = s.repo.TransitStats(ctx, query); err != nil {
+		return nil, fmt.Errorf("failed to get busiest routes: %w", err)
+	}
+
+	if dashboard.Backlog, err = s.repo.GetBacklog(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get analytics backlog: %w", err)
+	}
+	return dashboard, nil
+}
+
+// analyticsRange defaults a report to the analyticsDefaultRange up to now and bounds its limit
+func analyticsRange(query *dto.AnalyticsQuery, defaultLimit, maxLimit int) error {
+	if query.To == nil {
+		now := time.Now()
+		query.To = &now
+	}
+	if query.From == nil {
+		from := query.To.Add(-analyticsDefaultRange)
+		query.From = &from
+	}
+	if !query.From.Before(*query.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidAnalyticsQuery)
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultLimit
+	}
+	if query.Limit > maxLimit {
+		return fmt.Errorf("%w: limit may be at most %d", ErrInvalidAnalyticsQuery, maxLimit)
+	}
+	return nil
+}
+
+// ungrouped is the query summarised over all its rows
+func ungrouped(query *dto.AnalyticsQuery) *dto.AnalyticsQuery {
+	all := *query
+	all.GroupBy = "none"
+	return &all
+}
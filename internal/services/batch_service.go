@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+)
+
+// batchService looks up many records in one query each, for callers that resolve relations of
+// many parents at once (GraphQL dataloaders). Results come without relations preloaded and IDs
+// that do not exist are simply missing from the maps.
+type batchService struct {
+	productRepo     repository.ProductRepository
+	stakeholderRepo repository.StakeholderRepository
+	eventRepo       repository.SupplyChainEventRepository
+	transactionRepo repository.BlockchainTransactionRepository
+}
+
+func NewBatchService(productRepo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository, eventRepo repository.SupplyChainEventRepository, transactionRepo repository.BlockchainTransactionRepository) *batchService {
+	return &batchService{productRepo: productRepo, stakeholderRepo: stakeholderRepo, eventRepo: eventRepo, transactionRepo: transactionRepo}
+}
+
+func (s *batchService) ProductsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Product, error) {
+	products, err := s.productRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	return indexByID(products, func(p *domain.Product) uuid.UUID { return p.ID }), nil
+}
+
+func (s *batchService) StakeholdersByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Stakeholder, error) {
+	stakeholders, err := s.stakeholderRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stakeholders: %w", err)
+	}
+	return indexByID(stakeholders, func(s *domain.Stakeholder) uuid.UUID { return s.ID }), nil
+}
+
+func (s *batchService) EventsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.SupplyChainEvent, error) {
+	events, err := s.eventRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supply chain events: %w", err)
+	}
+	return indexByID(events, func(e *domain.SupplyChainEvent) uuid.UUID { return e.ID }), nil
+}
+
+// EventsByProducts returns up to perProduct of each product's first events, in timestamp order
+func (s *batchService) EventsByProducts(ctx context.Context, productIDs []uuid.UUID, perProduct int) (map[uuid.UUID][]*domain.SupplyChainEvent, error) {
+	events, err := s.eventRepo.GetByProducts(ctx, productIDs, perProduct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events by products: %w", err)
+	}
+	return groupByParent(events, func(e *domain.SupplyChainEvent) *uuid.UUID { return e.ProductID }), nil
+}
+
+// EventsByStakeholders returns up to perStakeholder of each stakeholder's latest events
+func (s *batchService) EventsByStakeholders(ctx context.Context, stakeholderIDs []uuid.UUID, perStakeholder int) (map[uuid.UUID][]*domain.SupplyChainEvent, error) {
+	events, err := s.eventRepo.GetByStakeholders(ctx, stakeholderIDs, perStakeholder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events by stakeholders: %w", err)
+	}
+	return groupByParent(events, func(e *domain.SupplyChainEvent) *uuid.UUID { return e.StakeholderID }), nil
+}
+
+// ProductsByManufacturers returns up to perManufacturer of each manufacturer's newest products
+func (s *batchService) ProductsByManufacturers(ctx context.Context, manufacturerIDs []uuid.UUID, perManufacturer int) (map[uuid.UUID][]*domain.Product, error) {
+	products, err := s.productRepo.GetByManufacturers(ctx, manufacturerIDs, perManufacturer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products by manufacturers: %w", err)
+	}
+	return groupByParent(products, func(p *domain.Product) *uuid.UUID { return p.ManufacturerID }), nil
+}
+
+// TransactionsByEvents returns up to perEvent of each event's latest blockchain transactions
+func (s *batchService) TransactionsByEvents(ctx context.Context, eventIDs []uuid.UUID, perEvent int) (map[uuid.UUID][]*domain.BlockchainTransaction, error) {
+	transactions, err := s.transactionRepo.GetByEvents(ctx, eventIDs, perEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions by events: %w", err)
+	}
+	return groupByParent(transactions, func(t *domain.BlockchainTransaction) *uuid.UUID { return t.EventID }), nil
+}
+
+func indexByID[T any](items []T, id func(T) uuid.UUID) map[uuid.UUID]T {
+	index := make(map[uuid.UUID]T, len(items))
+	for _, item := range items {
+		index[id(item)] = item
+	}
+	return index
+}
+
+func groupByParent[T any](items []T, parent func(T) *uuid.UUID) map[uuid.UUID][]T {
+	groups := make(map[uuid.UUID][]T)
+	for _, item := range items {
+		if id := parent(item); id != nil {
+			groups[*id] = append(groups[*id], item)
+		}
+	}
+	return groups
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
 	"github.com/koriebruh/suplyChainTrack/internal/repository"
 	"gorm.io/gorm"
 	"time"
@@ -15,10 +16,11 @@ import (
 type blockchainService struct {
 	repo      repository.BlockchainTransactionRepository
 	eventRepo repository.SupplyChainEventRepository
+	tx        repository.Transactor
 }
 
-func NewBlockchainService(repo repository.BlockchainTransactionRepository, eventRepo repository.SupplyChainEventRepository) *blockchainService {
-	return &blockchainService{repo: repo, eventRepo: eventRepo}
+func NewBlockchainService(repo repository.BlockchainTransactionRepository, eventRepo repository.SupplyChainEventRepository, tx repository.Transactor) *blockchainService {
+	return &blockchainService{repo: repo, eventRepo: eventRepo, tx: tx}
 }
 
 func (s *blockchainService) CreateTransaction(ctx context.Context, req *dto.CreateBlockchainTransactionRequest) (*domain.BlockchainTransaction, error) {
@@ -47,8 +49,17 @@ func (s *blockchainService) CreateTransaction(ctx context.Context, req *dto.Crea
 		CreatedAt:       time.Now(),
 	}
 
-	if err := s.repo.Create(ctx, transaction); err != nil {
-		return nil, fmt.Errorf("failed to create blockchain transaction: %w", err)
+	err := s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		if err := repos.BlockchainTransaction.Create(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to create blockchain transaction: %w", err)
+		}
+		if transaction.Status == domain.TransactionStatusConfirmed {
+			return emit(ctx, repos.Outbox, eventbus.TransactionConfirmed{Transaction: transaction})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return s.repo.GetByID(ctx, transaction.ID)
@@ -93,18 +104,12 @@ func (s *blockchainService) ListTransactions(ctx context.Context, filter *dto.Bl
 		filter = &dto.BlockchainTransactionFilter{Limit: 10, Offset: 0}
 	}
 
-	transactions, total, err := s.repo.List(ctx, filter)
+	transactions, page, err := s.repo.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list blockchain transactions: %w", err)
 	}
 
-	return &dto.PaginatedResponse{
-		Data:    transactions,
-		Total:   int(total),
-		Limit:   filter.Limit,
-		Offset:  filter.Offset,
-		HasMore: filter.Offset+filter.Limit < int(total),
-	}, nil
+	return dto.NewPaginatedResponse(transactions, page, filter.Limit, filter.Offset), nil
 }
 
 func (s *blockchainService) UpdateTransactionStatus(ctx context.Context, id uuid.UUID, status string, blockNumber *int64) error {
@@ -112,6 +117,11 @@ func (s *blockchainService) UpdateTransactionStatus(ctx context.Context, id uuid
 		return ErrInvalidTransactionStatus
 	}
 
+	previous, err := s.GetTransaction(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	updates := map[string]interface{}{
 		"status":     status,
 		"updated_at": time.Now(),
@@ -121,11 +131,24 @@ func (s *blockchainService) UpdateTransactionStatus(ctx context.Context, id uuid
 		updates["block_number"] = *blockNumber
 	}
 
-	if err := s.repo.Update(ctx, id, updates); err != nil {
-		return fmt.Errorf("failed to update transaction status: %w", err)
-	}
+	return s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		if err := repos.BlockchainTransaction.Update(ctx, id, updates); err != nil {
+			return fmt.Errorf("failed to update transaction status: %w", err)
+		}
+		if previous.Status == status {
+			return nil
+		}
 
-	return nil
+		updated, err := repos.BlockchainTransaction.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get updated transaction: %w", err)
+		}
+		events := []eventbus.Event{eventbus.TransactionStatusChanged{Transaction: updated, PreviousStatus: previous.Status}}
+		if status == domain.TransactionStatusConfirmed {
+			events = append(events, eventbus.TransactionConfirmed{Transaction: updated})
+		}
+		return emit(ctx, repos.Outbox, events...)
+	})
 }
 
 func (s *blockchainService) GetTransactionsByEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.BlockchainTransaction, error) {
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+)
+
+// maxContainmentDepth bounds how many levels of nesting (item → case → pallet → container ...) are walked
+const maxContainmentDepth = 16
+
+func (s *supplyChainService) GetContainmentAt(ctx context.Context, productID uuid.UUID, at time.Time) (*dto.ContainmentSnapshot, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to validate product: %w", err)
+	}
+
+	snapshot := &dto.ContainmentSnapshot{
+		ProductID:  productID,
+		At:         at,
+		Containers: []*domain.ProductContainment{},
+	}
+
+	// Walk outwards from the product to its outermost container
+	current := productID
+	for depth := 0; depth < maxContainmentDepth; depth++ {
+		containment, err := s.containmentRepo.GetContainerAt(ctx, current, at)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			return nil, fmt.Errorf("failed to get container: %w", err)
+		}
+		snapshot.Containers = append(snapshot.Containers, containment)
+		current = containment.ParentID
+	}
+
+	contents, err := s.containmentRepo.GetContentsAt(ctx, productID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container contents: %w", err)
+	}
+	snapshot.Contents = contents
+
+	return snapshot, nil
+}
+
+func (s *supplyChainService) GetContainmentHistory(ctx context.Context, productID uuid.UUID) ([]*domain.ProductContainment, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to validate product: %w", err)
+	}
+
+	history, err := s.containmentRepo.GetHistory(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get containment history: %w", err)
+	}
+
+	return history, nil
+}
+
+// applyContainment opens or closes containment records for a pack/unpack event
+func applyContainment(ctx context.Context, repo repository.ContainmentRepository, event *domain.SupplyChainEvent, childIDs []uuid.UUID) error {
+	switch event.EventType {
+	case domain.EventTypePack:
+		for _, childID := range childIDs {
+			containment := &domain.ProductContainment{
+				ID:          uuid.New(),
+				ParentID:    *event.ProductID,
+				ChildID:     childID,
+				PackEventID: &event.ID,
+				PackedAt:    event.Timestamp,
+				CreatedAt:   time.Now(),
+			}
+			if err := repo.Create(ctx, containment); err != nil {
+				return fmt.Errorf("failed to pack product: %w", err)
+			}
+		}
+	case domain.EventTypeUnpack:
+		affected, err := repo.Unpack(ctx, *event.ProductID, childIDs, event.ID, event.Timestamp)
+		if err != nil {
+			return fmt.Errorf("failed to unpack products: %w", err)
+		}
+		if len(childIDs) > 0 && affected != int64(len(childIDs)) {
+			return ErrNotContained
+		}
+	}
+	return nil
+}
+
+func (s *supplyChainService) validatePack(ctx context.Context, req *dto.CreateSupplyChainEventRequest) error {
+	if len(req.ChildIDs) == 0 {
+		return ErrInvalidContainment
+	}
+
+	children := make(map[uuid.UUID]bool, len(req.ChildIDs))
+	for _, childID := range req.ChildIDs {
+		if childID == *req.ProductID || children[childID] {
+			return ErrInvalidContainment
+		}
+		children[childID] = true
+
+		if _, err := s.productRepo.GetByID(ctx, childID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrProductNotFound
+			}
+			return fmt.Errorf("failed to validate child product: %w", err)
+		}
+
+		// A product can only sit in one container at a time
+		if _, err := s.containmentRepo.GetActiveByChild(ctx, childID); err == nil {
+			return ErrAlreadyContained
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to check containment: %w", err)
+		}
+	}
+
+	// Reject cycles: none of the children may currently contain the target container
+	current := *req.ProductID
+	for depth := 0; depth < maxContainmentDepth; depth++ {
+		containment, err := s.containmentRepo.GetActiveByChild(ctx, current)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			return fmt.Errorf("failed to check containment: %w", err)
+		}
+		if children[containment.ParentID] {
+			return ErrInvalidContainment
+		}
+		current = containment.ParentID
+	}
+
+	// Packing hands the container's custody to its contents, so the packer must hold them all
+	return s.requireHolder(ctx, req.StakeholderID, append([]uuid.UUID{*req.ProductID}, req.ChildIDs...))
+}
+
+func (s *supplyChainService) validateUnpack(ctx context.Context, req *dto.CreateSupplyChainEventRequest) error {
+	active, err := s.containmentRepo.GetActiveChildren(ctx, *req.ProductID)
+	if err != nil {
+		return fmt.Errorf("failed to get container contents: %w", err)
+	}
+
+	packed := make(map[uuid.UUID]*domain.ProductContainment, len(active))
+	for _, containment := range active {
+		packed[containment.ChildID] = containment
+	}
+
+	targets := active
+	if len(req.ChildIDs) > 0 {
+		targets = make([]*domain.ProductContainment, 0, len(req.ChildIDs))
+		for _, childID := range req.ChildIDs {
+			containment, ok := packed[childID]
+			if !ok {
+				return ErrNotContained
+			}
+			targets = append(targets, containment)
+		}
+	}
+	if len(targets) == 0 {
+		return ErrNotContained
+	}
+
+	for _, containment := range targets {
+		if req.Timestamp.Before(containment.PackedAt) {
+			return ErrInvalidEventSequence
+		}
+	}
+
+	return s.requireHolder(ctx, req.StakeholderID, []uuid.UUID{*req.ProductID})
+}
+
+// productHistory returns the product's own events followed by the transformations that produced it
+// and the events it inherited from containers
+func (s *supplyChainService) productHistory(ctx context.Context, productID uuid.UUID) ([]*domain.SupplyChainEvent, error) {
+	events, err := s.repo.GetByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	produced, err := s.transformationRepo.GetProducingEvents(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, produced...)
+
+	inherited, err := s.inheritedEvents(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range inherited {
+		events = append(events, item.Event)
+	}
+
+	return events, nil
+}
+
+// inheritedEvents collects events recorded on every container that held the product, limited to the time it was inside
+func (s *supplyChainService) inheritedEvents(ctx context.Context, productID uuid.UUID) ([]*dto.InheritedEvent, error) {
+	var inherited []*dto.InheritedEvent
+	if err := s.collectInheritedEvents(ctx, productID, time.Time{}, nil, 0, &inherited); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(inherited, func(i, j int) bool {
+		return inherited[i].Event.Timestamp.Before(inherited[j].Event.Timestamp)
+	})
+	return inherited, nil
+}
+
+func (s *supplyChainService) collectInheritedEvents(ctx context.Context, childID uuid.UUID, from time.Time, to *time.Time, depth int, out *[]*dto.InheritedEvent) error {
+	if depth >= maxContainmentDepth {
+		return nil
+	}
+
+	containments, err := s.containmentRepo.GetByChild(ctx, childID)
+	if err != nil {
+		return err
+	}
+
+	for _, containment := range containments {
+		// Intersect the containment period with the window inherited from the level below
+		start := containment.PackedAt
+		if from.After(start) {
+			start = from
+		}
+		end := containment.UnpackedAt
+		if to != nil && (end == nil || to.Before(*end)) {
+			end = to
+		}
+		if end != nil && end.Before(start) {
+			continue
+		}
+
+		events, err := s.repo.GetByProductBetween(ctx, containment.ParentID, start, end)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			*out = append(*out, &dto.InheritedEvent{ContainerID: containment.ParentID, Event: event})
+		}
+
+		if err := s.collectInheritedEvents(ctx, containment.ParentID, start, end, depth+1, out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"time"
+)
+
+// projectionReplayBatch is how many events RebuildProjection loads per query
+const projectionReplayBatch = 500
+
+// projectEvent folds an event into the custody projection of every product it touches:
+// the event's product, products unpacked or transformed by it, and everything packed inside them.
+// It runs inside the same transaction as the event write (see recordEvent).
+func projectEvent(ctx context.Context, repos *repository.RepositoriesManagers, event *domain.SupplyChainEvent) error {
+	if event.EventType == domain.EventTypeTransformed {
+		return projectTransformation(ctx, repos, event)
+	}
+	if event.ProductID == nil {
+		return nil
+	}
+
+	custody, err := advanceCustody(ctx, repos.CustodyProjection, *event.ProductID, event, func(c *domain.ProductCustody) {
+		switch event.EventType {
+		case domain.EventTypeShipped:
+			c.State = domain.CustodyStateInTransit // custody stays with the sender until received
+		case domain.EventTypeSold:
+			c.State = domain.CustodyStateSold
+		case domain.EventTypeReceived:
+			c.State = domain.CustodyStateInStock
+			// Custody only moves with an accepted transfer, which the received event names
+			if transferID, _ := event.Metadata["custody_transfer_id"].(string); transferID != "" {
+				holdBy(c, event)
+			}
+		case domain.EventTypeManufactured:
+			c.State = domain.CustodyStateInStock
+			startCustody(c, event)
+		default:
+			// Pack and unpack are recorded by the holder and leave custody where it is
+			c.State = domain.CustodyStateInStock
+		}
+	})
+	if err != nil || custody == nil {
+		return err
+	}
+
+	if event.EventType == domain.EventTypeUnpack {
+		unpacked, err := repos.Containment.GetByUnpackEvent(ctx, event.ID)
+		if err != nil {
+			return err
+		}
+		for _, containment := range unpacked {
+			child, err := advanceCustody(ctx, repos.CustodyProjection, containment.ChildID, event, func(c *domain.ProductCustody) {
+				c.ContainerID = nil
+				inheritCustody(c, custody)
+			})
+			if err != nil {
+				return err
+			}
+			if child != nil {
+				if err := propagateCustody(ctx, repos, child, event, 1); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return propagateCustody(ctx, repos, custody, event, 0)
+}
+
+// reprojectProducts rebuilds the custody projection of products after one of their events was
+// changed or removed. Containers pass their custody to their contents, so every product ever
+// packed with them is rebuilt too, by replaying all their events in order.
+func reprojectProducts(ctx context.Context, repos *repository.RepositoriesManagers, productIDs []uuid.UUID) error {
+	affected := make(map[uuid.UUID]bool, len(productIDs))
+	frontier := make([]uuid.UUID, 0, len(productIDs))
+	for _, id := range productIDs {
+		if !affected[id] {
+			affected[id] = true
+			frontier = append(frontier, id)
+		}
+	}
+	for depth := 0; depth < maxContainmentDepth && len(frontier) > 0; depth++ {
+		var next []uuid.UUID
+		for _, id := range frontier {
+			history, err := repos.Containment.GetHistory(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get containment history: %w", err)
+			}
+			for _, containment := range history {
+				for _, related := range []uuid.UUID{containment.ParentID, containment.ChildID} {
+					if !affected[related] {
+						affected[related] = true
+						next = append(next, related)
+					}
+				}
+			}
+		}
+		frontier = next
+	}
+
+	ids := make([]uuid.UUID, 0, len(affected))
+	for id := range affected {
+		ids = append(ids, id)
+	}
+	if err := repos.CustodyProjection.DeleteByProducts(ctx, ids); err != nil {
+		return fmt.Errorf("failed to clear custody projection: %w", err)
+	}
+	return repos.SupplyChainEvent.EachForProducts(ctx, ids, projectionReplayBatch, func(events []*domain.SupplyChainEvent) error {
+		for _, event := range events {
+			if err := projectEvent(ctx, repos, event); err != nil {
+				return fmt.Errorf("failed to replay event %s: %w", event.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+func projectTransformation(ctx context.Context, repos *repository.RepositoriesManagers, event *domain.SupplyChainEvent) error {
+	lines, err := repos.Transformation.GetByEvent(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		state := domain.CustodyStateInStock
+		if line.Direction == domain.TransformationDirectionInput {
+			state = domain.CustodyStateConsumed
+		}
+
+		custody, err := advanceCustody(ctx, repos.CustodyProjection, line.ProductID, event, func(c *domain.ProductCustody) {
+			c.State = state
+			startCustody(c, event) // outputs made by the transformation start with its holder
+		})
+		if err != nil {
+			return err
+		}
+		if custody != nil {
+			if err := propagateCustody(ctx, repos, custody, event, 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// propagateCustody copies a container's holder, location and state onto everything packed in it at the event time
+func propagateCustody(ctx context.Context, repos *repository.RepositoriesManagers, parent *domain.ProductCustody, event *domain.SupplyChainEvent, depth int) error {
+	if depth >= maxContainmentDepth {
+		return nil
+	}
+
+	contents, err := repos.Containment.GetContentsAt(ctx, parent.ProductID, event.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	containerID := parent.ProductID
+	for _, containment := range contents {
+		child, err := advanceCustody(ctx, repos.CustodyProjection, containment.ChildID, event, func(c *domain.ProductCustody) {
+			c.ContainerID = &containerID
+			inheritCustody(c, parent)
+		})
+		if err != nil {
+			return err
+		}
+		if child != nil {
+			if err := propagateCustody(ctx, repos, child, event, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// advanceCustody loads (or starts) a product's projection row, applies mutate and saves it.
+// It returns nil without writing when the projection already reflects a newer event.
+func advanceCustody(ctx context.Context, repo repository.CustodyProjectionRepository, productID uuid.UUID, event *domain.SupplyChainEvent, mutate func(c *domain.ProductCustody)) (*domain.ProductCustody, error) {
+	custody, err := repo.GetByProduct(ctx, productID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		custody = &domain.ProductCustody{ProductID: productID}
+	} else if event.Timestamp.Before(custody.LastEventAt) {
+		return nil, nil
+	}
+
+	if event.Location != nil {
+		custody.Location = event.Location
+	}
+	custody.LastEventID = &event.ID
+	custody.LastEventType = event.EventType
+	custody.LastEventAt = event.Timestamp
+	custody.UpdatedAt = time.Now()
+	mutate(custody)
+
+	if err := repo.Upsert(ctx, custody); err != nil {
+		return nil, fmt.Errorf("failed to update custody projection: %w", err)
+	}
+	return custody, nil
+}
+
+func holdBy(c *domain.ProductCustody, event *domain.SupplyChainEvent) {
+	if event.StakeholderID != nil {
+		c.HolderID = event.StakeholderID
+	}
+}
+
+// startCustody gives a product nobody holds yet to the event's stakeholder
+func startCustody(c *domain.ProductCustody, event *domain.SupplyChainEvent) {
+	if c.HolderID == nil {
+		holdBy(c, event)
+	}
+}
+
+func inheritCustody(c *domain.ProductCustody, parent *domain.ProductCustody) {
+	c.HolderID = parent.HolderID
+	c.Location = parent.Location
+	c.State = parent.State
+}
@@ -0,0 +1,417 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+)
+
+// defaultTransferTTL is how long a receiver has to respond before a pending transfer expires
+const defaultTransferTTL = 72 * time.Hour
+
+// transferExpiryInterval is how often the expirer looks for overdue pending transfers
+const transferExpiryInterval = time.Minute
+
+type custodyService struct {
+	repo            repository.CustodyTransferRepository
+	projectionRepo  repository.CustodyProjectionRepository
+	productRepo     repository.ProductRepository
+	stakeholderRepo repository.StakeholderRepository
+	supplyChain     SupplyChainService
+	metrics         Metrics
+	tx              repository.Transactor
+}
+
+func NewCustodyService(repo repository.CustodyTransferRepository, projectionRepo repository.CustodyProjectionRepository, productRepo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository, supplyChain SupplyChainService, metrics Metrics, tx repository.Transactor) *custodyService {
+	return &custodyService{repo: repo, projectionRepo: projectionRepo, productRepo: productRepo, stakeholderRepo: stakeholderRepo, supplyChain: supplyChain, metrics: metrics, tx: tx}
+}
+
+func (s *custodyService) CreateTransfer(ctx context.Context, req *dto.CreateCustodyTransferRequest) (*domain.CustodyTransfer, error) {
+	if req.SenderID == req.ReceiverID {
+		return nil, ErrInvalidCustodyTransfer
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(defaultTransferTTL)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return nil, ErrInvalidCustodyTransfer
+		}
+		expiresAt = *req.ExpiresAt
+	}
+	if req.ExpectedArrivalAt != nil && !req.ExpectedArrivalAt.After(now) {
+		return nil, ErrInvalidCustodyTransfer
+	}
+
+	// Validate product and both parties
+	if _, err := s.productRepo.GetByID(ctx, req.ProductID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to validate product: %w", err)
+	}
+	for _, stakeholderID := range []uuid.UUID{req.SenderID, req.ReceiverID} {
+		if _, err := s.stakeholderRepo.GetByID(ctx, stakeholderID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrStakeholderNotFound
+			}
+			return nil, fmt.Errorf("failed to validate stakeholder: %w", err)
+		}
+	}
+
+	// Only one handoff per product can be in flight
+	if _, err := s.repo.GetPendingByProduct(ctx, req.ProductID); err == nil {
+		return nil, ErrPendingTransferExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check pending transfers: %w", err)
+	}
+
+	holder, err := s.currentHolder(ctx, req.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current custody holder: %w", err)
+	}
+	if holder != nil && *holder != req.SenderID {
+		return nil, ErrNotCustodyHolder
+	}
+
+	transferID := uuid.New()
+	metadata := domain.JSONB{}
+	for key, value := range req.Metadata {
+		metadata[key] = value
+	}
+	metadata["custody_transfer_id"] = transferID.String()
+	metadata["receiver_id"] = req.ReceiverID.String()
+
+	shipReq := &dto.CreateSupplyChainEventRequest{
+		ProductID:     &req.ProductID,
+		StakeholderID: &req.SenderID,
+		EventType:     domain.EventTypeShipped,
+		Location:      req.Location,
+		Timestamp:     now,
+		Metadata:      metadata,
+	}
+	if err := s.supplyChain.ValidateEventSequence(ctx, shipReq); err != nil {
+		return nil, err
+	}
+
+	shipEvent := newEvent(shipReq)
+	transfer := &domain.CustodyTransfer{
+		ID:                transferID,
+		ProductID:         req.ProductID,
+		SenderID:          req.SenderID,
+		ReceiverID:        req.ReceiverID,
+		Status:            domain.CustodyTransferStatusPending,
+		Quantity:          req.Quantity,
+		Notes:             req.Notes,
+		ShipEventID:       &shipEvent.ID,
+		ExpiresAt:         expiresAt,
+		ExpectedArrivalAt: req.ExpectedArrivalAt,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		// An overdue transfer the expirer has not reached yet would still hold the product's
+		// one pending slot
+		if _, err := repos.CustodyTransfer.ExpirePendingByProduct(ctx, req.ProductID, now); err != nil {
+			return fmt.Errorf("failed to expire overdue custody transfer: %w", err)
+		}
+		if err := recordEvent(ctx, repos, s.metrics, shipEvent, shipReq); err != nil {
+			return err
+		}
+		if err := repos.CustodyTransfer.Create(ctx, transfer); err != nil {
+			return fmt.Errorf("failed to create custody transfer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, transfer.ID)
+}
+
+func (s *custodyService) GetTransfer(ctx context.Context, id uuid.UUID) (*domain.CustodyTransfer, error) {
+	transfer, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCustodyTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get custody transfer: %w", err)
+	}
+	return transfer, nil
+}
+
+func (s *custodyService) AcceptTransfer(ctx context.Context, id uuid.UUID, req *dto.AcceptCustodyTransferRequest) (*domain.CustodyTransfer, error) {
+	transfer, err := s.respondable(ctx, id, req.StakeholderID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	hasDiscrepancy := len(req.Discrepancies) > 0 ||
+		(transfer.Quantity != nil && req.ReceivedQuantity != nil && *req.ReceivedQuantity != *transfer.Quantity)
+
+	metadata := domain.JSONB{
+		"custody_transfer_id": transfer.ID.String(),
+		"sender_id":           transfer.SenderID.String(),
+	}
+	if req.ReceivedQuantity != nil {
+		metadata["received_quantity"] = *req.ReceivedQuantity
+	}
+	if len(req.Discrepancies) > 0 {
+		metadata["discrepancies"] = req.Discrepancies
+	}
+	if req.Notes != nil {
+		metadata["notes"] = *req.Notes
+	}
+
+	receiveReq := &dto.CreateSupplyChainEventRequest{
+		ProductID:         &transfer.ProductID,
+		StakeholderID:     &transfer.ReceiverID,
+		EventType:         domain.EventTypeReceived,
+		Location:          req.Location,
+		Timestamp:         now,
+		Metadata:          metadata,
+		CustodyTransferID: &transfer.ID,
+	}
+	if err := s.supplyChain.ValidateEventSequence(ctx, receiveReq); err != nil {
+		return nil, err
+	}
+
+	receiveEvent := newEvent(receiveReq)
+	updates := map[string]interface{}{
+		"status":            domain.CustodyTransferStatusAccepted,
+		"received_quantity": req.ReceivedQuantity,
+		"discrepancies":     req.Discrepancies,
+		"has_discrepancy":   hasDiscrepancy,
+		"receive_event_id":  receiveEvent.ID,
+		"responded_at":      now,
+		"updated_at":        now,
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		updated, err := repos.CustodyTransfer.UpdateIfPending(ctx, transfer.ID, updates)
+		if err != nil {
+			return fmt.Errorf("failed to accept custody transfer: %w", err)
+		}
+		if !updated {
+			return ErrCustodyTransferNotPending
+		}
+		return recordEvent(ctx, repos, s.metrics, receiveEvent, receiveReq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTransfer(ctx, transfer.ID)
+}
+
+func (s *custodyService) RejectTransfer(ctx context.Context, id uuid.UUID, req *dto.RejectCustodyTransferRequest) (*domain.CustodyTransfer, error) {
+	transfer, err := s.respondable(ctx, id, req.StakeholderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rejection records no event: custody stays with the sender
+	now := time.Now()
+	updated, err := s.repo.UpdateIfPending(ctx, transfer.ID, map[string]interface{}{
+		"status":           domain.CustodyTransferStatusRejected,
+		"rejection_reason": req.Reason,
+		"responded_at":     now,
+		"updated_at":       now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject custody transfer: %w", err)
+	}
+	if !updated {
+		return nil, ErrCustodyTransferNotPending
+	}
+
+	return s.GetTransfer(ctx, transfer.ID)
+}
+
+func (s *custodyService) ListTransfers(ctx context.Context, filter *dto.CustodyTransferFilter) (*dto.PaginatedResponse, error) {
+	if filter == nil {
+		filter = &dto.CustodyTransferFilter{Limit: 10, Offset: 0}
+	}
+	if filter.Direction != nil && *filter.Direction != "incoming" && *filter.Direction != "outgoing" {
+		return nil, ErrInvalidCustodyTransfer
+	}
+	if filter.Status != nil && !domain.IsValidCustodyTransferStatus(*filter.Status) {
+		return nil, ErrInvalidCustodyTransfer
+	}
+
+	transfers, page, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custody transfers: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(transfers, page, filter.Limit, filter.Offset), nil
+}
+
+func (s *custodyService) ListPendingTransfers(ctx context.Context, stakeholderID uuid.UUID, filter *dto.CustodyTransferFilter) (*dto.PaginatedResponse, error) {
+	if _, err := s.stakeholderRepo.GetByID(ctx, stakeholderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStakeholderNotFound
+		}
+		return nil, fmt.Errorf("failed to validate stakeholder: %w", err)
+	}
+
+	if filter == nil {
+		filter = &dto.CustodyTransferFilter{Limit: 10, Offset: 0}
+	}
+	status := domain.CustodyTransferStatusPending
+	filter.StakeholderID = &stakeholderID
+	filter.Status = &status
+
+	return s.ListTransfers(ctx, filter)
+}
+
+func (s *custodyService) ExpireTransfers(ctx context.Context) (int64, error) {
+	expired, err := s.repo.ExpirePending(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire custody transfers: %w", err)
+	}
+	return expired, nil
+}
+
+// RunExpiry expires overdue pending transfers every transferExpiryInterval until ctx is
+// cancelled. Expiring is a single conditional update, so several instances can run at once.
+func (s *custodyService) RunExpiry(ctx context.Context) {
+	ticker := time.NewTicker(transferExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := s.ExpireTransfers(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "custody transfer expiry failed", "error", err)
+		} else if expired > 0 {
+			slog.InfoContext(ctx, "expired pending custody transfers", "count", expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// respondable loads a transfer the given stakeholder is allowed to accept or reject
+func (s *custodyService) respondable(ctx context.Context, id uuid.UUID, stakeholderID uuid.UUID) (*domain.CustodyTransfer, error) {
+	transfer, err := s.GetTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ReceiverID != stakeholderID {
+		return nil, ErrUnauthorized
+	}
+	if transfer.Status != domain.CustodyTransferStatusPending {
+		return nil, ErrCustodyTransferNotPending
+	}
+
+	if time.Now().After(transfer.ExpiresAt) {
+		now := time.Now()
+		if _, err := s.repo.UpdateIfPending(ctx, transfer.ID, map[string]interface{}{
+			"status":       domain.CustodyTransferStatusExpired,
+			"responded_at": now,
+			"updated_at":   now,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to expire custody transfer: %w", err)
+		}
+		return nil, ErrCustodyTransferExpired
+	}
+
+	return transfer, nil
+}
+
+// currentHolder returns the holder recorded in the custody projection, or nil if the product has none yet.
+// Sold or consumed products cannot be handed over.
+func (s *custodyService) currentHolder(ctx context.Context, productID uuid.UUID) (*uuid.UUID, error) {
+	custody, err := s.projectionRepo.GetByProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if custody.State == domain.CustodyStateSold || custody.State == domain.CustodyStateConsumed {
+		return nil, ErrInvalidCustodyTransfer
+	}
+	return custody.HolderID, nil
+}
+
+func (s *custodyService) GetCurrentCustody(ctx context.Context, productID uuid.UUID) (*domain.ProductCustody, error) {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to validate product: %w", err)
+	}
+
+	custody, err := s.projectionRepo.GetByProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCustodyNotFound
+		}
+		return nil, fmt.Errorf("failed to get current custody: %w", err)
+	}
+	return custody, nil
+}
+
+func (s *custodyService) ListInventory(ctx context.Context, filter *dto.InventoryFilter) (*dto.PaginatedResponse, error) {
+	if filter == nil {
+		filter = &dto.InventoryFilter{Limit: 10, Offset: 0}
+	}
+	if filter.State != nil && !domain.IsValidCustodyState(*filter.State) {
+		return nil, ErrInvalidCustodyState
+	}
+	if filter.HolderID != nil {
+		if _, err := s.stakeholderRepo.GetByID(ctx, *filter.HolderID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrStakeholderNotFound
+			}
+			return nil, fmt.Errorf("failed to validate stakeholder: %w", err)
+		}
+	}
+
+	items, page, err := s.projectionRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(items, page, filter.Limit, filter.Offset), nil
+}
+
+// RebuildProjection regenerates the custody projection by replaying every event in time order.
+// The swap is atomic: readers see the old projection until the rebuild commits.
+func (s *custodyService) RebuildProjection(ctx context.Context) (int64, error) {
+	var replayed int64
+	err := s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		if err := repos.CustodyProjection.DeleteAll(ctx); err != nil {
+			return fmt.Errorf("failed to clear custody projection: %w", err)
+		}
+
+		return repos.SupplyChainEvent.EachInOrder(ctx, projectionReplayBatch, func(events []*domain.SupplyChainEvent) error {
+			for _, event := range events {
+				if err := projectEvent(ctx, repos, event); err != nil {
+					return fmt.Errorf("failed to replay event %s: %w", event.ID, err)
+				}
+				replayed++
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return replayed, nil
+}
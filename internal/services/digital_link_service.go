@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/gs1"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+)
+
+type digitalLinkService struct {
+	productRepo repository.ProductRepository
+	supplyChain SupplyChainService
+}
+
+func NewDigitalLinkService(productRepo repository.ProductRepository, supplyChain SupplyChainService) *digitalLinkService {
+	return &digitalLinkService{
+		productRepo: productRepo,
+		supplyChain: supplyChain,
+	}
+}
+
+// Resolve finds the product whose SKU is the link's GTIN, in whichever length it was stored, and
+// loads its trace. A lot or serial in the link must agree with the one recorded on the product.
+func (s *digitalLinkService) Resolve(ctx context.Context, link *gs1.DigitalLink, resolver string) (*dto.DigitalLinkResolution, error) {
+	if !gs1.ValidGTIN(link.GTIN) {
+		return nil, gs1.ErrInvalidGTIN
+	}
+
+	var found bool
+	resolution := &dto.DigitalLinkResolution{
+		Link:       link.URI(resolver),
+		GTIN:       link.GTIN,
+		Lot:        link.Lot,
+		Serial:     link.Serial,
+		Attributes: link.Attributes,
+	}
+	for _, sku := range gs1.GTINCandidates(link.GTIN) {
+		product, err := s.productRepo.GetBySKU(ctx, sku)
+		if err == nil {
+			resolution.Product = product
+			found = true
+			break
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to resolve GTIN: %w", err)
+		}
+	}
+	if !found {
+		return nil, ErrProductNotFound
+	}
+
+	product := resolution.Product
+	if link.Lot != "" && product.LotNumber != nil && *product.LotNumber != link.Lot {
+		return nil, ErrDigitalLinkMismatch
+	}
+	if link.Serial != "" && product.SerialNumber != nil && *product.SerialNumber != link.Serial {
+		return nil, ErrDigitalLinkMismatch
+	}
+
+	trace, err := s.supplyChain.GetProductTrace(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolution.Trace = trace
+
+	return resolution, nil
+}
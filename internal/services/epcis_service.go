@@ -0,0 +1,648 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/epcis"
+	"github.com/koriebruh/suplyChainTrack/internal/gs1"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"io"
+	"sort"
+	"time"
+)
+
+// Metadata keys under which captured EPCIS details are kept on an event
+const (
+	metadataEPCISEventID = "epcis_event_id"
+	metadataEPCIS        = "epcis"
+)
+
+const (
+	defaultEPCISPageSize = 100
+	maxEPCISPageSize     = 1000
+)
+
+// epcisDetails is the part of a captured EPCIS event the tracer model has no column for.
+// It is kept in the event metadata so the event renders back the way it was captured.
+type epcisDetails struct {
+	Action             string                 `json:"action,omitempty"`
+	BizStep            string                 `json:"bizStep,omitempty"`
+	Disposition        string                 `json:"disposition,omitempty"`
+	ReadPoint          string                 `json:"readPoint,omitempty"`
+	Quantity           *epcis.QuantityElement `json:"quantity,omitempty"`
+	BizTransactionList []epcis.BizTransaction `json:"bizTransactionList,omitempty"`
+	SourceList         []epcis.Source         `json:"sourceList,omitempty"`
+	DestinationList    []epcis.Destination    `json:"destinationList,omitempty"`
+
+	// Split is set when one EPCIS event named several products and was stored as one event per product
+	Split bool `json:"split,omitempty"`
+}
+
+type epcisService struct {
+	eventRepo          repository.SupplyChainEventRepository
+	productRepo        repository.ProductRepository
+	stakeholderRepo    repository.StakeholderRepository
+	containmentRepo    repository.ContainmentRepository
+	transformationRepo repository.TransformationRepository
+	supplyChain        SupplyChainService
+	metrics            Metrics
+	tx                 repository.Transactor
+}
+
+func NewEPCISService(eventRepo repository.SupplyChainEventRepository, productRepo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository, containmentRepo repository.ContainmentRepository, transformationRepo repository.TransformationRepository, supplyChain SupplyChainService, metrics Metrics, tx repository.Transactor) *epcisService {
+	return &epcisService{
+		eventRepo:          eventRepo,
+		productRepo:        productRepo,
+		stakeholderRepo:    stakeholderRepo,
+		containmentRepo:    containmentRepo,
+		transformationRepo: transformationRepo,
+		supplyChain:        supplyChain,
+		metrics:            metrics,
+		tx:                 tx,
+	}
+}
+
+// Capture records every event of a document in event time order. Each EPCIS event is
+// all-or-nothing; a failing event is reported and does not stop the others.
+func (s *epcisService) Capture(ctx context.Context, events []*epcis.Event, stakeholderID *uuid.UUID) (*dto.EPCISCaptureResult, error) {
+	result := &dto.EPCISCaptureResult{
+		Received: len(events),
+		EventIDs: []uuid.UUID{},
+		Errors:   []*dto.EPCISCaptureError{},
+	}
+
+	order := make([]int, len(events))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return events[order[a]].EventTime.Before(events[order[b]].EventTime)
+	})
+
+	for _, index := range order {
+		recorded, err := s.CaptureEvent(ctx, events[index], stakeholderID)
+		s.tally(result, index, events[index], recorded, err)
+	}
+
+	return result, nil
+}
+
+// CaptureEvent maps one EPCIS event onto tracer events and records them in a single transaction
+func (s *epcisService) CaptureEvent(ctx context.Context, event *epcis.Event, stakeholderID *uuid.UUID) ([]*domain.SupplyChainEvent, error) {
+	if err := epcis.Validate(event); err != nil {
+		return nil, err
+	}
+
+	if event.EventID != "" {
+		existing, err := s.eventRepo.GetByMetadataValue(ctx, metadataEPCISEventID, event.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check captured events: %w", err)
+		}
+		if len(existing) > 0 {
+			return nil, ErrEPCISDuplicateEvent
+		}
+	}
+
+	eventType, err := epcis.DomainEventType(event)
+	if err != nil {
+		return nil, err
+	}
+
+	stakeholder, err := s.resolveStakeholder(ctx, event, stakeholderID)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs, err := s.requests(ctx, event, eventType, stakeholder)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range reqs {
+		if err := s.supplyChain.ValidateEventSequence(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := make([]*domain.SupplyChainEvent, 0, len(reqs))
+	err = s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		for _, req := range reqs {
+			created := newEvent(req)
+			if err := recordEvent(ctx, repos, s.metrics, created, req); err != nil {
+				return err
+			}
+			recorded = append(recorded, created)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recorded, nil
+}
+
+// ImportXML captures an EPCIS 1.2 XML document while it is read, in document order, so large files are
+// never held in memory. Events are reported the same way as Capture; a document that stops parsing
+// keeps what was captured before the fault and reports the fault against the next index.
+func (s *epcisService) ImportXML(ctx context.Context, r io.Reader, stakeholderID *uuid.UUID) (*dto.EPCISCaptureResult, error) {
+	result := &dto.EPCISCaptureResult{
+		EventIDs: []uuid.UUID{},
+		Errors:   []*dto.EPCISCaptureError{},
+	}
+
+	err := epcis.DecodeXML(r, func(index int, event *epcis.Event, err error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result.Received++
+		if err != nil {
+			s.tally(result, index, &epcis.Event{}, nil, err)
+			return nil
+		}
+		recorded, err := s.CaptureEvent(ctx, event, stakeholderID)
+		s.tally(result, index, event, recorded, err)
+		return nil
+	})
+	if errors.Is(err, epcis.ErrMalformedXML) {
+		s.tally(result, result.Received, &epcis.Event{}, nil, err)
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ExportProductTrace renders a product's trace, including events inherited from its containers, in event time order
+func (s *epcisService) ExportProductTrace(ctx context.Context, productID uuid.UUID) ([]*epcis.Event, error) {
+	trace, err := s.supplyChain.GetProductTrace(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.SupplyChainEvent, 0, len(trace.Events)+len(trace.InheritedEvents))
+	seen := make(map[uuid.UUID]bool, cap(events))
+	for _, event := range trace.Events {
+		seen[event.ID] = true
+		events = append(events, event)
+	}
+	for _, inherited := range trace.InheritedEvents {
+		if !seen[inherited.Event.ID] {
+			seen[inherited.Event.ID] = true
+			events = append(events, inherited.Event)
+		}
+	}
+	sort.SliceStable(events, func(a, b int) bool {
+		return events[a].Timestamp.Before(events[b].Timestamp)
+	})
+
+	return s.RenderEvents(ctx, events)
+}
+
+// ExportEvents renders every event with from <= eventTime < to, in event time order, one at a time
+func (s *epcisService) ExportEvents(ctx context.Context, from, to *time.Time, fn func(event *epcis.Event) error) error {
+	return s.eventRepo.EachInRange(ctx, from, to, maxEPCISPageSize, func(events []*domain.SupplyChainEvent) error {
+		for _, event := range events {
+			rendered, err := s.render(ctx, event)
+			if err != nil {
+				return err
+			}
+			if err := fn(rendered); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *epcisService) Query(ctx context.Context, query *dto.EPCISQuery) (*dto.EPCISQueryResult, error) {
+	// Rendering needs each event's product for its EPC
+	filter := &dto.SupplyChainEventFilter{Limit: defaultEPCISPageSize, Count: paging.CountNone}
+	filter.Query.Include = []string{"product"}
+	if query.PerPage > 0 {
+		filter.Limit = min(query.PerPage, maxEPCISPageSize)
+	}
+	if query.NextPageToken != "" {
+		cursor, err := paging.ParseCursor(query.NextPageToken)
+		if err != nil {
+			return nil, ErrInvalidEPCISQuery
+		}
+		filter.Cursor = cursor
+	}
+
+	empty := &dto.EPCISQueryResult{Events: []*epcis.Event{}}
+
+	// eventType and EQ_bizStep both narrow the tracer event types; their intersection applies
+	var allowed map[string]bool
+	narrow := func(types []string) {
+		next := make(map[string]bool, len(types))
+		for _, t := range types {
+			if allowed == nil || allowed[t] {
+				next[t] = true
+			}
+		}
+		allowed = next
+	}
+	if len(query.EventTypes) > 0 {
+		var types []string
+		for _, epcisType := range query.EventTypes {
+			mapped, err := epcis.EventTypesFor(epcisType)
+			if err != nil {
+				return nil, ErrInvalidEPCISQuery
+			}
+			types = append(types, mapped...)
+		}
+		narrow(types)
+	}
+	if query.BizStep != nil {
+		narrow(epcis.EventTypesForBizStep(*query.BizStep))
+	}
+	if allowed != nil {
+		if len(allowed) == 0 {
+			return empty, nil
+		}
+		for t := range allowed {
+			filter.EventTypes = append(filter.EventTypes, t)
+		}
+		sort.Strings(filter.EventTypes)
+	}
+
+	if query.EPC != nil {
+		product, err := s.resolveProduct(ctx, *query.EPC)
+		if err != nil {
+			if errors.Is(err, ErrProductNotFound) {
+				return empty, nil
+			}
+			return nil, err
+		}
+		filter.ProductID = &product.ID
+	}
+	if query.BizLocation != nil {
+		location := epcis.LocationFromURI(*query.BizLocation)
+		filter.Location = &location
+	}
+	if query.FromTime != nil {
+		filter.FromDate = query.FromTime
+	}
+	if query.ToTime != nil {
+		// LT_eventTime is exclusive; timestamps are stored with microsecond precision
+		to := query.ToTime.Add(-time.Microsecond)
+		filter.ToDate = &to
+	}
+
+	events, page, err := s.eventRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+
+	rendered, err := s.RenderEvents(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.EPCISQueryResult{Events: rendered}
+	if page.NextCursor != nil {
+		result.NextPageToken = page.NextCursor.String()
+	}
+	return result, nil
+}
+
+// RenderEvents converts tracer events into EPCIS events
+func (s *epcisService) RenderEvents(ctx context.Context, events []*domain.SupplyChainEvent) ([]*epcis.Event, error) {
+	rendered := make([]*epcis.Event, 0, len(events))
+	for _, event := range events {
+		out, err := s.render(ctx, event)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, out)
+	}
+	return rendered, nil
+}
+
+func (s *epcisService) tally(result *dto.EPCISCaptureResult, index int, event *epcis.Event, recorded []*domain.SupplyChainEvent, err error) {
+	switch {
+	case errors.Is(err, ErrEPCISDuplicateEvent):
+		result.Duplicates++
+	case err != nil:
+		result.Failed++
+		result.Errors = append(result.Errors, &dto.EPCISCaptureError{
+			Index:   index,
+			EventID: event.EventID,
+			Error:   err.Error(),
+		})
+	default:
+		result.Captured++
+		for _, created := range recorded {
+			result.EventIDs = append(result.EventIDs, created.ID)
+		}
+	}
+}
+
+// resolveStakeholder uses the event's stakeholder extension when present, else the capturing stakeholder
+func (s *epcisService) resolveStakeholder(ctx context.Context, event *epcis.Event, fallback *uuid.UUID) (*uuid.UUID, error) {
+	stakeholderID := fallback
+	if event.Stakeholder != "" {
+		id, ok := epcis.ParsePartyURI(event.Stakeholder)
+		if !ok {
+			return nil, fmt.Errorf("%w: stakeholder %s", epcis.ErrInvalidEvent, event.Stakeholder)
+		}
+		stakeholderID = &id
+	}
+	if stakeholderID == nil {
+		return nil, nil
+	}
+
+	if _, err := s.stakeholderRepo.GetByID(ctx, *stakeholderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStakeholderNotFound
+		}
+		return nil, fmt.Errorf("failed to validate stakeholder: %w", err)
+	}
+	return stakeholderID, nil
+}
+
+// resolveProduct finds the product an EPC or EPC class names: by id for urn:uuid, else by SKU
+// (the GTIN in any of its lengths, or the raw identifier)
+func (s *epcisService) resolveProduct(ctx context.Context, epc string) (*domain.Product, error) {
+	identifier := epcis.ParseEPC(epc)
+
+	if identifier.ProductID != nil {
+		product, err := s.productRepo.GetByID(ctx, *identifier.ProductID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: %s", ErrProductNotFound, epc)
+			}
+			return nil, fmt.Errorf("failed to resolve product: %w", err)
+		}
+		return product, nil
+	}
+
+	skus := []string{identifier.Raw}
+	if identifier.GTIN != "" {
+		skus = gs1.GTINCandidates(identifier.GTIN)
+	}
+	for _, sku := range skus {
+		product, err := s.productRepo.GetBySKU(ctx, sku)
+		if err == nil {
+			return product, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to resolve product: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrProductNotFound, epc)
+}
+
+// requests builds the tracer event requests for one EPCIS event: one per product for object
+// events, a single pack/unpack for aggregations and a single transformed event for transformations
+func (s *epcisService) requests(ctx context.Context, event *epcis.Event, eventType string, stakeholderID *uuid.UUID) ([]*dto.CreateSupplyChainEventRequest, error) {
+	details := epcisDetails{
+		Action:             event.Action,
+		BizStep:            epcis.ShortCBV(event.BizStep),
+		Disposition:        epcis.ShortCBV(event.Disposition),
+		BizTransactionList: event.BizTransactionList,
+		SourceList:         event.SourceList,
+		DestinationList:    event.DestinationList,
+	}
+	if event.ReadPoint != nil {
+		details.ReadPoint = event.ReadPoint.ID
+	}
+
+	newRequest := func(productID *uuid.UUID, details epcisDetails) *dto.CreateSupplyChainEventRequest {
+		metadata := domain.JSONB{metadataEPCIS: details}
+		if event.EventID != "" {
+			metadata[metadataEPCISEventID] = event.EventID
+		}
+		return &dto.CreateSupplyChainEventRequest{
+			ProductID:     productID,
+			StakeholderID: stakeholderID,
+			EventType:     eventType,
+			Location:      epcis.EventLocation(event),
+			Timestamp:     event.EventTime,
+			Metadata:      metadata,
+		}
+	}
+
+	switch event.Type {
+	case epcis.AggregationEvent:
+		parent, err := s.resolveProduct(ctx, event.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		req := newRequest(&parent.ID, details)
+		for _, epc := range event.ChildEPCs {
+			child, err := s.resolveProduct(ctx, epc)
+			if err != nil {
+				return nil, err
+			}
+			req.ChildIDs = append(req.ChildIDs, child.ID)
+		}
+		for _, quantity := range event.ChildQuantityList {
+			child, err := s.resolveProduct(ctx, quantity.EPCClass)
+			if err != nil {
+				return nil, err
+			}
+			req.ChildIDs = append(req.ChildIDs, child.ID)
+		}
+		return []*dto.CreateSupplyChainEventRequest{req}, nil
+
+	case epcis.TransformationEvent:
+		req := newRequest(nil, details)
+		var err error
+		if req.Inputs, err = s.transformationLines(ctx, event.InputEPCList, event.InputQuantityList); err != nil {
+			return nil, err
+		}
+		if req.Outputs, err = s.transformationLines(ctx, event.OutputEPCList, event.OutputQuantityList); err != nil {
+			return nil, err
+		}
+		return []*dto.CreateSupplyChainEventRequest{req}, nil
+
+	default:
+		details.Split = len(event.EPCList)+len(event.QuantityList) > 1
+		reqs := make([]*dto.CreateSupplyChainEventRequest, 0, len(event.EPCList)+len(event.QuantityList))
+		for _, epc := range event.EPCList {
+			product, err := s.resolveProduct(ctx, epc)
+			if err != nil {
+				return nil, err
+			}
+			reqs = append(reqs, newRequest(&product.ID, details))
+		}
+		for _, quantity := range event.QuantityList {
+			product, err := s.resolveProduct(ctx, quantity.EPCClass)
+			if err != nil {
+				return nil, err
+			}
+			classDetails := details
+			classDetails.Quantity = &quantity
+			reqs = append(reqs, newRequest(&product.ID, classDetails))
+		}
+		return reqs, nil
+	}
+}
+
+// transformationLines turns EPC lists (one unit each) and quantity lists into transformation lines
+func (s *epcisService) transformationLines(ctx context.Context, epcs []string, quantities []epcis.QuantityElement) ([]dto.TransformationLineRequest, error) {
+	lines := make([]dto.TransformationLineRequest, 0, len(epcs)+len(quantities))
+	for _, epc := range epcs {
+		product, err := s.resolveProduct(ctx, epc)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, dto.TransformationLineRequest{ProductID: product.ID, Quantity: 1, LotNumber: lotOf(epc)})
+	}
+	for _, quantity := range quantities {
+		product, err := s.resolveProduct(ctx, quantity.EPCClass)
+		if err != nil {
+			return nil, err
+		}
+		line := dto.TransformationLineRequest{ProductID: product.ID, Quantity: 1, LotNumber: lotOf(quantity.EPCClass)}
+		if quantity.Quantity != nil {
+			line.Quantity = *quantity.Quantity
+		}
+		if quantity.UOM != "" {
+			uom := quantity.UOM
+			line.Unit = &uom
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+func lotOf(epc string) *string {
+	if lot := epcis.ParseEPC(epc).Lot; lot != "" {
+		return &lot
+	}
+	return nil
+}
+
+// render converts one tracer event, restoring whatever EPCIS detail was kept when it was captured
+func (s *epcisService) render(ctx context.Context, event *domain.SupplyChainEvent) (*epcis.Event, error) {
+	epcisType, action, bizStep, disposition := epcis.Describe(event.EventType)
+	details := detailsOf(event.Metadata)
+
+	recordTime := event.CreatedAt.UTC()
+	out := &epcis.Event{
+		Type:                epcisType,
+		EventID:             epcis.PartyURI(event.ID),
+		EventTime:           event.Timestamp.UTC(),
+		EventTimeZoneOffset: "+00:00",
+		RecordTime:          &recordTime,
+		Action:              action,
+		BizStep:             bizStep,
+		Disposition:         disposition,
+		BizTransactionList:  details.BizTransactionList,
+		SourceList:          details.SourceList,
+		DestinationList:     details.DestinationList,
+	}
+	if capturedID, ok := event.Metadata[metadataEPCISEventID].(string); ok && !details.Split {
+		out.EventID = capturedID
+	}
+	if details.BizStep != "" {
+		out.BizStep = details.BizStep
+	}
+	if details.Disposition != "" {
+		out.Disposition = details.Disposition
+	}
+	if details.ReadPoint != "" {
+		out.ReadPoint = &epcis.Location{ID: details.ReadPoint}
+	}
+	if event.Location != nil && *event.Location != "" {
+		out.BizLocation = &epcis.Location{ID: epcis.LocationURI(*event.Location)}
+	}
+	if event.StakeholderID != nil {
+		out.Stakeholder = epcis.PartyURI(*event.StakeholderID)
+	}
+
+	// Custody transfers record the receiver on the shipped event
+	if len(out.DestinationList) == 0 {
+		if receiver, ok := event.Metadata["receiver_id"].(string); ok {
+			if receiverID, err := uuid.Parse(receiver); err == nil {
+				out.DestinationList = []epcis.Destination{{Type: epcis.SourceDestPossessingParty, Destination: epcis.PartyURI(receiverID)}}
+			}
+		}
+	}
+
+	product := event.Product
+	if product == nil && event.ProductID != nil {
+		loaded, err := s.productRepo.GetByID(ctx, *event.ProductID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to load event product: %w", err)
+		}
+		product = loaded
+	}
+
+	switch epcisType {
+	case epcis.AggregationEvent:
+		if product != nil {
+			out.ParentID = epcis.ProductEPC(product)
+		}
+		load := s.containmentRepo.GetByPackEvent
+		if event.EventType == domain.EventTypeUnpack {
+			load = s.containmentRepo.GetByUnpackEvent
+		}
+		containments, err := load(ctx, event.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load aggregation children: %w", err)
+		}
+		for _, containment := range containments {
+			if containment.Child != nil {
+				out.ChildEPCs = append(out.ChildEPCs, epcis.ProductEPC(containment.Child))
+			}
+		}
+
+	case epcis.TransformationEvent:
+		lines, err := s.transformationRepo.GetByEvent(ctx, event.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load transformation lines: %w", err)
+		}
+		for _, line := range lines {
+			if line.Product == nil {
+				continue
+			}
+			quantity := line.Quantity
+			element := epcis.QuantityElement{EPCClass: epcis.ProductClass(line.Product, line.LotNumber), Quantity: &quantity}
+			if line.Unit != nil {
+				element.UOM = *line.Unit
+			}
+			if line.Direction == domain.TransformationDirectionInput {
+				out.InputQuantityList = append(out.InputQuantityList, element)
+			} else {
+				out.OutputQuantityList = append(out.OutputQuantityList, element)
+			}
+		}
+
+	default:
+		switch {
+		case details.Quantity != nil:
+			out.QuantityList = []epcis.QuantityElement{*details.Quantity}
+		case product != nil:
+			out.EPCList = []string{epcis.ProductEPC(product)}
+		}
+	}
+
+	return out, nil
+}
+
+// detailsOf reads the EPCIS details kept in event metadata; events not captured through EPCIS have none
+func detailsOf(metadata domain.JSONB) epcisDetails {
+	var details epcisDetails
+	raw, ok := metadata[metadataEPCIS]
+	if !ok {
+		return details
+	}
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return details
+	}
+	_ = json.Unmarshal(encoded, &details)
+	return details
+}
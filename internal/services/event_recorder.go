@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
+	"github.com/koriebruh/suplyChainTrack/internal/gs1"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// newEvent builds an unverified event from an already validated request
+func newEvent(req *dto.CreateSupplyChainEventRequest) *domain.SupplyChainEvent {
+	return &domain.SupplyChainEvent{
+		ID:             uuid.New(),
+		ProductID:      req.ProductID,
+		StakeholderID:  req.StakeholderID,
+		EventType:      req.EventType,
+		Location:       req.Location,
+		LocationID:     req.LocationID,
+		Timestamp:      req.Timestamp,
+		Metadata:       req.Metadata,
+		BlockchainHash: req.BlockchainHash,
+		IsVerified:     false,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// recordEvent writes an event together with every record derived from it (containment, transformation lines,
+// custody projection, route alerts, excursion incidents, stale analytics) and its EventRecorded domain event. It is the single write path for events and must run inside Transactor.WithinTransaction.
+func recordEvent(ctx context.Context, repos *repository.RepositoriesManagers, metrics Metrics, event *domain.SupplyChainEvent, req *dto.CreateSupplyChainEventRequest) error {
+	location, err := resolveLocation(ctx, repos.Location, event)
+	if err != nil {
+		return err
+	}
+	if event.EventType == domain.EventTypeReceived && event.ProductID != nil && req.CustodyTransferID == nil {
+		if err := acceptPendingTransfer(ctx, repos, event, req); err != nil {
+			return err
+		}
+	}
+	if err := repos.SupplyChainEvent.Create(ctx, event); err != nil {
+		return fmt.Errorf("failed to create supply chain event: %w", err)
+	}
+	if err := repos.Analytics.MarkStale(ctx, event.ID); err != nil {
+		return fmt.Errorf("failed to mark analytics stale: %w", err)
+	}
+	if err := applyContainment(ctx, repos.Containment, event, req.ChildIDs); err != nil {
+		return err
+	}
+	if err := applyTransformation(ctx, repos.Transformation, event, req); err != nil {
+		return err
+	}
+	if err := projectEvent(ctx, repos, event); err != nil {
+		return err
+	}
+	if event.ProductID != nil {
+		if _, _, err := checkRoutes(ctx, repos, metrics, eventSighting(event, location)); err != nil {
+			return err
+		}
+		if measured := eventMeasurement(event); measured != nil {
+			if _, err := checkExcursions(ctx, repos, []*measurement{measured}); err != nil {
+				return err
+			}
+		}
+	}
+	return emit(ctx, repos.Outbox, eventbus.EventRecorded{Event: event})
+}
+
+// acceptPendingTransfer accepts the product's pending custody transfer for a received event sent
+// without one (REST, gRPC, imports, EPCIS), so the event records the transfer like AcceptTransfer does
+func acceptPendingTransfer(ctx context.Context, repos *repository.RepositoriesManagers, event *domain.SupplyChainEvent, req *dto.CreateSupplyChainEventRequest) error {
+	transfer, err := pendingTransferTo(ctx, repos.CustodyTransfer, *event.ProductID, event.StakeholderID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	updated, err := repos.CustodyTransfer.UpdateIfPending(ctx, transfer.ID, map[string]interface{}{
+		"status":           domain.CustodyTransferStatusAccepted,
+		"receive_event_id": event.ID,
+		"responded_at":     now,
+		"updated_at":       now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to accept custody transfer: %w", err)
+	}
+	if !updated {
+		return ErrCustodyTransferNotPending
+	}
+
+	if event.Metadata == nil {
+		event.Metadata = domain.JSONB{}
+	}
+	event.Metadata["custody_transfer_id"] = transfer.ID.String()
+	event.Metadata["sender_id"] = transfer.SenderID.String()
+	req.CustodyTransferID = &transfer.ID
+	return nil
+}
+
+// pendingTransferTo returns the product's pending, unexpired custody transfer when it is addressed
+// to the receiving stakeholder
+func pendingTransferTo(ctx context.Context, transfers repository.CustodyTransferRepository, productID uuid.UUID, receiverID *uuid.UUID) (*domain.CustodyTransfer, error) {
+	transfer, err := transfers.GetPendingByProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: product has no pending custody transfer to receive", ErrInvalidEventSequence)
+		}
+		return nil, fmt.Errorf("failed to get pending custody transfer: %w", err)
+	}
+	if receiverID == nil || transfer.ReceiverID != *receiverID {
+		return nil, fmt.Errorf("%w: product's pending custody transfer is addressed to another stakeholder", ErrInvalidEventSequence)
+	}
+	return transfer, nil
+}
+
+// resolveLocation links an event to the registered location it was recorded at: the one given by
+// location_id, else the one whose GLN (plain or as an SGLN URN) or name the free-text location
+// matches. A name seen for the first time is registered as a location of type other. It returns
+// the location, or nil when the event names none.
+func resolveLocation(ctx context.Context, locations repository.LocationRepository, event *domain.SupplyChainEvent) (*domain.Location, error) {
+	if event.LocationID != nil {
+		location, err := locations.GetByID(ctx, *event.LocationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve location: %w", err)
+		}
+		if event.Location == nil {
+			event.Location = &location.Name
+		}
+		return location, nil
+	}
+	if event.Location == nil || strings.TrimSpace(*event.Location) == "" {
+		return nil, nil
+	}
+	name := strings.TrimSpace(*event.Location)
+
+	if gln, ok := locationGLN(name); ok {
+		location, err := locations.GetByGLN(ctx, gln)
+		if err == nil {
+			event.LocationID = &location.ID
+			return location, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to resolve location: %w", err)
+		}
+	}
+
+	location, err := locations.Register(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register location: %w", err)
+	}
+	event.LocationID = &location.ID
+	return location, nil
+}
+
+// locationGLN reads the GLN a free-text location names, if it is a GLN or an SGLN URN
+func locationGLN(location string) (string, bool) {
+	if gs1.ValidGLN(location) {
+		return location, true
+	}
+	gln, err := gs1.ParseSGLN(location)
+	return gln, err == nil
+}
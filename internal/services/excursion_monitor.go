@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
+	"github.com/koriebruh/suplyChainTrack/internal/gs1"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// measurement is what a product was measured at, by a telemetry reading or in an event's metadata
+type measurement struct {
+	productID  uuid.UUID
+	transferID *uuid.UUID
+	deviceID   *uuid.UUID
+	eventID    *uuid.UUID
+	at         time.Time
+	values     map[string]float64 // by domain.ExcursionMetric constant
+}
+
+// readingMeasurement reads a telemetry reading of a product; nil when it measured nothing a rule
+// can check
+func readingMeasurement(reading *domain.TelemetryReading) *measurement {
+	if reading.ProductID == nil {
+		return nil
+	}
+	values := make(map[string]float64)
+	if reading.Temperature != nil {
+		values[domain.ExcursionMetricTemperature] = *reading.Temperature
+	}
+	if reading.Humidity != nil {
+		values[domain.ExcursionMetricHumidity] = *reading.Humidity
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	deviceID := reading.DeviceID
+	return &measurement{
+		productID:  *reading.ProductID,
+		transferID: reading.CustodyTransferID,
+		deviceID:   &deviceID,
+		at:         reading.RecordedAt,
+		values:     values,
+	}
+}
+
+// eventMeasurement reads the numeric temperature and humidity keys of an event's metadata; nil
+// when it has neither
+func eventMeasurement(event *domain.SupplyChainEvent) *measurement {
+	if event.ProductID == nil {
+		return nil
+	}
+	values := make(map[string]float64)
+	for _, metric := range []string{domain.ExcursionMetricTemperature, domain.ExcursionMetricHumidity} {
+		switch value := event.Metadata[metric].(type) {
+		case float64:
+			values[metric] = value
+		case int:
+			values[metric] = float64(value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return &measurement{productID: *event.ProductID, eventID: &event.ID, at: event.Timestamp, values: values}
+}
+
+// checkExcursions runs measurements, oldest first, through the active excursion rules of their
+// products' categories. An out-of-range value opens an incident or extends the open one, a value
+// back in range after it closes it, and an incident outlasting its rule's tolerance is breached:
+// the rule's scope is quarantined and ExcursionBreached is emitted, which reaches the custody
+// holder as an excursion.breached webhook. It returns the incidents opened or breached.
+func checkExcursions(ctx context.Context, repos *repository.RepositoriesManagers, measurements []*measurement) ([]*domain.ExcursionIncident, error) {
+	if len(measurements) == 0 {
+		return nil, nil
+	}
+
+	var productIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, m := range measurements {
+		if !seen[m.productID] {
+			seen[m.productID] = true
+			productIDs = append(productIDs, m.productID)
+		}
+	}
+	products, err := repos.Product.GetByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	byProduct := make(map[uuid.UUID]*domain.Product, len(products))
+	var categories []string
+	for _, product := range products {
+		byProduct[product.ID] = product
+		if category := productCategory(product); category != "" && !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+
+	rules, err := repos.Excursion.GetActiveRules(ctx, categories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excursion rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	byCategory := make(map[string][]*domain.ExcursionRule)
+	byID := make(map[uuid.UUID]*domain.ExcursionRule, len(rules))
+	for _, rule := range rules {
+		category := strings.ToLower(strings.TrimSpace(rule.Category))
+		byCategory[category] = append(byCategory[category], rule)
+		byID[rule.ID] = rule
+	}
+
+	type incidentKey struct{ ruleID, productID uuid.UUID }
+	open, err := repos.Excursion.GetOpenIncidents(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open excursion incidents: %w", err)
+	}
+	active := make(map[incidentKey]*domain.ExcursionIncident, len(open))
+	for _, incident := range open {
+		active[incidentKey{incident.RuleID, incident.ProductID}] = incident
+	}
+
+	var changed, touched, breached []*domain.ExcursionIncident
+	mark := func(list *[]*domain.ExcursionIncident, incident *domain.ExcursionIncident) {
+		for _, listed := range *list {
+			if listed == incident {
+				return
+			}
+		}
+		*list = append(*list, incident)
+	}
+
+	sort.SliceStable(measurements, func(i, j int) bool { return measurements[i].at.Before(measurements[j].at) })
+	for _, m := range measurements {
+		product := byProduct[m.productID]
+		if product == nil {
+			continue
+		}
+		for _, rule := range byCategory[productCategory(product)] {
+			value, measured := m.values[rule.Metric]
+			if !measured {
+				continue
+			}
+			key := incidentKey{rule.ID, product.ID}
+			incident := active[key]
+
+			if !rule.OutOfRange(value) {
+				// Only a value after the last one out of range ends the excursion
+				if incident != nil && m.at.After(incident.LastOutAt) {
+					incident.Status = domain.ExcursionIncidentStatusClosed
+					incident.EndedAt = &m.at
+					mark(&changed, incident)
+					delete(active, key)
+				}
+				continue
+			}
+
+			if incident == nil {
+				opened, created, err := openIncident(ctx, repos, rule, m, value)
+				if err != nil {
+					return nil, err
+				}
+				incident = opened
+				active[key] = incident
+				if created {
+					mark(&touched, incident)
+				} else {
+					// Another evaluation opened it first; extend that one
+					observeExcursion(incident, rule, m, value)
+					mark(&changed, incident)
+				}
+			} else {
+				observeExcursion(incident, rule, m, value)
+				mark(&changed, incident)
+			}
+
+			tolerance := time.Duration(rule.MaxExcursionMinutes) * time.Minute
+			if incident.BreachedAt == nil && incident.LastOutAt.Sub(incident.StartedAt) >= tolerance {
+				incident.BreachedAt = &m.at
+				mark(&changed, incident)
+				mark(&touched, incident)
+				mark(&breached, incident)
+			}
+		}
+	}
+
+	events := make([]eventbus.Event, 0, len(breached))
+	for _, incident := range breached {
+		event, err := breachExcursion(ctx, repos, incident, byID[incident.RuleID], byProduct[incident.ProductID])
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	for _, incident := range changed {
+		if err := repos.Excursion.SaveIncident(ctx, incident); err != nil {
+			return nil, fmt.Errorf("failed to update excursion incident: %w", err)
+		}
+	}
+	if len(events) > 0 {
+		if err := emit(ctx, repos.Outbox, events...); err != nil {
+			return nil, err
+		}
+	}
+	return touched, nil
+}
+
+// openIncident writes a new open incident for a value out of range. When the product already has
+// one for the rule, it returns that one instead and reports false.
+func openIncident(ctx context.Context, repos *repository.RepositoriesManagers, rule *domain.ExcursionRule, m *measurement, value float64) (*domain.ExcursionIncident, bool, error) {
+	transferID := m.transferID
+	if transferID == nil {
+		transfer, err := repos.CustodyTransfer.GetPendingByProduct(ctx, m.productID)
+		switch {
+		case err == nil:
+			transferID = &transfer.ID
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, false, fmt.Errorf("failed to get pending custody transfer: %w", err)
+		}
+	}
+
+	now := time.Now()
+	incident := &domain.ExcursionIncident{
+		ID:                uuid.New(),
+		RuleID:            rule.ID,
+		ProductID:         m.productID,
+		CustodyTransferID: transferID,
+		DeviceID:          m.deviceID,
+		EventID:           m.eventID,
+		Metric:            rule.Metric,
+		Min:               rule.Min,
+		Max:               rule.Max,
+		Status:            domain.ExcursionIncidentStatusOpen,
+		StartedAt:         m.at,
+		LastOutAt:         m.at,
+		PeakValue:         value,
+		Measurements:      1,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	created, err := repos.Excursion.OpenIncident(ctx, incident)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open excursion incident: %w", err)
+	}
+	if created {
+		return incident, true, nil
+	}
+
+	existing, err := repos.Excursion.GetOpenIncident(ctx, rule.ID, m.productID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get open excursion incident: %w", err)
+	}
+	return existing, false, nil
+}
+
+// observeExcursion extends an open incident with another value out of range
+func observeExcursion(incident *domain.ExcursionIncident, rule *domain.ExcursionRule, m *measurement, value float64) {
+	if m.at.Before(incident.StartedAt) {
+		incident.StartedAt = m.at
+	}
+	if m.at.After(incident.LastOutAt) {
+		incident.LastOutAt = m.at
+	}
+	if rule.Deviation(value) > rule.Deviation(incident.PeakValue) {
+		incident.PeakValue = value
+	}
+	if incident.DeviceID == nil {
+		incident.DeviceID = m.deviceID
+	}
+	incident.Measurements++
+}
+
+// breachExcursion quarantines what a breached rule covers and records who held the product, the
+// stakeholder the breach is delivered to. It returns the domain event to emit.
+func breachExcursion(ctx context.Context, repos *repository.RepositoriesManagers, incident *domain.ExcursionIncident, rule *domain.ExcursionRule, product *domain.Product) (eventbus.Event, error) {
+	custody, err := repos.CustodyProjection.GetByProduct(ctx, product.ID)
+	switch {
+	case err == nil:
+		incident.HolderID = custody.HolderID
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("failed to get product custody: %w", err)
+	}
+
+	quarantined, err := quarantineExcursion(ctx, repos, incident, rule, product)
+	if err != nil {
+		return nil, err
+	}
+
+	incident.Rule, incident.Product = rule, product
+	return eventbus.ExcursionBreached{Incident: incident, Quarantined: quarantined}, nil
+}
+
+// quarantineExcursion quarantines the product of a breached incident, or its whole lot, as the
+// rule says, and returns the IDs of the products in scope. Products already in quarantine stay
+// in their existing one.
+func quarantineExcursion(ctx context.Context, repos *repository.RepositoriesManagers, incident *domain.ExcursionIncident, rule *domain.ExcursionRule, product *domain.Product) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	switch rule.QuarantineScope {
+	case domain.QuarantineScopeProduct:
+		ids = []uuid.UUID{product.ID}
+	case domain.QuarantineScopeLot:
+		if product.LotNumber == nil || strings.TrimSpace(*product.LotNumber) == "" {
+			ids = []uuid.UUID{product.ID}
+			break
+		}
+		// The same item may be stored under any form of its GTIN
+		skus := []string{product.SKU}
+		if gtin, err := gs1.NormalizeGTIN(product.SKU); err == nil {
+			skus = gs1.GTINCandidates(gtin)
+		}
+		var err error
+		if ids, err = repos.Excursion.GetLotProductIDs(ctx, *product.LotNumber, product.ManufacturerID, skus); err != nil {
+			return nil, fmt.Errorf("failed to get lot products: %w", err)
+		}
+	default:
+		return nil, nil
+	}
+
+	reason := fmt.Sprintf("%s of %s reached %g, outside %s for %s (rule %s)",
+		incident.Metric, product.Name, incident.PeakValue, describeRange(rule.Min, rule.Max),
+		incident.LastOutAt.Sub(incident.StartedAt), rule.Name)
+	now := time.Now()
+	quarantines := make([]*domain.ProductQuarantine, 0, len(ids))
+	for _, id := range ids {
+		quarantines = append(quarantines, &domain.ProductQuarantine{
+			ID:         uuid.New(),
+			ProductID:  id,
+			IncidentID: &incident.ID,
+			Reason:     reason,
+			Status:     domain.QuarantineStatusActive,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		})
+	}
+	if _, err := repos.Excursion.Quarantine(ctx, quarantines); err != nil {
+		return nil, fmt.Errorf("failed to quarantine products: %w", err)
+	}
+	return ids, nil
+}
+
+// describeRange spells out a rule's range, e.g. "2 to 8" or "at most 30"
+func describeRange(min, max *float64) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("%g to %g", *min, *max)
+	case min != nil:
+		return fmt.Sprintf("at least %g", *min)
+	case max != nil:
+		return fmt.Sprintf("at most %g", *max)
+	default:
+		return "any value"
+	}
+}
+
+// productCategory is the category rules match a product by
+func productCategory(product *domain.Product) string {
+	if product.Category == nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(*product.Category))
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// maxExcursionMinutes caps how long a rule may tolerate an excursion: one week
+const maxExcursionMinutes = 7 * 24 * 60
+
+type excursionService struct {
+	repo repository.ExcursionRepository
+}
+
+func NewExcursionService(repo repository.ExcursionRepository) *excursionService {
+	return &excursionService{repo: repo}
+}
+
+// CreateRule adds a rule for a product category. It applies to measurements taken from then on.
+func (s *excursionService) CreateRule(ctx context.Context, req *dto.CreateExcursionRuleRequest) (*domain.ExcursionRule, error) {
+	scope := domain.QuarantineScopeProduct
+	if req.QuarantineScope != nil {
+		scope = *req.QuarantineScope
+	}
+
+	now := time.Now()
+	rule := &domain.ExcursionRule{
+		ID:                  uuid.New(),
+		Name:                strings.TrimSpace(req.Name),
+		Category:            strings.TrimSpace(req.Category),
+		Metric:              req.Metric,
+		Min:                 req.Min,
+		Max:                 req.Max,
+		MaxExcursionMinutes: req.MaxExcursionMinutes,
+		QuarantineScope:     scope,
+		IsActive:            true,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	if rule.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidExcursionRule)
+	}
+	if rule.Category == "" {
+		return nil, fmt.Errorf("%w: category is required", ErrInvalidExcursionRule)
+	}
+	if !domain.IsValidExcursionMetric(rule.Metric) {
+		return nil, fmt.Errorf("%w: unknown metric %q", ErrInvalidExcursionRule, rule.Metric)
+	}
+	if err := validateExcursionRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create excursion rule: %w", err)
+	}
+
+	return s.GetRule(ctx, rule.ID)
+}
+
+func (s *excursionService) GetRule(ctx context.Context, id uuid.UUID) (*domain.ExcursionRule, error) {
+	rule, err := s.repo.GetRule(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExcursionRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get excursion rule: %w", err)
+	}
+	return rule, nil
+}
+
+// UpdateRule changes a rule. Incidents already open keep the range they opened with, but are
+// closed and breached by the rule as it is now.
+func (s *excursionService) UpdateRule(ctx context.Context, id uuid.UUID, req *dto.UpdateExcursionRuleRequest) (*domain.ExcursionRule, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+		if rule.Name == "" {
+			return nil, fmt.Errorf("%w: name is required", ErrInvalidExcursionRule)
+		}
+		updates["name"] = rule.Name
+	}
+	if req.Min != nil {
+		rule.Min = req.Min
+		updates["min"] = *req.Min
+	}
+	if req.Max != nil {
+		rule.Max = req.Max
+		updates["max"] = *req.Max
+	}
+	if req.MaxExcursionMinutes != nil {
+		rule.MaxExcursionMinutes = *req.MaxExcursionMinutes
+		updates["max_excursion_minutes"] = *req.MaxExcursionMinutes
+	}
+	if req.QuarantineScope != nil {
+		rule.QuarantineScope = *req.QuarantineScope
+		updates["quarantine_scope"] = *req.QuarantineScope
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+	if err := validateExcursionRule(rule); err != nil {
+		return nil, err
+	}
+
+	updates["updated_at"] = time.Now()
+
+	if err := s.repo.UpdateRule(ctx, id, updates); err != nil {
+		return nil, fmt.Errorf("failed to update excursion rule: %w", err)
+	}
+
+	return s.GetRule(ctx, id)
+}
+
+// DeleteRule removes a rule with its incidents; quarantines it caused stay until released
+func (s *excursionService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetRule(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteRule(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete excursion rule: %w", err)
+	}
+
+	return nil
+}
+
+func (s *excursionService) ListRules(ctx context.Context, filter *dto.ExcursionRuleFilter) (*dto.PaginatedResponse, error) {
+	if filter.Metric != nil && !domain.IsValidExcursionMetric(*filter.Metric) {
+		return nil, fmt.Errorf("%w: unknown metric %q", ErrInvalidExcursionFilter, *filter.Metric)
+	}
+
+	rules, page, err := s.repo.ListRules(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list excursion rules: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(rules, page, filter.Limit, filter.Offset), nil
+}
+
+func (s *excursionService) GetIncident(ctx context.Context, id uuid.UUID) (*domain.ExcursionIncident, error) {
+	incident, err := s.repo.GetIncident(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExcursionIncidentNotFound
+		}
+		return nil, fmt.Errorf("failed to get excursion incident: %w", err)
+	}
+	return incident, nil
+}
+
+func (s *excursionService) ListIncidents(ctx context.Context, filter *dto.ExcursionIncidentFilter) (*dto.PaginatedResponse, error) {
+	if filter.Status != nil && !domain.IsValidExcursionIncidentStatus(*filter.Status) {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidExcursionFilter, *filter.Status)
+	}
+
+	incidents, page, err := s.repo.ListIncidents(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list excursion incidents: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(incidents, page, filter.Limit, filter.Offset), nil
+}
+
+// AcknowledgeIncident records that someone looked into an incident. It does not close the
+// incident or release the quarantines it caused.
+func (s *excursionService) AcknowledgeIncident(ctx context.Context, id uuid.UUID, req *dto.AcknowledgeExcursionRequest) (*domain.ExcursionIncident, error) {
+	if _, err := s.GetIncident(ctx, id); err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{
+		"acknowledged_at": time.Now(),
+		"acknowledged_by": req.StakeholderID,
+		"notes":           req.Notes,
+	}
+	updated, err := s.repo.UpdateIncidentIfUnacknowledged(ctx, id, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acknowledge excursion incident: %w", err)
+	}
+	if !updated {
+		return nil, ErrExcursionAlreadyAcknowledged
+	}
+
+	return s.GetIncident(ctx, id)
+}
+
+func (s *excursionService) GetQuarantine(ctx context.Context, id uuid.UUID) (*domain.ProductQuarantine, error) {
+	quarantine, err := s.repo.GetQuarantine(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuarantineNotFound
+		}
+		return nil, fmt.Errorf("failed to get quarantine: %w", err)
+	}
+	return quarantine, nil
+}
+
+func (s *excursionService) ListQuarantines(ctx context.Context, filter *dto.QuarantineFilter) (*dto.PaginatedResponse, error) {
+	if filter.Status != nil && !domain.IsValidQuarantineStatus(*filter.Status) {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidExcursionFilter, *filter.Status)
+	}
+
+	quarantines, page, err := s.repo.ListQuarantines(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantines: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(quarantines, page, filter.Limit, filter.Offset), nil
+}
+
+// ReleaseQuarantine lets a product go back into the supply chain; a later breach quarantines it
+// again
+func (s *excursionService) ReleaseQuarantine(ctx context.Context, id uuid.UUID, req *dto.ReleaseQuarantineRequest) (*domain.ProductQuarantine, error) {
+	if _, err := s.GetQuarantine(ctx, id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":      domain.QuarantineStatusReleased,
+		"released_at": now,
+		"released_by": req.StakeholderID,
+		"notes":       req.Notes,
+		"updated_at":  now,
+	}
+	updated, err := s.repo.UpdateQuarantineIfActive(ctx, id, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release quarantine: %w", err)
+	}
+	if !updated {
+		return nil, ErrQuarantineNotActive
+	}
+
+	return s.GetQuarantine(ctx, id)
+}
+
+// validateExcursionRule checks the range, tolerance and scope of a rule
+func validateExcursionRule(rule *domain.ExcursionRule) error {
+	if rule.Min == nil && rule.Max == nil {
+		return fmt.Errorf("%w: min or max is required", ErrInvalidExcursionRule)
+	}
+	if rule.Min != nil && rule.Max != nil && *rule.Min >= *rule.Max {
+		return fmt.Errorf("%w: min must be below max", ErrInvalidExcursionRule)
+	}
+	if rule.MaxExcursionMinutes < 0 || rule.MaxExcursionMinutes > maxExcursionMinutes {
+		return fmt.Errorf("%w: max_excursion_minutes must be between 0 and %d", ErrInvalidExcursionRule, maxExcursionMinutes)
+	}
+	if !domain.IsValidQuarantineScope(rule.QuarantineScope) {
+		return fmt.Errorf("%w: unknown quarantine_scope %q", ErrInvalidExcursionRule, rule.QuarantineScope)
+	}
+	return nil
+}
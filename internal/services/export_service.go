@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/export"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"io"
+)
+
+// exportBatchSize is how many rows are read from the database per query while exporting
+const exportBatchSize = 1000
+
+type exportService struct {
+	eventRepo   repository.SupplyChainEventRepository
+	productRepo repository.ProductRepository
+}
+
+func NewExportService(eventRepo repository.SupplyChainEventRepository, productRepo repository.ProductRepository) *exportService {
+	return &exportService{
+		eventRepo:   eventRepo,
+		productRepo: productRepo,
+	}
+}
+
+// ValidateFormat reports ErrInvalidExport for a format that cannot be exported, so callers can
+// reject a request before they start streaming the response
+func (s *exportService) ValidateFormat(format string) error {
+	if _, err := export.ContentType(format); err != nil {
+		return fmt.Errorf("%w: format must be %s, %s or %s", ErrInvalidExport, export.FormatCSV, export.FormatNDJSON, export.FormatParquet)
+	}
+	return nil
+}
+
+// ExportEvents writes every event matching filter to w in timestamp order, ignoring its limit and
+// offset. Only one batch of events is held in memory at a time.
+func (s *exportService) ExportEvents(ctx context.Context, filter *dto.SupplyChainEventFilter, format string, w io.Writer) error {
+	writer, err := newExportWriter[export.EventRecord](w, format)
+	if err != nil {
+		return err
+	}
+
+	err = s.eventRepo.EachFiltered(ctx, filter, exportBatchSize, func(events []*domain.SupplyChainEvent) error {
+		records := make([]export.EventRecord, 0, len(events))
+		for _, event := range events {
+			record, err := export.NewEventRecord(event)
+			if err != nil {
+				return fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+			}
+			records = append(records, record)
+		}
+		return writer.Write(records)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export events: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finish events export: %w", err)
+	}
+	return nil
+}
+
+// ExportProducts writes every product matching filter to w in id order, ignoring its limit and offset
+func (s *exportService) ExportProducts(ctx context.Context, filter *dto.ProductFilter, format string, w io.Writer) error {
+	writer, err := newExportWriter[export.ProductRecord](w, format)
+	if err != nil {
+		return err
+	}
+
+	err = s.productRepo.EachFiltered(ctx, filter, exportBatchSize, func(products []*domain.Product) error {
+		records := make([]export.ProductRecord, 0, len(products))
+		for _, product := range products {
+			record, err := export.NewProductRecord(product)
+			if err != nil {
+				return fmt.Errorf("failed to encode product %s: %w", product.ID, err)
+			}
+			records = append(records, record)
+		}
+		return writer.Write(records)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export products: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finish products export: %w", err)
+	}
+	return nil
+}
+
+func newExportWriter[T export.Record](w io.Writer, format string) (export.Writer[T], error) {
+	writer, err := export.NewWriter[T](w, format)
+	if err != nil {
+		if errors.Is(err, export.ErrUnsupportedFormat) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+		}
+		return nil, fmt.Errorf("failed to start export: %w", err)
+	}
+	return writer, nil
+}
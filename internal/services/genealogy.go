@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"time"
+)
+
+// maxGenealogyDepth caps how many transformation levels a genealogy walk follows
+const maxGenealogyDepth = 50
+
+const (
+	genealogyUpstream   = "upstream"
+	genealogyDownstream = "downstream"
+)
+
+func (s *supplyChainService) GetUpstreamGenealogy(ctx context.Context, productID uuid.UUID, maxDepth int) (*dto.Genealogy, error) {
+	return s.buildGenealogy(ctx, productID, maxDepth, genealogyUpstream, s.transformationRepo.GetUpstream)
+}
+
+func (s *supplyChainService) GetDownstreamGenealogy(ctx context.Context, productID uuid.UUID, maxDepth int) (*dto.Genealogy, error) {
+	return s.buildGenealogy(ctx, productID, maxDepth, genealogyDownstream, s.transformationRepo.GetDownstream)
+}
+
+// GetLotUpstreamGenealogy walks up from the transformations that produced a lot, and
+// GetLotDownstreamGenealogy down from those that consumed it. productID narrows the lot to one
+// product, as lot numbers need not be unique across products.
+func (s *supplyChainService) GetLotUpstreamGenealogy(ctx context.Context, lot string, productID *uuid.UUID, maxDepth int) (*dto.Genealogy, error) {
+	return s.buildLotGenealogy(ctx, lot, productID, maxDepth, genealogyUpstream, s.transformationRepo.GetUpstreamOfLot, s.transformationRepo.GetUpstreamOfLots)
+}
+
+func (s *supplyChainService) GetLotDownstreamGenealogy(ctx context.Context, lot string, productID *uuid.UUID, maxDepth int) (*dto.Genealogy, error) {
+	return s.buildLotGenealogy(ctx, lot, productID, maxDepth, genealogyDownstream, s.transformationRepo.GetDownstreamOfLot, s.transformationRepo.GetDownstreamOfLots)
+}
+
+func (s *supplyChainService) GetTransformationLines(ctx context.Context, eventID uuid.UUID) ([]*domain.TransformationLine, error) {
+	if _, err := s.GetEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	lines, err := s.transformationRepo.GetByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transformation lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// buildGenealogy walks transformations level by level (one query per level) starting at productID
+func (s *supplyChainService) buildGenealogy(ctx context.Context, productID uuid.UUID, maxDepth int, direction string, next func(context.Context, []uuid.UUID) ([]*dto.GenealogyEdge, error)) (*dto.Genealogy, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to validate product: %w", err)
+	}
+
+	root := &dto.GenealogyNode{Product: product}
+	genealogy := &dto.Genealogy{
+		Direction: direction,
+		Root:      root,
+		Products:  []*domain.Product{},
+		Lots:      []string{},
+	}
+	// A product walk follows every lot of each product it reaches
+	frontier := map[dto.ProductLot][]*dto.GenealogyNode{{ProductID: productID}: {root}}
+	if err := walkGenealogy(ctx, genealogy, frontier, maxDepth, false, func(ctx context.Context, _ int, keys []dto.ProductLot) ([]*dto.GenealogyEdge, error) {
+		ids := make([]uuid.UUID, 0, len(keys))
+		for _, key := range keys {
+			ids = append(ids, key.ProductID)
+		}
+		return next(ctx, ids)
+	}); err != nil {
+		return nil, err
+	}
+	return genealogy, nil
+}
+
+// buildLotGenealogy walks transformations starting at the lines that carry lot, then on by the
+// product and lot of each line reached, so lots sharing an intermediate product stay apart. The
+// root is the lot itself, with no product.
+func (s *supplyChainService) buildLotGenealogy(ctx context.Context, lot string, productID *uuid.UUID, maxDepth int, direction string,
+	first func(context.Context, string, *uuid.UUID) ([]*dto.GenealogyEdge, error), next func(context.Context, []dto.ProductLot) ([]*dto.GenealogyEdge, error)) (*dto.Genealogy, error) {
+	if lot == "" {
+		return nil, fmt.Errorf("%w: lot is required", ErrInvalidGenealogyQuery)
+	}
+	if productID != nil {
+		if _, err := s.productRepo.GetByID(ctx, *productID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("failed to validate product: %w", err)
+		}
+	}
+
+	root := &dto.GenealogyNode{LotNumber: &lot}
+	genealogy := &dto.Genealogy{
+		Direction: direction,
+		Root:      root,
+		Products:  []*domain.Product{},
+		Lots:      []string{},
+	}
+	// Every product of the lot starts at the root, so the first level is found by lot instead
+	firstEdges, err := first(ctx, lot, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s genealogy: %w", direction, err)
+	}
+	frontier := make(map[dto.ProductLot][]*dto.GenealogyNode)
+	for _, edge := range firstEdges {
+		frontier[genealogyKey(edge.ProductID, edge.LotNumber, true)] = []*dto.GenealogyNode{root}
+	}
+	if err := walkGenealogy(ctx, genealogy, frontier, maxDepth, true, func(ctx context.Context, depth int, keys []dto.ProductLot) ([]*dto.GenealogyEdge, error) {
+		if depth == 1 {
+			return firstEdges, nil
+		}
+		return next(ctx, keys)
+	}); err != nil {
+		return nil, err
+	}
+	return genealogy, nil
+}
+
+// walkGenealogy walks transformations level by level (one query per level) from frontier, the
+// nodes of the products to expand, adding what it reaches to genealogy. byLot keys the walk by
+// product and lot instead of by product alone.
+func walkGenealogy(ctx context.Context, genealogy *dto.Genealogy, frontier map[dto.ProductLot][]*dto.GenealogyNode, maxDepth int, byLot bool,
+	next func(ctx context.Context, depth int, keys []dto.ProductLot) ([]*dto.GenealogyEdge, error)) error {
+	if maxDepth <= 0 || maxDepth > maxGenealogyDepth {
+		maxDepth = maxGenealogyDepth
+	}
+
+	// Keys already expanded; a product reached twice is listed again but not walked again
+	visited := make(map[dto.ProductLot]bool, len(frontier))
+	products := make(map[uuid.UUID]bool, len(frontier))
+	for key := range frontier {
+		visited[key] = true
+		products[key.ProductID] = true
+	}
+	lots := make(map[string]bool)
+
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		keys := make([]dto.ProductLot, 0, len(frontier))
+		for key := range frontier {
+			keys = append(keys, key)
+		}
+
+		edges, err := next(ctx, depth, keys)
+		if err != nil {
+			return fmt.Errorf("failed to walk %s genealogy: %w", genealogy.Direction, err)
+		}
+
+		nextFrontier := make(map[dto.ProductLot][]*dto.GenealogyNode)
+		for _, edge := range edges {
+			line := edge.Line
+			key := genealogyKey(line.ProductID, line.LotNumber, byLot)
+			for _, parent := range frontier[genealogyKey(edge.ProductID, edge.LotNumber, byLot)] {
+				node := &dto.GenealogyNode{
+					Product:   line.Product,
+					EventID:   &line.EventID,
+					Quantity:  line.Quantity,
+					Unit:      line.Unit,
+					LotNumber: line.LotNumber,
+					Depth:     depth,
+				}
+				parent.Children = append(parent.Children, node)
+
+				if line.LotNumber != nil && !lots[*line.LotNumber] {
+					lots[*line.LotNumber] = true
+					genealogy.Lots = append(genealogy.Lots, *line.LotNumber)
+				}
+				if !products[line.ProductID] {
+					products[line.ProductID] = true
+					genealogy.Products = append(genealogy.Products, line.Product)
+				}
+				if !visited[key] {
+					visited[key] = true
+					nextFrontier[key] = append(nextFrontier[key], node)
+				}
+			}
+		}
+		frontier = nextFrontier
+	}
+
+	return nil
+}
+
+// genealogyKey is the frontier key of a product on a line: its lot too when the walk is by lot
+func genealogyKey(productID uuid.UUID, lot *string, byLot bool) dto.ProductLot {
+	key := dto.ProductLot{ProductID: productID}
+	if byLot && lot != nil {
+		key.LotNumber = *lot
+	}
+	return key
+}
+
+// applyTransformation stores the consumed inputs and produced outputs of a transformed event
+func applyTransformation(ctx context.Context, repo repository.TransformationRepository, event *domain.SupplyChainEvent, req *dto.CreateSupplyChainEventRequest) error {
+	if event.EventType != domain.EventTypeTransformed {
+		return nil
+	}
+
+	lines := make([]*domain.TransformationLine, 0, len(req.Inputs)+len(req.Outputs))
+	appendLines := func(items []dto.TransformationLineRequest, direction string) {
+		for _, item := range items {
+			lines = append(lines, &domain.TransformationLine{
+				ID:        uuid.New(),
+				EventID:   event.ID,
+				ProductID: item.ProductID,
+				Direction: direction,
+				Quantity:  item.Quantity,
+				Unit:      item.Unit,
+				LotNumber: item.LotNumber,
+				CreatedAt: time.Now(),
+			})
+		}
+	}
+	appendLines(req.Inputs, domain.TransformationDirectionInput)
+	appendLines(req.Outputs, domain.TransformationDirectionOutput)
+
+	if err := repo.CreateLines(ctx, lines); err != nil {
+		return fmt.Errorf("failed to record transformation: %w", err)
+	}
+	return nil
+}
+
+func (s *supplyChainService) validateTransformation(ctx context.Context, req *dto.CreateSupplyChainEventRequest) error {
+	if len(req.Inputs) == 0 || len(req.Outputs) == 0 {
+		return ErrInvalidTransformation
+	}
+
+	sides := make(map[uuid.UUID]string)
+	check := func(items []dto.TransformationLineRequest, direction string) error {
+		for _, item := range items {
+			if item.ProductID == uuid.Nil || item.Quantity <= 0 {
+				return ErrInvalidTransformation
+			}
+			// The same product may appear twice on one side (different lots), never on both
+			if side, seen := sides[item.ProductID]; seen {
+				if side != direction {
+					return ErrInvalidTransformation
+				}
+				continue
+			}
+			sides[item.ProductID] = direction
+
+			if _, err := s.productRepo.GetByID(ctx, item.ProductID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrProductNotFound
+				}
+				return fmt.Errorf("failed to validate transformation product: %w", err)
+			}
+		}
+		return nil
+	}
+
+	if err := check(req.Inputs, domain.TransformationDirectionInput); err != nil {
+		return err
+	}
+	if err := check(req.Outputs, domain.TransformationDirectionOutput); err != nil {
+		return err
+	}
+
+	// Only the holder of every product involved may transform them
+	productIDs := make([]uuid.UUID, 0, len(sides))
+	for productID := range sides {
+		productIDs = append(productIDs, productID)
+	}
+	return s.requireHolder(ctx, req.StakeholderID, productIDs)
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// memoryTransformations answers the genealogy queries of TransformationRepository from lines in memory
+type memoryTransformations struct {
+	lines []*domain.TransformationLine
+}
+
+func (m *memoryTransformations) CreateLines(_ context.Context, lines []*domain.TransformationLine) error {
+	m.lines = append(m.lines, lines...)
+	return nil
+}
+
+func (m *memoryTransformations) GetByEvent(_ context.Context, eventID uuid.UUID) ([]*domain.TransformationLine, error) {
+	return m.find(func(line *domain.TransformationLine) bool { return line.EventID == eventID }), nil
+}
+
+func (m *memoryTransformations) GetProducingEvents(context.Context, uuid.UUID) ([]*domain.SupplyChainEvent, error) {
+	return nil, nil
+}
+
+func (m *memoryTransformations) GetUpstream(_ context.Context, productIDs []uuid.UUID) ([]*dto.GenealogyEdge, error) {
+	return m.edges(m.byProduct(productIDs, domain.TransformationDirectionOutput), domain.TransformationDirectionInput), nil
+}
+
+func (m *memoryTransformations) GetDownstream(_ context.Context, productIDs []uuid.UUID) ([]*dto.GenealogyEdge, error) {
+	return m.edges(m.byProduct(productIDs, domain.TransformationDirectionInput), domain.TransformationDirectionOutput), nil
+}
+
+func (m *memoryTransformations) GetUpstreamOfLot(_ context.Context, lot string, productID *uuid.UUID) ([]*dto.GenealogyEdge, error) {
+	return m.edges(m.byLot(lot, productID, domain.TransformationDirectionOutput), domain.TransformationDirectionInput), nil
+}
+
+func (m *memoryTransformations) GetDownstreamOfLot(_ context.Context, lot string, productID *uuid.UUID) ([]*dto.GenealogyEdge, error) {
+	return m.edges(m.byLot(lot, productID, domain.TransformationDirectionInput), domain.TransformationDirectionOutput), nil
+}
+
+func (m *memoryTransformations) GetUpstreamOfLots(_ context.Context, lots []dto.ProductLot) ([]*dto.GenealogyEdge, error) {
+	return m.edges(m.byProductLot(lots, domain.TransformationDirectionOutput), domain.TransformationDirectionInput), nil
+}
+
+func (m *memoryTransformations) GetDownstreamOfLots(_ context.Context, lots []dto.ProductLot) ([]*dto.GenealogyEdge, error) {
+	return m.edges(m.byProductLot(lots, domain.TransformationDirectionInput), domain.TransformationDirectionOutput), nil
+}
+
+func (m *memoryTransformations) find(match func(line *domain.TransformationLine) bool) []*domain.TransformationLine {
+	var found []*domain.TransformationLine
+	for _, line := range m.lines {
+		if match(line) {
+			found = append(found, line)
+		}
+	}
+	return found
+}
+
+func (m *memoryTransformations) byProduct(productIDs []uuid.UUID, direction string) []*domain.TransformationLine {
+	return m.find(func(line *domain.TransformationLine) bool {
+		for _, id := range productIDs {
+			if line.ProductID == id && line.Direction == direction {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (m *memoryTransformations) byLot(lot string, productID *uuid.UUID, direction string) []*domain.TransformationLine {
+	return m.find(func(line *domain.TransformationLine) bool {
+		return line.LotNumber != nil && *line.LotNumber == lot && line.Direction == direction &&
+			(productID == nil || line.ProductID == *productID)
+	})
+}
+
+func (m *memoryTransformations) byProductLot(lots []dto.ProductLot, direction string) []*domain.TransformationLine {
+	return m.find(func(line *domain.TransformationLine) bool {
+		for _, lot := range lots {
+			lotNumber := ""
+			if line.LotNumber != nil {
+				lotNumber = *line.LotNumber
+			}
+			if line.ProductID == lot.ProductID && lotNumber == lot.LotNumber && line.Direction == direction {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (m *memoryTransformations) edges(known []*domain.TransformationLine, otherDirection string) []*dto.GenealogyEdge {
+	var edges []*dto.GenealogyEdge
+	for _, line := range m.lines {
+		if line.Direction != otherDirection {
+			continue
+		}
+		for _, knownLine := range known {
+			if knownLine.EventID == line.EventID {
+				edges = append(edges, &dto.GenealogyEdge{ProductID: knownLine.ProductID, LotNumber: knownLine.LotNumber, Line: line})
+			}
+		}
+	}
+	return edges
+}
+
+// transformation returns the lines of one transformation of input into output, given as product and lot
+func transformation(input, output dto.ProductLot, products map[uuid.UUID]*domain.Product) []*domain.TransformationLine {
+	eventID := uuid.New()
+	line := func(lot dto.ProductLot, direction string) *domain.TransformationLine {
+		lotNumber := lot.LotNumber
+		return &domain.TransformationLine{ID: uuid.New(), EventID: eventID, ProductID: lot.ProductID, Direction: direction, Quantity: 1, LotNumber: &lotNumber, Product: products[lot.ProductID]}
+	}
+	return []*domain.TransformationLine{line(input, domain.TransformationDirectionInput), line(output, domain.TransformationDirectionOutput)}
+}
+
+// genealogyLots lists the product and lot of every node below root, sorted
+func genealogyLots(root *dto.GenealogyNode) []string {
+	var lots []string
+	for _, child := range root.Children {
+		lots = append(lots, child.Product.Name+"/"+*child.LotNumber)
+		lots = append(lots, genealogyLots(child)...)
+	}
+	sort.Strings(lots)
+	return lots
+}
+
+func TestLotGenealogyKeepsLotsApart(t *testing.T) {
+	// Two flour lots are each milled into their own dough lot, the intermediate product both share,
+	// and each dough lot is baked into its own bread lot
+	flour, dough, bread := uuid.New(), uuid.New(), uuid.New()
+	products := map[uuid.UUID]*domain.Product{
+		flour: {ID: flour, Name: "flour"},
+		dough: {ID: dough, Name: "dough"},
+		bread: {ID: bread, Name: "bread"},
+	}
+	repo := &memoryTransformations{}
+	for _, step := range [][2]dto.ProductLot{
+		{{ProductID: flour, LotNumber: "F-1"}, {ProductID: dough, LotNumber: "D-1"}},
+		{{ProductID: flour, LotNumber: "F-2"}, {ProductID: dough, LotNumber: "D-2"}},
+		{{ProductID: dough, LotNumber: "D-1"}, {ProductID: bread, LotNumber: "B-1"}},
+		{{ProductID: dough, LotNumber: "D-2"}, {ProductID: bread, LotNumber: "B-2"}},
+	} {
+		repo.lines = append(repo.lines, transformation(step[0], step[1], products)...)
+	}
+	service := &supplyChainService{transformationRepo: repo}
+
+	tests := []struct {
+		name      string
+		lot       string
+		direction string
+		want      []string
+	}{
+		{name: "downstream of the first flour lot", lot: "F-1", direction: genealogyDownstream, want: []string{"bread/B-1", "dough/D-1"}},
+		{name: "downstream of the second flour lot", lot: "F-2", direction: genealogyDownstream, want: []string{"bread/B-2", "dough/D-2"}},
+		{name: "upstream of the first bread lot", lot: "B-1", direction: genealogyUpstream, want: []string{"dough/D-1", "flour/F-1"}},
+		{name: "upstream of the second bread lot", lot: "B-2", direction: genealogyUpstream, want: []string{"dough/D-2", "flour/F-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walk := service.GetLotDownstreamGenealogy
+			if tt.direction == genealogyUpstream {
+				walk = service.GetLotUpstreamGenealogy
+			}
+			genealogy, err := walk(context.Background(), tt.lot, nil, 0)
+			if err != nil {
+				t.Fatalf("lot %s genealogy unexpected error: %v", tt.lot, err)
+			}
+			if got := genealogyLots(genealogy.Root); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lot %s %s genealogy = %v, want %v", tt.lot, tt.direction, got, tt.want)
+			}
+		})
+	}
+}
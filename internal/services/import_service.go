@@ -0,0 +1,581 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/spreadsheet"
+	"gorm.io/gorm"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxImportRows bounds the data rows of one import file
+	maxImportRows = 50000
+
+	// importProgressInterval is how many rows are processed between progress updates
+	importProgressInterval = 100
+
+	// importHeartbeatInterval is how often a running job records that it is alive, and
+	// importStaleAfter how long after its last heartbeat an unfinished job counts as interrupted
+	importHeartbeatInterval = 30 * time.Second
+	importStaleAfter        = 5 * time.Minute
+)
+
+// importColumns are the columns each kind of import requires
+var importColumns = map[string][]string{
+	domain.ImportJobKindProducts:     {"sku", "name"},
+	domain.ImportJobKindStakeholders: {"email", "name", "type"},
+	domain.ImportJobKindEvents:       {"event_type", "timestamp"},
+}
+
+// importOutcome is what happened (or, in a dry run, would happen) to a valid row
+type importOutcome int
+
+const (
+	importFailed importOutcome = iota
+	importCreated
+	importUpdated
+)
+
+// rowError is a problem with one row; field is empty when the row as a whole was rejected
+type rowError struct {
+	field   string
+	message string
+}
+
+type importService struct {
+	jobRepo     repository.ImportJobRepository
+	product     ProductService
+	stakeholder StakeholderService
+	supplyChain SupplyChainService
+}
+
+func NewImportService(jobRepo repository.ImportJobRepository, product ProductService, stakeholder StakeholderService, supplyChain SupplyChainService) *importService {
+	return &importService{
+		jobRepo:     jobRepo,
+		product:     product,
+		stakeholder: stakeholder,
+		supplyChain: supplyChain,
+	}
+}
+
+// StartImport reads and checks the file, records the job and processes its rows in the background.
+// Each row goes through the same service call (and so the same validation) as the single-record API;
+// products are upserted by SKU and stakeholders by email.
+func (s *importService) StartImport(ctx context.Context, req *dto.StartImportRequest, file io.Reader) (*domain.ImportJob, error) {
+	if !domain.IsValidImportJobKind(req.Kind) {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidImport, req.Kind)
+	}
+	format, err := spreadsheet.FormatOf(req.FileName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	sheet, err := spreadsheet.Read(file, format, maxImportRows)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	for _, column := range importColumns[req.Kind] {
+		if !sheet.Has(column) {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidImport, column)
+		}
+	}
+
+	now := time.Now()
+	job := &domain.ImportJob{
+		ID:          uuid.New(),
+		Kind:        req.Kind,
+		Format:      format,
+		FileName:    req.FileName,
+		DryRun:      req.DryRun,
+		Status:      domain.ImportJobStatusPending,
+		TotalRows:   sheet.Len(),
+		HeartbeatAt: &now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	// The job outlives the request that started it
+	go s.run(context.Background(), job, sheet)
+
+	return job, nil
+}
+
+// RunRecovery fails import jobs whose process stopped before finishing them (a restart or
+// deploy), so clients polling them see why, until ctx is cancelled. Jobs only run in the process
+// that started them, so they cannot be resumed elsewhere; the file has to be imported again.
+func (s *importService) RunRecovery(ctx context.Context) {
+	ticker := time.NewTicker(importHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		failed, err := s.jobRepo.FailStale(ctx, time.Now().Add(-importStaleAfter), "interrupted: the server stopped before the import finished; start it again")
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to recover interrupted import jobs", "error", err)
+		} else if failed > 0 {
+			slog.WarnContext(ctx, "failed interrupted import jobs", "count", failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *importService) GetImportJob(ctx context.Context, id uuid.UUID) (*domain.ImportJob, error) {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+	return job, nil
+}
+
+func (s *importService) ListImportJobs(ctx context.Context, filter *dto.ImportJobFilter) (*dto.PaginatedResponse, error) {
+	if filter == nil {
+		filter = &dto.ImportJobFilter{Limit: 10, Offset: 0}
+	}
+
+	jobs, page, err := s.jobRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import jobs: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(jobs, page, filter.Limit, filter.Offset), nil
+}
+
+func (s *importService) ListImportErrors(ctx context.Context, id uuid.UUID, limit, offset int) (*dto.PaginatedResponse, error) {
+	if _, err := s.GetImportJob(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rowErrors, total, err := s.jobRepo.ListErrors(ctx, id, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import errors: %w", err)
+	}
+
+	// Row errors are bounded by the import's size, so they keep offset pagination
+	page := &paging.Page{Total: &total, HasMore: int64(offset+limit) < total}
+	return dto.NewPaginatedResponse(rowErrors, page, limit, offset), nil
+}
+
+// WriteErrorReport writes every row error of a job as CSV: row, field, message
+func (s *importService) WriteErrorReport(ctx context.Context, id uuid.UUID, w io.Writer) error {
+	if _, err := s.GetImportJob(ctx, id); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"row", "field", "message"}); err != nil {
+		return err
+	}
+	for offset := 0; ; offset += maxImportRows {
+		rowErrors, _, err := s.jobRepo.ListErrors(ctx, id, maxImportRows, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list import errors: %w", err)
+		}
+		for _, rowError := range rowErrors {
+			var field string
+			if rowError.Field != nil {
+				field = *rowError.Field
+			}
+			if err := writer.Write([]string{strconv.Itoa(rowError.Row), field, rowError.Message}); err != nil {
+				return err
+			}
+		}
+		if len(rowErrors) < maxImportRows {
+			break
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// run processes every row, saving progress and row errors every importProgressInterval rows
+func (s *importService) run(ctx context.Context, job *domain.ImportJob, sheet *spreadsheet.Sheet) {
+	started := time.Now()
+	if err := s.jobRepo.Update(ctx, job.ID, map[string]interface{}{"status": domain.ImportJobStatusRunning, "started_at": started, "heartbeat_at": started}); err != nil {
+		slog.ErrorContext(ctx, "failed to start import job", "job_id", job.ID, "error", err)
+		return
+	}
+
+	// Keep the job from being taken for interrupted while rows take long
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(importHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := s.jobRepo.Update(ctx, job.ID, map[string]interface{}{"heartbeat_at": now}); err != nil {
+					slog.ErrorContext(ctx, "failed to record import heartbeat", "job_id", job.ID, "error", err)
+				}
+			}
+		}
+	}()
+
+	var pending []*domain.ImportRowError
+	var processed, created, updated, failed int
+	seen := make(map[string]int)
+
+	flush := func() error {
+		if err := s.jobRepo.CreateErrors(ctx, pending); err != nil {
+			return fmt.Errorf("failed to save import errors: %w", err)
+		}
+		pending = pending[:0]
+		return s.jobRepo.Update(ctx, job.ID, map[string]interface{}{
+			"processed_rows": processed,
+			"created_rows":   created,
+			"updated_rows":   updated,
+			"failed_rows":    failed,
+		})
+	}
+
+	err := sheet.Each(func(row *spreadsheet.Row) error {
+		outcome, rowErrors := s.importRow(ctx, job, row, seen)
+		processed++
+		switch outcome {
+		case importCreated:
+			created++
+		case importUpdated:
+			updated++
+		default:
+			failed++
+		}
+		for _, rowErr := range rowErrors {
+			rowError := &domain.ImportRowError{ID: uuid.New(), JobID: job.ID, Row: row.Line, Message: rowErr.message, CreatedAt: time.Now()}
+			if rowErr.field != "" {
+				field := rowErr.field
+				rowError.Field = &field
+			}
+			pending = append(pending, rowError)
+		}
+
+		if processed%importProgressInterval == 0 {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+
+	updates := map[string]interface{}{"status": domain.ImportJobStatusCompleted, "finished_at": time.Now()}
+	if err != nil {
+		slog.ErrorContext(ctx, "import job failed", "job_id", job.ID, "error", err)
+		updates["status"] = domain.ImportJobStatusFailed
+		updates["error"] = err.Error()
+	}
+	if err := s.jobRepo.Update(ctx, job.ID, updates); err != nil {
+		slog.ErrorContext(ctx, "failed to finish import job", "job_id", job.ID, "error", err)
+	}
+}
+
+func (s *importService) importRow(ctx context.Context, job *domain.ImportJob, row *spreadsheet.Row, seen map[string]int) (importOutcome, []rowError) {
+	switch job.Kind {
+	case domain.ImportJobKindProducts:
+		return s.importProduct(ctx, row, job.DryRun, seen)
+	case domain.ImportJobKindStakeholders:
+		return s.importStakeholder(ctx, row, job.DryRun, seen)
+	default:
+		return s.importEvent(ctx, row, job.DryRun)
+	}
+}
+
+func (s *importService) importProduct(ctx context.Context, row *spreadsheet.Row, dryRun bool, seen map[string]int) (importOutcome, []rowError) {
+	var errs []rowError
+	req := &dto.CreateProductRequest{
+		SKU:          row.Get("sku"),
+		Name:         row.Get("name"),
+		Description:  row.Optional("description"),
+		Category:     row.Optional("category"),
+		LotNumber:    row.Optional("lot_number"),
+		SerialNumber: row.Optional("serial_number"),
+	}
+	errs = append(errs, required(row, "sku", "name")...)
+	errs = append(errs, duplicate(seen, "sku", req.SKU, row.Line)...)
+
+	manufacturerID, err := s.stakeholderRef(ctx, row, "manufacturer_id", "manufacturer_email")
+	if err != nil {
+		errs = append(errs, *err)
+	}
+	req.ManufacturerID = manufacturerID
+
+	metadata, err := metadataOf(row)
+	if err != nil {
+		errs = append(errs, *err)
+	}
+	req.Metadata = metadata
+
+	if len(errs) > 0 {
+		return importFailed, errs
+	}
+	if err := s.product.ValidateProduct(ctx, req); err != nil {
+		return importFailed, []rowError{{field: productErrorField(err), message: err.Error()}}
+	}
+
+	existing, lookupErr := s.product.GetProductBySKU(ctx, req.SKU)
+	if lookupErr != nil && !errors.Is(lookupErr, ErrProductNotFound) {
+		return importFailed, []rowError{{message: lookupErr.Error()}}
+	}
+
+	switch {
+	case existing == nil && dryRun:
+		return importCreated, nil
+	case existing == nil:
+		if _, err := s.product.CreateProduct(ctx, req); err != nil {
+			return importFailed, []rowError{{field: productErrorField(err), message: err.Error()}}
+		}
+		return importCreated, nil
+	case dryRun:
+		return importUpdated, nil
+	default:
+		// Empty cells leave the existing value alone
+		update := &dto.UpdateProductRequest{
+			Name:           &req.Name,
+			Description:    req.Description,
+			Category:       req.Category,
+			ManufacturerID: req.ManufacturerID,
+			LotNumber:      req.LotNumber,
+			SerialNumber:   req.SerialNumber,
+			Metadata:       req.Metadata,
+		}
+		if _, err := s.product.UpdateProduct(ctx, existing.ID, update); err != nil {
+			return importFailed, []rowError{{field: productErrorField(err), message: err.Error()}}
+		}
+		return importUpdated, nil
+	}
+}
+
+func (s *importService) importStakeholder(ctx context.Context, row *spreadsheet.Row, dryRun bool, seen map[string]int) (importOutcome, []rowError) {
+	var errs []rowError
+	req := &dto.CreateStakeholderRequest{
+		Name:          row.Get("name"),
+		Type:          row.Get("type"),
+		Email:         row.Get("email"),
+		WalletAddress: row.Optional("wallet_address"),
+		Phone:         row.Optional("phone"),
+		Address:       row.Optional("address"),
+	}
+	errs = append(errs, required(row, "email", "name", "type")...)
+	errs = append(errs, duplicate(seen, "email", req.Email, row.Line)...)
+	if len(errs) > 0 {
+		return importFailed, errs
+	}
+	if err := s.stakeholder.ValidateStakeholder(ctx, req); err != nil {
+		return importFailed, []rowError{{field: stakeholderErrorField(err), message: err.Error()}}
+	}
+
+	existing, lookupErr := s.stakeholder.GetStakeholderByEmail(ctx, req.Email)
+	if lookupErr != nil && !errors.Is(lookupErr, ErrStakeholderNotFound) {
+		return importFailed, []rowError{{message: lookupErr.Error()}}
+	}
+	if existing != nil && existing.Type != req.Type {
+		return importFailed, []rowError{{field: "type", message: fmt.Sprintf("stakeholder type cannot be changed from %s", existing.Type)}}
+	}
+
+	switch {
+	case existing == nil && dryRun:
+		return importCreated, nil
+	case existing == nil:
+		if _, err := s.stakeholder.CreateStakeholder(ctx, req); err != nil {
+			return importFailed, []rowError{{field: stakeholderErrorField(err), message: err.Error()}}
+		}
+		return importCreated, nil
+	case dryRun:
+		return importUpdated, nil
+	default:
+		update := &dto.UpdateStakeholderRequest{
+			Name:          &req.Name,
+			WalletAddress: req.WalletAddress,
+			Phone:         req.Phone,
+			Address:       req.Address,
+		}
+		if _, err := s.stakeholder.UpdateStakeholder(ctx, existing.ID, update); err != nil {
+			return importFailed, []rowError{{field: stakeholderErrorField(err), message: err.Error()}}
+		}
+		return importUpdated, nil
+	}
+}
+
+// importEvent records one event. A dry run checks each row against the data already recorded,
+// so a row that depends on an earlier row of the same file (e.g. shipped after manufactured)
+// is reported as out of sequence.
+func (s *importService) importEvent(ctx context.Context, row *spreadsheet.Row, dryRun bool) (importOutcome, []rowError) {
+	errs := required(row, "event_type", "timestamp")
+	req := &dto.CreateSupplyChainEventRequest{
+		EventType: row.Get("event_type"),
+		Location:  row.Optional("location"),
+	}
+
+	if value := row.Get("timestamp"); value != "" {
+		timestamp, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errs = append(errs, rowError{field: "timestamp", message: "timestamp must be RFC3339"})
+		}
+		req.Timestamp = timestamp
+	}
+
+	productID, err := s.productRef(ctx, row)
+	if err != nil {
+		errs = append(errs, *err)
+	}
+	req.ProductID = productID
+
+	stakeholderID, err := s.stakeholderRef(ctx, row, "stakeholder_id", "stakeholder_email")
+	if err != nil {
+		errs = append(errs, *err)
+	}
+	req.StakeholderID = stakeholderID
+
+	metadata, err := metadataOf(row)
+	if err != nil {
+		errs = append(errs, *err)
+	}
+	req.Metadata = metadata
+
+	if len(errs) > 0 {
+		return importFailed, errs
+	}
+
+	if dryRun {
+		if err := s.supplyChain.ValidateEvent(ctx, req); err != nil {
+			return importFailed, []rowError{{field: eventErrorField(err), message: err.Error()}}
+		}
+		return importCreated, nil
+	}
+	if _, err := s.supplyChain.CreateEvent(ctx, req); err != nil {
+		return importFailed, []rowError{{field: eventErrorField(err), message: err.Error()}}
+	}
+	return importCreated, nil
+}
+
+// productRef resolves the product_id or product_sku column
+func (s *importService) productRef(ctx context.Context, row *spreadsheet.Row) (*uuid.UUID, *rowError) {
+	if value := row.Get("product_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, &rowError{field: "product_id", message: "product_id must be a UUID"}
+		}
+		return &id, nil
+	}
+	if sku := row.Get("product_sku"); sku != "" {
+		product, err := s.product.GetProductBySKU(ctx, sku)
+		if err != nil {
+			return nil, &rowError{field: "product_sku", message: err.Error()}
+		}
+		return &product.ID, nil
+	}
+	return nil, nil
+}
+
+// stakeholderRef resolves a stakeholder given by id or by email
+func (s *importService) stakeholderRef(ctx context.Context, row *spreadsheet.Row, idColumn, emailColumn string) (*uuid.UUID, *rowError) {
+	if value := row.Get(idColumn); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, &rowError{field: idColumn, message: idColumn + " must be a UUID"}
+		}
+		return &id, nil
+	}
+	if email := row.Get(emailColumn); email != "" {
+		stakeholder, err := s.stakeholder.GetStakeholderByEmail(ctx, email)
+		if err != nil {
+			return nil, &rowError{field: emailColumn, message: err.Error()}
+		}
+		return &stakeholder.ID, nil
+	}
+	return nil, nil
+}
+
+func required(row *spreadsheet.Row, columns ...string) []rowError {
+	var errs []rowError
+	for _, column := range columns {
+		if row.Get(column) == "" {
+			errs = append(errs, rowError{field: column, message: column + " is required"})
+		}
+	}
+	return errs
+}
+
+// duplicate rejects a key already used by an earlier row, which would otherwise overwrite it
+func duplicate(seen map[string]int, column, key string, line int) []rowError {
+	if key == "" {
+		return nil
+	}
+	if first, ok := seen[key]; ok {
+		return []rowError{{field: column, message: fmt.Sprintf("duplicate %s, first used on row %d", column, first)}}
+	}
+	seen[key] = line
+	return nil
+}
+
+func metadataOf(row *spreadsheet.Row) (domain.JSONB, *rowError) {
+	value := row.Get("metadata")
+	if value == "" {
+		return nil, nil
+	}
+	var metadata domain.JSONB
+	if err := json.Unmarshal([]byte(value), &metadata); err != nil {
+		return nil, &rowError{field: "metadata", message: "metadata must be a JSON object"}
+	}
+	return metadata, nil
+}
+
+func productErrorField(err error) string {
+	switch {
+	case errors.Is(err, ErrDuplicateSKU):
+		return "sku"
+	case errors.Is(err, ErrStakeholderNotFound), errors.Is(err, ErrInvalidStakeholderType):
+		return "manufacturer_id"
+	default:
+		return ""
+	}
+}
+
+func stakeholderErrorField(err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidStakeholderType):
+		return "type"
+	case errors.Is(err, ErrDuplicateEmail):
+		return "email"
+	case errors.Is(err, ErrDuplicateWallet):
+		return "wallet_address"
+	default:
+		return ""
+	}
+}
+
+func eventErrorField(err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidEventType):
+		return "event_type"
+	case errors.Is(err, ErrProductNotFound):
+		return "product_id"
+	case errors.Is(err, ErrStakeholderNotFound):
+		return "stakeholder_id"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/gs1"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+)
+
+// maxLabelSheetSize bounds how many labels one sheet request may print
+const maxLabelSheetSize = 1000
+
+type labelService struct {
+	productRepo     repository.ProductRepository
+	containmentRepo repository.ContainmentRepository
+	transferRepo    repository.CustodyTransferRepository
+}
+
+func NewLabelService(productRepo repository.ProductRepository, containmentRepo repository.ContainmentRepository, transferRepo repository.CustodyTransferRepository) *labelService {
+	return &labelService{
+		productRepo:     productRepo,
+		containmentRepo: containmentRepo,
+		transferRepo:    transferRepo,
+	}
+}
+
+// ProductLabel describes the label of one product unit, with its recorded lot and serial
+func (s *labelService) ProductLabel(ctx context.Context, productID uuid.UUID, base string) (*dto.ProductLabel, error) {
+	product, err := s.getProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := unitLabels([]*domain.Product{product}, base)
+	if err != nil {
+		return nil, err
+	}
+	return labels[0], nil
+}
+
+// LotLabel describes a class-level label for one lot of a product: GTIN and lot, no serial
+func (s *labelService) LotLabel(ctx context.Context, productID uuid.UUID, lot string, base string) (*dto.ProductLabel, error) {
+	if lot == "" {
+		return nil, fmt.Errorf("%w: lot is required", ErrInvalidLabelRequest)
+	}
+
+	product, err := s.getProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return newProductLabel(product, lot, "", base)
+}
+
+// SheetLabels describes the labels of a batch of products in the requested order
+func (s *labelService) SheetLabels(ctx context.Context, req *dto.CreateLabelSheetRequest, base string) ([]*dto.ProductLabel, error) {
+	if len(req.ProductIDs) == 0 {
+		return nil, fmt.Errorf("%w: product_ids is required", ErrInvalidLabelRequest)
+	}
+	if len(req.ProductIDs) > maxLabelSheetSize {
+		return nil, fmt.Errorf("%w: at most %d labels per sheet", ErrInvalidLabelRequest, maxLabelSheetSize)
+	}
+
+	products, err := s.productRepo.GetByIDs(ctx, req.ProductIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	byID := make(map[uuid.UUID]*domain.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	ordered := make([]*domain.Product, 0, len(req.ProductIDs))
+	for _, id := range req.ProductIDs {
+		product, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProductNotFound, id)
+		}
+		ordered = append(ordered, product)
+	}
+	return unitLabels(ordered, base)
+}
+
+// ShipmentLabels describes the labels for a custody transfer: the transferred product followed by
+// everything currently packed inside it, outermost level first
+func (s *labelService) ShipmentLabels(ctx context.Context, transferID uuid.UUID, base string) ([]*dto.ProductLabel, error) {
+	transfer, err := s.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCustodyTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get custody transfer: %w", err)
+	}
+
+	root, err := s.getProduct(ctx, transfer.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	products := []*domain.Product{root}
+	level := []uuid.UUID{root.ID}
+	for depth := 0; depth < maxContainmentDepth && len(level) > 0; depth++ {
+		var next []uuid.UUID
+		for _, parentID := range level {
+			children, err := s.containmentRepo.GetActiveChildren(ctx, parentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get container contents: %w", err)
+			}
+			for _, containment := range children {
+				if containment.Child == nil {
+					continue
+				}
+				products = append(products, containment.Child)
+				next = append(next, containment.ChildID)
+			}
+		}
+		if len(products) > maxLabelSheetSize {
+			return nil, fmt.Errorf("%w: shipment holds more than %d products", ErrInvalidLabelRequest, maxLabelSheetSize)
+		}
+		level = next
+	}
+
+	return unitLabels(products, base)
+}
+
+func (s *labelService) getProduct(ctx context.Context, productID uuid.UUID) (*domain.Product, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	return product, nil
+}
+
+func unitLabels(products []*domain.Product, base string) ([]*dto.ProductLabel, error) {
+	labels := make([]*dto.ProductLabel, 0, len(products))
+	for _, product := range products {
+		var lot, serial string
+		if product.LotNumber != nil {
+			lot = *product.LotNumber
+		}
+		if product.SerialNumber != nil {
+			serial = *product.SerialNumber
+		}
+		label, err := newProductLabel(product, lot, serial, base)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// newProductLabel encodes the Digital Link and GS1 payloads when the SKU is a GTIN; other products
+// are labelled with their trace URL only
+func newProductLabel(product *domain.Product, lot, serial, base string) (*dto.ProductLabel, error) {
+	label := &dto.ProductLabel{
+		ProductID: product.ID,
+		SKU:       product.SKU,
+		Name:      product.Name,
+		Lot:       lot,
+		Serial:    serial,
+		URL:       base + "/api/v1/epcis/products/" + product.ID.String() + "/trace",
+	}
+
+	gtin, err := gs1.NormalizeGTIN(product.SKU)
+	if err != nil {
+		return label, nil
+	}
+
+	link := &gs1.DigitalLink{GTIN: gtin, Lot: lot, Serial: serial}
+	if label.ElementString, err = link.ElementString(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidLabelRequest, product.SKU, err)
+	}
+	if label.GS1128Payload, err = link.Payload(gs1.SymbologyGS1128); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidLabelRequest, product.SKU, err)
+	}
+	if label.DataMatrixPayload, err = link.Payload(gs1.SymbologyGS1DataMatrix); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidLabelRequest, product.SKU, err)
+	}
+	label.DigitalLink = link.URI(base)
+	label.URL = label.DigitalLink
+
+	return label, nil
+}
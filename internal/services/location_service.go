@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/gs1"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRadiusKm bounds a radius query at half the earth's circumference, which covers every point
+	maxRadiusKm = 20038
+
+	// maxNearbyLocations caps how many locations one radius query returns
+	maxNearbyLocations = 100
+)
+
+type locationService struct {
+	repo            repository.LocationRepository
+	stakeholderRepo repository.StakeholderRepository
+	eventRepo       repository.SupplyChainEventRepository
+}
+
+func NewLocationService(repo repository.LocationRepository, stakeholderRepo repository.StakeholderRepository, eventRepo repository.SupplyChainEventRepository) *locationService {
+	return &locationService{repo: repo, stakeholderRepo: stakeholderRepo, eventRepo: eventRepo}
+}
+
+func (s *locationService) CreateLocation(ctx context.Context, req *dto.CreateLocationRequest) (*domain.Location, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidLocation)
+	}
+	if !domain.IsValidLocationType(req.Type) {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidLocation, req.Type)
+	}
+	if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+	if req.GLN != nil && !gs1.ValidGLN(*req.GLN) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLocation, gs1.ErrInvalidGLN)
+	}
+	if req.StakeholderID != nil {
+		if err := s.validateOwner(ctx, *req.StakeholderID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check if name or GLN already exists
+	if _, err := s.repo.GetByName(ctx, name); err == nil {
+		return nil, ErrDuplicateLocation
+	}
+	if req.GLN != nil {
+		if _, err := s.repo.GetByGLN(ctx, *req.GLN); err == nil {
+			return nil, ErrDuplicateGLN
+		}
+	}
+
+	location := &domain.Location{
+		ID:            uuid.New(),
+		Name:          name,
+		GLN:           req.GLN,
+		Type:          req.Type,
+		Address:       req.Address,
+		Latitude:      req.Latitude,
+		Longitude:     req.Longitude,
+		StakeholderID: req.StakeholderID,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if err := s.repo.Create(ctx, location); err != nil {
+		return nil, fmt.Errorf("failed to create location: %w", err)
+	}
+
+	return s.GetLocation(ctx, location.ID)
+}
+
+func (s *locationService) GetLocation(ctx context.Context, id uuid.UUID) (*domain.Location, error) {
+	location, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	return location, nil
+}
+
+func (s *locationService) UpdateLocation(ctx context.Context, id uuid.UUID, req *dto.UpdateLocationRequest) (*domain.Location, error) {
+	existing, err := s.GetLocation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name is required", ErrInvalidLocation)
+		}
+		// Check if new name already exists
+		if other, err := s.repo.GetByName(ctx, name); err == nil && other.ID != id {
+			return nil, ErrDuplicateLocation
+		}
+		updates["name"] = name
+	}
+	if req.GLN != nil {
+		if !gs1.ValidGLN(*req.GLN) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLocation, gs1.ErrInvalidGLN)
+		}
+		// Check if new GLN already exists
+		if other, err := s.repo.GetByGLN(ctx, *req.GLN); err == nil && other.ID != id {
+			return nil, ErrDuplicateGLN
+		}
+		updates["gln"] = *req.GLN
+	}
+	if req.Type != nil {
+		if !domain.IsValidLocationType(*req.Type) {
+			return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidLocation, *req.Type)
+		}
+		updates["type"] = *req.Type
+	}
+	if req.Address != nil {
+		updates["address"] = *req.Address
+	}
+	if req.Latitude != nil || req.Longitude != nil {
+		latitude, longitude := existing.Latitude, existing.Longitude
+		if req.Latitude != nil {
+			latitude = req.Latitude
+		}
+		if req.Longitude != nil {
+			longitude = req.Longitude
+		}
+		if err := validateCoordinates(latitude, longitude); err != nil {
+			return nil, err
+		}
+		updates["latitude"], updates["longitude"] = *latitude, *longitude
+	}
+	if req.StakeholderID != nil {
+		if err := s.validateOwner(ctx, *req.StakeholderID); err != nil {
+			return nil, err
+		}
+		updates["stakeholder_id"] = *req.StakeholderID
+	}
+
+	updates["updated_at"] = time.Now()
+
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return s.GetLocation(ctx, id)
+}
+
+// DeleteLocation removes a location from the registry; its events keep their location name
+func (s *locationService) DeleteLocation(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetLocation(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete location: %w", err)
+	}
+
+	return nil
+}
+
+func (s *locationService) ListLocations(ctx context.Context, filter *dto.LocationFilter) (*dto.PaginatedResponse, error) {
+	if filter.Type != nil && !domain.IsValidLocationType(*filter.Type) {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidLocation, *filter.Type)
+	}
+
+	locations, page, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(locations, page, filter.Limit, filter.Offset), nil
+}
+
+// ListNearby returns the locations within near, nearest first, with their distance
+func (s *locationService) ListNearby(ctx context.Context, near dto.GeoRadius, filter *dto.LocationFilter) ([]*dto.NearbyLocation, error) {
+	if err := validateGeoRadius(near); err != nil {
+		return nil, err
+	}
+	if filter.Type != nil && !domain.IsValidLocationType(*filter.Type) {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidLocation, *filter.Type)
+	}
+	if filter.Limit <= 0 || filter.Limit > maxNearbyLocations {
+		filter.Limit = maxNearbyLocations
+	}
+
+	locations, err := s.repo.ListNearby(ctx, near, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nearby locations: %w", err)
+	}
+	return locations, nil
+}
+
+// ListEvents lists the events recorded at a location, or at any location within filter.Near
+func (s *locationService) ListEvents(ctx context.Context, filter *dto.SupplyChainEventFilter) (*dto.PaginatedResponse, error) {
+	if filter.LocationID != nil {
+		if _, err := s.GetLocation(ctx, *filter.LocationID); err != nil {
+			return nil, err
+		}
+	}
+	if filter.Near != nil {
+		if err := validateGeoRadius(*filter.Near); err != nil {
+			return nil, err
+		}
+	}
+
+	events, page, err := s.eventRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list location events: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(events, page, filter.Limit, filter.Offset), nil
+}
+
+func (s *locationService) validateOwner(ctx context.Context, stakeholderID uuid.UUID) error {
+	if _, err := s.stakeholderRepo.GetByID(ctx, stakeholderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrStakeholderNotFound
+		}
+		return fmt.Errorf("failed to validate stakeholder: %w", err)
+	}
+	return nil
+}
+
+// validateCoordinates accepts no coordinates, or a latitude and longitude on the globe
+func validateCoordinates(latitude, longitude *float64) error {
+	if latitude == nil && longitude == nil {
+		return nil
+	}
+	if latitude == nil || longitude == nil {
+		return fmt.Errorf("%w: latitude and longitude are set together", ErrInvalidLocation)
+	}
+	if *latitude < -90 || *latitude > 90 || *longitude < -180 || *longitude > 180 {
+		return fmt.Errorf("%w: coordinates out of range", ErrInvalidLocation)
+	}
+	return nil
+}
+
+func validateGeoRadius(near dto.GeoRadius) error {
+	if err := validateCoordinates(&near.Latitude, &near.Longitude); err != nil {
+		return err
+	}
+	if near.RadiusKm <= 0 || near.RadiusKm > maxRadiusKm {
+		return fmt.Errorf("%w: radius_km must be above 0 and at most %d", ErrInvalidLocation, maxRadiusKm)
+	}
+	return nil
+}
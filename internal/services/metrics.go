@@ -0,0 +1,19 @@
+package services
+
+// Metrics counts what services do for the metrics endpoint. Services record a measurement once the
+// transaction it belongs to has committed, so rolled back work is never counted.
+type Metrics interface {
+	RecordRouteEvaluation(source string)
+	RecordRouteAlert(alertType string)
+}
+
+// nopMetrics discards every measurement; it is for tools that expose no metrics endpoint
+type nopMetrics struct{}
+
+func NewNopMetrics() Metrics {
+	return nopMetrics{}
+}
+
+func (nopMetrics) RecordRouteEvaluation(source string) {}
+
+func (nopMetrics) RecordRouteAlert(alertType string) {}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"log/slog"
+	"time"
+)
+
+const (
+	// outboxBatchSize is how many messages the relay publishes per broker call
+	outboxBatchSize = 100
+
+	// outboxPollInterval is how often the relay looks for new messages once it has caught up
+	outboxPollInterval = time.Second
+
+	// outboxRetention is how long published messages are kept before the relay prunes them
+	outboxRetention = 7 * 24 * time.Hour
+)
+
+// emit stores domain events in the outbox. Call it with the repositories of the transaction that
+// makes the change, so the events are published if and only if the change commits.
+func emit(ctx context.Context, outbox repository.OutboxRepository, events ...eventbus.Event) error {
+	now := time.Now()
+	messages := make([]*domain.OutboxMessage, 0, len(events))
+	for _, event := range events {
+		message, err := eventbus.NewMessage(event, now)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", event.EventName(), err)
+		}
+		messages = append(messages, &domain.OutboxMessage{
+			ID:           message.ID,
+			Name:         message.Name,
+			AggregateKey: message.Key,
+			Payload:      string(message.Payload),
+			OccurredAt:   message.OccurredAt,
+		})
+	}
+
+	if err := outbox.Add(ctx, messages); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	return nil
+}
+
+type outboxRelay struct {
+	repo      repository.OutboxRepository
+	publisher eventbus.Publisher
+}
+
+func NewOutboxRelay(repo repository.OutboxRepository, publisher eventbus.Publisher) *outboxRelay {
+	return &outboxRelay{repo: repo, publisher: publisher}
+}
+
+// RelayPending numbers the messages committed since the last pass, then publishes one batch in
+// that order and reports how many were published. Delivery is at least once: a crash between the
+// broker accepting a batch and the outbox recording it publishes the batch again.
+func (r *outboxRelay) RelayPending(ctx context.Context) (int, error) {
+	// Consumers of the outbox log, such as webhooks, follow the numbering whether or not the broker is up
+	for {
+		numbered, err := r.repo.Sequence(ctx, outboxBatchSize)
+		if err != nil {
+			return 0, fmt.Errorf("failed to number outbox messages: %w", err)
+		}
+		if numbered < outboxBatchSize {
+			break
+		}
+	}
+
+	return r.repo.ProcessPending(ctx, outboxBatchSize, func(pending []*domain.OutboxMessage) error {
+		messages := make([]eventbus.Message, 0, len(pending))
+		for _, message := range pending {
+			messages = append(messages, eventbus.Message{
+				ID:         message.ID,
+				Name:       message.Name,
+				Key:        message.AggregateKey,
+				OccurredAt: message.OccurredAt.UTC(),
+				Payload:    []byte(message.Payload),
+			})
+		}
+		return r.publisher.Publish(ctx, messages...)
+	})
+}
+
+// Run relays the outbox until ctx is cancelled, pruning old published messages once an hour
+func (r *outboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+	var lastPrune time.Time
+
+	for {
+		// Drain full batches before waiting again
+		for {
+			published, err := r.RelayPending(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to relay outbox", "error", err)
+			}
+			if err != nil || published < outboxBatchSize {
+				break
+			}
+		}
+
+		if time.Since(lastPrune) > time.Hour {
+			if _, err := r.repo.DeletePublishedBefore(ctx, time.Now().Add(-outboxRetention)); err != nil {
+				slog.ErrorContext(ctx, "failed to prune outbox", "error", err)
+			}
+			lastPrune = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
 	"github.com/koriebruh/suplyChainTrack/internal/repository"
 	"gorm.io/gorm"
 	"time"
@@ -15,10 +16,11 @@ import (
 type productService struct {
 	repo            repository.ProductRepository
 	stakeholderRepo repository.StakeholderRepository
+	tx              repository.Transactor
 }
 
-func NewProductService(repo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository) *productService {
-	return &productService{repo: repo, stakeholderRepo: stakeholderRepo}
+func NewProductService(repo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository, tx repository.Transactor) *productService {
+	return &productService{repo: repo, stakeholderRepo: stakeholderRepo, tx: tx}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, req *dto.CreateProductRequest) (*domain.Product, error) {
@@ -27,18 +29,8 @@ func (s *productService) CreateProduct(ctx context.Context, req *dto.CreateProdu
 		return nil, ErrDuplicateSKU
 	}
 
-	// Validate manufacturer if provided
-	if req.ManufacturerID != nil {
-		manufacturer, err := s.stakeholderRepo.GetByID(ctx, *req.ManufacturerID)
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, ErrStakeholderNotFound
-			}
-			return nil, fmt.Errorf("failed to validate manufacturer: %w", err)
-		}
-		if manufacturer.Type != domain.StakeholderTypeManufacturer {
-			return nil, ErrInvalidStakeholderType
-		}
+	if err := s.ValidateProduct(ctx, req); err != nil {
+		return nil, err
 	}
 
 	product := &domain.Product{
@@ -48,18 +40,50 @@ func (s *productService) CreateProduct(ctx context.Context, req *dto.CreateProdu
 		Description:    req.Description,
 		Category:       req.Category,
 		ManufacturerID: req.ManufacturerID,
+		LotNumber:      req.LotNumber,
+		SerialNumber:   req.SerialNumber,
 		Metadata:       req.Metadata,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
 
-	if err := s.repo.Create(ctx, product); err != nil {
-		return nil, fmt.Errorf("failed to create product: %w", err)
+	err := s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		if err := repos.Product.Create(ctx, product); err != nil {
+			return fmt.Errorf("failed to create product: %w", err)
+		}
+		return emit(ctx, repos.Outbox, eventbus.ProductCreated{Product: product})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return s.repo.GetByID(ctx, product.ID)
 }
 
+// ValidateProduct applies the CreateProduct rules that do not depend on the SKU being new,
+// so an import can check a row it may end up upserting
+func (s *productService) ValidateProduct(ctx context.Context, req *dto.CreateProductRequest) error {
+	// Validate manufacturer if provided
+	if req.ManufacturerID != nil {
+		return s.validateManufacturer(ctx, *req.ManufacturerID)
+	}
+	return nil
+}
+
+func (s *productService) validateManufacturer(ctx context.Context, manufacturerID uuid.UUID) error {
+	manufacturer, err := s.stakeholderRepo.GetByID(ctx, manufacturerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrStakeholderNotFound
+		}
+		return fmt.Errorf("failed to validate manufacturer: %w", err)
+	}
+	if manufacturer.Type != domain.StakeholderTypeManufacturer {
+		return ErrInvalidStakeholderType
+	}
+	return nil
+}
+
 func (s *productService) GetProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
 	product, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -100,19 +124,17 @@ func (s *productService) UpdateProduct(ctx context.Context, id uuid.UUID, req *d
 		updates["category"] = *req.Category
 	}
 	if req.ManufacturerID != nil {
-		// Validate manufacturer
-		manufacturer, err := s.stakeholderRepo.GetByID(ctx, *req.ManufacturerID)
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, ErrStakeholderNotFound
-			}
-			return nil, fmt.Errorf("failed to validate manufacturer: %w", err)
-		}
-		if manufacturer.Type != domain.StakeholderTypeManufacturer {
-			return nil, ErrInvalidStakeholderType
+		if err := s.validateManufacturer(ctx, *req.ManufacturerID); err != nil {
+			return nil, err
 		}
 		updates["manufacturer_id"] = *req.ManufacturerID
 	}
+	if req.LotNumber != nil {
+		updates["lot_number"] = *req.LotNumber
+	}
+	if req.SerialNumber != nil {
+		updates["serial_number"] = *req.SerialNumber
+	}
 	if req.Metadata != nil {
 		updates["metadata"] = req.Metadata
 	}
@@ -143,18 +165,12 @@ func (s *productService) ListProducts(ctx context.Context, filter *dto.ProductFi
 		filter = &dto.ProductFilter{Limit: 10, Offset: 0}
 	}
 
-	products, total, err := s.repo.List(ctx, filter)
+	products, page, err := s.repo.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
 
-	return &dto.PaginatedResponse{
-		Data:    products,
-		Total:   int(total),
-		Limit:   filter.Limit,
-		Offset:  filter.Offset,
-		HasMore: filter.Offset+filter.Limit < int(total),
-	}, nil
+	return dto.NewPaginatedResponse(products, page, filter.Limit, filter.Offset), nil
 }
 
 func (s *productService) GetProductStats(ctx context.Context, id uuid.UUID) (*dto.ProductStats, error) {
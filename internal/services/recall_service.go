@@ -0,0 +1,532 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
+	"github.com/koriebruh/suplyChainTrack/internal/paging"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// custodyLookupBatch bounds how many product ids go into one custody projection query
+const custodyLookupBatch = 1000
+
+type recallService struct {
+	repo               repository.RecallRepository
+	projectionRepo     repository.CustodyProjectionRepository
+	containmentRepo    repository.ContainmentRepository
+	transformationRepo repository.TransformationRepository
+	productRepo        repository.ProductRepository
+	stakeholderRepo    repository.StakeholderRepository
+	tx                 repository.Transactor
+}
+
+func NewRecallService(repo repository.RecallRepository, projectionRepo repository.CustodyProjectionRepository, containmentRepo repository.ContainmentRepository, transformationRepo repository.TransformationRepository, productRepo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository, tx repository.Transactor) *recallService {
+	return &recallService{
+		repo:               repo,
+		projectionRepo:     projectionRepo,
+		containmentRepo:    containmentRepo,
+		transformationRepo: transformationRepo,
+		productRepo:        productRepo,
+		stakeholderRepo:    stakeholderRepo,
+		tx:                 tx,
+	}
+}
+
+func (s *recallService) CreateRecall(ctx context.Context, req *dto.CreateRecallRequest) (*domain.Recall, error) {
+	if strings.TrimSpace(req.Title) == "" {
+		return nil, ErrInvalidRecall
+	}
+
+	severity := req.Severity
+	if severity == "" {
+		severity = domain.RecallSeverityMedium
+	}
+	if !domain.IsValidRecallSeverity(severity) {
+		return nil, ErrInvalidRecall
+	}
+
+	now := time.Now()
+	recall := &domain.Recall{
+		ID:               uuid.New(),
+		Title:            req.Title,
+		Reason:           req.Reason,
+		Severity:         severity,
+		Status:           domain.RecallStatusDraft,
+		InitiatedBy:      req.InitiatedBy,
+		ProductID:        req.ProductID,
+		LotNumber:        req.LotNumber,
+		SerialFrom:       req.SerialFrom,
+		SerialTo:         req.SerialTo,
+		ManufacturedFrom: req.ManufacturedFrom,
+		ManufacturedTo:   req.ManufacturedTo,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if !recall.HasScope() {
+		return nil, ErrInvalidRecall
+	}
+	if recall.ManufacturedFrom != nil && recall.ManufacturedTo != nil && recall.ManufacturedTo.Before(*recall.ManufacturedFrom) {
+		return nil, ErrInvalidRecall
+	}
+
+	// Validate referenced product and initiator
+	if req.ProductID != nil {
+		if _, err := s.productRepo.GetByID(ctx, *req.ProductID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("failed to validate product: %w", err)
+		}
+	}
+	if req.InitiatedBy != nil {
+		if _, err := s.stakeholderRepo.GetByID(ctx, *req.InitiatedBy); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrStakeholderNotFound
+			}
+			return nil, fmt.Errorf("failed to validate stakeholder: %w", err)
+		}
+	}
+
+	if err := s.repo.Create(ctx, recall); err != nil {
+		return nil, fmt.Errorf("failed to create recall: %w", err)
+	}
+
+	return s.GetRecall(ctx, recall.ID)
+}
+
+func (s *recallService) GetRecall(ctx context.Context, id uuid.UUID) (*domain.Recall, error) {
+	recall, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecallNotFound
+		}
+		return nil, fmt.Errorf("failed to get recall: %w", err)
+	}
+	return recall, nil
+}
+
+func (s *recallService) ListRecalls(ctx context.Context, filter *dto.RecallFilter) (*dto.PaginatedResponse, error) {
+	if filter == nil {
+		filter = &dto.RecallFilter{Limit: 10, Offset: 0}
+	}
+	if filter.Status != nil && !domain.IsValidRecallStatus(*filter.Status) {
+		return nil, ErrInvalidRecall
+	}
+	if filter.Severity != nil && !domain.IsValidRecallSeverity(*filter.Severity) {
+		return nil, ErrInvalidRecall
+	}
+
+	recalls, page, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recalls: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(recalls, page, filter.Limit, filter.Offset), nil
+}
+
+// ActivateRecall freezes the set of affected units and their current holders, then notifies
+// every holder. Units and notifications are written atomically with the status change; delivery
+// happens after commit and failed deliveries can be retried with NotifyStakeholders.
+func (s *recallService) ActivateRecall(ctx context.Context, id uuid.UUID) (*domain.Recall, error) {
+	recall, err := s.GetRecall(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recall.Status != domain.RecallStatusDraft {
+		return nil, ErrRecallNotDraft
+	}
+
+	scoped, err := s.repo.FindScopedProducts(ctx, recall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find products in recall scope: %w", err)
+	}
+	if len(scoped) == 0 {
+		return nil, ErrRecallNoUnits
+	}
+
+	productIDs, sources, err := s.affectedUnits(ctx, scoped)
+	if err != nil {
+		return nil, err
+	}
+
+	custodies, err := s.custodyByProduct(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	units := make([]*domain.RecallUnit, 0, len(productIDs))
+	unitsByHolder := make(map[uuid.UUID]int)
+	holders := make([]uuid.UUID, 0)
+	for _, productID := range productIDs {
+		unit := &domain.RecallUnit{
+			ID:        uuid.New(),
+			RecallID:  recall.ID,
+			ProductID: productID,
+			Source:    sources[productID],
+			CreatedAt: now,
+		}
+		if custody, ok := custodies[productID]; ok {
+			state := custody.State
+			unit.HolderID = custody.HolderID
+			unit.Location = custody.Location
+			unit.State = &state
+		}
+		if unit.HolderID != nil {
+			if _, seen := unitsByHolder[*unit.HolderID]; !seen {
+				holders = append(holders, *unit.HolderID)
+			}
+			unitsByHolder[*unit.HolderID]++
+		}
+		units = append(units, unit)
+	}
+
+	notifications := make([]*domain.RecallNotification, 0, len(holders))
+	for _, holderID := range holders {
+		notifications = append(notifications, &domain.RecallNotification{
+			ID:            uuid.New(),
+			RecallID:      recall.ID,
+			StakeholderID: holderID,
+			UnitCount:     unitsByHolder[holderID],
+			Status:        domain.RecallNotificationStatusPending,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		activated, err := repos.Recall.UpdateIfStatus(ctx, recall.ID, domain.RecallStatusDraft, map[string]interface{}{
+			"status":         domain.RecallStatusActive,
+			"affected_units": len(units),
+			"activated_at":   now,
+			"updated_at":     now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to activate recall: %w", err)
+		}
+		if !activated {
+			return ErrRecallNotDraft
+		}
+		if err := repos.Recall.CreateUnits(ctx, units); err != nil {
+			return fmt.Errorf("failed to record recall units: %w", err)
+		}
+		if err := repos.Recall.CreateNotifications(ctx, notifications); err != nil {
+			return fmt.Errorf("failed to record recall notifications: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recall, err = s.GetRecall(ctx, recall.ID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.dispatch(ctx, recall); err != nil {
+		slog.Error("recall notification failed", "recall_id", recall.ID, "error", err)
+	}
+
+	return recall, nil
+}
+
+// NotifyStakeholders (re)sends every notice that has not been delivered yet and returns how many were sent
+func (s *recallService) NotifyStakeholders(ctx context.Context, id uuid.UUID) (int, error) {
+	recall, err := s.GetRecall(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if recall.Status != domain.RecallStatusActive {
+		return 0, ErrRecallNotActive
+	}
+	return s.dispatch(ctx, recall)
+}
+
+func (s *recallService) AcknowledgeRecall(ctx context.Context, id uuid.UUID, req *dto.AcknowledgeRecallRequest) (*domain.RecallNotification, error) {
+	recall, err := s.GetRecall(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if recall.Status != domain.RecallStatusActive {
+		return nil, ErrRecallNotActive
+	}
+
+	notification, err := s.repo.GetNotification(ctx, recall.ID, req.StakeholderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecallNotificationNotFound
+		}
+		return nil, fmt.Errorf("failed to get recall notification: %w", err)
+	}
+
+	// Acknowledging twice keeps the first acknowledgement time
+	if notification.Status == domain.RecallNotificationStatusAcknowledged {
+		return notification, nil
+	}
+
+	now := time.Now()
+	if err := s.repo.UpdateNotification(ctx, notification.ID, map[string]interface{}{
+		"status":          domain.RecallNotificationStatusAcknowledged,
+		"acknowledged_at": now,
+		"notes":           req.Notes,
+		"updated_at":      now,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to acknowledge recall: %w", err)
+	}
+
+	return s.repo.GetNotification(ctx, recall.ID, req.StakeholderID)
+}
+
+func (s *recallService) CloseRecall(ctx context.Context, id uuid.UUID) (*domain.Recall, error) {
+	if _, err := s.GetRecall(ctx, id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	closed, err := s.repo.UpdateIfStatus(ctx, id, domain.RecallStatusActive, map[string]interface{}{
+		"status":     domain.RecallStatusClosed,
+		"closed_at":  now,
+		"updated_at": now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to close recall: %w", err)
+	}
+	if !closed {
+		return nil, ErrRecallNotActive
+	}
+
+	return s.GetRecall(ctx, id)
+}
+
+func (s *recallService) ListAffectedUnits(ctx context.Context, id uuid.UUID, filter *dto.RecallUnitFilter) (*dto.PaginatedResponse, error) {
+	if _, err := s.GetRecall(ctx, id); err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		filter = &dto.RecallUnitFilter{Limit: 10, Offset: 0}
+	}
+
+	units, page, err := s.repo.ListUnits(ctx, id, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recall units: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(units, page, filter.Limit, filter.Offset), nil
+}
+
+func (s *recallService) GetRecallReport(ctx context.Context, id uuid.UUID) (*dto.RecallReport, error) {
+	recall, err := s.GetRecall(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.CountUnits(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recall units: %w", err)
+	}
+	notifications, err := s.repo.ListNotifications(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recall notifications: %w", err)
+	}
+
+	report := &dto.RecallReport{
+		Recall:        recall,
+		UnitsBySource: make(map[string]int64),
+		UnitsByState:  make(map[string]int64),
+		Holders:       make([]*dto.RecallHolderSummary, 0, len(notifications)),
+	}
+
+	for _, count := range counts {
+		report.TotalUnits += count.Count
+		report.UnitsBySource[count.Source] += count.Count
+		state := count.State
+		if state == "" {
+			state = "unknown"
+		}
+		report.UnitsByState[state] += count.Count
+		if !count.HasHolder {
+			report.UnitsWithoutHolder += count.Count
+		}
+	}
+
+	for _, notification := range notifications {
+		report.Stakeholders++
+		switch notification.Status {
+		case domain.RecallNotificationStatusAcknowledged:
+			report.Acknowledged++
+		case domain.RecallNotificationStatusFailed:
+			report.Failed++
+			report.Outstanding++
+		default:
+			report.Outstanding++
+		}
+
+		holder := &dto.RecallHolderSummary{
+			StakeholderID:  notification.StakeholderID,
+			Units:          notification.UnitCount,
+			Status:         notification.Status,
+			SentAt:         notification.SentAt,
+			AcknowledgedAt: notification.AcknowledgedAt,
+		}
+		if notification.Stakeholder != nil {
+			holder.Name = notification.Stakeholder.Name
+			holder.Type = notification.Stakeholder.Type
+		}
+		report.Holders = append(report.Holders, holder)
+	}
+
+	if report.Stakeholders > 0 {
+		report.AcknowledgementRate = float64(report.Acknowledged) / float64(report.Stakeholders)
+	}
+
+	return report, nil
+}
+
+func (s *recallService) ListStakeholderRecalls(ctx context.Context, stakeholderID uuid.UUID, status *string) ([]*domain.RecallNotification, error) {
+	if _, err := s.stakeholderRepo.GetByID(ctx, stakeholderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStakeholderNotFound
+		}
+		return nil, fmt.Errorf("failed to validate stakeholder: %w", err)
+	}
+
+	notifications, err := s.repo.ListNotificationsByStakeholder(ctx, stakeholderID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stakeholder recalls: %w", err)
+	}
+	return notifications, nil
+}
+
+// affectedUnits expands the scoped products to everything currently packed inside them and
+// everything made from them, level by level. It returns the units in discovery order with the
+// reason each one was included.
+func (s *recallService) affectedUnits(ctx context.Context, scoped []uuid.UUID) ([]uuid.UUID, map[uuid.UUID]string, error) {
+	sources := make(map[uuid.UUID]string, len(scoped))
+	ordered := make([]uuid.UUID, 0, len(scoped))
+	add := func(productID uuid.UUID, source string, next *[]uuid.UUID) {
+		if _, seen := sources[productID]; seen {
+			return
+		}
+		sources[productID] = source
+		ordered = append(ordered, productID)
+		*next = append(*next, productID)
+	}
+
+	frontier := make([]uuid.UUID, 0, len(scoped))
+	for _, productID := range scoped {
+		add(productID, domain.RecallUnitSourceScope, &frontier)
+	}
+
+	for depth := 0; depth < maxGenealogyDepth && len(frontier) > 0; depth++ {
+		var next []uuid.UUID
+
+		for _, productID := range frontier {
+			contents, err := s.containmentRepo.GetActiveChildren(ctx, productID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get container contents: %w", err)
+			}
+			for _, containment := range contents {
+				add(containment.ChildID, domain.RecallUnitSourceContents, &next)
+			}
+		}
+
+		edges, err := s.transformationRepo.GetDownstream(ctx, frontier)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to walk downstream genealogy: %w", err)
+		}
+		for _, edge := range edges {
+			add(edge.Line.ProductID, domain.RecallUnitSourceDownstream, &next)
+		}
+
+		frontier = next
+	}
+
+	return ordered, sources, nil
+}
+
+// custodyByProduct reads the current custody of the given products from the projection
+func (s *recallService) custodyByProduct(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]*domain.ProductCustody, error) {
+	custodies := make(map[uuid.UUID]*domain.ProductCustody, len(productIDs))
+	for start := 0; start < len(productIDs); start += custodyLookupBatch {
+		end := min(start+custodyLookupBatch, len(productIDs))
+		items, err := s.projectionRepo.GetByProducts(ctx, productIDs[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current custody: %w", err)
+		}
+		for _, item := range items {
+			custodies[item.ProductID] = item
+		}
+	}
+	return custodies, nil
+}
+
+// dispatch sends every pending or failed notice of the recall as a recall.notice webhook to the
+// stakeholder it is addressed to, and records the outcome on each. A stakeholder with no webhook
+// subscription receiving recall notices has nowhere to be sent to, so its notice stays pending.
+func (s *recallService) dispatch(ctx context.Context, recall *domain.Recall) (int, error) {
+	notifications, err := s.repo.ListNotifications(ctx, recall.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list recall notifications: %w", err)
+	}
+
+	sent := 0
+	for _, notification := range notifications {
+		if notification.Status != domain.RecallNotificationStatusPending && notification.Status != domain.RecallNotificationStatusFailed {
+			continue
+		}
+
+		holderID := notification.StakeholderID
+		units, _, err := s.repo.ListUnits(ctx, recall.ID, &dto.RecallUnitFilter{HolderID: &holderID, Count: paging.CountNone})
+		if err != nil {
+			return sent, fmt.Errorf("failed to list recall units: %w", err)
+		}
+
+		delivered := false
+		err = s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+			subscribed, err := subscribedTo(ctx, repos.Webhook, holderID, domain.WebhookTopicRecallNotice)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			updates := map[string]interface{}{"updated_at": now}
+			if subscribed {
+				// The notice is queued with the status change, so it is delivered once the update commits
+				if err := emit(ctx, repos.Outbox, eventbus.RecallNotice{Recall: recall, StakeholderID: holderID, Units: units}); err != nil {
+					return err
+				}
+				updates["status"] = domain.RecallNotificationStatusSent
+				updates["error"] = nil
+				updates["sent_at"] = now
+			} else {
+				updates["status"] = domain.RecallNotificationStatusPending
+				updates["error"] = "no active webhook subscription receives recall notices"
+			}
+			if err := repos.Recall.UpdateNotification(ctx, notification.ID, updates); err != nil {
+				return fmt.Errorf("failed to update recall notification: %w", err)
+			}
+			delivered = subscribed
+			return nil
+		})
+		if err != nil {
+			return sent, err
+		}
+		if delivered {
+			sent++
+		} else {
+			slog.Warn("recall notice not sent: no webhook subscription", "recall_id", recall.ID, "stakeholder_id", holderID)
+		}
+	}
+
+	return sent, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
+	"github.com/koriebruh/suplyChainTrack/internal/geo"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"slices"
+	"time"
+)
+
+// sighting is a product seen at a point, at a registered location, or both
+type sighting struct {
+	productID uuid.UUID
+	source    string // one of the domain.RouteAlertSource constants
+	at        time.Time
+	point     *geo.Point
+	eventID   *uuid.UUID
+	location  *domain.Location
+}
+
+// eventSighting places an event's product at the location it was recorded at
+func eventSighting(event *domain.SupplyChainEvent, location *domain.Location) sighting {
+	seen := sighting{
+		productID: *event.ProductID,
+		source:    domain.RouteAlertSourceEvent,
+		at:        event.Timestamp,
+		eventID:   &event.ID,
+		location:  location,
+	}
+	if location != nil && location.Latitude != nil && location.Longitude != nil {
+		seen.point = &geo.Point{Latitude: *location.Latitude, Longitude: *location.Longitude}
+	}
+	return seen
+}
+
+// checkRoutes checks a sighting against the route plans of the product's shipments in flight and
+// writes an alert, with its RouteAlertRaised domain event, for every rule it breaks that has no
+// open alert yet. It returns the alerts written and how many plans were checked; the metrics
+// count both once the transaction commits.
+func checkRoutes(ctx context.Context, repos *repository.RepositoriesManagers, metrics Metrics, seen sighting) ([]*domain.RouteAlert, int, error) {
+	plans, err := repos.Route.GetActivePlans(ctx, seen.productID, seen.eventID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get route plans: %w", err)
+	}
+	if len(plans) == 0 {
+		return nil, 0, nil
+	}
+
+	var raised []*domain.RouteAlert
+	for _, plan := range plans {
+		for _, alert := range evaluateRoute(plan, seen) {
+			written, err := repos.Route.RaiseAlert(ctx, alert)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to raise route alert: %w", err)
+			}
+			if written {
+				raised = append(raised, alert)
+			}
+		}
+	}
+
+	events := make([]eventbus.Event, 0, len(raised))
+	for _, alert := range raised {
+		events = append(events, eventbus.RouteAlertRaised{Alert: alert})
+	}
+	if len(events) > 0 {
+		if err := emit(ctx, repos.Outbox, events...); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	repos.AfterCommit(func() {
+		for range plans {
+			metrics.RecordRouteEvaluation(seen.source)
+		}
+		for _, alert := range raised {
+			metrics.RecordRouteAlert(alert.Type)
+		}
+	})
+	return raised, len(plans), nil
+}
+
+// evaluateRoute lists the alerts a sighting raises against one plan
+func evaluateRoute(plan *domain.RoutePlan, seen sighting) []*domain.RouteAlert {
+	var alerts []*domain.RouteAlert
+	newAlert := func(alertType, subject, message string, distance *float64) *domain.RouteAlert {
+		alert := &domain.RouteAlert{
+			ID:                uuid.New(),
+			RoutePlanID:       plan.ID,
+			CustodyTransferID: plan.CustodyTransferID,
+			ProductID:         plan.ProductID,
+			Type:              alertType,
+			Subject:           subject,
+			Source:            seen.source,
+			EventID:           seen.eventID,
+			DistanceKm:        distance,
+			Message:           message,
+			Status:            domain.RouteAlertStatusOpen,
+			ObservedAt:        seen.at,
+			CreatedAt:         time.Now(),
+		}
+		if seen.location != nil {
+			alert.LocationID = &seen.location.ID
+		}
+		if seen.point != nil {
+			alert.Latitude, alert.Longitude = &seen.point.Latitude, &seen.point.Longitude
+		}
+		return alert
+	}
+
+	if seen.location != nil && len(plan.ExpectedLocationIDs) > 0 && !slices.Contains(plan.ExpectedLocationIDs, seen.location.ID) {
+		alerts = append(alerts, newAlert(domain.RouteAlertTypeUnexpectedFacility, seen.location.ID.String(),
+			fmt.Sprintf("recorded at %s, which the route plan does not expect", seen.location.Name), nil))
+	}
+	if seen.point == nil {
+		return alerts
+	}
+
+	if len(plan.Waypoints) >= 2 && plan.CorridorKm > 0 {
+		path := make([]geo.Point, 0, len(plan.Waypoints))
+		for _, waypoint := range plan.Waypoints {
+			path = append(path, geo.Point{Latitude: waypoint.Latitude, Longitude: waypoint.Longitude})
+		}
+		if distance := geo.DistanceToPath(*seen.point, path); distance > plan.CorridorKm {
+			alerts = append(alerts, newAlert(domain.RouteAlertTypeRouteDeviation, "",
+				fmt.Sprintf("%.1f km from the planned route, outside its %.1f km corridor", distance, plan.CorridorKm), &distance))
+		}
+	}
+	for _, fence := range plan.Geofences {
+		if distance := geo.Distance(*seen.point, geo.Point{Latitude: fence.Latitude, Longitude: fence.Longitude}); distance <= fence.RadiusKm {
+			alerts = append(alerts, newAlert(domain.RouteAlertTypeGeofenceEntered, fence.Name,
+				fmt.Sprintf("inside geofence %s, %.1f km from its centre", fence.Name, distance), &distance))
+		}
+	}
+	return alerts
+}
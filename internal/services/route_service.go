@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/geo"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRouteWaypoints caps the points of a planned route
+	maxRouteWaypoints = 1000
+
+	// maxRouteGeofences caps the geofences of one plan
+	maxRouteGeofences = 100
+
+	// maxExpectedLocations caps the locations one plan expects
+	maxExpectedLocations = 100
+)
+
+type routeService struct {
+	repo         repository.RouteRepository
+	transferRepo repository.CustodyTransferRepository
+	productRepo  repository.ProductRepository
+	locationRepo repository.LocationRepository
+	metrics      Metrics
+	tx           repository.Transactor
+}
+
+func NewRouteService(repo repository.RouteRepository, transferRepo repository.CustodyTransferRepository, productRepo repository.ProductRepository, locationRepo repository.LocationRepository, metrics Metrics, tx repository.Transactor) *routeService {
+	return &routeService{repo: repo, transferRepo: transferRepo, productRepo: productRepo, locationRepo: locationRepo, metrics: metrics, tx: tx}
+}
+
+// SetRoutePlan gives a pending shipment its route plan, replacing the one it had. Positions
+// reported before the plan was set are not checked again.
+func (s *routeService) SetRoutePlan(ctx context.Context, transferID uuid.UUID, req *dto.SetRoutePlanRequest) (*domain.RoutePlan, error) {
+	transfer, err := s.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCustodyTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get custody transfer: %w", err)
+	}
+	if transfer.Status != domain.CustodyTransferStatusPending {
+		return nil, ErrCustodyTransferNotPending
+	}
+	if err := s.validatePlan(ctx, req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	plan := &domain.RoutePlan{
+		ID:                  uuid.New(),
+		CustodyTransferID:   transfer.ID,
+		ProductID:           transfer.ProductID,
+		Waypoints:           req.Waypoints,
+		CorridorKm:          req.CorridorKm,
+		Geofences:           req.Geofences,
+		ExpectedLocationIDs: req.ExpectedLocationIDs,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	for i := range plan.Geofences {
+		plan.Geofences[i].Name = strings.TrimSpace(plan.Geofences[i].Name)
+	}
+	if err := s.repo.SavePlan(ctx, plan); err != nil {
+		return nil, fmt.Errorf("failed to save route plan: %w", err)
+	}
+
+	return s.GetRoutePlan(ctx, transferID)
+}
+
+func (s *routeService) GetRoutePlan(ctx context.Context, transferID uuid.UUID) (*domain.RoutePlan, error) {
+	plan, err := s.repo.GetPlanByTransfer(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoutePlanNotFound
+		}
+		return nil, fmt.Errorf("failed to get route plan: %w", err)
+	}
+	return plan, nil
+}
+
+// DeleteRoutePlan stops checking a shipment's positions; its alerts are deleted with it
+func (s *routeService) DeleteRoutePlan(ctx context.Context, transferID uuid.UUID) error {
+	if _, err := s.GetRoutePlan(ctx, transferID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePlan(ctx, transferID); err != nil {
+		return fmt.Errorf("failed to delete route plan: %w", err)
+	}
+
+	return nil
+}
+
+// ReportPosition checks where a product is against the route plans of its shipments in flight
+// and returns the alerts it raised. The position itself is not stored.
+func (s *routeService) ReportPosition(ctx context.Context, req *dto.PositionReport) (*dto.PositionResult, error) {
+	point := geo.Point{Latitude: req.Latitude, Longitude: req.Longitude}
+	if !point.Valid() {
+		return nil, fmt.Errorf("%w: coordinates out of range", ErrInvalidPosition)
+	}
+	at := time.Now()
+	if req.RecordedAt != nil {
+		at = *req.RecordedAt
+	}
+	if _, err := s.productRepo.GetByID(ctx, req.ProductID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to validate product: %w", err)
+	}
+
+	result := &dto.PositionResult{Alerts: []*domain.RouteAlert{}}
+	err := s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		seen := sighting{productID: req.ProductID, source: domain.RouteAlertSourcePing, at: at, point: &point}
+		alerts, evaluated, err := checkRoutes(ctx, repos, s.metrics, seen)
+		if err != nil {
+			return err
+		}
+		result.Evaluated = evaluated
+		result.Alerts = append(result.Alerts, alerts...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *routeService) GetAlert(ctx context.Context, id uuid.UUID) (*domain.RouteAlert, error) {
+	alert, err := s.repo.GetAlert(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRouteAlertNotFound
+		}
+		return nil, fmt.Errorf("failed to get route alert: %w", err)
+	}
+	return alert, nil
+}
+
+func (s *routeService) ListAlerts(ctx context.Context, filter *dto.RouteAlertFilter) (*dto.PaginatedResponse, error) {
+	if filter.Type != nil && !domain.IsValidRouteAlertType(*filter.Type) {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidRouteAlertFilter, *filter.Type)
+	}
+	if filter.Status != nil && !domain.IsValidRouteAlertStatus(*filter.Status) {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidRouteAlertFilter, *filter.Status)
+	}
+
+	alerts, page, err := s.repo.ListAlerts(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list route alerts: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(alerts, page, filter.Limit, filter.Offset), nil
+}
+
+// AcknowledgeAlert closes an open alert; the next sighting breaking the same rule raises a new one
+func (s *routeService) AcknowledgeAlert(ctx context.Context, id uuid.UUID, req *dto.AcknowledgeRouteAlertRequest) (*domain.RouteAlert, error) {
+	if _, err := s.GetAlert(ctx, id); err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{
+		"status":          domain.RouteAlertStatusAcknowledged,
+		"acknowledged_at": time.Now(),
+		"acknowledged_by": req.StakeholderID,
+		"notes":           req.Notes,
+	}
+	updated, err := s.repo.UpdateAlertIfOpen(ctx, id, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acknowledge route alert: %w", err)
+	}
+	if !updated {
+		return nil, ErrRouteAlertNotOpen
+	}
+
+	return s.GetAlert(ctx, id)
+}
+
+func (s *routeService) validatePlan(ctx context.Context, req *dto.SetRoutePlanRequest) error {
+	if len(req.Waypoints) == 0 && len(req.Geofences) == 0 && len(req.ExpectedLocationIDs) == 0 {
+		return fmt.Errorf("%w: waypoints, geofences or expected_location_ids are required", ErrInvalidRoutePlan)
+	}
+
+	if len(req.Waypoints) > maxRouteWaypoints {
+		return fmt.Errorf("%w: at most %d waypoints", ErrInvalidRoutePlan, maxRouteWaypoints)
+	}
+	if len(req.Waypoints) == 1 {
+		return fmt.Errorf("%w: a route needs at least 2 waypoints", ErrInvalidRoutePlan)
+	}
+	for i, waypoint := range req.Waypoints {
+		if !(geo.Point{Latitude: waypoint.Latitude, Longitude: waypoint.Longitude}).Valid() {
+			return fmt.Errorf("%w: waypoint %d is out of range", ErrInvalidRoutePlan, i)
+		}
+	}
+	if len(req.Waypoints) > 0 && (req.CorridorKm <= 0 || req.CorridorKm > maxRadiusKm) {
+		return fmt.Errorf("%w: corridor_km must be above 0 and at most %d", ErrInvalidRoutePlan, maxRadiusKm)
+	}
+	if len(req.Waypoints) == 0 && req.CorridorKm != 0 {
+		return fmt.Errorf("%w: corridor_km needs waypoints", ErrInvalidRoutePlan)
+	}
+
+	if len(req.Geofences) > maxRouteGeofences {
+		return fmt.Errorf("%w: at most %d geofences", ErrInvalidRoutePlan, maxRouteGeofences)
+	}
+	names := make(map[string]bool, len(req.Geofences))
+	for _, fence := range req.Geofences {
+		name := strings.TrimSpace(fence.Name)
+		if name == "" {
+			return fmt.Errorf("%w: geofence name is required", ErrInvalidRoutePlan)
+		}
+		if names[name] {
+			return fmt.Errorf("%w: geofence %s is given twice", ErrInvalidRoutePlan, name)
+		}
+		names[name] = true
+		if !(geo.Point{Latitude: fence.Latitude, Longitude: fence.Longitude}).Valid() {
+			return fmt.Errorf("%w: geofence %s is out of range", ErrInvalidRoutePlan, name)
+		}
+		if fence.RadiusKm <= 0 || fence.RadiusKm > maxRadiusKm {
+			return fmt.Errorf("%w: radius_km of geofence %s must be above 0 and at most %d", ErrInvalidRoutePlan, name, maxRadiusKm)
+		}
+	}
+
+	if len(req.ExpectedLocationIDs) > maxExpectedLocations {
+		return fmt.Errorf("%w: at most %d expected locations", ErrInvalidRoutePlan, maxExpectedLocations)
+	}
+	for _, id := range req.ExpectedLocationIDs {
+		if _, err := s.locationRepo.GetByID(ctx, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %s", ErrLocationNotFound, id)
+			}
+			return fmt.Errorf("failed to validate location: %w", err)
+		}
+	}
+	return nil
+}
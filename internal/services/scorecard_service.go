@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+)
+
+const (
+	// scorecardDefaultRange is the period scorecards cover unless from is given
+	scorecardDefaultRange = 90 * 24 * time.Hour
+	// scorecardMaxPeriods bounds the periods of a scorecard's trend
+	scorecardMaxPeriods = 366
+	// scorecardDefaultRanked and scorecardMaxRanked bound the scorecards of a ranking
+	scorecardDefaultRanked = 100
+	scorecardMaxRanked     = 1000
+)
+
+// scorecardPeriods is the shortest length of each trend period, to bound how many a range spans
+var scorecardPeriods = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 28 * 24 * time.Hour,
+}
+
+type scorecardService struct {
+	repo            repository.ScorecardRepository
+	stakeholderRepo repository.StakeholderRepository
+}
+
+func NewScorecardService(repo repository.ScorecardRepository, stakeholderRepo repository.StakeholderRepository) *scorecardService {
+	return &scorecardService{repo: repo, stakeholderRepo: stakeholderRepo}
+}
+
+// GetScorecard scores a stakeholder over the query's range, ranked among the stakeholders of its
+// type, with its metrics per period (month unless the query says otherwise)
+func (s *scorecardService) GetScorecard(ctx context.Context, id uuid.UUID, query *dto.ScorecardQuery) (*dto.Scorecard, error) {
+	if query.Period == "" {
+		query.Period = "month"
+	}
+	length, ok := scorecardPeriods[query.Period]
+	if !ok {
+		return nil, fmt.Errorf("%w: period must be day, week or month", ErrInvalidScorecardQuery)
+	}
+	if err := scorecardRange(query); err != nil {
+		return nil, err
+	}
+	if query.To.Sub(*query.From) > length*scorecardMaxPeriods {
+		return nil, fmt.Errorf("%w: a trend may span at most %d periods", ErrInvalidScorecardQuery, scorecardMaxPeriods)
+	}
+
+	stakeholder, err := s.stakeholderRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStakeholderNotFound
+		}
+		return nil, fmt.Errorf("failed to get stakeholder: %w", err)
+	}
+
+	peers, err := s.repo.Metrics(ctx, &dto.ScorecardQuery{Type: &stakeholder.Type, From: query.From, To: query.To})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get peer metrics: %w", err)
+	}
+	var scorecard *dto.Scorecard
+	for _, candidate := range scorePeers(peers) {
+		if candidate.Metrics.StakeholderID == id {
+			scorecard = candidate
+			break
+		}
+	}
+	if scorecard == nil {
+		return nil, ErrStakeholderNotFound
+	}
+	scorecard.Stakeholder = stakeholder
+	scorecard.From, scorecard.To = *query.From, *query.To
+
+	trend, err := s.repo.Metrics(ctx, &dto.ScorecardQuery{StakeholderID: &id, From: query.From, To: query.To, Period: query.Period})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trend: %w", err)
+	}
+	for _, metrics := range trend {
+		withRates(metrics)
+	}
+	scorecard.Trend = trend
+	return scorecard, nil
+}
+
+// RankStakeholders scores every stakeholder of the query's type over its range, best first
+func (s *scorecardService) RankStakeholders(ctx context.Context, query *dto.ScorecardQuery) (*dto.ScorecardRanking, error) {
+	if query.Type == nil || !domain.IsValidStakeholderType(*query.Type) {
+		return nil, fmt.Errorf("%w: type must be manufacturer, distributor or retailer", ErrInvalidScorecardQuery)
+	}
+	if query.Limit <= 0 {
+		query.Limit = scorecardDefaultRanked
+	}
+	if query.Limit > scorecardMaxRanked {
+		return nil, fmt.Errorf("%w: limit may be at most %d", ErrInvalidScorecardQuery, scorecardMaxRanked)
+	}
+	if err := scorecardRange(query); err != nil {
+		return nil, err
+	}
+
+	peers, err := s.repo.Metrics(ctx, &dto.ScorecardQuery{Type: query.Type, From: query.From, To: query.To})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get peer metrics: %w", err)
+	}
+	scorecards := scorePeers(peers)
+	ranking := &dto.ScorecardRanking{Type: *query.Type, From: *query.From, To: *query.To, Peers: len(scorecards)}
+	if len(scorecards) > query.Limit {
+		scorecards = scorecards[:query.Limit]
+	}
+
+	ids := make([]uuid.UUID, 0, len(scorecards))
+	for _, scorecard := range scorecards {
+		ids = append(ids, scorecard.Metrics.StakeholderID)
+	}
+	stakeholders, err := s.stakeholderRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stakeholders: %w", err)
+	}
+	byID := make(map[uuid.UUID]*domain.Stakeholder, len(stakeholders))
+	for _, stakeholder := range stakeholders {
+		byID[stakeholder.ID] = stakeholder
+	}
+	for _, scorecard := range scorecards {
+		scorecard.Stakeholder = byID[scorecard.Metrics.StakeholderID]
+		scorecard.From, scorecard.To = *query.From, *query.To
+	}
+	ranking.Scorecards = scorecards
+	return ranking, nil
+}
+
+// scorePeers scores and ranks stakeholders of one type against each other, best first and those
+// without any activity to score last. Handling speed scores by how many peers hold products
+// longer, so the fastest scores 1 and the slowest 0.
+func scorePeers(peers []*dto.ScorecardMetrics) []*dto.Scorecard {
+	var handling []float64
+	for _, metrics := range peers {
+		withRates(metrics)
+		if metrics.AvgHandlingHours != nil {
+			handling = append(handling, *metrics.AvgHandlingHours)
+		}
+	}
+	sort.Float64s(handling)
+
+	scorecards := make([]*dto.Scorecard, 0, len(peers))
+	for _, metrics := range peers {
+		var components []float64
+		if metrics.OnTimeRate != nil {
+			components = append(components, *metrics.OnTimeRate)
+		}
+		if metrics.VerificationRate != nil {
+			components = append(components, *metrics.VerificationRate)
+		}
+		if metrics.DiscrepancyRate != nil {
+			components = append(components, 1-*metrics.DiscrepancyRate)
+		}
+		if metrics.RejectRate != nil {
+			components = append(components, 1-*metrics.RejectRate)
+		}
+		if metrics.AvgHandlingHours != nil {
+			if len(handling) == 1 {
+				components = append(components, 1)
+			} else {
+				faster := sort.SearchFloat64s(handling, *metrics.AvgHandlingHours)
+				components = append(components, 1-float64(faster)/float64(len(handling)-1))
+			}
+		}
+
+		scorecard := &dto.Scorecard{Metrics: metrics, Peers: len(peers)}
+		if len(components) > 0 {
+			var sum float64
+			for _, component := range components {
+				sum += component
+			}
+			score := sum / float64(len(components))
+			scorecard.Score = &score
+		}
+		scorecards = append(scorecards, scorecard)
+	}
+
+	sort.SliceStable(scorecards, func(i, j int) bool {
+		if scorecards[i].Score == nil || scorecards[j].Score == nil {
+			return scorecards[j].Score == nil && scorecards[i].Score != nil
+		}
+		return *scorecards[i].Score > *scorecards[j].Score
+	})
+	// Equal scores share a rank, and the next rank skips past them
+	for i, scorecard := range scorecards {
+		if scorecard.Score == nil {
+			break
+		}
+		rank := i + 1
+		if i > 0 && *scorecards[i-1].Score == *scorecard.Score {
+			rank = *scorecards[i-1].Rank
+		}
+		scorecard.Rank = &rank
+	}
+	return scorecards
+}
+
+// withRates fills in the rates of metrics from its counts
+func withRates(metrics *dto.ScorecardMetrics) {
+	rate := func(n, of int64) *float64 {
+		if of == 0 {
+			return nil
+		}
+		r := float64(n) / float64(of)
+		return &r
+	}
+	metrics.OnTimeRate = rate(metrics.OnTimeReceipts, metrics.ReceiptsDue)
+	metrics.DiscrepancyRate = rate(metrics.Discrepancies, metrics.Accepted)
+	metrics.RejectRate = rate(metrics.Rejected, metrics.Accepted+metrics.Rejected+metrics.Expired)
+	metrics.VerificationRate = rate(metrics.VerifiedEvents, metrics.Events)
+}
+
+// scorecardRange defaults a scorecard to the scorecardDefaultRange up to now
+func scorecardRange(query *dto.ScorecardQuery) error {
+	if query.To == nil {
+		now := time.Now()
+		query.To = &now
+	}
+	if query.From == nil {
+		from := query.To.Add(-scorecardDefaultRange)
+		query.From = &from
+	}
+	if !query.From.Before(*query.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidScorecardQuery)
+	}
+	return nil
+}
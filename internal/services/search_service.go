@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Search limits: results per type, and the length of the query text
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	maxSearchQuery     = 200
+)
+
+var searchTypes = []string{dto.SearchProducts, dto.SearchStakeholders, dto.SearchLocations}
+
+type searchService struct {
+	repo repository.SearchRepository
+}
+
+func NewSearchService(repo repository.SearchRepository) *searchService {
+	return &searchService{repo: repo}
+}
+
+func (s *searchService) Search(ctx context.Context, req *dto.SearchRequest) (*dto.SearchResults, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: q is required", ErrInvalidSearch)
+	}
+	if utf8.RuneCountInString(query) > maxSearchQuery {
+		return nil, fmt.Errorf("%w: q is longer than %d characters", ErrInvalidSearch, maxSearchQuery)
+	}
+
+	types := req.Types
+	if len(types) == 0 {
+		types = searchTypes
+	}
+	for _, t := range types {
+		if !slices.Contains(searchTypes, t) {
+			return nil, fmt.Errorf("%w: type must be %s", ErrInvalidSearch, strings.Join(searchTypes, ", "))
+		}
+	}
+
+	limit := req.Limit
+	switch {
+	case limit < 0:
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrInvalidSearch)
+	case limit == 0:
+		limit = defaultSearchLimit
+	case limit > maxSearchLimit:
+		limit = maxSearchLimit
+	}
+
+	results := &dto.SearchResults{Query: query}
+	var err error
+	if slices.Contains(types, dto.SearchProducts) {
+		if results.Products, err = s.repo.SearchProducts(ctx, query, limit); err != nil {
+			return nil, fmt.Errorf("failed to search products: %w", err)
+		}
+	}
+	if slices.Contains(types, dto.SearchStakeholders) {
+		if results.Stakeholders, err = s.repo.SearchStakeholders(ctx, query, limit); err != nil {
+			return nil, fmt.Errorf("failed to search stakeholders: %w", err)
+		}
+	}
+	if slices.Contains(types, dto.SearchLocations) {
+		if results.Locations, err = s.repo.SearchLocations(ctx, query, limit); err != nil {
+			return nil, fmt.Errorf("failed to search locations: %w", err)
+		}
+	}
+	return results, nil
+}
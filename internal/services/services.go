@@ -6,23 +6,86 @@ import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/epcis"
+	"github.com/koriebruh/suplyChainTrack/internal/gs1"
 	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/stream"
+	"io"
+	"time"
 )
 
 // custom error definitions for the supply chain tracking service
 var (
-	ErrStakeholderNotFound      = errors.New("stakeholder not found")
-	ErrProductNotFound          = errors.New("product not found")
-	ErrEventNotFound            = errors.New("event not found")
-	ErrTransactionNotFound      = errors.New("transaction not found")
-	ErrDuplicateEmail           = errors.New("email already exists")
-	ErrDuplicateSKU             = errors.New("SKU already exists")
-	ErrDuplicateWallet          = errors.New("wallet address already exists")
-	ErrInvalidStakeholderType   = errors.New("invalid stakeholder type")
-	ErrInvalidEventType         = errors.New("invalid event type")
-	ErrInvalidTransactionStatus = errors.New("invalid transaction status")
-	ErrUnauthorized             = errors.New("unauthorized access")
-	ErrInvalidEventSequence     = errors.New("invalid event sequence")
+	ErrStakeholderNotFound          = errors.New("stakeholder not found")
+	ErrProductNotFound              = errors.New("product not found")
+	ErrEventNotFound                = errors.New("event not found")
+	ErrTransactionNotFound          = errors.New("transaction not found")
+	ErrDuplicateEmail               = errors.New("email already exists")
+	ErrDuplicateSKU                 = errors.New("SKU already exists")
+	ErrDuplicateWallet              = errors.New("wallet address already exists")
+	ErrInvalidStakeholderType       = errors.New("invalid stakeholder type")
+	ErrInvalidEventType             = errors.New("invalid event type")
+	ErrInvalidTransactionStatus     = errors.New("invalid transaction status")
+	ErrUnauthorized                 = errors.New("unauthorized access")
+	ErrInvalidEventSequence         = errors.New("invalid event sequence")
+	ErrInvalidContainment           = errors.New("invalid containment")
+	ErrAlreadyContained             = errors.New("product is already packed in a container")
+	ErrNotContained                 = errors.New("product is not packed in this container")
+	ErrInvalidTransformation        = errors.New("invalid transformation")
+	ErrCustodyTransferNotFound      = errors.New("custody transfer not found")
+	ErrInvalidCustodyTransfer       = errors.New("invalid custody transfer")
+	ErrPendingTransferExists        = errors.New("product already has a pending custody transfer")
+	ErrCustodyTransferNotPending    = errors.New("custody transfer is not pending")
+	ErrCustodyTransferExpired       = errors.New("custody transfer has expired")
+	ErrNotCustodyHolder             = errors.New("stakeholder does not hold custody of the product")
+	ErrCustodyNotFound              = errors.New("no custody recorded for product")
+	ErrInvalidCustodyState          = errors.New("invalid custody state")
+	ErrRecallNotFound               = errors.New("recall not found")
+	ErrInvalidRecall                = errors.New("invalid recall")
+	ErrRecallNotDraft               = errors.New("recall has already been activated")
+	ErrRecallNotActive              = errors.New("recall is not active")
+	ErrRecallNoUnits                = errors.New("recall scope matches no products")
+	ErrRecallNotificationNotFound   = errors.New("stakeholder was not notified of this recall")
+	ErrEPCISDuplicateEvent          = errors.New("EPCIS event already captured")
+	ErrInvalidEPCISQuery            = errors.New("invalid EPCIS query")
+	ErrDigitalLinkMismatch          = errors.New("digital link lot or serial does not match the product")
+	ErrInvalidLabelRequest          = errors.New("invalid label request")
+	ErrInvalidImport                = errors.New("invalid import")
+	ErrImportJobNotFound            = errors.New("import job not found")
+	ErrInvalidExport                = errors.New("invalid export")
+	ErrInvalidWebhook               = errors.New("invalid webhook subscription")
+	ErrWebhookNotFound              = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrWebhookDisabled              = errors.New("webhook subscription is disabled")
+	ErrInvalidStreamRequest         = errors.New("invalid stream request")
+	ErrStreamForbidden              = errors.New("stream token may not follow this stakeholder")
+	ErrStreamOverflow               = errors.New("stream client fell too far behind")
+	ErrInvalidSearch                = errors.New("invalid search")
+	ErrLocationNotFound             = errors.New("location not found")
+	ErrInvalidLocation              = errors.New("invalid location")
+	ErrDuplicateLocation            = errors.New("location name already exists")
+	ErrDuplicateGLN                 = errors.New("GLN already exists")
+	ErrRoutePlanNotFound            = errors.New("route plan not found")
+	ErrInvalidRoutePlan             = errors.New("invalid route plan")
+	ErrInvalidPosition              = errors.New("invalid position")
+	ErrRouteAlertNotFound           = errors.New("route alert not found")
+	ErrRouteAlertNotOpen            = errors.New("route alert is not open")
+	ErrInvalidRouteAlertFilter      = errors.New("invalid route alert filter")
+	ErrSensorDeviceNotFound         = errors.New("sensor device not found")
+	ErrDuplicateDeviceSerial        = errors.New("device serial already exists")
+	ErrInvalidSensorDevice          = errors.New("invalid sensor device")
+	ErrInvalidTelemetry             = errors.New("invalid telemetry")
+	ErrInvalidTelemetryQuery        = errors.New("invalid telemetry query")
+	ErrExcursionRuleNotFound        = errors.New("excursion rule not found")
+	ErrInvalidExcursionRule         = errors.New("invalid excursion rule")
+	ErrExcursionIncidentNotFound    = errors.New("excursion incident not found")
+	ErrExcursionAlreadyAcknowledged = errors.New("excursion incident has already been acknowledged")
+	ErrInvalidExcursionFilter       = errors.New("invalid excursion filter")
+	ErrQuarantineNotFound           = errors.New("quarantine not found")
+	ErrQuarantineNotActive          = errors.New("quarantine is not active")
+	ErrInvalidAnalyticsQuery        = errors.New("invalid analytics query")
+	ErrInvalidScorecardQuery        = errors.New("invalid scorecard query")
+	ErrInvalidGenealogyQuery        = errors.New("invalid genealogy query")
 )
 
 type ServiceManager struct {
@@ -30,19 +93,58 @@ type ServiceManager struct {
 	Product     ProductService
 	SupplyChain SupplyChainService
 	Blockchain  BlockchainService
+	Custody     CustodyService
+	Recall      RecallService
+	EPCIS       EPCISService
+	DigitalLink DigitalLinkService
+	Label       LabelService
+	Import      ImportService
+	Export      ExportService
+	Webhook     WebhookService
+	Stream      StreamService
+	Batch       BatchService
+	Search      SearchService
+	Location    LocationService
+	Route       RouteService
+	Telemetry   TelemetryService
+	Excursion   ExcursionService
+	Analytics   AnalyticsService
+	Scorecard   ScorecardService
 }
 
-func NewServiceManager(repos *repository.RepositoriesManagers) *ServiceManager {
+func NewServiceManager(repos *repository.RepositoriesManagers, metrics Metrics) *ServiceManager {
+	supplyChain := NewSupplyChainService(repos.SupplyChainEvent, repos.Product, repos.Stakeholder, repos.Location, repos.Containment, repos.Transformation, repos.Telemetry, repos.CustodyTransfer, repos.CustodyProjection, metrics, repos)
+	stakeholder := NewStakeholderService(repos.Stakeholder, repos)
+	product := NewProductService(repos.Product, repos.Stakeholder, repos)
+
 	return &ServiceManager{
-		Stakeholder: NewStakeholderService(repos.Stakeholder),
-		Product:     NewProductService(repos.Product, repos.Stakeholder),
-		SupplyChain: NewSupplyChainService(repos.SupplyChainEvent, repos.Product, repos.Stakeholder),
-		Blockchain:  NewBlockchainService(repos.BlockchainTransaction, repos.SupplyChainEvent),
+		Stakeholder: stakeholder,
+		Product:     product,
+		SupplyChain: supplyChain,
+		Blockchain:  NewBlockchainService(repos.BlockchainTransaction, repos.SupplyChainEvent, repos),
+		Custody:     NewCustodyService(repos.CustodyTransfer, repos.CustodyProjection, repos.Product, repos.Stakeholder, supplyChain, metrics, repos),
+		EPCIS:       NewEPCISService(repos.SupplyChainEvent, repos.Product, repos.Stakeholder, repos.Containment, repos.Transformation, supplyChain, metrics, repos),
+		Recall:      NewRecallService(repos.Recall, repos.CustodyProjection, repos.Containment, repos.Transformation, repos.Product, repos.Stakeholder, repos),
+		DigitalLink: NewDigitalLinkService(repos.Product, supplyChain),
+		Label:       NewLabelService(repos.Product, repos.Containment, repos.CustodyTransfer),
+		Import:      NewImportService(repos.ImportJob, product, stakeholder, supplyChain),
+		Export:      NewExportService(repos.SupplyChainEvent, repos.Product),
+		Webhook:     NewWebhookService(repos.Webhook, repos.Stakeholder, repos.Product, repos),
+		Batch:       NewBatchService(repos.Product, repos.Stakeholder, repos.SupplyChainEvent, repos.BlockchainTransaction),
+		Stream:      NewStreamService(repos.Outbox, repos.SupplyChainEvent, repos.Product, repos.Stakeholder, repos.CustodyProjection),
+		Search:      NewSearchService(repos.Search),
+		Location:    NewLocationService(repos.Location, repos.Stakeholder, repos.SupplyChainEvent),
+		Route:       NewRouteService(repos.Route, repos.CustodyTransfer, repos.Product, repos.Location, metrics, repos),
+		Telemetry:   NewTelemetryService(repos.Telemetry, repos.Product, repos.Stakeholder, repos.CustodyTransfer, metrics, repos),
+		Excursion:   NewExcursionService(repos.Excursion),
+		Analytics:   NewAnalyticsService(repos.Analytics, repos),
+		Scorecard:   NewScorecardService(repos.Scorecard, repos.Stakeholder),
 	}
 }
 
 type StakeholderService interface {
 	CreateStakeholder(ctx context.Context, req *dto.CreateStakeholderRequest) (*domain.Stakeholder, error)
+	ValidateStakeholder(ctx context.Context, req *dto.CreateStakeholderRequest) error
 	GetStakeholder(ctx context.Context, id uuid.UUID) (*domain.Stakeholder, error)
 	GetStakeholderByEmail(ctx context.Context, email string) (*domain.Stakeholder, error)
 	UpdateStakeholder(ctx context.Context, id uuid.UUID, req *dto.UpdateStakeholderRequest) (*domain.Stakeholder, error)
@@ -54,6 +156,7 @@ type StakeholderService interface {
 
 type ProductService interface {
 	CreateProduct(ctx context.Context, req *dto.CreateProductRequest) (*domain.Product, error)
+	ValidateProduct(ctx context.Context, req *dto.CreateProductRequest) error
 	GetProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error)
 	GetProductBySKU(ctx context.Context, sku string) (*domain.Product, error)
 	UpdateProduct(ctx context.Context, id uuid.UUID, req *dto.UpdateProductRequest) (*domain.Product, error)
@@ -65,6 +168,7 @@ type ProductService interface {
 
 type SupplyChainService interface {
 	CreateEvent(ctx context.Context, req *dto.CreateSupplyChainEventRequest) (*domain.SupplyChainEvent, error)
+	ValidateEvent(ctx context.Context, req *dto.CreateSupplyChainEventRequest) error
 	GetEvent(ctx context.Context, id uuid.UUID) (*domain.SupplyChainEvent, error)
 	UpdateEvent(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*domain.SupplyChainEvent, error)
 	DeleteEvent(ctx context.Context, id uuid.UUID) error
@@ -74,6 +178,13 @@ type SupplyChainService interface {
 	GetEventsByProduct(ctx context.Context, productID uuid.UUID) ([]*domain.SupplyChainEvent, error)
 	GetEventsByStakeholder(ctx context.Context, stakeholderID uuid.UUID) ([]*domain.SupplyChainEvent, error)
 	ValidateEventSequence(ctx context.Context, req *dto.CreateSupplyChainEventRequest) error
+	GetContainmentAt(ctx context.Context, productID uuid.UUID, at time.Time) (*dto.ContainmentSnapshot, error)
+	GetContainmentHistory(ctx context.Context, productID uuid.UUID) ([]*domain.ProductContainment, error)
+	GetUpstreamGenealogy(ctx context.Context, productID uuid.UUID, maxDepth int) (*dto.Genealogy, error)
+	GetDownstreamGenealogy(ctx context.Context, productID uuid.UUID, maxDepth int) (*dto.Genealogy, error)
+	GetLotUpstreamGenealogy(ctx context.Context, lot string, productID *uuid.UUID, maxDepth int) (*dto.Genealogy, error)
+	GetLotDownstreamGenealogy(ctx context.Context, lot string, productID *uuid.UUID, maxDepth int) (*dto.Genealogy, error)
+	GetTransformationLines(ctx context.Context, eventID uuid.UUID) ([]*domain.TransformationLine, error)
 }
 
 type BlockchainService interface {
@@ -85,3 +196,182 @@ type BlockchainService interface {
 	UpdateTransactionStatus(ctx context.Context, id uuid.UUID, status string, blockNumber *int64) error
 	GetTransactionsByEvent(ctx context.Context, eventID uuid.UUID) ([]*domain.BlockchainTransaction, error)
 }
+
+type CustodyService interface {
+	CreateTransfer(ctx context.Context, req *dto.CreateCustodyTransferRequest) (*domain.CustodyTransfer, error)
+	GetTransfer(ctx context.Context, id uuid.UUID) (*domain.CustodyTransfer, error)
+	AcceptTransfer(ctx context.Context, id uuid.UUID, req *dto.AcceptCustodyTransferRequest) (*domain.CustodyTransfer, error)
+	RejectTransfer(ctx context.Context, id uuid.UUID, req *dto.RejectCustodyTransferRequest) (*domain.CustodyTransfer, error)
+	ListTransfers(ctx context.Context, filter *dto.CustodyTransferFilter) (*dto.PaginatedResponse, error)
+	ListPendingTransfers(ctx context.Context, stakeholderID uuid.UUID, filter *dto.CustodyTransferFilter) (*dto.PaginatedResponse, error)
+	ExpireTransfers(ctx context.Context) (int64, error)
+	RunExpiry(ctx context.Context)
+	GetCurrentCustody(ctx context.Context, productID uuid.UUID) (*domain.ProductCustody, error)
+	ListInventory(ctx context.Context, filter *dto.InventoryFilter) (*dto.PaginatedResponse, error)
+	RebuildProjection(ctx context.Context) (int64, error)
+}
+
+type RecallService interface {
+	CreateRecall(ctx context.Context, req *dto.CreateRecallRequest) (*domain.Recall, error)
+	GetRecall(ctx context.Context, id uuid.UUID) (*domain.Recall, error)
+	ListRecalls(ctx context.Context, filter *dto.RecallFilter) (*dto.PaginatedResponse, error)
+	ActivateRecall(ctx context.Context, id uuid.UUID) (*domain.Recall, error)
+	NotifyStakeholders(ctx context.Context, id uuid.UUID) (int, error)
+	AcknowledgeRecall(ctx context.Context, id uuid.UUID, req *dto.AcknowledgeRecallRequest) (*domain.RecallNotification, error)
+	CloseRecall(ctx context.Context, id uuid.UUID) (*domain.Recall, error)
+	ListAffectedUnits(ctx context.Context, id uuid.UUID, filter *dto.RecallUnitFilter) (*dto.PaginatedResponse, error)
+	GetRecallReport(ctx context.Context, id uuid.UUID) (*dto.RecallReport, error)
+	ListStakeholderRecalls(ctx context.Context, stakeholderID uuid.UUID, status *string) ([]*domain.RecallNotification, error)
+}
+
+type EPCISService interface {
+	Capture(ctx context.Context, events []*epcis.Event, stakeholderID *uuid.UUID) (*dto.EPCISCaptureResult, error)
+	CaptureEvent(ctx context.Context, event *epcis.Event, stakeholderID *uuid.UUID) ([]*domain.SupplyChainEvent, error)
+	Query(ctx context.Context, query *dto.EPCISQuery) (*dto.EPCISQueryResult, error)
+	RenderEvents(ctx context.Context, events []*domain.SupplyChainEvent) ([]*epcis.Event, error)
+	ImportXML(ctx context.Context, r io.Reader, stakeholderID *uuid.UUID) (*dto.EPCISCaptureResult, error)
+	ExportProductTrace(ctx context.Context, productID uuid.UUID) ([]*epcis.Event, error)
+	ExportEvents(ctx context.Context, from, to *time.Time, fn func(event *epcis.Event) error) error
+}
+
+type DigitalLinkService interface {
+	Resolve(ctx context.Context, link *gs1.DigitalLink, resolver string) (*dto.DigitalLinkResolution, error)
+}
+
+type LabelService interface {
+	ProductLabel(ctx context.Context, productID uuid.UUID, base string) (*dto.ProductLabel, error)
+	LotLabel(ctx context.Context, productID uuid.UUID, lot string, base string) (*dto.ProductLabel, error)
+	SheetLabels(ctx context.Context, req *dto.CreateLabelSheetRequest, base string) ([]*dto.ProductLabel, error)
+	ShipmentLabels(ctx context.Context, transferID uuid.UUID, base string) ([]*dto.ProductLabel, error)
+}
+
+type ImportService interface {
+	StartImport(ctx context.Context, req *dto.StartImportRequest, file io.Reader) (*domain.ImportJob, error)
+	RunRecovery(ctx context.Context)
+	GetImportJob(ctx context.Context, id uuid.UUID) (*domain.ImportJob, error)
+	ListImportJobs(ctx context.Context, filter *dto.ImportJobFilter) (*dto.PaginatedResponse, error)
+	ListImportErrors(ctx context.Context, id uuid.UUID, limit, offset int) (*dto.PaginatedResponse, error)
+	WriteErrorReport(ctx context.Context, id uuid.UUID, w io.Writer) error
+}
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, req *dto.CreateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionSecret, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, req *dto.UpdateWebhookSubscriptionRequest) (*domain.WebhookSubscription, error)
+	RotateSecret(ctx context.Context, id uuid.UUID) (*dto.WebhookSubscriptionSecret, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	ListSubscriptions(ctx context.Context, filter *dto.WebhookSubscriptionFilter) (*dto.PaginatedResponse, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter *dto.WebhookDeliveryFilter) (*dto.PaginatedResponse, error)
+	ReplayDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error)
+	QueueFromOutbox(ctx context.Context) (int, error)
+	DispatchDue(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
+
+// OutboxRelay publishes the domain events services write to the outbox
+type OutboxRelay interface {
+	RelayPending(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
+
+type ExportService interface {
+	ValidateFormat(format string) error
+	ExportEvents(ctx context.Context, filter *dto.SupplyChainEventFilter, format string, w io.Writer) error
+	ExportProducts(ctx context.Context, filter *dto.ProductFilter, format string, w io.Writer) error
+}
+
+// SearchService finds products, stakeholders and event locations by free text, tolerating typos
+type SearchService interface {
+	Search(ctx context.Context, req *dto.SearchRequest) (*dto.SearchResults, error)
+}
+
+// StreamService pushes recorded and verified events to connected clients as they happen
+type StreamService interface {
+	IssueToken(ctx context.Context, req *dto.CreateStreamTokenRequest, secret string) (*dto.StreamToken, error)
+	Open(ctx context.Context, claims *stream.Claims, cursor *stream.Cursor) (*StreamConnection, error)
+}
+
+// BatchService resolves the relations of many records at once, one query per relation
+type BatchService interface {
+	ProductsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Product, error)
+	StakeholdersByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Stakeholder, error)
+	EventsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.SupplyChainEvent, error)
+	EventsByProducts(ctx context.Context, productIDs []uuid.UUID, perProduct int) (map[uuid.UUID][]*domain.SupplyChainEvent, error)
+	EventsByStakeholders(ctx context.Context, stakeholderIDs []uuid.UUID, perStakeholder int) (map[uuid.UUID][]*domain.SupplyChainEvent, error)
+	ProductsByManufacturers(ctx context.Context, manufacturerIDs []uuid.UUID, perManufacturer int) (map[uuid.UUID][]*domain.Product, error)
+	TransactionsByEvents(ctx context.Context, eventIDs []uuid.UUID, perEvent int) (map[uuid.UUID][]*domain.BlockchainTransaction, error)
+}
+
+// LocationService keeps the registry of facilities events are recorded at and answers radius queries
+type LocationService interface {
+	CreateLocation(ctx context.Context, req *dto.CreateLocationRequest) (*domain.Location, error)
+	GetLocation(ctx context.Context, id uuid.UUID) (*domain.Location, error)
+	UpdateLocation(ctx context.Context, id uuid.UUID, req *dto.UpdateLocationRequest) (*domain.Location, error)
+	DeleteLocation(ctx context.Context, id uuid.UUID) error
+	ListLocations(ctx context.Context, filter *dto.LocationFilter) (*dto.PaginatedResponse, error)
+	ListNearby(ctx context.Context, near dto.GeoRadius, filter *dto.LocationFilter) ([]*dto.NearbyLocation, error)
+	ListEvents(ctx context.Context, filter *dto.SupplyChainEventFilter) (*dto.PaginatedResponse, error)
+}
+
+// RouteService keeps the route plans of shipments and raises alerts when a shipment strays from its
+// corridor, enters a geofence or turns up at a facility its plan does not expect
+type RouteService interface {
+	SetRoutePlan(ctx context.Context, transferID uuid.UUID, req *dto.SetRoutePlanRequest) (*domain.RoutePlan, error)
+	GetRoutePlan(ctx context.Context, transferID uuid.UUID) (*domain.RoutePlan, error)
+	DeleteRoutePlan(ctx context.Context, transferID uuid.UUID) error
+	ReportPosition(ctx context.Context, req *dto.PositionReport) (*dto.PositionResult, error)
+	GetAlert(ctx context.Context, id uuid.UUID) (*domain.RouteAlert, error)
+	ListAlerts(ctx context.Context, filter *dto.RouteAlertFilter) (*dto.PaginatedResponse, error)
+	AcknowledgeAlert(ctx context.Context, id uuid.UUID, req *dto.AcknowledgeRouteAlertRequest) (*domain.RouteAlert, error)
+}
+
+// TelemetryService keeps the sensor devices shipments carry and stores and downsamples their
+// readings
+type TelemetryService interface {
+	RegisterDevice(ctx context.Context, req *dto.CreateSensorDeviceRequest) (*domain.SensorDevice, error)
+	GetDevice(ctx context.Context, id uuid.UUID) (*domain.SensorDevice, error)
+	UpdateDevice(ctx context.Context, id uuid.UUID, req *dto.UpdateSensorDeviceRequest) (*domain.SensorDevice, error)
+	DeleteDevice(ctx context.Context, id uuid.UUID) error
+	ListDevices(ctx context.Context, filter *dto.SensorDeviceFilter) (*dto.PaginatedResponse, error)
+	AttachDevice(ctx context.Context, id uuid.UUID, req *dto.AttachSensorDeviceRequest) (*domain.SensorDevice, error)
+	DetachDevice(ctx context.Context, id uuid.UUID) (*domain.SensorDevice, error)
+	Ingest(ctx context.Context, batch *dto.TelemetryBatch) (*dto.TelemetryIngestResult, error)
+	ListReadings(ctx context.Context, query *dto.TelemetryQuery) ([]*domain.TelemetryReading, error)
+	GetSeries(ctx context.Context, query *dto.TelemetryQuery) (*dto.TelemetrySeries, error)
+}
+
+// ExcursionService keeps the acceptable ranges of product categories and the incidents and
+// quarantines raised when products leave them
+type ExcursionService interface {
+	CreateRule(ctx context.Context, req *dto.CreateExcursionRuleRequest) (*domain.ExcursionRule, error)
+	GetRule(ctx context.Context, id uuid.UUID) (*domain.ExcursionRule, error)
+	UpdateRule(ctx context.Context, id uuid.UUID, req *dto.UpdateExcursionRuleRequest) (*domain.ExcursionRule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	ListRules(ctx context.Context, filter *dto.ExcursionRuleFilter) (*dto.PaginatedResponse, error)
+	GetIncident(ctx context.Context, id uuid.UUID) (*domain.ExcursionIncident, error)
+	ListIncidents(ctx context.Context, filter *dto.ExcursionIncidentFilter) (*dto.PaginatedResponse, error)
+	AcknowledgeIncident(ctx context.Context, id uuid.UUID, req *dto.AcknowledgeExcursionRequest) (*domain.ExcursionIncident, error)
+	GetQuarantine(ctx context.Context, id uuid.UUID) (*domain.ProductQuarantine, error)
+	ListQuarantines(ctx context.Context, filter *dto.QuarantineFilter) (*dto.PaginatedResponse, error)
+	ReleaseQuarantine(ctx context.Context, id uuid.UUID, req *dto.ReleaseQuarantineRequest) (*domain.ProductQuarantine, error)
+}
+
+// AnalyticsService reports transit times, dwell times and throughput from figures derived from
+// events, and keeps those figures fresh
+type AnalyticsService interface {
+	Run(ctx context.Context)
+	Refresh(ctx context.Context) (*dto.AnalyticsRefresh, error)
+	ListLegs(ctx context.Context, filter *dto.TransitLegFilter) (*dto.PaginatedResponse, error)
+	ListDwells(ctx context.Context, filter *dto.DwellFilter) (*dto.PaginatedResponse, error)
+	GetTransitReport(ctx context.Context, query *dto.AnalyticsQuery) (*dto.TransitReport, error)
+	GetDwellReport(ctx context.Context, query *dto.AnalyticsQuery) (*dto.DwellReport, error)
+	GetThroughput(ctx context.Context, query *dto.AnalyticsQuery) (*dto.ThroughputReport, error)
+	GetDashboard(ctx context.Context, query *dto.AnalyticsQuery) (*dto.AnalyticsDashboard, error)
+}
+
+// ScorecardService scores stakeholders on reliability and ranks them against others of their type
+type ScorecardService interface {
+	GetScorecard(ctx context.Context, id uuid.UUID, query *dto.ScorecardQuery) (*dto.Scorecard, error)
+	RankStakeholders(ctx context.Context, query *dto.ScorecardQuery) (*dto.ScorecardRanking, error)
+}
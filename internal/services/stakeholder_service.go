@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
 	"github.com/koriebruh/suplyChainTrack/internal/repository"
 	"gorm.io/gorm"
 	"time"
@@ -14,15 +15,16 @@ import (
 
 type stakeholderService struct {
 	repo repository.StakeholderRepository
+	tx   repository.Transactor
 }
 
-func NewStakeholderService(repo repository.StakeholderRepository) *stakeholderService {
-	return &stakeholderService{repo: repo}
+func NewStakeholderService(repo repository.StakeholderRepository, tx repository.Transactor) *stakeholderService {
+	return &stakeholderService{repo: repo, tx: tx}
 }
 
 func (s *stakeholderService) CreateStakeholder(ctx context.Context, req *dto.CreateStakeholderRequest) (*domain.Stakeholder, error) {
-	if !domain.IsValidStakeholderType(req.Type) {
-		return nil, ErrInvalidStakeholderType
+	if err := s.ValidateStakeholder(ctx, req); err != nil {
+		return nil, err
 	}
 
 	// Check if email already exists
@@ -30,13 +32,6 @@ func (s *stakeholderService) CreateStakeholder(ctx context.Context, req *dto.Cre
 		return nil, ErrDuplicateEmail
 	}
 
-	// Check if wallet address already exists (if provided)
-	if req.WalletAddress != nil {
-		if _, err := s.repo.GetByWalletAddress(ctx, *req.WalletAddress); err == nil {
-			return nil, ErrDuplicateWallet
-		}
-	}
-
 	stakeholder := &domain.Stakeholder{
 		ID:            uuid.New(),
 		Name:          req.Name,
@@ -57,6 +52,23 @@ func (s *stakeholderService) CreateStakeholder(ctx context.Context, req *dto.Cre
 	return stakeholder, nil
 }
 
+// ValidateStakeholder applies the CreateStakeholder rules that do not depend on the email being new:
+// a wallet address already held by the stakeholder with the same email is not a conflict
+func (s *stakeholderService) ValidateStakeholder(ctx context.Context, req *dto.CreateStakeholderRequest) error {
+	if !domain.IsValidStakeholderType(req.Type) {
+		return ErrInvalidStakeholderType
+	}
+
+	// Check if wallet address already exists (if provided)
+	if req.WalletAddress != nil {
+		if owner, err := s.repo.GetByWalletAddress(ctx, *req.WalletAddress); err == nil && owner.Email != req.Email {
+			return ErrDuplicateWallet
+		}
+	}
+
+	return nil
+}
+
 func (s *stakeholderService) GetStakeholder(ctx context.Context, id uuid.UUID) (*domain.Stakeholder, error) {
 	stakeholder, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -145,18 +157,12 @@ func (s *stakeholderService) ListStakeholders(ctx context.Context, filter *dto.S
 		filter = &dto.StakeholderFilter{Limit: 10, Offset: 0}
 	}
 
-	stakeholders, total, err := s.repo.List(ctx, filter)
+	stakeholders, page, err := s.repo.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list stakeholders: %w", err)
 	}
 
-	return &dto.PaginatedResponse{
-		Data:    stakeholders,
-		Total:   int(total),
-		Limit:   filter.Limit,
-		Offset:  filter.Offset,
-		HasMore: filter.Offset+filter.Limit < int(total),
-	}, nil
+	return dto.NewPaginatedResponse(stakeholders, page, filter.Limit, filter.Offset), nil
 }
 
 func (s *stakeholderService) GetStakeholderStats(ctx context.Context, id uuid.UUID) (*dto.StakeholderStats, error) {
@@ -172,15 +178,25 @@ func (s *stakeholderService) GetStakeholderStats(ctx context.Context, id uuid.UU
 	return stats, nil
 }
 
+// VerifyStakeholder marks a stakeholder verified; StakeholderVerified is only emitted the first time
 func (s *stakeholderService) VerifyStakeholder(ctx context.Context, id uuid.UUID) error {
+	stakeholder, err := s.GetStakeholder(ctx, id)
+	if err != nil {
+		return err
+	}
+	if stakeholder.IsVerified {
+		return nil
+	}
+
 	updates := map[string]interface{}{
 		"is_verified": true,
 		"updated_at":  time.Now(),
 	}
 
-	if err := s.repo.Update(ctx, id, updates); err != nil {
-		return fmt.Errorf("failed to verify stakeholder: %w", err)
-	}
-
-	return nil
+	return s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		if err := repos.Stakeholder.Update(ctx, id, updates); err != nil {
+			return fmt.Errorf("failed to verify stakeholder: %w", err)
+		}
+		return emit(ctx, repos.Outbox, eventbus.StakeholderVerified{StakeholderID: id})
+	})
 }
@@ -0,0 +1,438 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/stream"
+	"gorm.io/gorm"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// streamPollInterval is how often the hub reads new outbox messages
+	streamPollInterval = 500 * time.Millisecond
+
+	// streamPageSize is how many outbox messages are read per query
+	streamPageSize = 500
+
+	// streamBuffer is how many messages may wait for a slow client before it is disconnected
+	streamBuffer = 256
+
+	// Stream token lifetimes
+	defaultStreamTokenTTL = time.Hour
+	maxStreamTokenTTL     = 24 * time.Hour
+)
+
+// streamTopics are the outbox messages the stream follows
+var streamTopics = []string{eventbus.NameEventRecorded, eventbus.NameEventVerified}
+
+// streamItem is an outbox message resolved to its event, shared by every connection
+type streamItem struct {
+	cursor   stream.Cursor
+	topic    string
+	event    *domain.SupplyChainEvent
+	involved []uuid.UUID
+}
+
+type streamService struct {
+	outboxRepo      repository.OutboxRepository
+	eventRepo       repository.SupplyChainEventRepository
+	productRepo     repository.ProductRepository
+	stakeholderRepo repository.StakeholderRepository
+	custodyRepo     repository.CustodyProjectionRepository
+
+	start       sync.Once
+	mu          sync.Mutex
+	connections map[*StreamConnection]struct{}
+}
+
+func NewStreamService(outboxRepo repository.OutboxRepository, eventRepo repository.SupplyChainEventRepository, productRepo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository, custodyRepo repository.CustodyProjectionRepository) *streamService {
+	return &streamService{
+		outboxRepo:      outboxRepo,
+		eventRepo:       eventRepo,
+		productRepo:     productRepo,
+		stakeholderRepo: stakeholderRepo,
+		custodyRepo:     custodyRepo,
+		connections:     map[*StreamConnection]struct{}{},
+	}
+}
+
+// IssueToken signs a stream token with secret, optionally restricted to one stakeholder
+func (s *streamService) IssueToken(ctx context.Context, req *dto.CreateStreamTokenRequest, secret string) (*dto.StreamToken, error) {
+	ttl := defaultStreamTokenTTL
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	if ttl > maxStreamTokenTTL {
+		return nil, fmt.Errorf("%w: ttl_seconds may be at most %d", ErrInvalidStreamRequest, int(maxStreamTokenTTL.Seconds()))
+	}
+
+	if req.StakeholderID != nil {
+		if _, err := s.stakeholderRepo.GetByID(ctx, *req.StakeholderID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrStakeholderNotFound
+			}
+			return nil, fmt.Errorf("failed to validate stakeholder: %w", err)
+		}
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	token, err := stream.IssueToken(secret, stream.Claims{StakeholderID: req.StakeholderID, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign stream token: %w", err)
+	}
+
+	return &dto.StreamToken{Token: token, StakeholderID: req.StakeholderID, ExpiresAt: expiresAt}, nil
+}
+
+// Open starts a connection for claims. With a cursor, everything after it is replayed before live
+// messages; without one, only messages from now on are delivered. The connection ends when ctx
+// is cancelled or the client falls too far behind.
+func (s *streamService) Open(ctx context.Context, claims *stream.Claims, cursor *stream.Cursor) (*StreamConnection, error) {
+	s.start.Do(func() {
+		// The hub outlives the request that happens to start it
+		go s.run(context.Background())
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	conn := &StreamConnection{
+		service:  s,
+		claims:   claims,
+		filters:  map[string]stream.Filter{},
+		live:     make(chan *streamItem, streamBuffer),
+		messages: make(chan stream.Message, streamBuffer),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	// Register before replaying, so nothing written during the replay is missed
+	s.mu.Lock()
+	s.connections[conn] = struct{}{}
+	s.mu.Unlock()
+
+	go conn.pump(cursor)
+	return conn, nil
+}
+
+// run follows the outbox in sequence order and fans each message out to the open connections.
+// While nobody is connected it only keeps up with the end of the log.
+func (s *streamService) run(ctx context.Context) {
+	var position stream.Cursor
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.mu.Lock()
+		idle := len(s.connections) == 0
+		s.mu.Unlock()
+		if idle {
+			last, err := s.outboxRepo.LastSequence(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to read stream position", "error", err)
+			} else {
+				position = stream.Cursor{Sequence: last}
+			}
+		} else {
+			for {
+				items, next, read, err := s.read(ctx, position, streamPageSize)
+				if err != nil {
+					slog.ErrorContext(ctx, "failed to read stream", "error", err)
+					break
+				}
+				position = next
+				s.broadcast(items)
+				if read < streamPageSize {
+					break
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// read resolves the outbox messages numbered after position and returns the position of the last
+// one and how many messages were read. Messages whose event no longer exists still advance the
+// position, so callers page on the count read rather than on the items returned.
+func (s *streamService) read(ctx context.Context, position stream.Cursor, limit int) ([]*streamItem, stream.Cursor, int, error) {
+	messages, err := s.outboxRepo.ListSequenced(ctx, streamTopics, position.Sequence, limit)
+	if err != nil {
+		return nil, position, 0, fmt.Errorf("failed to read outbox: %w", err)
+	}
+
+	items := make([]*streamItem, 0, len(messages))
+	for _, message := range messages {
+		item, err := s.resolve(ctx, message)
+		if err != nil {
+			return items, position, len(messages), err
+		}
+		position = stream.Cursor{Sequence: *message.Sequence}
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+	return items, position, len(messages), nil
+}
+
+func (s *streamService) resolve(ctx context.Context, message *domain.OutboxMessage) (*streamItem, error) {
+	var eventID uuid.UUID
+	payload := eventbus.Message{Name: message.Name, Payload: []byte(message.Payload)}
+	switch message.Name {
+	case eventbus.NameEventRecorded:
+		recorded, err := eventbus.Decode[eventbus.EventRecorded](payload)
+		if err != nil || recorded.Event == nil {
+			return nil, nil
+		}
+		eventID = recorded.Event.ID
+	case eventbus.NameEventVerified:
+		verified, err := eventbus.Decode[eventbus.EventVerified](payload)
+		if err != nil {
+			return nil, nil
+		}
+		eventID = verified.EventID
+	default:
+		return nil, nil
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	involved, err := involvedStakeholders(ctx, s.productRepo, s.custodyRepo, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamItem{
+		cursor:   stream.Cursor{Sequence: *message.Sequence},
+		topic:    message.Name,
+		event:    event,
+		involved: involved,
+	}, nil
+}
+
+func (s *streamService) broadcast(items []*streamItem) {
+	if len(items) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.connections {
+		for _, item := range items {
+			select {
+			case conn.live <- item:
+				continue
+			default:
+			}
+			// Too slow to keep up: drop it, the client resumes from its cursor
+			delete(s.connections, conn)
+			conn.overflow()
+			break
+		}
+	}
+}
+
+func (s *streamService) remove(conn *StreamConnection) {
+	s.mu.Lock()
+	delete(s.connections, conn)
+	s.mu.Unlock()
+}
+
+// StreamConnection is one client's subscription to the stream. Messages is closed when the
+// connection ends; Err then tells why.
+type StreamConnection struct {
+	service *streamService
+	claims  *stream.Claims
+
+	mu      sync.Mutex
+	filters map[string]stream.Filter
+	err     error
+
+	live     chan *streamItem
+	messages chan stream.Message
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// Subscribe adds or replaces the filter with filter.ID. A connection restricted to a stakeholder
+// may not follow another stakeholder's events.
+func (c *StreamConnection) Subscribe(filter stream.Filter) error {
+	if filter.ID == "" {
+		return fmt.Errorf("%w: subscription id is required", ErrInvalidStreamRequest)
+	}
+	if filter.EventType != nil && !domain.IsValidEventType(*filter.EventType) {
+		return fmt.Errorf("%w: unknown event type %q", ErrInvalidStreamRequest, *filter.EventType)
+	}
+	if c.claims.StakeholderID != nil && filter.StakeholderID != nil && *filter.StakeholderID != *c.claims.StakeholderID {
+		return ErrStreamForbidden
+	}
+
+	c.mu.Lock()
+	c.filters[filter.ID] = filter
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *StreamConnection) Unsubscribe(id string) {
+	c.mu.Lock()
+	delete(c.filters, id)
+	c.mu.Unlock()
+}
+
+func (c *StreamConnection) Messages() <-chan stream.Message {
+	return c.messages
+}
+
+// Err is why the connection ended: nil when it was closed, ErrStreamOverflow when the client
+// fell behind
+func (c *StreamConnection) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *StreamConnection) Close() {
+	c.service.remove(c)
+	c.cancel()
+}
+
+func (c *StreamConnection) overflow() {
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = ErrStreamOverflow
+	}
+	c.mu.Unlock()
+	c.cancel()
+}
+
+// pump replays from the cursor, then forwards live messages, skipping any the replay already sent
+func (c *StreamConnection) pump(cursor *stream.Cursor) {
+	defer close(c.messages)
+	defer c.service.remove(c)
+
+	var last stream.Cursor
+	if cursor != nil {
+		last = *cursor
+		for {
+			items, next, read, err := c.service.read(c.ctx, last, streamPageSize)
+			if err != nil {
+				if c.ctx.Err() == nil {
+					slog.ErrorContext(c.ctx, "failed to replay stream", "error", err)
+				}
+				return
+			}
+			for _, item := range items {
+				if !c.deliver(item) {
+					return
+				}
+			}
+			last = next
+			if read < streamPageSize {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case item := <-c.live:
+			if !item.cursor.After(last) {
+				continue
+			}
+			if !c.deliver(item) {
+				return
+			}
+			last = item.cursor
+		}
+	}
+}
+
+// deliver sends an item the connection may see and that matches a filter; false means the
+// connection has ended
+func (c *StreamConnection) deliver(item *streamItem) bool {
+	if c.claims.StakeholderID != nil && !slices.Contains(item.involved, *c.claims.StakeholderID) {
+		return true
+	}
+
+	c.mu.Lock()
+	var matched []string
+	for id, filter := range c.filters {
+		if filter.Matches(item.event) {
+			matched = append(matched, id)
+		}
+	}
+	c.mu.Unlock()
+	if len(matched) == 0 {
+		return true
+	}
+	slices.Sort(matched)
+
+	message := stream.Message{
+		Cursor:        item.cursor.String(),
+		Topic:         item.topic,
+		Event:         item.event,
+		Subscriptions: matched,
+	}
+	select {
+	case c.messages <- message:
+		return true
+	case <-c.ctx.Done():
+		return false
+	}
+}
+
+// involvedStakeholders lists who an event touches: the stakeholder that recorded it, the
+// manufacturer of its product and whoever holds the product now
+func involvedStakeholders(ctx context.Context, productRepo repository.ProductRepository, custodyRepo repository.CustodyProjectionRepository, event *domain.SupplyChainEvent) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	add := func(id *uuid.UUID) {
+		if id != nil && !slices.Contains(ids, *id) {
+			ids = append(ids, *id)
+		}
+	}
+
+	add(event.StakeholderID)
+	if event.ProductID == nil {
+		return ids, nil
+	}
+
+	product := event.Product
+	if product == nil {
+		var err error
+		if product, err = productRepo.GetByID(ctx, *event.ProductID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+	}
+	if product != nil {
+		add(product.ManufacturerID)
+	}
+
+	custody, err := custodyRepo.GetByProduct(ctx, *event.ProductID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get custody: %w", err)
+	}
+	if custody != nil {
+		add(custody.HolderID)
+	}
+
+	return ids, nil
+}
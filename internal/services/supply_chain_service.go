@@ -7,34 +7,59 @@ import (
 	"github.com/google/uuid"
 	"github.com/koriebruh/suplyChainTrack/internal/domain"
 	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
 	"github.com/koriebruh/suplyChainTrack/internal/repository"
 	"gorm.io/gorm"
-	"time"
 )
 
 type supplyChainService struct {
-	repo            repository.SupplyChainEventRepository
-	productRepo     repository.ProductRepository
-	stakeholderRepo repository.StakeholderRepository
+	repo               repository.SupplyChainEventRepository
+	productRepo        repository.ProductRepository
+	stakeholderRepo    repository.StakeholderRepository
+	locationRepo       repository.LocationRepository
+	containmentRepo    repository.ContainmentRepository
+	transformationRepo repository.TransformationRepository
+	telemetryRepo      repository.TelemetryRepository
+	transferRepo       repository.CustodyTransferRepository
+	projectionRepo     repository.CustodyProjectionRepository
+	metrics            Metrics
+	tx                 repository.Transactor
 }
 
-func NewSupplyChainService(repo repository.SupplyChainEventRepository, productRepo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository) *supplyChainService {
-	return &supplyChainService{repo: repo, productRepo: productRepo, stakeholderRepo: stakeholderRepo}
+func NewSupplyChainService(repo repository.SupplyChainEventRepository, productRepo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository, locationRepo repository.LocationRepository, containmentRepo repository.ContainmentRepository, transformationRepo repository.TransformationRepository, telemetryRepo repository.TelemetryRepository, transferRepo repository.CustodyTransferRepository, projectionRepo repository.CustodyProjectionRepository, metrics Metrics, tx repository.Transactor) *supplyChainService {
+	return &supplyChainService{repo: repo, productRepo: productRepo, stakeholderRepo: stakeholderRepo, locationRepo: locationRepo, containmentRepo: containmentRepo, transformationRepo: transformationRepo, telemetryRepo: telemetryRepo, transferRepo: transferRepo, projectionRepo: projectionRepo, metrics: metrics, tx: tx}
 }
 
 func (s *supplyChainService) CreateEvent(ctx context.Context, req *dto.CreateSupplyChainEventRequest) (*domain.SupplyChainEvent, error) {
+	if err := s.ValidateEvent(ctx, req); err != nil {
+		return nil, err
+	}
+
+	event := newEvent(req)
+	err := s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		return recordEvent(ctx, repos, s.metrics, event, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, event.ID)
+}
+
+// ValidateEvent runs every check CreateEvent makes before recording an event
+func (s *supplyChainService) ValidateEvent(ctx context.Context, req *dto.CreateSupplyChainEventRequest) error {
 	// Validate event type
 	if !domain.IsValidEventType(req.EventType) {
-		return nil, ErrInvalidEventType
+		return ErrInvalidEventType
 	}
 
 	// Validate product if provided
 	if req.ProductID != nil {
 		if _, err := s.productRepo.GetByID(ctx, *req.ProductID); err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, ErrProductNotFound
+				return ErrProductNotFound
 			}
-			return nil, fmt.Errorf("failed to validate product: %w", err)
+			return fmt.Errorf("failed to validate product: %w", err)
 		}
 	}
 
@@ -42,35 +67,24 @@ func (s *supplyChainService) CreateEvent(ctx context.Context, req *dto.CreateSup
 	if req.StakeholderID != nil {
 		if _, err := s.stakeholderRepo.GetByID(ctx, *req.StakeholderID); err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, ErrStakeholderNotFound
+				return ErrStakeholderNotFound
 			}
-			return nil, fmt.Errorf("failed to validate stakeholder: %w", err)
+			return fmt.Errorf("failed to validate stakeholder: %w", err)
 		}
 	}
 
-	// Validate event sequence
-	if err := s.ValidateEventSequence(ctx, req); err != nil {
-		return nil, err
-	}
-
-	event := &domain.SupplyChainEvent{
-		ID:             uuid.New(),
-		ProductID:      req.ProductID,
-		StakeholderID:  req.StakeholderID,
-		EventType:      req.EventType,
-		Location:       req.Location,
-		Timestamp:      req.Timestamp,
-		Metadata:       req.Metadata,
-		BlockchainHash: req.BlockchainHash,
-		IsVerified:     false,
-		CreatedAt:      time.Now(),
-	}
-
-	if err := s.repo.Create(ctx, event); err != nil {
-		return nil, fmt.Errorf("failed to create supply chain event: %w", err)
+	// Validate location if provided
+	if req.LocationID != nil {
+		if _, err := s.locationRepo.GetByID(ctx, *req.LocationID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrLocationNotFound
+			}
+			return fmt.Errorf("failed to validate location: %w", err)
+		}
 	}
 
-	return s.repo.GetByID(ctx, event.ID)
+	// Validate event sequence
+	return s.ValidateEventSequence(ctx, req)
 }
 
 func (s *supplyChainService) GetEvent(ctx context.Context, id uuid.UUID) (*domain.SupplyChainEvent, error) {
@@ -85,46 +99,99 @@ func (s *supplyChainService) GetEvent(ctx context.Context, id uuid.UUID) (*domai
 }
 
 func (s *supplyChainService) UpdateEvent(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*domain.SupplyChainEvent, error) {
-	if _, err := s.GetEvent(ctx, id); err != nil {
+	event, err := s.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.eventProducts(ctx, event)
+	if err != nil {
 		return nil, err
 	}
 
-	if err := s.repo.Update(ctx, id, updates); err != nil {
-		return nil, fmt.Errorf("failed to update supply chain event: %w", err)
+	err = s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		// Analytics of the event's product and stakeholder day, before and after the change
+		if err := repos.Analytics.MarkStale(ctx, id); err != nil {
+			return fmt.Errorf("failed to mark analytics stale: %w", err)
+		}
+		if err := repos.SupplyChainEvent.Update(ctx, id, updates); err != nil {
+			return fmt.Errorf("failed to update supply chain event: %w", err)
+		}
+		if err := repos.Analytics.MarkStale(ctx, id); err != nil {
+			return fmt.Errorf("failed to mark analytics stale: %w", err)
+		}
+		updated, err := repos.SupplyChainEvent.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get supply chain event: %w", err)
+		}
+		if updated.ProductID != nil {
+			products = append(products, *updated.ProductID)
+		}
+		return reprojectProducts(ctx, repos, products)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return s.GetEvent(ctx, id)
 }
 
 func (s *supplyChainService) DeleteEvent(ctx context.Context, id uuid.UUID) error {
-	if _, err := s.GetEvent(ctx, id); err != nil {
+	event, err := s.GetEvent(ctx, id)
+	if err != nil {
 		return err
 	}
-
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete supply chain event: %w", err)
+	products, err := s.eventProducts(ctx, event)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		// Queued while the event still names its product and stakeholder day
+		if err := repos.Analytics.MarkStale(ctx, id); err != nil {
+			return fmt.Errorf("failed to mark analytics stale: %w", err)
+		}
+		if err := repos.SupplyChainEvent.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete supply chain event: %w", err)
+		}
+		return reprojectProducts(ctx, repos, products)
+	})
+}
+
+// eventProducts lists the products whose custody an event feeds into: its own product and, for a
+// transformation, every product it consumed or produced
+func (s *supplyChainService) eventProducts(ctx context.Context, event *domain.SupplyChainEvent) ([]uuid.UUID, error) {
+	var products []uuid.UUID
+	if event.ProductID != nil {
+		products = append(products, *event.ProductID)
+	}
+	if event.EventType == domain.EventTypeTransformed {
+		lines, err := s.transformationRepo.GetByEvent(ctx, event.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transformation lines: %w", err)
+		}
+		for _, line := range lines {
+			products = append(products, line.ProductID)
+		}
+	}
+	return products, nil
 }
 
 func (s *supplyChainService) ListEvents(ctx context.Context, filter *dto.SupplyChainEventFilter) (*dto.PaginatedResponse, error) {
 	if filter == nil {
 		filter = &dto.SupplyChainEventFilter{Limit: 10, Offset: 0}
 	}
+	if filter.Near != nil {
+		if err := validateGeoRadius(*filter.Near); err != nil {
+			return nil, err
+		}
+	}
 
-	events, total, err := s.repo.List(ctx, filter)
+	events, page, err := s.repo.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list supply chain events: %w", err)
 	}
 
-	return &dto.PaginatedResponse{
-		Data:    events,
-		Total:   int(total),
-		Limit:   filter.Limit,
-		Offset:  filter.Offset,
-		HasMore: filter.Offset+filter.Limit < int(total),
-	}, nil
+	return dto.NewPaginatedResponse(events, page, filter.Limit, filter.Offset), nil
 }
 
 func (s *supplyChainService) GetProductTrace(ctx context.Context, productID uuid.UUID) (*dto.SupplyChainTrace, error) {
@@ -140,19 +207,35 @@ func (s *supplyChainService) GetProductTrace(ctx context.Context, productID uuid
 		return nil, fmt.Errorf("failed to get product trace: %w", err)
 	}
 
+	// Events recorded on cases/pallets apply to everything packed inside them
+	inherited, err := s.inheritedEvents(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inherited events: %w", err)
+	}
+	trace.InheritedEvents = inherited
+
+	// Sensor readings are summarised rather than listed, however long the product was tracked
+	telemetry, err := productTelemetry(ctx, s.telemetryRepo, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product telemetry: %w", err)
+	}
+	trace.Telemetry = telemetry
+
 	return trace, nil
 }
 
 func (s *supplyChainService) VerifyEvent(ctx context.Context, id uuid.UUID, blockchainHash string) error {
-	if _, err := s.GetEvent(ctx, id); err != nil {
+	event, err := s.GetEvent(ctx, id)
+	if err != nil {
 		return err
 	}
 
-	if err := s.repo.VerifyEvent(ctx, id, blockchainHash); err != nil {
-		return fmt.Errorf("failed to verify event: %w", err)
-	}
-
-	return nil
+	return s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		if err := repos.SupplyChainEvent.VerifyEvent(ctx, id, blockchainHash); err != nil {
+			return fmt.Errorf("failed to verify event: %w", err)
+		}
+		return emit(ctx, repos.Outbox, eventbus.EventVerified{EventID: id, ProductID: event.ProductID, BlockchainHash: blockchainHash})
+	})
 }
 
 func (s *supplyChainService) GetEventsByProduct(ctx context.Context, productID uuid.UUID) ([]*domain.SupplyChainEvent, error) {
@@ -188,12 +271,28 @@ func (s *supplyChainService) GetEventsByStakeholder(ctx context.Context, stakeho
 }
 
 func (s *supplyChainService) ValidateEventSequence(ctx context.Context, req *dto.CreateSupplyChainEventRequest) error {
+	isContainmentEvent := req.EventType == domain.EventTypePack || req.EventType == domain.EventTypeUnpack
+	if len(req.ChildIDs) > 0 && !isContainmentEvent {
+		return ErrInvalidContainment // Only pack/unpack events carry children
+	}
+
+	isTransformation := req.EventType == domain.EventTypeTransformed
+	if (len(req.Inputs) > 0 || len(req.Outputs) > 0) && !isTransformation {
+		return ErrInvalidTransformation // Only transformed events carry inputs/outputs
+	}
+	if isTransformation {
+		return s.validateTransformation(ctx, req)
+	}
+
 	if req.ProductID == nil {
+		if isContainmentEvent {
+			return ErrInvalidContainment // Pack/unpack need the container as product
+		}
 		return nil // Skip validation if no product specified
 	}
 
-	// Get existing events for the product
-	existingEvents, err := s.repo.GetByProduct(ctx, *req.ProductID)
+	// Get existing events for the product, including those inherited from its containers
+	existingEvents, err := s.productHistory(ctx, *req.ProductID)
 	if err != nil {
 		return fmt.Errorf("failed to get existing events: %w", err)
 	}
@@ -208,10 +307,11 @@ func (s *supplyChainService) ValidateEventSequence(ctx context.Context, req *dto
 			}
 		}
 	case domain.EventTypeShipped:
-		// Shipped requires previous manufactured or received event
+		// Shipped requires previous manufactured, received, pack or transformed (as output) event
 		hasValidPrevious := false
 		for _, event := range existingEvents {
-			if event.EventType == domain.EventTypeManufactured || event.EventType == domain.EventTypeReceived {
+			if event.EventType == domain.EventTypeManufactured || event.EventType == domain.EventTypeReceived ||
+				event.EventType == domain.EventTypePack || event.EventType == domain.EventTypeTransformed {
 				hasValidPrevious = true
 				break
 			}
@@ -220,6 +320,13 @@ func (s *supplyChainService) ValidateEventSequence(ctx context.Context, req *dto
 			return ErrInvalidEventSequence
 		}
 	case domain.EventTypeReceived:
+		// Only an accepted custody transfer receives a product and moves its custody; a received
+		// event that names none accepts the product's pending transfer to its stakeholder
+		if req.CustodyTransferID == nil {
+			if _, err := pendingTransferTo(ctx, s.transferRepo, *req.ProductID, req.StakeholderID); err != nil {
+				return err
+			}
+		}
 		// Received requires previous shipped event
 		hasShipped := false
 		for _, event := range existingEvents {
@@ -243,7 +350,26 @@ func (s *supplyChainService) ValidateEventSequence(ctx context.Context, req *dto
 		if !hasReceived {
 			return ErrInvalidEventSequence
 		}
+	case domain.EventTypePack:
+		return s.validatePack(ctx, req)
+	case domain.EventTypeUnpack:
+		return s.validateUnpack(ctx, req)
 	}
 
 	return nil
 }
+
+// requireHolder rejects an event recorded by anyone but the stakeholder holding custody of the
+// products. A product nobody holds yet passes; only its manufactured event gives it a holder.
+func (s *supplyChainService) requireHolder(ctx context.Context, stakeholderID *uuid.UUID, productIDs []uuid.UUID) error {
+	custodies, err := s.projectionRepo.GetByProducts(ctx, productIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get current custody holder: %w", err)
+	}
+	for _, custody := range custodies {
+		if custody.HolderID != nil && (stakeholderID == nil || *custody.HolderID != *stakeholderID) {
+			return ErrNotCustodyHolder
+		}
+	}
+	return nil
+}
@@ -0,0 +1,559 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/geo"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+const (
+	// maxTelemetryBatch caps the readings of one ingest request or bridge flush
+	maxTelemetryBatch = 5000
+
+	// defaultTelemetryReadings and maxTelemetryReadings bound a query for raw readings
+	defaultTelemetryReadings = 1000
+	maxTelemetryReadings     = 10000
+
+	// maxTelemetryBuckets caps how many intervals a downsampled query returns
+	maxTelemetryBuckets = 10000
+
+	// defaultTelemetryWindow is the range a query covers when it gives no from
+	defaultTelemetryWindow = 24 * time.Hour
+
+	// traceTelemetryPoints is about how many buckets a product trace summarises its telemetry in
+	traceTelemetryPoints = 500
+)
+
+// traceBuckets are the intervals a product trace picks from, finest first
+var traceBuckets = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour, 6 * time.Hour, 24 * time.Hour, 7 * 24 * time.Hour}
+
+type telemetryService struct {
+	repo            repository.TelemetryRepository
+	productRepo     repository.ProductRepository
+	stakeholderRepo repository.StakeholderRepository
+	transferRepo    repository.CustodyTransferRepository
+	metrics         Metrics
+	tx              repository.Transactor
+}
+
+func NewTelemetryService(repo repository.TelemetryRepository, productRepo repository.ProductRepository, stakeholderRepo repository.StakeholderRepository, transferRepo repository.CustodyTransferRepository, metrics Metrics, tx repository.Transactor) *telemetryService {
+	return &telemetryService{repo: repo, productRepo: productRepo, stakeholderRepo: stakeholderRepo, transferRepo: transferRepo, metrics: metrics, tx: tx}
+}
+
+func (s *telemetryService) RegisterDevice(ctx context.Context, req *dto.CreateSensorDeviceRequest) (*domain.SensorDevice, error) {
+	serial := strings.TrimSpace(req.Serial)
+	if serial == "" {
+		return nil, fmt.Errorf("%w: serial is required", ErrInvalidSensorDevice)
+	}
+	if req.StakeholderID != nil {
+		if err := s.validateStakeholder(ctx, *req.StakeholderID); err != nil {
+			return nil, err
+		}
+	}
+	if req.ProductID != nil {
+		if err := s.validateProduct(ctx, *req.ProductID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check if serial already exists
+	if _, err := s.repo.GetDeviceBySerial(ctx, serial); err == nil {
+		return nil, ErrDuplicateDeviceSerial
+	}
+
+	now := time.Now()
+	device := &domain.SensorDevice{
+		ID:            uuid.New(),
+		Serial:        serial,
+		Name:          req.Name,
+		Model:         req.Model,
+		StakeholderID: req.StakeholderID,
+		ProductID:     req.ProductID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if req.ProductID != nil {
+		device.AttachedAt = &now
+	}
+	if err := s.repo.CreateDevice(ctx, device); err != nil {
+		return nil, fmt.Errorf("failed to register sensor device: %w", err)
+	}
+
+	return s.GetDevice(ctx, device.ID)
+}
+
+func (s *telemetryService) GetDevice(ctx context.Context, id uuid.UUID) (*domain.SensorDevice, error) {
+	device, err := s.repo.GetDeviceByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSensorDeviceNotFound
+		}
+		return nil, fmt.Errorf("failed to get sensor device: %w", err)
+	}
+	return device, nil
+}
+
+func (s *telemetryService) UpdateDevice(ctx context.Context, id uuid.UUID, req *dto.UpdateSensorDeviceRequest) (*domain.SensorDevice, error) {
+	if _, err := s.GetDevice(ctx, id); err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Model != nil {
+		updates["model"] = *req.Model
+	}
+	if req.StakeholderID != nil {
+		if err := s.validateStakeholder(ctx, *req.StakeholderID); err != nil {
+			return nil, err
+		}
+		updates["stakeholder_id"] = *req.StakeholderID
+	}
+
+	updates["updated_at"] = time.Now()
+
+	if err := s.repo.UpdateDevice(ctx, id, updates); err != nil {
+		return nil, fmt.Errorf("failed to update sensor device: %w", err)
+	}
+
+	return s.GetDevice(ctx, id)
+}
+
+// DeleteDevice removes a device together with its readings
+func (s *telemetryService) DeleteDevice(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetDevice(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteDevice(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete sensor device: %w", err)
+	}
+
+	return nil
+}
+
+func (s *telemetryService) ListDevices(ctx context.Context, filter *dto.SensorDeviceFilter) (*dto.PaginatedResponse, error) {
+	devices, page, err := s.repo.ListDevices(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sensor devices: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(devices, page, filter.Limit, filter.Offset), nil
+}
+
+// AttachDevice links the device's readings from now on to a product, replacing the one it was
+// attached to
+func (s *telemetryService) AttachDevice(ctx context.Context, id uuid.UUID, req *dto.AttachSensorDeviceRequest) (*domain.SensorDevice, error) {
+	if _, err := s.GetDevice(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.validateProduct(ctx, req.ProductID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"product_id":  req.ProductID,
+		"attached_at": now,
+		"updated_at":  now,
+	}
+	if err := s.repo.UpdateDevice(ctx, id, updates); err != nil {
+		return nil, fmt.Errorf("failed to attach sensor device: %w", err)
+	}
+
+	return s.GetDevice(ctx, id)
+}
+
+func (s *telemetryService) DetachDevice(ctx context.Context, id uuid.UUID) (*domain.SensorDevice, error) {
+	if _, err := s.GetDevice(ctx, id); err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{
+		"product_id":  nil,
+		"attached_at": nil,
+		"updated_at":  time.Now(),
+	}
+	if err := s.repo.UpdateDevice(ctx, id, updates); err != nil {
+		return nil, fmt.Errorf("failed to detach sensor device: %w", err)
+	}
+
+	return s.GetDevice(ctx, id)
+}
+
+// Ingest stores a batch of readings. A reading that names an unknown device or product, or that
+// does not validate, is rejected on its own; the others are stored in one transaction. Each
+// reading is linked to its product's pending custody transfer and run through the excursion
+// rules of its product's category, and the newest reading with coordinates of each product is
+// checked against the route plans of its shipments.
+func (s *telemetryService) Ingest(ctx context.Context, batch *dto.TelemetryBatch) (*dto.TelemetryIngestResult, error) {
+	if len(batch.Readings) == 0 {
+		return nil, fmt.Errorf("%w: readings are required", ErrInvalidTelemetry)
+	}
+	if len(batch.Readings) > maxTelemetryBatch {
+		return nil, fmt.Errorf("%w: at most %d readings per batch", ErrInvalidTelemetry, maxTelemetryBatch)
+	}
+
+	result := &dto.TelemetryIngestResult{
+		Received:   len(batch.Readings),
+		Rejected:   []*dto.TelemetryRejection{},
+		Alerts:     []*domain.RouteAlert{},
+		Excursions: []*domain.ExcursionIncident{},
+	}
+	reject := func(index int, err error) {
+		result.Rejected = append(result.Rejected, &dto.TelemetryRejection{Index: index, Error: err.Error()})
+	}
+
+	devices, err := s.resolveDevices(ctx, batch.Readings)
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.resolveProducts(ctx, batch.Readings)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	transfers := make(map[uuid.UUID]*uuid.UUID)
+	lastSeen := make(map[uuid.UUID]time.Time)
+	positioned := make(map[uuid.UUID]*domain.TelemetryReading)
+	var order []uuid.UUID
+	readings := make([]*domain.TelemetryReading, 0, len(batch.Readings))
+
+	for i, in := range batch.Readings {
+		if in == nil {
+			reject(i, fmt.Errorf("%w: reading is empty", ErrInvalidTelemetry))
+			continue
+		}
+		device := devices.lookup(in)
+		if device == nil {
+			reject(i, ErrSensorDeviceNotFound)
+			continue
+		}
+		if err := validateReading(in); err != nil {
+			reject(i, err)
+			continue
+		}
+		productID := device.ProductID
+		if in.ProductID != nil {
+			if !products[*in.ProductID] {
+				reject(i, ErrProductNotFound)
+				continue
+			}
+			productID = in.ProductID
+		}
+
+		reading := &domain.TelemetryReading{
+			DeviceID:    device.ID,
+			ProductID:   productID,
+			RecordedAt:  in.RecordedAt,
+			Temperature: in.Temperature,
+			Humidity:    in.Humidity,
+			Latitude:    in.Latitude,
+			Longitude:   in.Longitude,
+			ReceivedAt:  now,
+		}
+		if productID != nil {
+			transferID, seen := transfers[*productID]
+			if !seen {
+				if transferID, err = s.pendingTransfer(ctx, *productID); err != nil {
+					return nil, err
+				}
+				transfers[*productID] = transferID
+				order = append(order, *productID)
+			}
+			reading.CustodyTransferID = transferID
+
+			if reading.Latitude != nil {
+				if newest := positioned[*productID]; newest == nil || reading.RecordedAt.After(newest.RecordedAt) {
+					positioned[*productID] = reading
+				}
+			}
+		}
+		if reading.RecordedAt.After(lastSeen[device.ID]) {
+			lastSeen[device.ID] = reading.RecordedAt
+		}
+		readings = append(readings, reading)
+	}
+
+	if len(readings) == 0 {
+		return result, nil
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		stored, err := repos.Telemetry.InsertReadings(ctx, readings)
+		if err != nil {
+			return fmt.Errorf("failed to store telemetry: %w", err)
+		}
+		result.Stored = len(stored)
+		result.Duplicates = len(readings) - result.Stored
+
+		for deviceID, at := range lastSeen {
+			if err := repos.Telemetry.TouchDevice(ctx, deviceID, at); err != nil {
+				return fmt.Errorf("failed to update sensor device: %w", err)
+			}
+		}
+
+		for _, productID := range order {
+			reading := positioned[productID]
+			if reading == nil {
+				continue
+			}
+			seen := sighting{
+				productID: productID,
+				source:    domain.RouteAlertSourceTelemetry,
+				at:        reading.RecordedAt,
+				point:     &geo.Point{Latitude: *reading.Latitude, Longitude: *reading.Longitude},
+			}
+			alerts, _, err := checkRoutes(ctx, repos, s.metrics, seen)
+			if err != nil {
+				return err
+			}
+			result.Alerts = append(result.Alerts, alerts...)
+		}
+
+		// Duplicates were measured when first stored, so only new readings extend incidents
+		measurements := make([]*measurement, 0, len(stored))
+		for _, reading := range stored {
+			if measured := readingMeasurement(reading); measured != nil {
+				measurements = append(measurements, measured)
+			}
+		}
+		incidents, err := checkExcursions(ctx, repos, measurements)
+		if err != nil {
+			return err
+		}
+		result.Excursions = append(result.Excursions, incidents...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListReadings returns the raw readings of a device, product or shipment, oldest first
+func (s *telemetryService) ListReadings(ctx context.Context, query *dto.TelemetryQuery) ([]*domain.TelemetryReading, error) {
+	if err := validateTelemetryQuery(query); err != nil {
+		return nil, err
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultTelemetryReadings
+	}
+	if query.Limit > maxTelemetryReadings {
+		query.Limit = maxTelemetryReadings
+	}
+
+	readings, err := s.repo.ListReadings(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list telemetry: %w", err)
+	}
+	return readings, nil
+}
+
+// GetSeries downsamples the readings of a device, product or shipment into query.Bucket
+// intervals, by default over the last 24 hours
+func (s *telemetryService) GetSeries(ctx context.Context, query *dto.TelemetryQuery) (*dto.TelemetrySeries, error) {
+	if err := validateTelemetryQuery(query); err != nil {
+		return nil, err
+	}
+	if query.Bucket < time.Second {
+		return nil, fmt.Errorf("%w: bucket must be at least 1s", ErrInvalidTelemetryQuery)
+	}
+
+	to := time.Now()
+	if query.To != nil {
+		to = *query.To
+	}
+	from := to.Add(-defaultTelemetryWindow)
+	if query.From != nil {
+		from = *query.From
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidTelemetryQuery)
+	}
+	if to.Sub(from)/query.Bucket > maxTelemetryBuckets {
+		return nil, fmt.Errorf("%w: at most %d buckets per query, use a larger bucket", ErrInvalidTelemetryQuery, maxTelemetryBuckets)
+	}
+	query.From, query.To = &from, &to
+
+	buckets, err := s.repo.Downsample(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to downsample telemetry: %w", err)
+	}
+
+	return &dto.TelemetrySeries{From: from, To: to, Bucket: query.Bucket.String(), Buckets: buckets}, nil
+}
+
+// productTelemetry summarises every reading of a product in about traceTelemetryPoints buckets;
+// nil when the product has none
+func productTelemetry(ctx context.Context, repo repository.TelemetryRepository, productID uuid.UUID) (*dto.TelemetrySeries, error) {
+	first, last, err := repo.GetProductSpan(ctx, productID)
+	if err != nil || first == nil {
+		return nil, err
+	}
+
+	bucket := traceBuckets[len(traceBuckets)-1]
+	for _, candidate := range traceBuckets {
+		if last.Sub(*first)/candidate < traceTelemetryPoints {
+			bucket = candidate
+			break
+		}
+	}
+	to := last.Add(bucket)
+
+	buckets, err := repo.Downsample(ctx, &dto.TelemetryQuery{ProductID: &productID, From: first, To: &to, Bucket: bucket})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TelemetrySeries{From: *first, To: to, Bucket: bucket.String(), Buckets: buckets}, nil
+}
+
+// deviceIndex finds the devices of a batch by ID or serial
+type deviceIndex struct {
+	byID     map[uuid.UUID]*domain.SensorDevice
+	bySerial map[string]*domain.SensorDevice
+}
+
+func (d deviceIndex) lookup(in *dto.TelemetryReadingInput) *domain.SensorDevice {
+	if in.DeviceID != nil {
+		return d.byID[*in.DeviceID]
+	}
+	if in.Serial != nil {
+		return d.bySerial[strings.TrimSpace(*in.Serial)]
+	}
+	return nil
+}
+
+func (s *telemetryService) resolveDevices(ctx context.Context, readings []*dto.TelemetryReadingInput) (deviceIndex, error) {
+	index := deviceIndex{byID: make(map[uuid.UUID]*domain.SensorDevice), bySerial: make(map[string]*domain.SensorDevice)}
+
+	var ids []uuid.UUID
+	var serials []string
+	seenIDs, seenSerials := make(map[uuid.UUID]bool), make(map[string]bool)
+	for _, in := range readings {
+		switch {
+		case in == nil:
+		case in.DeviceID != nil && !seenIDs[*in.DeviceID]:
+			seenIDs[*in.DeviceID] = true
+			ids = append(ids, *in.DeviceID)
+		case in.DeviceID == nil && in.Serial != nil && !seenSerials[strings.TrimSpace(*in.Serial)]:
+			seenSerials[strings.TrimSpace(*in.Serial)] = true
+			serials = append(serials, strings.TrimSpace(*in.Serial))
+		}
+	}
+
+	devices, err := s.repo.GetDevices(ctx, ids, serials)
+	if err != nil {
+		return index, fmt.Errorf("failed to resolve sensor devices: %w", err)
+	}
+	for _, device := range devices {
+		index.byID[device.ID] = device
+		index.bySerial[device.Serial] = device
+	}
+	return index, nil
+}
+
+// resolveProducts reports which of the products readings name explicitly exist
+func (s *telemetryService) resolveProducts(ctx context.Context, readings []*dto.TelemetryReadingInput) (map[uuid.UUID]bool, error) {
+	known := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, in := range readings {
+		if in != nil && in.ProductID != nil {
+			if _, listed := known[*in.ProductID]; !listed {
+				known[*in.ProductID] = false
+				ids = append(ids, *in.ProductID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return known, nil
+	}
+
+	products, err := s.productRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve products: %w", err)
+	}
+	for _, product := range products {
+		known[product.ID] = true
+	}
+	return known, nil
+}
+
+// pendingTransfer returns the ID of the product's pending custody transfer, if it has one
+func (s *telemetryService) pendingTransfer(ctx context.Context, productID uuid.UUID) (*uuid.UUID, error) {
+	transfer, err := s.transferRepo.GetPendingByProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pending custody transfer: %w", err)
+	}
+	return &transfer.ID, nil
+}
+
+func (s *telemetryService) validateStakeholder(ctx context.Context, stakeholderID uuid.UUID) error {
+	if _, err := s.stakeholderRepo.GetByID(ctx, stakeholderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrStakeholderNotFound
+		}
+		return fmt.Errorf("failed to validate stakeholder: %w", err)
+	}
+	return nil
+}
+
+func (s *telemetryService) validateProduct(ctx context.Context, productID uuid.UUID) error {
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProductNotFound
+		}
+		return fmt.Errorf("failed to validate product: %w", err)
+	}
+	return nil
+}
+
+// validateReading checks a reading has a time and at least one plausible measurement
+func validateReading(in *dto.TelemetryReadingInput) error {
+	if in.RecordedAt.IsZero() {
+		return fmt.Errorf("%w: recorded_at is required", ErrInvalidTelemetry)
+	}
+	if in.Temperature == nil && in.Humidity == nil && in.Latitude == nil && in.Longitude == nil {
+		return fmt.Errorf("%w: temperature, humidity or a position is required", ErrInvalidTelemetry)
+	}
+	if in.Temperature != nil && *in.Temperature < -273.15 {
+		return fmt.Errorf("%w: temperature is below absolute zero", ErrInvalidTelemetry)
+	}
+	if in.Humidity != nil && (*in.Humidity < 0 || *in.Humidity > 100) {
+		return fmt.Errorf("%w: humidity must be between 0 and 100", ErrInvalidTelemetry)
+	}
+	if (in.Latitude == nil) != (in.Longitude == nil) {
+		return fmt.Errorf("%w: latitude and longitude are set together", ErrInvalidTelemetry)
+	}
+	if in.Latitude != nil && !(geo.Point{Latitude: *in.Latitude, Longitude: *in.Longitude}).Valid() {
+		return fmt.Errorf("%w: coordinates out of range", ErrInvalidTelemetry)
+	}
+	return nil
+}
+
+// validateTelemetryQuery requires a device, product or shipment to read
+func validateTelemetryQuery(query *dto.TelemetryQuery) error {
+	if query.DeviceID == nil && query.ProductID == nil && query.CustodyTransferID == nil {
+		return fmt.Errorf("%w: device_id, product_id or custody_transfer_id is required", ErrInvalidTelemetryQuery)
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidTelemetryQuery)
+	}
+	return nil
+}
@@ -0,0 +1,681 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"github.com/koriebruh/suplyChainTrack/internal/eventbus"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/webhook"
+	"gorm.io/gorm"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// webhookMaxAttempts is how many times a delivery is tried before it is marked failed
+	webhookMaxAttempts = 8
+
+	// webhookRetryBase is the wait before the first retry; every further retry waits twice as long
+	webhookRetryBase = time.Minute
+
+	// webhookDisableThreshold is how many attempts in a row may fail before the endpoint is disabled
+	webhookDisableThreshold = 20
+
+	// webhookTimeout bounds one delivery attempt
+	webhookTimeout = 10 * time.Second
+
+	// webhookDispatchBatch is how many due deliveries one dispatch pass claims
+	webhookDispatchBatch = 50
+
+	// webhookDispatchConcurrency is how many deliveries of a pass are sent at once
+	webhookDispatchConcurrency = 10
+
+	// webhookDeliveryLease keeps a claimed delivery from being claimed again while it is attempted
+	webhookDeliveryLease = 2 * time.Minute
+
+	// webhookPollInterval is how often the dispatcher looks for due retries
+	webhookPollInterval = 5 * time.Second
+
+	// maxWebhookResponseError caps how much of a failed response body is kept in the delivery log
+	maxWebhookResponseError = 1024
+)
+
+// webhookOutboxConsumer is the webhooks' position in the outbox log
+const webhookOutboxConsumer = "webhooks"
+
+// webhookOutboxTopics are the outbox messages that become deliveries
+var webhookOutboxTopics = []string{eventbus.NameEventRecorded, eventbus.NameEventVerified, eventbus.NameTransactionStatus, eventbus.NameRecallNotice, eventbus.NameExcursionBreached}
+
+// webhookEnvelope is the JSON body of every delivery
+type webhookEnvelope struct {
+	ID        uuid.UUID `json:"id"` // the same for every subscription notified of one change
+	Topic     string    `json:"topic"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// webhookTransactionData is the data of a transaction.status_changed notification
+type webhookTransactionData struct {
+	Transaction    *domain.BlockchainTransaction `json:"transaction"`
+	PreviousStatus string                        `json:"previous_status"`
+}
+
+// webhookTarget is who an outbox message is delivered to, and what subscription filters it is matched by
+type webhookTarget struct {
+	stakeholderIDs []uuid.UUID
+	eventID        *uuid.UUID // nil for notices, which are not about one event
+	eventType      string
+	productIDs     []uuid.UUID
+}
+
+type webhookService struct {
+	repo            repository.WebhookRepository
+	stakeholderRepo repository.StakeholderRepository
+	productRepo     repository.ProductRepository
+	tx              repository.Transactor
+	client          *http.Client
+
+	// wake nudges a running dispatcher when new deliveries are queued
+	wake chan struct{}
+}
+
+func NewWebhookService(repo repository.WebhookRepository, stakeholderRepo repository.StakeholderRepository, productRepo repository.ProductRepository, tx repository.Transactor) *webhookService {
+	return &webhookService{
+		repo:            repo,
+		stakeholderRepo: stakeholderRepo,
+		productRepo:     productRepo,
+		tx:              tx,
+		client: &http.Client{
+			Timeout:   webhookTimeout,
+			Transport: webhook.NewTransport(),
+			// A redirect would send the signed payload somewhere the subscriber did not register
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		wake: make(chan struct{}, 1),
+	}
+}
+
+func (s *webhookService) CreateSubscription(ctx context.Context, req *dto.CreateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionSecret, error) {
+	if _, err := s.stakeholderRepo.GetByID(ctx, req.StakeholderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStakeholderNotFound
+		}
+		return nil, fmt.Errorf("failed to validate stakeholder: %w", err)
+	}
+	if err := s.validateSubscription(ctx, req.URL, req.Topics, req.EventTypes, req.ProductIDs); err != nil {
+		return nil, err
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	subscription := &domain.WebhookSubscription{
+		ID:            uuid.New(),
+		StakeholderID: req.StakeholderID,
+		URL:           req.URL,
+		Secret:        secret,
+		Topics:        req.Topics,
+		EventTypes:    req.EventTypes,
+		ProductIDs:    req.ProductIDs,
+		IsActive:      true,
+	}
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	created, err := s.GetSubscription(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.WebhookSubscriptionSecret{WebhookSubscription: created, Secret: secret}, nil
+}
+
+func (s *webhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	subscription, err := s.repo.GetSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+func (s *webhookService) UpdateSubscription(ctx context.Context, id uuid.UUID, req *dto.UpdateWebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if req.URL != nil {
+		subscription.URL = *req.URL
+		updates["url"] = *req.URL
+	}
+	// Map updates bypass the JSON serializer of these columns, so they are encoded here
+	if req.Topics != nil {
+		subscription.Topics = *req.Topics
+		topics, _ := json.Marshal(subscription.Topics)
+		updates["topics"] = string(topics)
+	}
+	if req.EventTypes != nil {
+		subscription.EventTypes = *req.EventTypes
+		eventTypes, _ := json.Marshal(subscription.EventTypes)
+		updates["event_types"] = string(eventTypes)
+	}
+	if req.ProductIDs != nil {
+		subscription.ProductIDs = *req.ProductIDs
+		productIDs, _ := json.Marshal(subscription.ProductIDs)
+		updates["product_ids"] = string(productIDs)
+	}
+	if err := s.validateSubscription(ctx, subscription.URL, subscription.Topics, subscription.EventTypes, subscription.ProductIDs); err != nil {
+		return nil, err
+	}
+
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+		if *req.IsActive {
+			updates["consecutive_failures"] = 0
+			updates["disabled_at"] = nil
+			updates["disabled_reason"] = nil
+		}
+	}
+	if len(updates) == 0 {
+		return subscription, nil
+	}
+
+	if err := s.repo.UpdateSubscription(ctx, id, updates); err != nil {
+		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	return s.GetSubscription(ctx, id)
+}
+
+// RotateSecret replaces the signing secret; deliveries sent from now on use the new one
+func (s *webhookService) RotateSecret(ctx context.Context, id uuid.UUID) (*dto.WebhookSubscriptionSecret, error) {
+	if _, err := s.GetSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	if err := s.repo.UpdateSubscription(ctx, id, map[string]interface{}{"secret": secret}); err != nil {
+		return nil, fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.WebhookSubscriptionSecret{WebhookSubscription: subscription, Secret: secret}, nil
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetSubscription(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *webhookService) ListSubscriptions(ctx context.Context, filter *dto.WebhookSubscriptionFilter) (*dto.PaginatedResponse, error) {
+	subscriptions, page, err := s.repo.ListSubscriptions(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(subscriptions, page, filter.Limit, filter.Offset), nil
+}
+
+func (s *webhookService) GetDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, filter *dto.WebhookDeliveryFilter) (*dto.PaginatedResponse, error) {
+	if filter.Status != nil && !domain.IsValidWebhookDeliveryStatus(*filter.Status) {
+		return nil, fmt.Errorf("%w: unknown delivery status %q", ErrInvalidWebhook, *filter.Status)
+	}
+
+	deliveries, page, err := s.repo.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return dto.NewPaginatedResponse(deliveries, page, filter.Limit, filter.Offset), nil
+}
+
+// ReplayDelivery queues the payload of an earlier delivery again as a new delivery, whatever the
+// outcome of the original. The subscription must be active.
+func (s *webhookService) ReplayDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	original, err := s.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if original.Subscription == nil || !original.Subscription.IsActive {
+		return nil, ErrWebhookDisabled
+	}
+
+	now := time.Now()
+	replay := &domain.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: original.SubscriptionID,
+		Topic:          original.Topic,
+		EventID:        original.EventID,
+		Payload:        original.Payload,
+		Status:         domain.WebhookDeliveryStatusPending,
+		NextAttemptAt:  &now,
+		ReplayOf:       &original.ID,
+	}
+	if err := s.repo.CreateDeliveries(ctx, []*domain.WebhookDelivery{replay}); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook replay: %w", err)
+	}
+	s.notify()
+
+	return s.GetDelivery(ctx, replay.ID)
+}
+
+// QueueFromOutbox turns the next batch of outbox messages into deliveries and reports how many
+// messages it read. Deliveries and the consumer's position are written in one transaction, so
+// every change is queued once, and only after it has committed.
+func (s *webhookService) QueueFromOutbox(ctx context.Context) (int, error) {
+	var read int
+	err := s.tx.WithinTransaction(ctx, func(repos *repository.RepositoriesManagers) error {
+		position, err := repos.Outbox.LockConsumer(ctx, webhookOutboxConsumer)
+		if err != nil {
+			return fmt.Errorf("failed to lock webhook outbox position: %w", err)
+		}
+		messages, err := repos.Outbox.ListSequenced(ctx, webhookOutboxTopics, position, outboxBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to read outbox: %w", err)
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		var deliveries []*domain.WebhookDelivery
+		for _, message := range messages {
+			queued, err := s.deliveriesFor(ctx, repos, message)
+			if err != nil {
+				return err
+			}
+			deliveries = append(deliveries, queued...)
+		}
+		if err := repos.Webhook.CreateDeliveries(ctx, deliveries); err != nil {
+			return fmt.Errorf("failed to create webhook deliveries: %w", err)
+		}
+
+		read = len(messages)
+		return repos.Outbox.SetConsumerPosition(ctx, webhookOutboxConsumer, *messages[len(messages)-1].Sequence)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return read, nil
+}
+
+// deliveriesFor resolves an outbox message to its topic, data and target, and queues one delivery
+// to every active subscription of the target stakeholders whose filters match. Messages that cannot
+// be decoded, or whose event no longer exists, queue nothing.
+func (s *webhookService) deliveriesFor(ctx context.Context, repos *repository.RepositoriesManagers, message *domain.OutboxMessage) ([]*domain.WebhookDelivery, error) {
+	var topic string
+	var eventID uuid.UUID
+	var data any
+	payload := eventbus.Message{Name: message.Name, Payload: []byte(message.Payload)}
+	switch message.Name {
+	case eventbus.NameEventRecorded:
+		recorded, err := eventbus.Decode[eventbus.EventRecorded](payload)
+		if err != nil || recorded.Event == nil {
+			return nil, nil
+		}
+		topic, eventID = domain.WebhookTopicEventCreated, recorded.Event.ID
+	case eventbus.NameEventVerified:
+		verified, err := eventbus.Decode[eventbus.EventVerified](payload)
+		if err != nil {
+			return nil, nil
+		}
+		topic, eventID = domain.WebhookTopicEventVerified, verified.EventID
+	case eventbus.NameTransactionStatus:
+		// Transactions not tied to an event touch no stakeholder and are not delivered
+		changed, err := eventbus.Decode[eventbus.TransactionStatusChanged](payload)
+		if err != nil || changed.Transaction == nil || changed.Transaction.EventID == nil {
+			return nil, nil
+		}
+		topic, eventID = domain.WebhookTopicTransactionStatus, *changed.Transaction.EventID
+		data = webhookTransactionData{Transaction: changed.Transaction, PreviousStatus: changed.PreviousStatus}
+	case eventbus.NameRecallNotice:
+		notice, err := eventbus.Decode[eventbus.RecallNotice](payload)
+		if err != nil || notice.Recall == nil {
+			return nil, nil
+		}
+		target := &webhookTarget{stakeholderIDs: []uuid.UUID{notice.StakeholderID}}
+		for _, unit := range notice.Units {
+			target.productIDs = append(target.productIDs, unit.ProductID)
+		}
+		return s.queueDeliveries(ctx, repos, message, domain.WebhookTopicRecallNotice, notice, target)
+	case eventbus.NameExcursionBreached:
+		// A breach is delivered to whoever held the product; nobody is told when nobody held it
+		breached, err := eventbus.Decode[eventbus.ExcursionBreached](payload)
+		if err != nil || breached.Incident == nil || breached.Incident.HolderID == nil {
+			return nil, nil
+		}
+		target := &webhookTarget{
+			stakeholderIDs: []uuid.UUID{*breached.Incident.HolderID},
+			productIDs:     append([]uuid.UUID{breached.Incident.ProductID}, breached.Quarantined...),
+		}
+		return s.queueDeliveries(ctx, repos, message, domain.WebhookTopicExcursionBreached, breached, target)
+	default:
+		return nil, nil
+	}
+
+	event, err := repos.SupplyChainEvent.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if data == nil {
+		data = event
+	}
+
+	stakeholderIDs, err := involvedStakeholders(ctx, repos.Product, repos.CustodyProjection, event)
+	if err != nil {
+		return nil, err
+	}
+	target := &webhookTarget{stakeholderIDs: stakeholderIDs, eventID: &event.ID, eventType: event.EventType}
+	if event.ProductID != nil {
+		target.productIDs = []uuid.UUID{*event.ProductID}
+	}
+	return s.queueDeliveries(ctx, repos, message, topic, data, target)
+}
+
+// queueDeliveries builds one delivery of data to every active subscription of the target that matches
+func (s *webhookService) queueDeliveries(ctx context.Context, repos *repository.RepositoriesManagers, message *domain.OutboxMessage, topic string, data any, target *webhookTarget) ([]*domain.WebhookDelivery, error) {
+	subscriptions, err := repos.Webhook.GetActiveByStakeholders(ctx, target.stakeholderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+
+	var matched []*domain.WebhookSubscription
+	for _, subscription := range subscriptions {
+		if subscriptionMatches(subscription, topic, target) {
+			matched = append(matched, subscription)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	// The envelope ID is the message ID, so a receiver can tell the same change apart from a new one
+	payloadJSON, err := json.Marshal(webhookEnvelope{ID: message.ID, Topic: topic, CreatedAt: message.OccurredAt.UTC(), Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := make([]*domain.WebhookDelivery, 0, len(matched))
+	for _, subscription := range matched {
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			Topic:          topic,
+			EventID:        target.eventID,
+			Payload:        string(payloadJSON),
+			Status:         domain.WebhookDeliveryStatusPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	return deliveries, nil
+}
+
+func subscriptionMatches(subscription *domain.WebhookSubscription, topic string, target *webhookTarget) bool {
+	if len(subscription.Topics) > 0 && !slices.Contains(subscription.Topics, topic) {
+		return false
+	}
+	if len(subscription.EventTypes) > 0 && target.eventType != "" && !slices.Contains(subscription.EventTypes, target.eventType) {
+		return false
+	}
+	if len(subscription.ProductIDs) > 0 && !slices.ContainsFunc(target.productIDs, func(id uuid.UUID) bool {
+		return slices.Contains(subscription.ProductIDs, id)
+	}) {
+		return false
+	}
+	return true
+}
+
+// subscribedTo reports whether a stakeholder has an active subscription that receives topic
+func subscribedTo(ctx context.Context, webhooks repository.WebhookRepository, stakeholderID uuid.UUID, topic string) (bool, error) {
+	subscriptions, err := webhooks.GetActiveByStakeholders(ctx, []uuid.UUID{stakeholderID})
+	if err != nil {
+		return false, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	for _, subscription := range subscriptions {
+		if len(subscription.Topics) == 0 || slices.Contains(subscription.Topics, topic) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Run queues deliveries for new outbox messages and dispatches due deliveries until ctx is
+// cancelled, waking early whenever a replay is queued through this service. Several dispatchers
+// may run against the same database.
+func (s *webhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain full batches before waiting again
+		for {
+			read, err := s.QueueFromOutbox(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to queue webhooks", "error", err)
+			}
+			if err != nil || read < outboxBatchSize {
+				break
+			}
+		}
+		for {
+			dispatched, err := s.DispatchDue(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to dispatch webhooks", "error", err)
+			}
+			if err != nil || dispatched < webhookDispatchBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// DispatchDue makes one attempt at each delivery that is due, up to one batch, and reports how
+// many were attempted
+func (s *webhookService) DispatchDue(ctx context.Context) (int, error) {
+	deliveries, err := s.repo.ClaimDueDeliveries(ctx, time.Now(), webhookDeliveryLease, webhookDispatchBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, webhookDispatchConcurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(delivery *domain.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := s.attempt(ctx, delivery); err != nil {
+				slog.ErrorContext(ctx, "failed to record webhook attempt", "delivery_id", delivery.ID, "error", err)
+			}
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// attempt sends a delivery once and records the outcome: success, a retry after exponential
+// backoff, or failure once the attempts run out. Every failed attempt counts towards disabling
+// the endpoint; any success resets the count.
+func (s *webhookService) attempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	subscription := delivery.Subscription
+	now := time.Now()
+	if subscription == nil || !subscription.IsActive {
+		return s.repo.UpdateDelivery(ctx, delivery.ID, map[string]interface{}{
+			"status":          domain.WebhookDeliveryStatusFailed,
+			"next_attempt_at": nil,
+			"last_error":      "endpoint disabled",
+		})
+	}
+
+	attempts := delivery.Attempts + 1
+	status, sendErr := s.send(ctx, subscription, delivery, now)
+	updates := map[string]interface{}{
+		"attempts":        attempts,
+		"last_attempt_at": now,
+		"response_status": status,
+	}
+
+	if sendErr == nil {
+		updates["status"] = domain.WebhookDeliveryStatusSucceeded
+		updates["next_attempt_at"] = nil
+		updates["delivered_at"] = now
+		updates["last_error"] = nil
+		if err := s.repo.UpdateDelivery(ctx, delivery.ID, updates); err != nil {
+			return err
+		}
+		if subscription.ConsecutiveFailures > 0 {
+			return s.repo.UpdateSubscription(ctx, subscription.ID, map[string]interface{}{"consecutive_failures": 0})
+		}
+		return nil
+	}
+
+	updates["last_error"] = sendErr.Error()
+	if attempts >= webhookMaxAttempts {
+		updates["status"] = domain.WebhookDeliveryStatusFailed
+		updates["next_attempt_at"] = nil
+	} else {
+		updates["next_attempt_at"] = now.Add(webhookRetryBase << (attempts - 1))
+	}
+	if err := s.repo.UpdateDelivery(ctx, delivery.ID, updates); err != nil {
+		return err
+	}
+
+	failures, err := s.repo.RecordFailure(ctx, subscription.ID)
+	if err != nil {
+		return err
+	}
+	if failures >= webhookDisableThreshold {
+		reason := fmt.Sprintf("disabled after %d consecutive failed deliveries; last error: %v", failures, sendErr)
+		slog.WarnContext(ctx, "webhook endpoint disabled", "subscription_id", subscription.ID, "url", subscription.URL, "failures", failures)
+		return s.repo.UpdateSubscription(ctx, subscription.ID, map[string]interface{}{
+			"is_active":       false,
+			"disabled_at":     now,
+			"disabled_reason": reason,
+		})
+	}
+	return nil
+}
+
+// send POSTs the signed payload and returns the response status, nil when no response arrived
+func (s *webhookService) send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery, now time.Time) (*int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SupplyChainTracker-Webhooks/1.0")
+	req.Header.Set(webhook.HeaderDelivery, delivery.ID.String())
+	req.Header.Set(webhook.HeaderTopic, delivery.Topic)
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(subscription.Secret, now, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	if status >= 200 && status < 300 {
+		return &status, nil
+	}
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseError))
+	return &status, fmt.Errorf("endpoint answered %d: %s", status, bytes.TrimSpace(excerpt))
+}
+
+// notify wakes a running dispatcher without blocking when one is already due to run
+func (s *webhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// validateSubscription checks the endpoint URL (https only in production, public addresses only)
+// and the filters
+func (s *webhookService) validateSubscription(ctx context.Context, endpoint string, topics, eventTypes []string, productIDs []uuid.UUID) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidWebhook)
+	}
+	if u.Scheme != "https" && conf.IsProduction() {
+		return fmt.Errorf("%w: url must use https", ErrInvalidWebhook)
+	}
+	// Sends are checked again when they connect, in case the host has been re-pointed since
+	if err := webhook.CheckHost(ctx, u.Hostname()); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	for _, topic := range topics {
+		if !domain.IsValidWebhookTopic(topic) {
+			return fmt.Errorf("%w: unknown topic %q", ErrInvalidWebhook, topic)
+		}
+	}
+	for _, eventType := range eventTypes {
+		if !domain.IsValidEventType(eventType) {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, eventType)
+		}
+	}
+
+	if len(productIDs) > 0 {
+		products, err := s.productRepo.GetByIDs(ctx, productIDs)
+		if err != nil {
+			return fmt.Errorf("failed to validate products: %w", err)
+		}
+		if len(products) != len(productIDs) {
+			return fmt.Errorf("%w: product_ids contains unknown or repeated products", ErrInvalidWebhook)
+		}
+	}
+	return nil
+}
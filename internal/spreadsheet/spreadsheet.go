@@ -0,0 +1,180 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Supported file formats
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported file format")
+	ErrEmptySheet        = errors.New("file has no header row")
+	ErrTooManyRows       = errors.New("file has too many rows")
+)
+
+// Sheet is a table read from a CSV file or the first worksheet of an XLSX workbook. Header names
+// are lower-cased and trimmed; Rows hold the data rows, each padded to the header's width.
+type Sheet struct {
+	Header []string
+	Rows   [][]string
+
+	columns map[string]int
+}
+
+// Row is one data row with its 1-based position in the file, counting the header as row 1
+type Row struct {
+	Line  int
+	sheet *Sheet
+	cells []string
+}
+
+// FormatOf picks the format from a file name's extension
+func FormatOf(filename string) (string, error) {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), ".")) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Read parses a whole file. Blank rows are skipped; more than maxRows data rows is an error.
+func Read(r io.Reader, format string, maxRows int) (*Sheet, error) {
+	var records [][]string
+	var err error
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(r)
+	case FormatXLSX:
+		records, err = readXLSX(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrEmptySheet
+	}
+
+	sheet := &Sheet{columns: make(map[string]int)}
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		sheet.Header = append(sheet.Header, name)
+		if _, exists := sheet.columns[name]; !exists && name != "" {
+			sheet.columns[name] = i
+		}
+	}
+
+	for _, record := range records[1:] {
+		if blank(record) {
+			sheet.Rows = append(sheet.Rows, nil)
+			continue
+		}
+		if len(sheet.Rows) >= maxRows {
+			return nil, fmt.Errorf("%w: at most %d", ErrTooManyRows, maxRows)
+		}
+		cells := make([]string, len(sheet.Header))
+		copy(cells, record)
+		sheet.Rows = append(sheet.Rows, cells)
+	}
+
+	return sheet, nil
+}
+
+// Each calls fn for every non-blank data row, in file order
+func (s *Sheet) Each(fn func(row *Row) error) error {
+	for i, cells := range s.Rows {
+		if cells == nil {
+			continue
+		}
+		if err := fn(&Row{Line: i + 2, sheet: s, cells: cells}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Len is the number of non-blank data rows
+func (s *Sheet) Len() int {
+	n := 0
+	for _, cells := range s.Rows {
+		if cells != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Has reports whether the header has a column
+func (s *Sheet) Has(column string) bool {
+	_, ok := s.columns[column]
+	return ok
+}
+
+// Get returns the trimmed cell of a column, "" when the column is absent
+func (r *Row) Get(column string) string {
+	i, ok := r.sheet.columns[column]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(r.cells[i])
+}
+
+// Optional returns nil for an empty cell
+func (r *Row) Optional(column string) *string {
+	if value := r.Get(column); value != "" {
+		return &value
+	}
+	return nil
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	return records, nil
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	workbook, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	defer workbook.Close()
+
+	sheets := workbook.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, ErrEmptySheet
+	}
+	records, err := workbook.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	return records, nil
+}
+
+func blank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
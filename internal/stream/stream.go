@@ -0,0 +1,74 @@
+package stream
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/koriebruh/suplyChainTrack/internal/domain"
+	"strconv"
+)
+
+var ErrInvalidCursor = errors.New("invalid stream cursor")
+
+// Cursor is a position in the stream: the outbox sequence number of the last message a client
+// saw. Sequence numbers follow commit order, so nothing can appear behind a cursor once it has
+// been handed out. Clients treat it as opaque and hand it back to resume after a reconnect.
+type Cursor struct {
+	Sequence int64
+}
+
+func (c Cursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.Sequence, 10)))
+}
+
+// After reports whether c comes later in the stream than other
+func (c Cursor) After(other Cursor) bool {
+	return c.Sequence > other.Sequence
+}
+
+func ParseCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	sequence, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || sequence < 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{Sequence: sequence}, nil
+}
+
+// Filter selects the events a subscription receives. Fields that are set are combined with AND;
+// an empty filter receives every event the connection may see.
+type Filter struct {
+	ID            string     `json:"id"`
+	ProductID     *uuid.UUID `json:"product_id"`
+	LotNumber     *string    `json:"lot_number"`
+	StakeholderID *uuid.UUID `json:"stakeholder_id"`
+	EventType     *string    `json:"event_type"`
+}
+
+// Matches checks an event whose Product is preloaded
+func (f Filter) Matches(event *domain.SupplyChainEvent) bool {
+	if f.ProductID != nil && (event.ProductID == nil || *event.ProductID != *f.ProductID) {
+		return false
+	}
+	if f.LotNumber != nil && (event.Product == nil || event.Product.LotNumber == nil || *event.Product.LotNumber != *f.LotNumber) {
+		return false
+	}
+	if f.StakeholderID != nil && (event.StakeholderID == nil || *event.StakeholderID != *f.StakeholderID) {
+		return false
+	}
+	if f.EventType != nil && event.EventType != *f.EventType {
+		return false
+	}
+	return true
+}
+
+// Message is one change delivered to a client: a new event or an event that was verified
+type Message struct {
+	Cursor        string                   `json:"cursor"`
+	Topic         string                   `json:"topic"` // 'event.recorded', 'event.verified'
+	Event         *domain.SupplyChainEvent `json:"event"`
+	Subscriptions []string                 `json:"subscriptions,omitempty"` // IDs of the filters it matched
+}
@@ -0,0 +1,65 @@
+package stream
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken  = errors.New("invalid stream token")
+	ErrExpiredToken  = errors.New("stream token has expired")
+	ErrMissingSecret = errors.New("stream token secret is not configured")
+)
+
+// Claims is what a connection is allowed to see. A nil StakeholderID sees every event; otherwise
+// only events that touch that stakeholder are delivered.
+type Claims struct {
+	StakeholderID *uuid.UUID `json:"sid,omitempty"`
+	ExpiresAt     int64      `json:"exp"`
+}
+
+// IssueToken signs claims into a compact token that browsers can pass in the connection URL,
+// where they cannot set the API key header
+func IssueToken(secret string, claims Claims) (string, error) {
+	if secret == "" {
+		return "", ErrMissingSecret
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	return body + "." + sign(secret, body), nil
+}
+
+func VerifyToken(secret, token string, now time.Time) (*Claims, error) {
+	body, signature, ok := strings.Cut(token, ".")
+	if secret == "" || !ok || !hmac.Equal([]byte(signature), []byte(sign(secret, body))) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+	return &claims, nil
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
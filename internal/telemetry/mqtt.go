@@ -0,0 +1,169 @@
+// Package telemetry bridges sensor readings published over MQTT into the telemetry service.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/goccy/go-json"
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/dto"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	// flushReadings is how many readings the bridge collects before it ingests them
+	flushReadings = 1000
+
+	// flushInterval is how long a reading waits at most before the bridge ingests it
+	flushInterval = time.Second
+
+	// maxMessageReadings caps the readings of one MQTT message, which keeps every flush within
+	// the batch limit of the telemetry service
+	maxMessageReadings = 1000
+)
+
+// Sink stores the readings the bridge receives
+type Sink interface {
+	Ingest(ctx context.Context, batch *dto.TelemetryBatch) (*dto.TelemetryIngestResult, error)
+}
+
+// Bridge subscribes to sensor readings with QoS 1 and ingests them in batches. A message is
+// acknowledged only once its readings are stored, so with a persistent session the broker
+// redelivers whatever was in flight when the bridge stopped or the database failed; the unique
+// (device, recorded_at) index drops the readings stored twice.
+//
+// A message carries one reading or an array of them in the JSON of POST /telemetry. A reading
+// naming neither device_id nor serial belongs to the device whose serial is the last level of
+// the topic, e.g. supplychain/telemetry/SN-0042.
+type Bridge struct {
+	config   conf.MQTTConfig
+	sink     Sink
+	messages chan mqtt.Message
+}
+
+func NewBridge(config conf.MQTTConfig, sink Sink) *Bridge {
+	return &Bridge{config: config, sink: sink, messages: make(chan mqtt.Message, flushReadings)}
+}
+
+// Run connects to the broker and ingests readings until ctx is cancelled
+func (b *Bridge) Run(ctx context.Context) error {
+	options := mqtt.NewClientOptions().
+		AddBroker(b.config.BrokerURL).
+		SetClientID(b.config.ClientID).
+		SetUsername(b.config.Username).
+		SetPassword(b.config.Password).
+		SetCleanSession(false).
+		SetAutoAckDisabled(true).
+		SetOrderMatters(false).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(5 * time.Second).
+		SetOnConnectHandler(func(client mqtt.Client) {
+			// Subscribe on every connect; the broker keeps the session but not always the subscription.
+			// Handlers run on their own goroutines, so one waiting for a batch to flush does not
+			// stall the connection.
+			token := client.Subscribe(b.config.Topic, 1, func(_ mqtt.Client, message mqtt.Message) {
+				select {
+				case b.messages <- message:
+				case <-ctx.Done():
+				}
+			})
+			if token.Wait() && token.Error() != nil {
+				slog.ErrorContext(ctx, "failed to subscribe to telemetry", "topic", b.config.Topic, "error", token.Error())
+			}
+		}).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			slog.WarnContext(ctx, "lost connection to MQTT broker", "error", err)
+		})
+
+	client := mqtt.NewClient(options)
+	if token := client.Connect(); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("failed to connect to MQTT broker: %w", token.Error())
+	}
+	defer client.Disconnect(250)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var pending []mqtt.Message
+	var readings []*dto.TelemetryReadingInput
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		if len(readings) > 0 {
+			result, err := b.sink.Ingest(ctx, &dto.TelemetryBatch{Readings: readings})
+			if err != nil {
+				// Keep the batch and retry on the next tick; unacknowledged messages are redelivered
+				// if the bridge stops first
+				slog.ErrorContext(ctx, "failed to ingest telemetry", "readings", len(readings), "error", err)
+				return
+			}
+			for _, rejected := range result.Rejected {
+				slog.WarnContext(ctx, "telemetry reading rejected", "error", rejected.Error)
+			}
+		}
+		for _, message := range pending {
+			message.Ack()
+		}
+		pending, readings = nil, nil
+	}
+
+	for {
+		// While a full batch fails to ingest, stop taking messages; the broker holds the rest
+		incoming := b.messages
+		if len(readings) >= flushReadings {
+			incoming = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case message := <-incoming:
+			decoded, err := decode(message)
+			if err != nil {
+				// Redelivery cannot fix a malformed payload, so it is acknowledged and dropped
+				slog.WarnContext(ctx, "dropping telemetry message", "topic", message.Topic(), "error", err)
+			}
+			pending = append(pending, message)
+			readings = append(readings, decoded...)
+			if len(readings) >= flushReadings {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// decode reads the readings of a message, naming the topic's device where a reading names none
+func decode(message mqtt.Message) ([]*dto.TelemetryReadingInput, error) {
+	payload := bytes.TrimSpace(message.Payload())
+
+	var readings []*dto.TelemetryReadingInput
+	if len(payload) > 0 && payload[0] == '[' {
+		if err := json.Unmarshal(payload, &readings); err != nil {
+			return nil, err
+		}
+	} else {
+		var reading dto.TelemetryReadingInput
+		if err := json.Unmarshal(payload, &reading); err != nil {
+			return nil, err
+		}
+		readings = append(readings, &reading)
+	}
+	if len(readings) > maxMessageReadings {
+		return nil, fmt.Errorf("at most %d readings per message, got %d", maxMessageReadings, len(readings))
+	}
+
+	serial := message.Topic()[strings.LastIndex(message.Topic(), "/")+1:]
+	for _, reading := range readings {
+		if reading != nil && reading.DeviceID == nil && reading.Serial == nil && serial != "" {
+			reading.Serial = &serial
+		}
+	}
+	return readings, nil
+}
@@ -0,0 +1,633 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: supplychain/v1/blockchain.proto
+
+package supplychainv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateTransactionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EventId         *string                `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3,oneof" json:"event_id,omitempty"`
+	TransactionHash string                 `protobuf:"bytes,2,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	BlockNumber     *int64                 `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3,oneof" json:"block_number,omitempty"`
+	GasUsed         *int64                 `protobuf:"varint,4,opt,name=gas_used,json=gasUsed,proto3,oneof" json:"gas_used,omitempty"`
+	Status          string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // pending, confirmed or failed
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateTransactionRequest) Reset() {
+	*x = CreateTransactionRequest{}
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransactionRequest) ProtoMessage() {}
+
+func (x *CreateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_blockchain_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateTransactionRequest) GetEventId() string {
+	if x != nil && x.EventId != nil {
+		return *x.EventId
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetBlockNumber() int64 {
+	if x != nil && x.BlockNumber != nil {
+		return *x.BlockNumber
+	}
+	return 0
+}
+
+func (x *CreateTransactionRequest) GetGasUsed() int64 {
+	if x != nil && x.GasUsed != nil {
+		return *x.GasUsed
+	}
+	return 0
+}
+
+func (x *CreateTransactionRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_blockchain_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTransactionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTransactionByHashRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionHash string                 `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetTransactionByHashRequest) Reset() {
+	*x = GetTransactionByHashRequest{}
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionByHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionByHashRequest) ProtoMessage() {}
+
+func (x *GetTransactionByHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionByHashRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionByHashRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_blockchain_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTransactionByHashRequest) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+type ListTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       *string                `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3,oneof" json:"event_id,omitempty"`
+	Status        *string                `protobuf:"bytes,2,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // default 10
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Cursor        *string                `protobuf:"bytes,5,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"` // next_cursor of the previous page; replaces offset
+	Count         string                 `protobuf:"bytes,6,opt,name=count,proto3" json:"count,omitempty"`         // exact, estimated or none
+	Sort          string                 `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`           // comma separated fields, - for descending, e.g. -block_number
+	Where         []*Predicate           `protobuf:"bytes,8,rep,name=where,proto3" json:"where,omitempty"`         // all must match
+	Include       []string               `protobuf:"bytes,9,rep,name=include,proto3" json:"include,omitempty"`     // relations to load: event
+	Fields        []string               `protobuf:"bytes,10,rep,name=fields,proto3" json:"fields,omitempty"`      // return only these fields (and id); every field when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_blockchain_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTransactionsRequest) GetEventId() string {
+	if x != nil && x.EventId != nil {
+		return *x.EventId
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetWhere() []*Predicate {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Transactions  []*BlockchainTransaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Page          *Page                    `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_blockchain_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*BlockchainTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type UpdateTransactionStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	BlockNumber   *int64                 `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3,oneof" json:"block_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTransactionStatusRequest) Reset() {
+	*x = UpdateTransactionStatusRequest{}
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTransactionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTransactionStatusRequest) ProtoMessage() {}
+
+func (x *UpdateTransactionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTransactionStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTransactionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_blockchain_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateTransactionStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTransactionStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateTransactionStatusRequest) GetBlockNumber() int64 {
+	if x != nil && x.BlockNumber != nil {
+		return *x.BlockNumber
+	}
+	return 0
+}
+
+type ListTransactionsByEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsByEventRequest) Reset() {
+	*x = ListTransactionsByEventRequest{}
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsByEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsByEventRequest) ProtoMessage() {}
+
+func (x *ListTransactionsByEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsByEventRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsByEventRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_blockchain_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTransactionsByEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+type ListTransactionsByEventResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Transactions  []*BlockchainTransaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsByEventResponse) Reset() {
+	*x = ListTransactionsByEventResponse{}
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsByEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsByEventResponse) ProtoMessage() {}
+
+func (x *ListTransactionsByEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_blockchain_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsByEventResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsByEventResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_blockchain_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTransactionsByEventResponse) GetTransactions() []*BlockchainTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+var File_supplychain_v1_blockchain_proto protoreflect.FileDescriptor
+
+const file_supplychain_v1_blockchain_proto_rawDesc = "" +
+	"\n" +
+	"\x1fsupplychain/v1/blockchain.proto\x12\x0esupplychain.v1\x1a\x1asupplychain/v1/types.proto\"\xf0\x01\n" +
+	"\x18CreateTransactionRequest\x12\x1e\n" +
+	"\bevent_id\x18\x01 \x01(\tH\x00R\aeventId\x88\x01\x01\x12)\n" +
+	"\x10transaction_hash\x18\x02 \x01(\tR\x0ftransactionHash\x12&\n" +
+	"\fblock_number\x18\x03 \x01(\x03H\x01R\vblockNumber\x88\x01\x01\x12\x1e\n" +
+	"\bgas_used\x18\x04 \x01(\x03H\x02R\agasUsed\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06statusB\v\n" +
+	"\t_event_idB\x0f\n" +
+	"\r_block_numberB\v\n" +
+	"\t_gas_used\"'\n" +
+	"\x15GetTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x1bGetTransactionByHashRequest\x12)\n" +
+	"\x10transaction_hash\x18\x01 \x01(\tR\x0ftransactionHash\"\xd1\x02\n" +
+	"\x17ListTransactionsRequest\x12\x1e\n" +
+	"\bevent_id\x18\x01 \x01(\tH\x00R\aeventId\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x02 \x01(\tH\x01R\x06status\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\x06cursor\x18\x05 \x01(\tH\x02R\x06cursor\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\tR\x05count\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\x12/\n" +
+	"\x05where\x18\b \x03(\v2\x19.supplychain.v1.PredicateR\x05where\x12\x18\n" +
+	"\ainclude\x18\t \x03(\tR\ainclude\x12\x16\n" +
+	"\x06fields\x18\n" +
+	" \x03(\tR\x06fieldsB\v\n" +
+	"\t_event_idB\t\n" +
+	"\a_statusB\t\n" +
+	"\a_cursor\"\x8f\x01\n" +
+	"\x18ListTransactionsResponse\x12I\n" +
+	"\ftransactions\x18\x01 \x03(\v2%.supplychain.v1.BlockchainTransactionR\ftransactions\x12(\n" +
+	"\x04page\x18\x02 \x01(\v2\x14.supplychain.v1.PageR\x04page\"\x81\x01\n" +
+	"\x1eUpdateTransactionStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12&\n" +
+	"\fblock_number\x18\x03 \x01(\x03H\x00R\vblockNumber\x88\x01\x01B\x0f\n" +
+	"\r_block_number\";\n" +
+	"\x1eListTransactionsByEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\"l\n" +
+	"\x1fListTransactionsByEventResponse\x12I\n" +
+	"\ftransactions\x18\x01 \x03(\v2%.supplychain.v1.BlockchainTransactionR\ftransactions2\x9a\x05\n" +
+	"\x11BlockchainService\x12d\n" +
+	"\x11CreateTransaction\x12(.supplychain.v1.CreateTransactionRequest\x1a%.supplychain.v1.BlockchainTransaction\x12^\n" +
+	"\x0eGetTransaction\x12%.supplychain.v1.GetTransactionRequest\x1a%.supplychain.v1.BlockchainTransaction\x12j\n" +
+	"\x14GetTransactionByHash\x12+.supplychain.v1.GetTransactionByHashRequest\x1a%.supplychain.v1.BlockchainTransaction\x12e\n" +
+	"\x10ListTransactions\x12'.supplychain.v1.ListTransactionsRequest\x1a(.supplychain.v1.ListTransactionsResponse\x12p\n" +
+	"\x17UpdateTransactionStatus\x12..supplychain.v1.UpdateTransactionStatusRequest\x1a%.supplychain.v1.BlockchainTransaction\x12z\n" +
+	"\x17ListTransactionsByEvent\x12..supplychain.v1.ListTransactionsByEventRequest\x1a/.supplychain.v1.ListTransactionsByEventResponseBJZHgithub.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1;supplychainv1b\x06proto3"
+
+var (
+	file_supplychain_v1_blockchain_proto_rawDescOnce sync.Once
+	file_supplychain_v1_blockchain_proto_rawDescData []byte
+)
+
+func file_supplychain_v1_blockchain_proto_rawDescGZIP() []byte {
+	file_supplychain_v1_blockchain_proto_rawDescOnce.Do(func() {
+		file_supplychain_v1_blockchain_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_supplychain_v1_blockchain_proto_rawDesc), len(file_supplychain_v1_blockchain_proto_rawDesc)))
+	})
+	return file_supplychain_v1_blockchain_proto_rawDescData
+}
+
+var file_supplychain_v1_blockchain_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_supplychain_v1_blockchain_proto_goTypes = []any{
+	(*CreateTransactionRequest)(nil),        // 0: supplychain.v1.CreateTransactionRequest
+	(*GetTransactionRequest)(nil),           // 1: supplychain.v1.GetTransactionRequest
+	(*GetTransactionByHashRequest)(nil),     // 2: supplychain.v1.GetTransactionByHashRequest
+	(*ListTransactionsRequest)(nil),         // 3: supplychain.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),        // 4: supplychain.v1.ListTransactionsResponse
+	(*UpdateTransactionStatusRequest)(nil),  // 5: supplychain.v1.UpdateTransactionStatusRequest
+	(*ListTransactionsByEventRequest)(nil),  // 6: supplychain.v1.ListTransactionsByEventRequest
+	(*ListTransactionsByEventResponse)(nil), // 7: supplychain.v1.ListTransactionsByEventResponse
+	(*Predicate)(nil),                       // 8: supplychain.v1.Predicate
+	(*BlockchainTransaction)(nil),           // 9: supplychain.v1.BlockchainTransaction
+	(*Page)(nil),                            // 10: supplychain.v1.Page
+}
+var file_supplychain_v1_blockchain_proto_depIdxs = []int32{
+	8,  // 0: supplychain.v1.ListTransactionsRequest.where:type_name -> supplychain.v1.Predicate
+	9,  // 1: supplychain.v1.ListTransactionsResponse.transactions:type_name -> supplychain.v1.BlockchainTransaction
+	10, // 2: supplychain.v1.ListTransactionsResponse.page:type_name -> supplychain.v1.Page
+	9,  // 3: supplychain.v1.ListTransactionsByEventResponse.transactions:type_name -> supplychain.v1.BlockchainTransaction
+	0,  // 4: supplychain.v1.BlockchainService.CreateTransaction:input_type -> supplychain.v1.CreateTransactionRequest
+	1,  // 5: supplychain.v1.BlockchainService.GetTransaction:input_type -> supplychain.v1.GetTransactionRequest
+	2,  // 6: supplychain.v1.BlockchainService.GetTransactionByHash:input_type -> supplychain.v1.GetTransactionByHashRequest
+	3,  // 7: supplychain.v1.BlockchainService.ListTransactions:input_type -> supplychain.v1.ListTransactionsRequest
+	5,  // 8: supplychain.v1.BlockchainService.UpdateTransactionStatus:input_type -> supplychain.v1.UpdateTransactionStatusRequest
+	6,  // 9: supplychain.v1.BlockchainService.ListTransactionsByEvent:input_type -> supplychain.v1.ListTransactionsByEventRequest
+	9,  // 10: supplychain.v1.BlockchainService.CreateTransaction:output_type -> supplychain.v1.BlockchainTransaction
+	9,  // 11: supplychain.v1.BlockchainService.GetTransaction:output_type -> supplychain.v1.BlockchainTransaction
+	9,  // 12: supplychain.v1.BlockchainService.GetTransactionByHash:output_type -> supplychain.v1.BlockchainTransaction
+	4,  // 13: supplychain.v1.BlockchainService.ListTransactions:output_type -> supplychain.v1.ListTransactionsResponse
+	9,  // 14: supplychain.v1.BlockchainService.UpdateTransactionStatus:output_type -> supplychain.v1.BlockchainTransaction
+	7,  // 15: supplychain.v1.BlockchainService.ListTransactionsByEvent:output_type -> supplychain.v1.ListTransactionsByEventResponse
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_supplychain_v1_blockchain_proto_init() }
+func file_supplychain_v1_blockchain_proto_init() {
+	if File_supplychain_v1_blockchain_proto != nil {
+		return
+	}
+	file_supplychain_v1_types_proto_init()
+	file_supplychain_v1_blockchain_proto_msgTypes[0].OneofWrappers = []any{}
+	file_supplychain_v1_blockchain_proto_msgTypes[3].OneofWrappers = []any{}
+	file_supplychain_v1_blockchain_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_supplychain_v1_blockchain_proto_rawDesc), len(file_supplychain_v1_blockchain_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_supplychain_v1_blockchain_proto_goTypes,
+		DependencyIndexes: file_supplychain_v1_blockchain_proto_depIdxs,
+		MessageInfos:      file_supplychain_v1_blockchain_proto_msgTypes,
+	}.Build()
+	File_supplychain_v1_blockchain_proto = out.File
+	file_supplychain_v1_blockchain_proto_goTypes = nil
+	file_supplychain_v1_blockchain_proto_depIdxs = nil
+}
@@ -0,0 +1,1008 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: supplychain/v1/product.proto
+
+package supplychainv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateProductRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Sku            string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description    *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Category       *string                `protobuf:"bytes,4,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	ManufacturerId *string                `protobuf:"bytes,5,opt,name=manufacturer_id,json=manufacturerId,proto3,oneof" json:"manufacturer_id,omitempty"`
+	LotNumber      *string                `protobuf:"bytes,6,opt,name=lot_number,json=lotNumber,proto3,oneof" json:"lot_number,omitempty"`
+	SerialNumber   *string                `protobuf:"bytes,7,opt,name=serial_number,json=serialNumber,proto3,oneof" json:"serial_number,omitempty"`
+	Metadata       *structpb.Struct       `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetManufacturerId() string {
+	if x != nil && x.ManufacturerId != nil {
+		return *x.ManufacturerId
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetLotNumber() string {
+	if x != nil && x.LotNumber != nil {
+		return *x.LotNumber
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetSerialNumber() string {
+	if x != nil && x.SerialNumber != nil {
+		return *x.SerialNumber
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetProductBySKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductBySKURequest) Reset() {
+	*x = GetProductBySKURequest{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductBySKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductBySKURequest) ProtoMessage() {}
+
+func (x *GetProductBySKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductBySKURequest.ProtoReflect.Descriptor instead.
+func (*GetProductBySKURequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetProductBySKURequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+// Unset fields are left unchanged
+type UpdateProductRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description    *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Category       *string                `protobuf:"bytes,4,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	ManufacturerId *string                `protobuf:"bytes,5,opt,name=manufacturer_id,json=manufacturerId,proto3,oneof" json:"manufacturer_id,omitempty"`
+	LotNumber      *string                `protobuf:"bytes,6,opt,name=lot_number,json=lotNumber,proto3,oneof" json:"lot_number,omitempty"`
+	SerialNumber   *string                `protobuf:"bytes,7,opt,name=serial_number,json=serialNumber,proto3,oneof" json:"serial_number,omitempty"`
+	Metadata       *structpb.Struct       `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetManufacturerId() string {
+	if x != nil && x.ManufacturerId != nil {
+		return *x.ManufacturerId
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetLotNumber() string {
+	if x != nil && x.LotNumber != nil {
+		return *x.LotNumber
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetSerialNumber() string {
+	if x != nil && x.SerialNumber != nil {
+		return *x.SerialNumber
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{5}
+}
+
+type ListProductsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Category       *string                `protobuf:"bytes,1,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	ManufacturerId *string                `protobuf:"bytes,2,opt,name=manufacturer_id,json=manufacturerId,proto3,oneof" json:"manufacturer_id,omitempty"`
+	Sku            *string                `protobuf:"bytes,3,opt,name=sku,proto3,oneof" json:"sku,omitempty"`
+	Name           *string                `protobuf:"bytes,4,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	LotNumber      *string                `protobuf:"bytes,5,opt,name=lot_number,json=lotNumber,proto3,oneof" json:"lot_number,omitempty"`
+	Limit          int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"` // default 10
+	Offset         int32                  `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	Cursor         *string                `protobuf:"bytes,8,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"` // next_cursor of the previous page; replaces offset
+	Count          string                 `protobuf:"bytes,9,opt,name=count,proto3" json:"count,omitempty"`         // exact, estimated or none
+	Sort           string                 `protobuf:"bytes,10,opt,name=sort,proto3" json:"sort,omitempty"`          // comma separated fields, - for descending, e.g. category,-created_at
+	Where          []*Predicate           `protobuf:"bytes,11,rep,name=where,proto3" json:"where,omitempty"`        // all must match
+	Include        []string               `protobuf:"bytes,12,rep,name=include,proto3" json:"include,omitempty"`    // relations to load: manufacturer
+	Fields         []string               `protobuf:"bytes,13,rep,name=fields,proto3" json:"fields,omitempty"`      // return only these fields (and id); every field when empty
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListProductsRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetManufacturerId() string {
+	if x != nil && x.ManufacturerId != nil {
+		return *x.ManufacturerId
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSku() string {
+	if x != nil && x.Sku != nil {
+		return *x.Sku
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetLotNumber() string {
+	if x != nil && x.LotNumber != nil {
+		return *x.LotNumber
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetWhere() []*Predicate {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type GetProductStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductStatsRequest) Reset() {
+	*x = GetProductStatsRequest{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductStatsRequest) ProtoMessage() {}
+
+func (x *GetProductStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductStatsRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetProductStatsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ProductStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	TotalEvents     int64                  `protobuf:"varint,2,opt,name=total_events,json=totalEvents,proto3" json:"total_events,omitempty"`
+	VerifiedEvents  int64                  `protobuf:"varint,3,opt,name=verified_events,json=verifiedEvents,proto3" json:"verified_events,omitempty"`
+	CurrentLocation *string                `protobuf:"bytes,4,opt,name=current_location,json=currentLocation,proto3,oneof" json:"current_location,omitempty"`
+	CurrentHolder   *string                `protobuf:"bytes,5,opt,name=current_holder,json=currentHolder,proto3,oneof" json:"current_holder,omitempty"`
+	CurrentState    *string                `protobuf:"bytes,6,opt,name=current_state,json=currentState,proto3,oneof" json:"current_state,omitempty"`
+	LastStakeholder *string                `protobuf:"bytes,7,opt,name=last_stakeholder,json=lastStakeholder,proto3,oneof" json:"last_stakeholder,omitempty"`
+	LastActivity    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProductStats) Reset() {
+	*x = ProductStats{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductStats) ProtoMessage() {}
+
+func (x *ProductStats) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductStats.ProtoReflect.Descriptor instead.
+func (*ProductStats) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProductStats) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductStats) GetTotalEvents() int64 {
+	if x != nil {
+		return x.TotalEvents
+	}
+	return 0
+}
+
+func (x *ProductStats) GetVerifiedEvents() int64 {
+	if x != nil {
+		return x.VerifiedEvents
+	}
+	return 0
+}
+
+func (x *ProductStats) GetCurrentLocation() string {
+	if x != nil && x.CurrentLocation != nil {
+		return *x.CurrentLocation
+	}
+	return ""
+}
+
+func (x *ProductStats) GetCurrentHolder() string {
+	if x != nil && x.CurrentHolder != nil {
+		return *x.CurrentHolder
+	}
+	return ""
+}
+
+func (x *ProductStats) GetCurrentState() string {
+	if x != nil && x.CurrentState != nil {
+		return *x.CurrentState
+	}
+	return ""
+}
+
+func (x *ProductStats) GetLastStakeholder() string {
+	if x != nil && x.LastStakeholder != nil {
+		return *x.LastStakeholder
+	}
+	return ""
+}
+
+func (x *ProductStats) GetLastActivity() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivity
+	}
+	return nil
+}
+
+type ListProductsByManufacturerRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ManufacturerId string                 `protobuf:"bytes,1,opt,name=manufacturer_id,json=manufacturerId,proto3" json:"manufacturer_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListProductsByManufacturerRequest) Reset() {
+	*x = ListProductsByManufacturerRequest{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsByManufacturerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsByManufacturerRequest) ProtoMessage() {}
+
+func (x *ListProductsByManufacturerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsByManufacturerRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsByManufacturerRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListProductsByManufacturerRequest) GetManufacturerId() string {
+	if x != nil {
+		return x.ManufacturerId
+	}
+	return ""
+}
+
+type ListProductsByManufacturerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsByManufacturerResponse) Reset() {
+	*x = ListProductsByManufacturerResponse{}
+	mi := &file_supplychain_v1_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsByManufacturerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsByManufacturerResponse) ProtoMessage() {}
+
+func (x *ListProductsByManufacturerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsByManufacturerResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsByManufacturerResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListProductsByManufacturerResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+var File_supplychain_v1_product_proto protoreflect.FileDescriptor
+
+const file_supplychain_v1_product_proto_rawDesc = "" +
+	"\n" +
+	"\x1csupplychain/v1/product.proto\x12\x0esupplychain.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1asupplychain/v1/types.proto\"\x87\x03\n" +
+	"\x14CreateProductRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\x04 \x01(\tH\x01R\bcategory\x88\x01\x01\x12,\n" +
+	"\x0fmanufacturer_id\x18\x05 \x01(\tH\x02R\x0emanufacturerId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"lot_number\x18\x06 \x01(\tH\x03R\tlotNumber\x88\x01\x01\x12(\n" +
+	"\rserial_number\x18\a \x01(\tH\x04R\fserialNumber\x88\x01\x01\x123\n" +
+	"\bmetadata\x18\b \x01(\v2\x17.google.protobuf.StructR\bmetadataB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_categoryB\x12\n" +
+	"\x10_manufacturer_idB\r\n" +
+	"\v_lot_numberB\x10\n" +
+	"\x0e_serial_number\"#\n" +
+	"\x11GetProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"*\n" +
+	"\x16GetProductBySKURequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\"\x93\x03\n" +
+	"\x14UpdateProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\x04 \x01(\tH\x02R\bcategory\x88\x01\x01\x12,\n" +
+	"\x0fmanufacturer_id\x18\x05 \x01(\tH\x03R\x0emanufacturerId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"lot_number\x18\x06 \x01(\tH\x04R\tlotNumber\x88\x01\x01\x12(\n" +
+	"\rserial_number\x18\a \x01(\tH\x05R\fserialNumber\x88\x01\x01\x123\n" +
+	"\bmetadata\x18\b \x01(\v2\x17.google.protobuf.StructR\bmetadataB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_categoryB\x12\n" +
+	"\x10_manufacturer_idB\r\n" +
+	"\v_lot_numberB\x10\n" +
+	"\x0e_serial_number\"&\n" +
+	"\x14DeleteProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeleteProductResponse\"\xdc\x03\n" +
+	"\x13ListProductsRequest\x12\x1f\n" +
+	"\bcategory\x18\x01 \x01(\tH\x00R\bcategory\x88\x01\x01\x12,\n" +
+	"\x0fmanufacturer_id\x18\x02 \x01(\tH\x01R\x0emanufacturerId\x88\x01\x01\x12\x15\n" +
+	"\x03sku\x18\x03 \x01(\tH\x02R\x03sku\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x04 \x01(\tH\x03R\x04name\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"lot_number\x18\x05 \x01(\tH\x04R\tlotNumber\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\a \x01(\x05R\x06offset\x12\x1b\n" +
+	"\x06cursor\x18\b \x01(\tH\x05R\x06cursor\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\t \x01(\tR\x05count\x12\x12\n" +
+	"\x04sort\x18\n" +
+	" \x01(\tR\x04sort\x12/\n" +
+	"\x05where\x18\v \x03(\v2\x19.supplychain.v1.PredicateR\x05where\x12\x18\n" +
+	"\ainclude\x18\f \x03(\tR\ainclude\x12\x16\n" +
+	"\x06fields\x18\r \x03(\tR\x06fieldsB\v\n" +
+	"\t_categoryB\x12\n" +
+	"\x10_manufacturer_idB\x06\n" +
+	"\x04_skuB\a\n" +
+	"\x05_nameB\r\n" +
+	"\v_lot_numberB\t\n" +
+	"\a_cursor\"u\n" +
+	"\x14ListProductsResponse\x123\n" +
+	"\bproducts\x18\x01 \x03(\v2\x17.supplychain.v1.ProductR\bproducts\x12(\n" +
+	"\x04page\x18\x02 \x01(\v2\x14.supplychain.v1.PageR\x04page\"(\n" +
+	"\x16GetProductStatsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbf\x03\n" +
+	"\fProductStats\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\ftotal_events\x18\x02 \x01(\x03R\vtotalEvents\x12'\n" +
+	"\x0fverified_events\x18\x03 \x01(\x03R\x0everifiedEvents\x12.\n" +
+	"\x10current_location\x18\x04 \x01(\tH\x00R\x0fcurrentLocation\x88\x01\x01\x12*\n" +
+	"\x0ecurrent_holder\x18\x05 \x01(\tH\x01R\rcurrentHolder\x88\x01\x01\x12(\n" +
+	"\rcurrent_state\x18\x06 \x01(\tH\x02R\fcurrentState\x88\x01\x01\x12.\n" +
+	"\x10last_stakeholder\x18\a \x01(\tH\x03R\x0flastStakeholder\x88\x01\x01\x12?\n" +
+	"\rlast_activity\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\flastActivityB\x13\n" +
+	"\x11_current_locationB\x11\n" +
+	"\x0f_current_holderB\x10\n" +
+	"\x0e_current_stateB\x13\n" +
+	"\x11_last_stakeholder\"L\n" +
+	"!ListProductsByManufacturerRequest\x12'\n" +
+	"\x0fmanufacturer_id\x18\x01 \x01(\tR\x0emanufacturerId\"Y\n" +
+	"\"ListProductsByManufacturerResponse\x123\n" +
+	"\bproducts\x18\x01 \x03(\v2\x17.supplychain.v1.ProductR\bproducts2\xe6\x05\n" +
+	"\x0eProductService\x12N\n" +
+	"\rCreateProduct\x12$.supplychain.v1.CreateProductRequest\x1a\x17.supplychain.v1.Product\x12H\n" +
+	"\n" +
+	"GetProduct\x12!.supplychain.v1.GetProductRequest\x1a\x17.supplychain.v1.Product\x12R\n" +
+	"\x0fGetProductBySKU\x12&.supplychain.v1.GetProductBySKURequest\x1a\x17.supplychain.v1.Product\x12N\n" +
+	"\rUpdateProduct\x12$.supplychain.v1.UpdateProductRequest\x1a\x17.supplychain.v1.Product\x12\\\n" +
+	"\rDeleteProduct\x12$.supplychain.v1.DeleteProductRequest\x1a%.supplychain.v1.DeleteProductResponse\x12Y\n" +
+	"\fListProducts\x12#.supplychain.v1.ListProductsRequest\x1a$.supplychain.v1.ListProductsResponse\x12W\n" +
+	"\x0fGetProductStats\x12&.supplychain.v1.GetProductStatsRequest\x1a\x1c.supplychain.v1.ProductStats\x12\x83\x01\n" +
+	"\x1aListProductsByManufacturer\x121.supplychain.v1.ListProductsByManufacturerRequest\x1a2.supplychain.v1.ListProductsByManufacturerResponseBJZHgithub.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1;supplychainv1b\x06proto3"
+
+var (
+	file_supplychain_v1_product_proto_rawDescOnce sync.Once
+	file_supplychain_v1_product_proto_rawDescData []byte
+)
+
+func file_supplychain_v1_product_proto_rawDescGZIP() []byte {
+	file_supplychain_v1_product_proto_rawDescOnce.Do(func() {
+		file_supplychain_v1_product_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_supplychain_v1_product_proto_rawDesc), len(file_supplychain_v1_product_proto_rawDesc)))
+	})
+	return file_supplychain_v1_product_proto_rawDescData
+}
+
+var file_supplychain_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_supplychain_v1_product_proto_goTypes = []any{
+	(*CreateProductRequest)(nil),               // 0: supplychain.v1.CreateProductRequest
+	(*GetProductRequest)(nil),                  // 1: supplychain.v1.GetProductRequest
+	(*GetProductBySKURequest)(nil),             // 2: supplychain.v1.GetProductBySKURequest
+	(*UpdateProductRequest)(nil),               // 3: supplychain.v1.UpdateProductRequest
+	(*DeleteProductRequest)(nil),               // 4: supplychain.v1.DeleteProductRequest
+	(*DeleteProductResponse)(nil),              // 5: supplychain.v1.DeleteProductResponse
+	(*ListProductsRequest)(nil),                // 6: supplychain.v1.ListProductsRequest
+	(*ListProductsResponse)(nil),               // 7: supplychain.v1.ListProductsResponse
+	(*GetProductStatsRequest)(nil),             // 8: supplychain.v1.GetProductStatsRequest
+	(*ProductStats)(nil),                       // 9: supplychain.v1.ProductStats
+	(*ListProductsByManufacturerRequest)(nil),  // 10: supplychain.v1.ListProductsByManufacturerRequest
+	(*ListProductsByManufacturerResponse)(nil), // 11: supplychain.v1.ListProductsByManufacturerResponse
+	(*structpb.Struct)(nil),                    // 12: google.protobuf.Struct
+	(*Predicate)(nil),                          // 13: supplychain.v1.Predicate
+	(*Product)(nil),                            // 14: supplychain.v1.Product
+	(*Page)(nil),                               // 15: supplychain.v1.Page
+	(*timestamppb.Timestamp)(nil),              // 16: google.protobuf.Timestamp
+}
+var file_supplychain_v1_product_proto_depIdxs = []int32{
+	12, // 0: supplychain.v1.CreateProductRequest.metadata:type_name -> google.protobuf.Struct
+	12, // 1: supplychain.v1.UpdateProductRequest.metadata:type_name -> google.protobuf.Struct
+	13, // 2: supplychain.v1.ListProductsRequest.where:type_name -> supplychain.v1.Predicate
+	14, // 3: supplychain.v1.ListProductsResponse.products:type_name -> supplychain.v1.Product
+	15, // 4: supplychain.v1.ListProductsResponse.page:type_name -> supplychain.v1.Page
+	16, // 5: supplychain.v1.ProductStats.last_activity:type_name -> google.protobuf.Timestamp
+	14, // 6: supplychain.v1.ListProductsByManufacturerResponse.products:type_name -> supplychain.v1.Product
+	0,  // 7: supplychain.v1.ProductService.CreateProduct:input_type -> supplychain.v1.CreateProductRequest
+	1,  // 8: supplychain.v1.ProductService.GetProduct:input_type -> supplychain.v1.GetProductRequest
+	2,  // 9: supplychain.v1.ProductService.GetProductBySKU:input_type -> supplychain.v1.GetProductBySKURequest
+	3,  // 10: supplychain.v1.ProductService.UpdateProduct:input_type -> supplychain.v1.UpdateProductRequest
+	4,  // 11: supplychain.v1.ProductService.DeleteProduct:input_type -> supplychain.v1.DeleteProductRequest
+	6,  // 12: supplychain.v1.ProductService.ListProducts:input_type -> supplychain.v1.ListProductsRequest
+	8,  // 13: supplychain.v1.ProductService.GetProductStats:input_type -> supplychain.v1.GetProductStatsRequest
+	10, // 14: supplychain.v1.ProductService.ListProductsByManufacturer:input_type -> supplychain.v1.ListProductsByManufacturerRequest
+	14, // 15: supplychain.v1.ProductService.CreateProduct:output_type -> supplychain.v1.Product
+	14, // 16: supplychain.v1.ProductService.GetProduct:output_type -> supplychain.v1.Product
+	14, // 17: supplychain.v1.ProductService.GetProductBySKU:output_type -> supplychain.v1.Product
+	14, // 18: supplychain.v1.ProductService.UpdateProduct:output_type -> supplychain.v1.Product
+	5,  // 19: supplychain.v1.ProductService.DeleteProduct:output_type -> supplychain.v1.DeleteProductResponse
+	7,  // 20: supplychain.v1.ProductService.ListProducts:output_type -> supplychain.v1.ListProductsResponse
+	9,  // 21: supplychain.v1.ProductService.GetProductStats:output_type -> supplychain.v1.ProductStats
+	11, // 22: supplychain.v1.ProductService.ListProductsByManufacturer:output_type -> supplychain.v1.ListProductsByManufacturerResponse
+	15, // [15:23] is the sub-list for method output_type
+	7,  // [7:15] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_supplychain_v1_product_proto_init() }
+func file_supplychain_v1_product_proto_init() {
+	if File_supplychain_v1_product_proto != nil {
+		return
+	}
+	file_supplychain_v1_types_proto_init()
+	file_supplychain_v1_product_proto_msgTypes[0].OneofWrappers = []any{}
+	file_supplychain_v1_product_proto_msgTypes[3].OneofWrappers = []any{}
+	file_supplychain_v1_product_proto_msgTypes[6].OneofWrappers = []any{}
+	file_supplychain_v1_product_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_supplychain_v1_product_proto_rawDesc), len(file_supplychain_v1_product_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_supplychain_v1_product_proto_goTypes,
+		DependencyIndexes: file_supplychain_v1_product_proto_depIdxs,
+		MessageInfos:      file_supplychain_v1_product_proto_msgTypes,
+	}.Build()
+	File_supplychain_v1_product_proto = out.File
+	file_supplychain_v1_product_proto_goTypes = nil
+	file_supplychain_v1_product_proto_depIdxs = nil
+}
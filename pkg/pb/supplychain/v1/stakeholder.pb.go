@@ -0,0 +1,874 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: supplychain/v1/stakeholder.proto
+
+package supplychainv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateStakeholderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	WalletAddress *string                `protobuf:"bytes,3,opt,name=wallet_address,json=walletAddress,proto3,oneof" json:"wallet_address,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         *string                `protobuf:"bytes,5,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	Address       *string                `protobuf:"bytes,6,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStakeholderRequest) Reset() {
+	*x = CreateStakeholderRequest{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStakeholderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStakeholderRequest) ProtoMessage() {}
+
+func (x *CreateStakeholderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStakeholderRequest.ProtoReflect.Descriptor instead.
+func (*CreateStakeholderRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateStakeholderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateStakeholderRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateStakeholderRequest) GetWalletAddress() string {
+	if x != nil && x.WalletAddress != nil {
+		return *x.WalletAddress
+	}
+	return ""
+}
+
+func (x *CreateStakeholderRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateStakeholderRequest) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *CreateStakeholderRequest) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+type GetStakeholderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStakeholderRequest) Reset() {
+	*x = GetStakeholderRequest{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStakeholderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStakeholderRequest) ProtoMessage() {}
+
+func (x *GetStakeholderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStakeholderRequest.ProtoReflect.Descriptor instead.
+func (*GetStakeholderRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetStakeholderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetStakeholderByEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStakeholderByEmailRequest) Reset() {
+	*x = GetStakeholderByEmailRequest{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStakeholderByEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStakeholderByEmailRequest) ProtoMessage() {}
+
+func (x *GetStakeholderByEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStakeholderByEmailRequest.ProtoReflect.Descriptor instead.
+func (*GetStakeholderByEmailRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetStakeholderByEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// Unset fields are left unchanged
+type UpdateStakeholderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	WalletAddress *string                `protobuf:"bytes,3,opt,name=wallet_address,json=walletAddress,proto3,oneof" json:"wallet_address,omitempty"`
+	Email         *string                `protobuf:"bytes,4,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Phone         *string                `protobuf:"bytes,5,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	Address       *string                `protobuf:"bytes,6,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	IsVerified    *bool                  `protobuf:"varint,7,opt,name=is_verified,json=isVerified,proto3,oneof" json:"is_verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStakeholderRequest) Reset() {
+	*x = UpdateStakeholderRequest{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStakeholderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStakeholderRequest) ProtoMessage() {}
+
+func (x *UpdateStakeholderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStakeholderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStakeholderRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateStakeholderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateStakeholderRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateStakeholderRequest) GetWalletAddress() string {
+	if x != nil && x.WalletAddress != nil {
+		return *x.WalletAddress
+	}
+	return ""
+}
+
+func (x *UpdateStakeholderRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *UpdateStakeholderRequest) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *UpdateStakeholderRequest) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *UpdateStakeholderRequest) GetIsVerified() bool {
+	if x != nil && x.IsVerified != nil {
+		return *x.IsVerified
+	}
+	return false
+}
+
+type DeleteStakeholderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStakeholderRequest) Reset() {
+	*x = DeleteStakeholderRequest{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStakeholderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStakeholderRequest) ProtoMessage() {}
+
+func (x *DeleteStakeholderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStakeholderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteStakeholderRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteStakeholderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteStakeholderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStakeholderResponse) Reset() {
+	*x = DeleteStakeholderResponse{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStakeholderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStakeholderResponse) ProtoMessage() {}
+
+func (x *DeleteStakeholderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStakeholderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteStakeholderResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{5}
+}
+
+type ListStakeholdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          *string                `protobuf:"bytes,1,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	IsVerified    *bool                  `protobuf:"varint,2,opt,name=is_verified,json=isVerified,proto3,oneof" json:"is_verified,omitempty"`
+	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // default 10
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	Cursor        *string                `protobuf:"bytes,6,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"` // next_cursor of the previous page; replaces offset
+	Count         string                 `protobuf:"bytes,7,opt,name=count,proto3" json:"count,omitempty"`         // exact, estimated or none
+	Sort          string                 `protobuf:"bytes,8,opt,name=sort,proto3" json:"sort,omitempty"`           // comma separated fields, - for descending, e.g. type,name
+	Where         []*Predicate           `protobuf:"bytes,9,rep,name=where,proto3" json:"where,omitempty"`         // all must match
+	Fields        []string               `protobuf:"bytes,10,rep,name=fields,proto3" json:"fields,omitempty"`      // return only these fields (and id); every field when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStakeholdersRequest) Reset() {
+	*x = ListStakeholdersRequest{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStakeholdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStakeholdersRequest) ProtoMessage() {}
+
+func (x *ListStakeholdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStakeholdersRequest.ProtoReflect.Descriptor instead.
+func (*ListStakeholdersRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListStakeholdersRequest) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *ListStakeholdersRequest) GetIsVerified() bool {
+	if x != nil && x.IsVerified != nil {
+		return *x.IsVerified
+	}
+	return false
+}
+
+func (x *ListStakeholdersRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *ListStakeholdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListStakeholdersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListStakeholdersRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListStakeholdersRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+func (x *ListStakeholdersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListStakeholdersRequest) GetWhere() []*Predicate {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *ListStakeholdersRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type ListStakeholdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stakeholders  []*Stakeholder         `protobuf:"bytes,1,rep,name=stakeholders,proto3" json:"stakeholders,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStakeholdersResponse) Reset() {
+	*x = ListStakeholdersResponse{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStakeholdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStakeholdersResponse) ProtoMessage() {}
+
+func (x *ListStakeholdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStakeholdersResponse.ProtoReflect.Descriptor instead.
+func (*ListStakeholdersResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListStakeholdersResponse) GetStakeholders() []*Stakeholder {
+	if x != nil {
+		return x.Stakeholders
+	}
+	return nil
+}
+
+func (x *ListStakeholdersResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type GetStakeholderStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStakeholderStatsRequest) Reset() {
+	*x = GetStakeholderStatsRequest{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStakeholderStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStakeholderStatsRequest) ProtoMessage() {}
+
+func (x *GetStakeholderStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStakeholderStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStakeholderStatsRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetStakeholderStatsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type StakeholderStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StakeholderId  string                 `protobuf:"bytes,1,opt,name=stakeholder_id,json=stakeholderId,proto3" json:"stakeholder_id,omitempty"`
+	TotalProducts  int64                  `protobuf:"varint,2,opt,name=total_products,json=totalProducts,proto3" json:"total_products,omitempty"`
+	TotalEvents    int64                  `protobuf:"varint,3,opt,name=total_events,json=totalEvents,proto3" json:"total_events,omitempty"`
+	VerifiedEvents int64                  `protobuf:"varint,4,opt,name=verified_events,json=verifiedEvents,proto3" json:"verified_events,omitempty"`
+	PendingEvents  int64                  `protobuf:"varint,5,opt,name=pending_events,json=pendingEvents,proto3" json:"pending_events,omitempty"`
+	LastActivity   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StakeholderStats) Reset() {
+	*x = StakeholderStats{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StakeholderStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StakeholderStats) ProtoMessage() {}
+
+func (x *StakeholderStats) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StakeholderStats.ProtoReflect.Descriptor instead.
+func (*StakeholderStats) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StakeholderStats) GetStakeholderId() string {
+	if x != nil {
+		return x.StakeholderId
+	}
+	return ""
+}
+
+func (x *StakeholderStats) GetTotalProducts() int64 {
+	if x != nil {
+		return x.TotalProducts
+	}
+	return 0
+}
+
+func (x *StakeholderStats) GetTotalEvents() int64 {
+	if x != nil {
+		return x.TotalEvents
+	}
+	return 0
+}
+
+func (x *StakeholderStats) GetVerifiedEvents() int64 {
+	if x != nil {
+		return x.VerifiedEvents
+	}
+	return 0
+}
+
+func (x *StakeholderStats) GetPendingEvents() int64 {
+	if x != nil {
+		return x.PendingEvents
+	}
+	return 0
+}
+
+func (x *StakeholderStats) GetLastActivity() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivity
+	}
+	return nil
+}
+
+type VerifyStakeholderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyStakeholderRequest) Reset() {
+	*x = VerifyStakeholderRequest{}
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyStakeholderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyStakeholderRequest) ProtoMessage() {}
+
+func (x *VerifyStakeholderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_stakeholder_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyStakeholderRequest.ProtoReflect.Descriptor instead.
+func (*VerifyStakeholderRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_stakeholder_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VerifyStakeholderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_supplychain_v1_stakeholder_proto protoreflect.FileDescriptor
+
+const file_supplychain_v1_stakeholder_proto_rawDesc = "" +
+	"\n" +
+	" supplychain/v1/stakeholder.proto\x12\x0esupplychain.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1asupplychain/v1/types.proto\"\xe7\x01\n" +
+	"\x18CreateStakeholderRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12*\n" +
+	"\x0ewallet_address\x18\x03 \x01(\tH\x00R\rwalletAddress\x88\x01\x01\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x19\n" +
+	"\x05phone\x18\x05 \x01(\tH\x01R\x05phone\x88\x01\x01\x12\x1d\n" +
+	"\aaddress\x18\x06 \x01(\tH\x02R\aaddress\x88\x01\x01B\x11\n" +
+	"\x0f_wallet_addressB\b\n" +
+	"\x06_phoneB\n" +
+	"\n" +
+	"\b_address\"'\n" +
+	"\x15GetStakeholderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"4\n" +
+	"\x1cGetStakeholderByEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\xb6\x02\n" +
+	"\x18UpdateStakeholderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12*\n" +
+	"\x0ewallet_address\x18\x03 \x01(\tH\x01R\rwalletAddress\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x04 \x01(\tH\x02R\x05email\x88\x01\x01\x12\x19\n" +
+	"\x05phone\x18\x05 \x01(\tH\x03R\x05phone\x88\x01\x01\x12\x1d\n" +
+	"\aaddress\x18\x06 \x01(\tH\x04R\aaddress\x88\x01\x01\x12$\n" +
+	"\vis_verified\x18\a \x01(\bH\x05R\n" +
+	"isVerified\x88\x01\x01B\a\n" +
+	"\x05_nameB\x11\n" +
+	"\x0f_wallet_addressB\b\n" +
+	"\x06_emailB\b\n" +
+	"\x06_phoneB\n" +
+	"\n" +
+	"\b_addressB\x0e\n" +
+	"\f_is_verified\"*\n" +
+	"\x18DeleteStakeholderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1b\n" +
+	"\x19DeleteStakeholderResponse\"\xdf\x02\n" +
+	"\x17ListStakeholdersRequest\x12\x17\n" +
+	"\x04type\x18\x01 \x01(\tH\x00R\x04type\x88\x01\x01\x12$\n" +
+	"\vis_verified\x18\x02 \x01(\bH\x01R\n" +
+	"isVerified\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tH\x02R\x05email\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\x06cursor\x18\x06 \x01(\tH\x03R\x06cursor\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\a \x01(\tR\x05count\x12\x12\n" +
+	"\x04sort\x18\b \x01(\tR\x04sort\x12/\n" +
+	"\x05where\x18\t \x03(\v2\x19.supplychain.v1.PredicateR\x05where\x12\x16\n" +
+	"\x06fields\x18\n" +
+	" \x03(\tR\x06fieldsB\a\n" +
+	"\x05_typeB\x0e\n" +
+	"\f_is_verifiedB\b\n" +
+	"\x06_emailB\t\n" +
+	"\a_cursor\"\x85\x01\n" +
+	"\x18ListStakeholdersResponse\x12?\n" +
+	"\fstakeholders\x18\x01 \x03(\v2\x1b.supplychain.v1.StakeholderR\fstakeholders\x12(\n" +
+	"\x04page\x18\x02 \x01(\v2\x14.supplychain.v1.PageR\x04page\",\n" +
+	"\x1aGetStakeholderStatsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x94\x02\n" +
+	"\x10StakeholderStats\x12%\n" +
+	"\x0estakeholder_id\x18\x01 \x01(\tR\rstakeholderId\x12%\n" +
+	"\x0etotal_products\x18\x02 \x01(\x03R\rtotalProducts\x12!\n" +
+	"\ftotal_events\x18\x03 \x01(\x03R\vtotalEvents\x12'\n" +
+	"\x0fverified_events\x18\x04 \x01(\x03R\x0everifiedEvents\x12%\n" +
+	"\x0epending_events\x18\x05 \x01(\x03R\rpendingEvents\x12?\n" +
+	"\rlast_activity\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\"*\n" +
+	"\x18VerifyStakeholderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\x98\x06\n" +
+	"\x12StakeholderService\x12Z\n" +
+	"\x11CreateStakeholder\x12(.supplychain.v1.CreateStakeholderRequest\x1a\x1b.supplychain.v1.Stakeholder\x12T\n" +
+	"\x0eGetStakeholder\x12%.supplychain.v1.GetStakeholderRequest\x1a\x1b.supplychain.v1.Stakeholder\x12b\n" +
+	"\x15GetStakeholderByEmail\x12,.supplychain.v1.GetStakeholderByEmailRequest\x1a\x1b.supplychain.v1.Stakeholder\x12Z\n" +
+	"\x11UpdateStakeholder\x12(.supplychain.v1.UpdateStakeholderRequest\x1a\x1b.supplychain.v1.Stakeholder\x12h\n" +
+	"\x11DeleteStakeholder\x12(.supplychain.v1.DeleteStakeholderRequest\x1a).supplychain.v1.DeleteStakeholderResponse\x12e\n" +
+	"\x10ListStakeholders\x12'.supplychain.v1.ListStakeholdersRequest\x1a(.supplychain.v1.ListStakeholdersResponse\x12c\n" +
+	"\x13GetStakeholderStats\x12*.supplychain.v1.GetStakeholderStatsRequest\x1a .supplychain.v1.StakeholderStats\x12Z\n" +
+	"\x11VerifyStakeholder\x12(.supplychain.v1.VerifyStakeholderRequest\x1a\x1b.supplychain.v1.StakeholderBJZHgithub.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1;supplychainv1b\x06proto3"
+
+var (
+	file_supplychain_v1_stakeholder_proto_rawDescOnce sync.Once
+	file_supplychain_v1_stakeholder_proto_rawDescData []byte
+)
+
+func file_supplychain_v1_stakeholder_proto_rawDescGZIP() []byte {
+	file_supplychain_v1_stakeholder_proto_rawDescOnce.Do(func() {
+		file_supplychain_v1_stakeholder_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_supplychain_v1_stakeholder_proto_rawDesc), len(file_supplychain_v1_stakeholder_proto_rawDesc)))
+	})
+	return file_supplychain_v1_stakeholder_proto_rawDescData
+}
+
+var file_supplychain_v1_stakeholder_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_supplychain_v1_stakeholder_proto_goTypes = []any{
+	(*CreateStakeholderRequest)(nil),     // 0: supplychain.v1.CreateStakeholderRequest
+	(*GetStakeholderRequest)(nil),        // 1: supplychain.v1.GetStakeholderRequest
+	(*GetStakeholderByEmailRequest)(nil), // 2: supplychain.v1.GetStakeholderByEmailRequest
+	(*UpdateStakeholderRequest)(nil),     // 3: supplychain.v1.UpdateStakeholderRequest
+	(*DeleteStakeholderRequest)(nil),     // 4: supplychain.v1.DeleteStakeholderRequest
+	(*DeleteStakeholderResponse)(nil),    // 5: supplychain.v1.DeleteStakeholderResponse
+	(*ListStakeholdersRequest)(nil),      // 6: supplychain.v1.ListStakeholdersRequest
+	(*ListStakeholdersResponse)(nil),     // 7: supplychain.v1.ListStakeholdersResponse
+	(*GetStakeholderStatsRequest)(nil),   // 8: supplychain.v1.GetStakeholderStatsRequest
+	(*StakeholderStats)(nil),             // 9: supplychain.v1.StakeholderStats
+	(*VerifyStakeholderRequest)(nil),     // 10: supplychain.v1.VerifyStakeholderRequest
+	(*Predicate)(nil),                    // 11: supplychain.v1.Predicate
+	(*Stakeholder)(nil),                  // 12: supplychain.v1.Stakeholder
+	(*Page)(nil),                         // 13: supplychain.v1.Page
+	(*timestamppb.Timestamp)(nil),        // 14: google.protobuf.Timestamp
+}
+var file_supplychain_v1_stakeholder_proto_depIdxs = []int32{
+	11, // 0: supplychain.v1.ListStakeholdersRequest.where:type_name -> supplychain.v1.Predicate
+	12, // 1: supplychain.v1.ListStakeholdersResponse.stakeholders:type_name -> supplychain.v1.Stakeholder
+	13, // 2: supplychain.v1.ListStakeholdersResponse.page:type_name -> supplychain.v1.Page
+	14, // 3: supplychain.v1.StakeholderStats.last_activity:type_name -> google.protobuf.Timestamp
+	0,  // 4: supplychain.v1.StakeholderService.CreateStakeholder:input_type -> supplychain.v1.CreateStakeholderRequest
+	1,  // 5: supplychain.v1.StakeholderService.GetStakeholder:input_type -> supplychain.v1.GetStakeholderRequest
+	2,  // 6: supplychain.v1.StakeholderService.GetStakeholderByEmail:input_type -> supplychain.v1.GetStakeholderByEmailRequest
+	3,  // 7: supplychain.v1.StakeholderService.UpdateStakeholder:input_type -> supplychain.v1.UpdateStakeholderRequest
+	4,  // 8: supplychain.v1.StakeholderService.DeleteStakeholder:input_type -> supplychain.v1.DeleteStakeholderRequest
+	6,  // 9: supplychain.v1.StakeholderService.ListStakeholders:input_type -> supplychain.v1.ListStakeholdersRequest
+	8,  // 10: supplychain.v1.StakeholderService.GetStakeholderStats:input_type -> supplychain.v1.GetStakeholderStatsRequest
+	10, // 11: supplychain.v1.StakeholderService.VerifyStakeholder:input_type -> supplychain.v1.VerifyStakeholderRequest
+	12, // 12: supplychain.v1.StakeholderService.CreateStakeholder:output_type -> supplychain.v1.Stakeholder
+	12, // 13: supplychain.v1.StakeholderService.GetStakeholder:output_type -> supplychain.v1.Stakeholder
+	12, // 14: supplychain.v1.StakeholderService.GetStakeholderByEmail:output_type -> supplychain.v1.Stakeholder
+	12, // 15: supplychain.v1.StakeholderService.UpdateStakeholder:output_type -> supplychain.v1.Stakeholder
+	5,  // 16: supplychain.v1.StakeholderService.DeleteStakeholder:output_type -> supplychain.v1.DeleteStakeholderResponse
+	7,  // 17: supplychain.v1.StakeholderService.ListStakeholders:output_type -> supplychain.v1.ListStakeholdersResponse
+	9,  // 18: supplychain.v1.StakeholderService.GetStakeholderStats:output_type -> supplychain.v1.StakeholderStats
+	12, // 19: supplychain.v1.StakeholderService.VerifyStakeholder:output_type -> supplychain.v1.Stakeholder
+	12, // [12:20] is the sub-list for method output_type
+	4,  // [4:12] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_supplychain_v1_stakeholder_proto_init() }
+func file_supplychain_v1_stakeholder_proto_init() {
+	if File_supplychain_v1_stakeholder_proto != nil {
+		return
+	}
+	file_supplychain_v1_types_proto_init()
+	file_supplychain_v1_stakeholder_proto_msgTypes[0].OneofWrappers = []any{}
+	file_supplychain_v1_stakeholder_proto_msgTypes[3].OneofWrappers = []any{}
+	file_supplychain_v1_stakeholder_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_supplychain_v1_stakeholder_proto_rawDesc), len(file_supplychain_v1_stakeholder_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_supplychain_v1_stakeholder_proto_goTypes,
+		DependencyIndexes: file_supplychain_v1_stakeholder_proto_depIdxs,
+		MessageInfos:      file_supplychain_v1_stakeholder_proto_msgTypes,
+	}.Build()
+	File_supplychain_v1_stakeholder_proto = out.File
+	file_supplychain_v1_stakeholder_proto_goTypes = nil
+	file_supplychain_v1_stakeholder_proto_depIdxs = nil
+}
@@ -0,0 +1,387 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: supplychain/v1/stakeholder.proto
+
+package supplychainv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StakeholderService_CreateStakeholder_FullMethodName     = "/supplychain.v1.StakeholderService/CreateStakeholder"
+	StakeholderService_GetStakeholder_FullMethodName        = "/supplychain.v1.StakeholderService/GetStakeholder"
+	StakeholderService_GetStakeholderByEmail_FullMethodName = "/supplychain.v1.StakeholderService/GetStakeholderByEmail"
+	StakeholderService_UpdateStakeholder_FullMethodName     = "/supplychain.v1.StakeholderService/UpdateStakeholder"
+	StakeholderService_DeleteStakeholder_FullMethodName     = "/supplychain.v1.StakeholderService/DeleteStakeholder"
+	StakeholderService_ListStakeholders_FullMethodName      = "/supplychain.v1.StakeholderService/ListStakeholders"
+	StakeholderService_GetStakeholderStats_FullMethodName   = "/supplychain.v1.StakeholderService/GetStakeholderStats"
+	StakeholderService_VerifyStakeholder_FullMethodName     = "/supplychain.v1.StakeholderService/VerifyStakeholder"
+)
+
+// StakeholderServiceClient is the client API for StakeholderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StakeholderServiceClient interface {
+	CreateStakeholder(ctx context.Context, in *CreateStakeholderRequest, opts ...grpc.CallOption) (*Stakeholder, error)
+	GetStakeholder(ctx context.Context, in *GetStakeholderRequest, opts ...grpc.CallOption) (*Stakeholder, error)
+	GetStakeholderByEmail(ctx context.Context, in *GetStakeholderByEmailRequest, opts ...grpc.CallOption) (*Stakeholder, error)
+	UpdateStakeholder(ctx context.Context, in *UpdateStakeholderRequest, opts ...grpc.CallOption) (*Stakeholder, error)
+	DeleteStakeholder(ctx context.Context, in *DeleteStakeholderRequest, opts ...grpc.CallOption) (*DeleteStakeholderResponse, error)
+	ListStakeholders(ctx context.Context, in *ListStakeholdersRequest, opts ...grpc.CallOption) (*ListStakeholdersResponse, error)
+	GetStakeholderStats(ctx context.Context, in *GetStakeholderStatsRequest, opts ...grpc.CallOption) (*StakeholderStats, error)
+	VerifyStakeholder(ctx context.Context, in *VerifyStakeholderRequest, opts ...grpc.CallOption) (*Stakeholder, error)
+}
+
+type stakeholderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStakeholderServiceClient(cc grpc.ClientConnInterface) StakeholderServiceClient {
+	return &stakeholderServiceClient{cc}
+}
+
+func (c *stakeholderServiceClient) CreateStakeholder(ctx context.Context, in *CreateStakeholderRequest, opts ...grpc.CallOption) (*Stakeholder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stakeholder)
+	err := c.cc.Invoke(ctx, StakeholderService_CreateStakeholder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeholderServiceClient) GetStakeholder(ctx context.Context, in *GetStakeholderRequest, opts ...grpc.CallOption) (*Stakeholder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stakeholder)
+	err := c.cc.Invoke(ctx, StakeholderService_GetStakeholder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeholderServiceClient) GetStakeholderByEmail(ctx context.Context, in *GetStakeholderByEmailRequest, opts ...grpc.CallOption) (*Stakeholder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stakeholder)
+	err := c.cc.Invoke(ctx, StakeholderService_GetStakeholderByEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeholderServiceClient) UpdateStakeholder(ctx context.Context, in *UpdateStakeholderRequest, opts ...grpc.CallOption) (*Stakeholder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stakeholder)
+	err := c.cc.Invoke(ctx, StakeholderService_UpdateStakeholder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeholderServiceClient) DeleteStakeholder(ctx context.Context, in *DeleteStakeholderRequest, opts ...grpc.CallOption) (*DeleteStakeholderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteStakeholderResponse)
+	err := c.cc.Invoke(ctx, StakeholderService_DeleteStakeholder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeholderServiceClient) ListStakeholders(ctx context.Context, in *ListStakeholdersRequest, opts ...grpc.CallOption) (*ListStakeholdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStakeholdersResponse)
+	err := c.cc.Invoke(ctx, StakeholderService_ListStakeholders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeholderServiceClient) GetStakeholderStats(ctx context.Context, in *GetStakeholderStatsRequest, opts ...grpc.CallOption) (*StakeholderStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StakeholderStats)
+	err := c.cc.Invoke(ctx, StakeholderService_GetStakeholderStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeholderServiceClient) VerifyStakeholder(ctx context.Context, in *VerifyStakeholderRequest, opts ...grpc.CallOption) (*Stakeholder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stakeholder)
+	err := c.cc.Invoke(ctx, StakeholderService_VerifyStakeholder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StakeholderServiceServer is the server API for StakeholderService service.
+// All implementations must embed UnimplementedStakeholderServiceServer
+// for forward compatibility.
+type StakeholderServiceServer interface {
+	CreateStakeholder(context.Context, *CreateStakeholderRequest) (*Stakeholder, error)
+	GetStakeholder(context.Context, *GetStakeholderRequest) (*Stakeholder, error)
+	GetStakeholderByEmail(context.Context, *GetStakeholderByEmailRequest) (*Stakeholder, error)
+	UpdateStakeholder(context.Context, *UpdateStakeholderRequest) (*Stakeholder, error)
+	DeleteStakeholder(context.Context, *DeleteStakeholderRequest) (*DeleteStakeholderResponse, error)
+	ListStakeholders(context.Context, *ListStakeholdersRequest) (*ListStakeholdersResponse, error)
+	GetStakeholderStats(context.Context, *GetStakeholderStatsRequest) (*StakeholderStats, error)
+	VerifyStakeholder(context.Context, *VerifyStakeholderRequest) (*Stakeholder, error)
+	mustEmbedUnimplementedStakeholderServiceServer()
+}
+
+// UnimplementedStakeholderServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStakeholderServiceServer struct{}
+
+func (UnimplementedStakeholderServiceServer) CreateStakeholder(context.Context, *CreateStakeholderRequest) (*Stakeholder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStakeholder not implemented")
+}
+func (UnimplementedStakeholderServiceServer) GetStakeholder(context.Context, *GetStakeholderRequest) (*Stakeholder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStakeholder not implemented")
+}
+func (UnimplementedStakeholderServiceServer) GetStakeholderByEmail(context.Context, *GetStakeholderByEmailRequest) (*Stakeholder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStakeholderByEmail not implemented")
+}
+func (UnimplementedStakeholderServiceServer) UpdateStakeholder(context.Context, *UpdateStakeholderRequest) (*Stakeholder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStakeholder not implemented")
+}
+func (UnimplementedStakeholderServiceServer) DeleteStakeholder(context.Context, *DeleteStakeholderRequest) (*DeleteStakeholderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStakeholder not implemented")
+}
+func (UnimplementedStakeholderServiceServer) ListStakeholders(context.Context, *ListStakeholdersRequest) (*ListStakeholdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStakeholders not implemented")
+}
+func (UnimplementedStakeholderServiceServer) GetStakeholderStats(context.Context, *GetStakeholderStatsRequest) (*StakeholderStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStakeholderStats not implemented")
+}
+func (UnimplementedStakeholderServiceServer) VerifyStakeholder(context.Context, *VerifyStakeholderRequest) (*Stakeholder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyStakeholder not implemented")
+}
+func (UnimplementedStakeholderServiceServer) mustEmbedUnimplementedStakeholderServiceServer() {}
+func (UnimplementedStakeholderServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeStakeholderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StakeholderServiceServer will
+// result in compilation errors.
+type UnsafeStakeholderServiceServer interface {
+	mustEmbedUnimplementedStakeholderServiceServer()
+}
+
+func RegisterStakeholderServiceServer(s grpc.ServiceRegistrar, srv StakeholderServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStakeholderServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StakeholderService_ServiceDesc, srv)
+}
+
+func _StakeholderService_CreateStakeholder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStakeholderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeholderServiceServer).CreateStakeholder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakeholderService_CreateStakeholder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeholderServiceServer).CreateStakeholder(ctx, req.(*CreateStakeholderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakeholderService_GetStakeholder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStakeholderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeholderServiceServer).GetStakeholder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakeholderService_GetStakeholder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeholderServiceServer).GetStakeholder(ctx, req.(*GetStakeholderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakeholderService_GetStakeholderByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStakeholderByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeholderServiceServer).GetStakeholderByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakeholderService_GetStakeholderByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeholderServiceServer).GetStakeholderByEmail(ctx, req.(*GetStakeholderByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakeholderService_UpdateStakeholder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStakeholderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeholderServiceServer).UpdateStakeholder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakeholderService_UpdateStakeholder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeholderServiceServer).UpdateStakeholder(ctx, req.(*UpdateStakeholderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakeholderService_DeleteStakeholder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStakeholderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeholderServiceServer).DeleteStakeholder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakeholderService_DeleteStakeholder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeholderServiceServer).DeleteStakeholder(ctx, req.(*DeleteStakeholderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakeholderService_ListStakeholders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStakeholdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeholderServiceServer).ListStakeholders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakeholderService_ListStakeholders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeholderServiceServer).ListStakeholders(ctx, req.(*ListStakeholdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakeholderService_GetStakeholderStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStakeholderStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeholderServiceServer).GetStakeholderStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakeholderService_GetStakeholderStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeholderServiceServer).GetStakeholderStats(ctx, req.(*GetStakeholderStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakeholderService_VerifyStakeholder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyStakeholderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeholderServiceServer).VerifyStakeholder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakeholderService_VerifyStakeholder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeholderServiceServer).VerifyStakeholder(ctx, req.(*VerifyStakeholderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StakeholderService_ServiceDesc is the grpc.ServiceDesc for StakeholderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StakeholderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "supplychain.v1.StakeholderService",
+	HandlerType: (*StakeholderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateStakeholder",
+			Handler:    _StakeholderService_CreateStakeholder_Handler,
+		},
+		{
+			MethodName: "GetStakeholder",
+			Handler:    _StakeholderService_GetStakeholder_Handler,
+		},
+		{
+			MethodName: "GetStakeholderByEmail",
+			Handler:    _StakeholderService_GetStakeholderByEmail_Handler,
+		},
+		{
+			MethodName: "UpdateStakeholder",
+			Handler:    _StakeholderService_UpdateStakeholder_Handler,
+		},
+		{
+			MethodName: "DeleteStakeholder",
+			Handler:    _StakeholderService_DeleteStakeholder_Handler,
+		},
+		{
+			MethodName: "ListStakeholders",
+			Handler:    _StakeholderService_ListStakeholders_Handler,
+		},
+		{
+			MethodName: "GetStakeholderStats",
+			Handler:    _StakeholderService_GetStakeholderStats_Handler,
+		},
+		{
+			MethodName: "VerifyStakeholder",
+			Handler:    _StakeholderService_VerifyStakeholder_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "supplychain/v1/stakeholder.proto",
+}
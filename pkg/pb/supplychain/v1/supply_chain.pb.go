@@ -0,0 +1,1476 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: supplychain/v1/supply_chain.proto
+
+package supplychainv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TransformationLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit          *string                `protobuf:"bytes,3,opt,name=unit,proto3,oneof" json:"unit,omitempty"`
+	LotNumber     *string                `protobuf:"bytes,4,opt,name=lot_number,json=lotNumber,proto3,oneof" json:"lot_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransformationLine) Reset() {
+	*x = TransformationLine{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransformationLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransformationLine) ProtoMessage() {}
+
+func (x *TransformationLine) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransformationLine.ProtoReflect.Descriptor instead.
+func (*TransformationLine) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransformationLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *TransformationLine) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *TransformationLine) GetUnit() string {
+	if x != nil && x.Unit != nil {
+		return *x.Unit
+	}
+	return ""
+}
+
+func (x *TransformationLine) GetLotNumber() string {
+	if x != nil && x.LotNumber != nil {
+		return *x.LotNumber
+	}
+	return ""
+}
+
+type RecordEventRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      *string                `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3,oneof" json:"product_id,omitempty"`
+	StakeholderId  *string                `protobuf:"bytes,2,opt,name=stakeholder_id,json=stakeholderId,proto3,oneof" json:"stakeholder_id,omitempty"`
+	EventType      string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // manufactured, shipped, received, sold, pack, unpack or transformed
+	Location       *string                `protobuf:"bytes,4,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Metadata       *structpb.Struct       `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	BlockchainHash *string                `protobuf:"bytes,7,opt,name=blockchain_hash,json=blockchainHash,proto3,oneof" json:"blockchain_hash,omitempty"`
+	ChildIds       []string               `protobuf:"bytes,8,rep,name=child_ids,json=childIds,proto3" json:"child_ids,omitempty"`              // pack/unpack
+	Inputs         []*TransformationLine  `protobuf:"bytes,9,rep,name=inputs,proto3" json:"inputs,omitempty"`                                  // transformed
+	Outputs        []*TransformationLine  `protobuf:"bytes,10,rep,name=outputs,proto3" json:"outputs,omitempty"`                               // transformed
+	LocationId     *string                `protobuf:"bytes,11,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"` // a registered location; takes precedence over location
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RecordEventRequest) Reset() {
+	*x = RecordEventRequest{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEventRequest) ProtoMessage() {}
+
+func (x *RecordEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEventRequest.ProtoReflect.Descriptor instead.
+func (*RecordEventRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RecordEventRequest) GetProductId() string {
+	if x != nil && x.ProductId != nil {
+		return *x.ProductId
+	}
+	return ""
+}
+
+func (x *RecordEventRequest) GetStakeholderId() string {
+	if x != nil && x.StakeholderId != nil {
+		return *x.StakeholderId
+	}
+	return ""
+}
+
+func (x *RecordEventRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *RecordEventRequest) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
+func (x *RecordEventRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *RecordEventRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RecordEventRequest) GetBlockchainHash() string {
+	if x != nil && x.BlockchainHash != nil {
+		return *x.BlockchainHash
+	}
+	return ""
+}
+
+func (x *RecordEventRequest) GetChildIds() []string {
+	if x != nil {
+		return x.ChildIds
+	}
+	return nil
+}
+
+func (x *RecordEventRequest) GetInputs() []*TransformationLine {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *RecordEventRequest) GetOutputs() []*TransformationLine {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *RecordEventRequest) GetLocationId() string {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return ""
+}
+
+type GetEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventRequest) Reset() {
+	*x = GetEventRequest{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventRequest) ProtoMessage() {}
+
+func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
+func (*GetEventRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEventRequest) Reset() {
+	*x = DeleteEventRequest{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEventRequest) ProtoMessage() {}
+
+func (x *DeleteEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEventRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEventRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEventResponse) Reset() {
+	*x = DeleteEventResponse{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEventResponse) ProtoMessage() {}
+
+func (x *DeleteEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEventResponse.ProtoReflect.Descriptor instead.
+func (*DeleteEventResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{4}
+}
+
+type ListEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     *string                `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3,oneof" json:"product_id,omitempty"`
+	StakeholderId *string                `protobuf:"bytes,2,opt,name=stakeholder_id,json=stakeholderId,proto3,oneof" json:"stakeholder_id,omitempty"`
+	EventType     *string                `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3,oneof" json:"event_type,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,4,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Location      *string                `protobuf:"bytes,5,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	IsVerified    *bool                  `protobuf:"varint,6,opt,name=is_verified,json=isVerified,proto3,oneof" json:"is_verified,omitempty"`
+	FromDate      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	Limit         int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"` // default 10
+	Offset        int32                  `protobuf:"varint,10,opt,name=offset,proto3" json:"offset,omitempty"`
+	Cursor        *string                `protobuf:"bytes,11,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"` // next_cursor of the previous page; replaces offset
+	Count         string                 `protobuf:"bytes,12,opt,name=count,proto3" json:"count,omitempty"`         // exact, estimated or none
+	Sort          string                 `protobuf:"bytes,13,opt,name=sort,proto3" json:"sort,omitempty"`           // comma separated fields, - for descending, e.g. -timestamp,location
+	Where         []*Predicate           `protobuf:"bytes,14,rep,name=where,proto3" json:"where,omitempty"`         // all must match
+	Include       []string               `protobuf:"bytes,15,rep,name=include,proto3" json:"include,omitempty"`     // relations to load: product, stakeholder
+	Fields        []string               `protobuf:"bytes,16,rep,name=fields,proto3" json:"fields,omitempty"`       // return only these fields (and id); every field when empty
+	LocationId    *string                `protobuf:"bytes,17,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"`
+	Near          *GeoRadius             `protobuf:"bytes,18,opt,name=near,proto3" json:"near,omitempty"` // events at registered locations within the radius
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsRequest) Reset() {
+	*x = ListEventsRequest{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsRequest) ProtoMessage() {}
+
+func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListEventsRequest) GetProductId() string {
+	if x != nil && x.ProductId != nil {
+		return *x.ProductId
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetStakeholderId() string {
+	if x != nil && x.StakeholderId != nil {
+		return *x.StakeholderId
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetEventType() string {
+	if x != nil && x.EventType != nil {
+		return *x.EventType
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetIsVerified() bool {
+	if x != nil && x.IsVerified != nil {
+		return *x.IsVerified
+	}
+	return false
+}
+
+func (x *ListEventsRequest) GetFromDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromDate
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetToDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToDate
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetWhere() []*Predicate {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetLocationId() string {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetNear() *GeoRadius {
+	if x != nil {
+		return x.Near
+	}
+	return nil
+}
+
+type ListEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SupplyChainEvent    `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsResponse) Reset() {
+	*x = ListEventsResponse{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsResponse) ProtoMessage() {}
+
+func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListEventsResponse) GetEvents() []*SupplyChainEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListEventsResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type GetProductTraceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductTraceRequest) Reset() {
+	*x = GetProductTraceRequest{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductTraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductTraceRequest) ProtoMessage() {}
+
+func (x *GetProductTraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductTraceRequest.ProtoReflect.Descriptor instead.
+func (*GetProductTraceRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetProductTraceRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type InheritedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Event         *SupplyChainEvent      `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InheritedEvent) Reset() {
+	*x = InheritedEvent{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InheritedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InheritedEvent) ProtoMessage() {}
+
+func (x *InheritedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InheritedEvent.ProtoReflect.Descriptor instead.
+func (*InheritedEvent) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *InheritedEvent) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *InheritedEvent) GetEvent() *SupplyChainEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type ProductTrace struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Product         *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Events          []*SupplyChainEvent    `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	InheritedEvents []*InheritedEvent      `protobuf:"bytes,3,rep,name=inherited_events,json=inheritedEvents,proto3" json:"inherited_events,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProductTrace) Reset() {
+	*x = ProductTrace{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductTrace) ProtoMessage() {}
+
+func (x *ProductTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductTrace.ProtoReflect.Descriptor instead.
+func (*ProductTrace) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProductTrace) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *ProductTrace) GetEvents() []*SupplyChainEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ProductTrace) GetInheritedEvents() []*InheritedEvent {
+	if x != nil {
+		return x.InheritedEvents
+	}
+	return nil
+}
+
+type VerifyEventRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BlockchainHash string                 `protobuf:"bytes,2,opt,name=blockchain_hash,json=blockchainHash,proto3" json:"blockchain_hash,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyEventRequest) Reset() {
+	*x = VerifyEventRequest{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEventRequest) ProtoMessage() {}
+
+func (x *VerifyEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEventRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEventRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VerifyEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VerifyEventRequest) GetBlockchainHash() string {
+	if x != nil {
+		return x.BlockchainHash
+	}
+	return ""
+}
+
+type ListEventsByProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsByProductRequest) Reset() {
+	*x = ListEventsByProductRequest{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsByProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsByProductRequest) ProtoMessage() {}
+
+func (x *ListEventsByProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsByProductRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsByProductRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListEventsByProductRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ListEventsByProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SupplyChainEvent    `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsByProductResponse) Reset() {
+	*x = ListEventsByProductResponse{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsByProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsByProductResponse) ProtoMessage() {}
+
+func (x *ListEventsByProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsByProductResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsByProductResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListEventsByProductResponse) GetEvents() []*SupplyChainEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ListEventsByStakeholderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StakeholderId string                 `protobuf:"bytes,1,opt,name=stakeholder_id,json=stakeholderId,proto3" json:"stakeholder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsByStakeholderRequest) Reset() {
+	*x = ListEventsByStakeholderRequest{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsByStakeholderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsByStakeholderRequest) ProtoMessage() {}
+
+func (x *ListEventsByStakeholderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsByStakeholderRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsByStakeholderRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListEventsByStakeholderRequest) GetStakeholderId() string {
+	if x != nil {
+		return x.StakeholderId
+	}
+	return ""
+}
+
+type ListEventsByStakeholderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SupplyChainEvent    `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsByStakeholderResponse) Reset() {
+	*x = ListEventsByStakeholderResponse{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsByStakeholderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsByStakeholderResponse) ProtoMessage() {}
+
+func (x *ListEventsByStakeholderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsByStakeholderResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsByStakeholderResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListEventsByStakeholderResponse) GetEvents() []*SupplyChainEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type WatchEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     *string                `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3,oneof" json:"product_id,omitempty"`
+	LotNumber     *string                `protobuf:"bytes,2,opt,name=lot_number,json=lotNumber,proto3,oneof" json:"lot_number,omitempty"`
+	StakeholderId *string                `protobuf:"bytes,3,opt,name=stakeholder_id,json=stakeholderId,proto3,oneof" json:"stakeholder_id,omitempty"`
+	EventType     *string                `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3,oneof" json:"event_type,omitempty"`
+	Topics        []string               `protobuf:"bytes,5,rep,name=topics,proto3" json:"topics,omitempty"` // event.recorded, event.verified; empty for both
+	Cursor        *string                `protobuf:"bytes,6,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchEventsRequest) GetProductId() string {
+	if x != nil && x.ProductId != nil {
+		return *x.ProductId
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetLotNumber() string {
+	if x != nil && x.LotNumber != nil {
+		return *x.LotNumber
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetStakeholderId() string {
+	if x != nil && x.StakeholderId != nil {
+		return *x.StakeholderId
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetEventType() string {
+	if x != nil && x.EventType != nil {
+		return *x.EventType
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *WatchEventsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type EventMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Event         *SupplyChainEvent      `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventMessage) Reset() {
+	*x = EventMessage{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventMessage) ProtoMessage() {}
+
+func (x *EventMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventMessage.ProtoReflect.Descriptor instead.
+func (*EventMessage) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EventMessage) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *EventMessage) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *EventMessage) GetEvent() *SupplyChainEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type RecordEventResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                         // position in the stream, from 0
+	EventId       *string                `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3,oneof" json:"event_id,omitempty"` // set when recorded
+	Error         *string                `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"`                    // set when rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordEventResult) Reset() {
+	*x = RecordEventResult{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEventResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEventResult) ProtoMessage() {}
+
+func (x *RecordEventResult) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEventResult.ProtoReflect.Descriptor instead.
+func (*RecordEventResult) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecordEventResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RecordEventResult) GetEventId() string {
+	if x != nil && x.EventId != nil {
+		return *x.EventId
+	}
+	return ""
+}
+
+func (x *RecordEventResult) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type RecordEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recorded      int32                  `protobuf:"varint,1,opt,name=recorded,proto3" json:"recorded,omitempty"`
+	Failed        int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Results       []*RecordEventResult   `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordEventsResponse) Reset() {
+	*x = RecordEventsResponse{}
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEventsResponse) ProtoMessage() {}
+
+func (x *RecordEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_supply_chain_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEventsResponse.ProtoReflect.Descriptor instead.
+func (*RecordEventsResponse) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_supply_chain_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RecordEventsResponse) GetRecorded() int32 {
+	if x != nil {
+		return x.Recorded
+	}
+	return 0
+}
+
+func (x *RecordEventsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *RecordEventsResponse) GetResults() []*RecordEventResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_supplychain_v1_supply_chain_proto protoreflect.FileDescriptor
+
+const file_supplychain_v1_supply_chain_proto_rawDesc = "" +
+	"\n" +
+	"!supplychain/v1/supply_chain.proto\x12\x0esupplychain.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1asupplychain/v1/types.proto\"\xa4\x01\n" +
+	"\x12TransformationLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x17\n" +
+	"\x04unit\x18\x03 \x01(\tH\x00R\x04unit\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"lot_number\x18\x04 \x01(\tH\x01R\tlotNumber\x88\x01\x01B\a\n" +
+	"\x05_unitB\r\n" +
+	"\v_lot_number\"\xd1\x04\n" +
+	"\x12RecordEventRequest\x12\"\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tH\x00R\tproductId\x88\x01\x01\x12*\n" +
+	"\x0estakeholder_id\x18\x02 \x01(\tH\x01R\rstakeholderId\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x1f\n" +
+	"\blocation\x18\x04 \x01(\tH\x02R\blocation\x88\x01\x01\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x123\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12,\n" +
+	"\x0fblockchain_hash\x18\a \x01(\tH\x03R\x0eblockchainHash\x88\x01\x01\x12\x1b\n" +
+	"\tchild_ids\x18\b \x03(\tR\bchildIds\x12:\n" +
+	"\x06inputs\x18\t \x03(\v2\".supplychain.v1.TransformationLineR\x06inputs\x12<\n" +
+	"\aoutputs\x18\n" +
+	" \x03(\v2\".supplychain.v1.TransformationLineR\aoutputs\x12$\n" +
+	"\vlocation_id\x18\v \x01(\tH\x04R\n" +
+	"locationId\x88\x01\x01B\r\n" +
+	"\v_product_idB\x11\n" +
+	"\x0f_stakeholder_idB\v\n" +
+	"\t_locationB\x12\n" +
+	"\x10_blockchain_hashB\x0e\n" +
+	"\f_location_id\"!\n" +
+	"\x0fGetEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"$\n" +
+	"\x12DeleteEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x15\n" +
+	"\x13DeleteEventResponse\"\xf3\x05\n" +
+	"\x11ListEventsRequest\x12\"\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tH\x00R\tproductId\x88\x01\x01\x12*\n" +
+	"\x0estakeholder_id\x18\x02 \x01(\tH\x01R\rstakeholderId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tH\x02R\teventType\x88\x01\x01\x12\x1f\n" +
+	"\vevent_types\x18\x04 \x03(\tR\n" +
+	"eventTypes\x12\x1f\n" +
+	"\blocation\x18\x05 \x01(\tH\x03R\blocation\x88\x01\x01\x12$\n" +
+	"\vis_verified\x18\x06 \x01(\bH\x04R\n" +
+	"isVerified\x88\x01\x01\x127\n" +
+	"\tfrom_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bfromDate\x123\n" +
+	"\ato_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x06toDate\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\n" +
+	" \x01(\x05R\x06offset\x12\x1b\n" +
+	"\x06cursor\x18\v \x01(\tH\x05R\x06cursor\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\f \x01(\tR\x05count\x12\x12\n" +
+	"\x04sort\x18\r \x01(\tR\x04sort\x12/\n" +
+	"\x05where\x18\x0e \x03(\v2\x19.supplychain.v1.PredicateR\x05where\x12\x18\n" +
+	"\ainclude\x18\x0f \x03(\tR\ainclude\x12\x16\n" +
+	"\x06fields\x18\x10 \x03(\tR\x06fields\x12$\n" +
+	"\vlocation_id\x18\x11 \x01(\tH\x06R\n" +
+	"locationId\x88\x01\x01\x12-\n" +
+	"\x04near\x18\x12 \x01(\v2\x19.supplychain.v1.GeoRadiusR\x04nearB\r\n" +
+	"\v_product_idB\x11\n" +
+	"\x0f_stakeholder_idB\r\n" +
+	"\v_event_typeB\v\n" +
+	"\t_locationB\x0e\n" +
+	"\f_is_verifiedB\t\n" +
+	"\a_cursorB\x0e\n" +
+	"\f_location_id\"x\n" +
+	"\x12ListEventsResponse\x128\n" +
+	"\x06events\x18\x01 \x03(\v2 .supplychain.v1.SupplyChainEventR\x06events\x12(\n" +
+	"\x04page\x18\x02 \x01(\v2\x14.supplychain.v1.PageR\x04page\"7\n" +
+	"\x16GetProductTraceRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"k\n" +
+	"\x0eInheritedEvent\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\x126\n" +
+	"\x05event\x18\x02 \x01(\v2 .supplychain.v1.SupplyChainEventR\x05event\"\xc6\x01\n" +
+	"\fProductTrace\x121\n" +
+	"\aproduct\x18\x01 \x01(\v2\x17.supplychain.v1.ProductR\aproduct\x128\n" +
+	"\x06events\x18\x02 \x03(\v2 .supplychain.v1.SupplyChainEventR\x06events\x12I\n" +
+	"\x10inherited_events\x18\x03 \x03(\v2\x1e.supplychain.v1.InheritedEventR\x0finheritedEvents\"M\n" +
+	"\x12VerifyEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0fblockchain_hash\x18\x02 \x01(\tR\x0eblockchainHash\";\n" +
+	"\x1aListEventsByProductRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"W\n" +
+	"\x1bListEventsByProductResponse\x128\n" +
+	"\x06events\x18\x01 \x03(\v2 .supplychain.v1.SupplyChainEventR\x06events\"G\n" +
+	"\x1eListEventsByStakeholderRequest\x12%\n" +
+	"\x0estakeholder_id\x18\x01 \x01(\tR\rstakeholderId\"[\n" +
+	"\x1fListEventsByStakeholderResponse\x128\n" +
+	"\x06events\x18\x01 \x03(\v2 .supplychain.v1.SupplyChainEventR\x06events\"\xac\x02\n" +
+	"\x12WatchEventsRequest\x12\"\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tH\x00R\tproductId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"lot_number\x18\x02 \x01(\tH\x01R\tlotNumber\x88\x01\x01\x12*\n" +
+	"\x0estakeholder_id\x18\x03 \x01(\tH\x02R\rstakeholderId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tH\x03R\teventType\x88\x01\x01\x12\x16\n" +
+	"\x06topics\x18\x05 \x03(\tR\x06topics\x12\x1b\n" +
+	"\x06cursor\x18\x06 \x01(\tH\x04R\x06cursor\x88\x01\x01B\r\n" +
+	"\v_product_idB\r\n" +
+	"\v_lot_numberB\x11\n" +
+	"\x0f_stakeholder_idB\r\n" +
+	"\v_event_typeB\t\n" +
+	"\a_cursor\"t\n" +
+	"\fEventMessage\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x126\n" +
+	"\x05event\x18\x03 \x01(\v2 .supplychain.v1.SupplyChainEventR\x05event\"{\n" +
+	"\x11RecordEventResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1e\n" +
+	"\bevent_id\x18\x02 \x01(\tH\x00R\aeventId\x88\x01\x01\x12\x19\n" +
+	"\x05error\x18\x03 \x01(\tH\x01R\x05error\x88\x01\x01B\v\n" +
+	"\t_event_idB\b\n" +
+	"\x06_error\"\x87\x01\n" +
+	"\x14RecordEventsResponse\x12\x1a\n" +
+	"\brecorded\x18\x01 \x01(\x05R\brecorded\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x05R\x06failed\x12;\n" +
+	"\aresults\x18\x03 \x03(\v2!.supplychain.v1.RecordEventResultR\aresults2\xae\a\n" +
+	"\x12SupplyChainService\x12S\n" +
+	"\vRecordEvent\x12\".supplychain.v1.RecordEventRequest\x1a .supplychain.v1.SupplyChainEvent\x12M\n" +
+	"\bGetEvent\x12\x1f.supplychain.v1.GetEventRequest\x1a .supplychain.v1.SupplyChainEvent\x12V\n" +
+	"\vDeleteEvent\x12\".supplychain.v1.DeleteEventRequest\x1a#.supplychain.v1.DeleteEventResponse\x12S\n" +
+	"\n" +
+	"ListEvents\x12!.supplychain.v1.ListEventsRequest\x1a\".supplychain.v1.ListEventsResponse\x12W\n" +
+	"\x0fGetProductTrace\x12&.supplychain.v1.GetProductTraceRequest\x1a\x1c.supplychain.v1.ProductTrace\x12S\n" +
+	"\vVerifyEvent\x12\".supplychain.v1.VerifyEventRequest\x1a .supplychain.v1.SupplyChainEvent\x12n\n" +
+	"\x13ListEventsByProduct\x12*.supplychain.v1.ListEventsByProductRequest\x1a+.supplychain.v1.ListEventsByProductResponse\x12z\n" +
+	"\x17ListEventsByStakeholder\x12..supplychain.v1.ListEventsByStakeholderRequest\x1a/.supplychain.v1.ListEventsByStakeholderResponse\x12Q\n" +
+	"\vWatchEvents\x12\".supplychain.v1.WatchEventsRequest\x1a\x1c.supplychain.v1.EventMessage0\x01\x12Z\n" +
+	"\fRecordEvents\x12\".supplychain.v1.RecordEventRequest\x1a$.supplychain.v1.RecordEventsResponse(\x01BJZHgithub.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1;supplychainv1b\x06proto3"
+
+var (
+	file_supplychain_v1_supply_chain_proto_rawDescOnce sync.Once
+	file_supplychain_v1_supply_chain_proto_rawDescData []byte
+)
+
+func file_supplychain_v1_supply_chain_proto_rawDescGZIP() []byte {
+	file_supplychain_v1_supply_chain_proto_rawDescOnce.Do(func() {
+		file_supplychain_v1_supply_chain_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_supplychain_v1_supply_chain_proto_rawDesc), len(file_supplychain_v1_supply_chain_proto_rawDesc)))
+	})
+	return file_supplychain_v1_supply_chain_proto_rawDescData
+}
+
+var file_supplychain_v1_supply_chain_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_supplychain_v1_supply_chain_proto_goTypes = []any{
+	(*TransformationLine)(nil),              // 0: supplychain.v1.TransformationLine
+	(*RecordEventRequest)(nil),              // 1: supplychain.v1.RecordEventRequest
+	(*GetEventRequest)(nil),                 // 2: supplychain.v1.GetEventRequest
+	(*DeleteEventRequest)(nil),              // 3: supplychain.v1.DeleteEventRequest
+	(*DeleteEventResponse)(nil),             // 4: supplychain.v1.DeleteEventResponse
+	(*ListEventsRequest)(nil),               // 5: supplychain.v1.ListEventsRequest
+	(*ListEventsResponse)(nil),              // 6: supplychain.v1.ListEventsResponse
+	(*GetProductTraceRequest)(nil),          // 7: supplychain.v1.GetProductTraceRequest
+	(*InheritedEvent)(nil),                  // 8: supplychain.v1.InheritedEvent
+	(*ProductTrace)(nil),                    // 9: supplychain.v1.ProductTrace
+	(*VerifyEventRequest)(nil),              // 10: supplychain.v1.VerifyEventRequest
+	(*ListEventsByProductRequest)(nil),      // 11: supplychain.v1.ListEventsByProductRequest
+	(*ListEventsByProductResponse)(nil),     // 12: supplychain.v1.ListEventsByProductResponse
+	(*ListEventsByStakeholderRequest)(nil),  // 13: supplychain.v1.ListEventsByStakeholderRequest
+	(*ListEventsByStakeholderResponse)(nil), // 14: supplychain.v1.ListEventsByStakeholderResponse
+	(*WatchEventsRequest)(nil),              // 15: supplychain.v1.WatchEventsRequest
+	(*EventMessage)(nil),                    // 16: supplychain.v1.EventMessage
+	(*RecordEventResult)(nil),               // 17: supplychain.v1.RecordEventResult
+	(*RecordEventsResponse)(nil),            // 18: supplychain.v1.RecordEventsResponse
+	(*timestamppb.Timestamp)(nil),           // 19: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                 // 20: google.protobuf.Struct
+	(*Predicate)(nil),                       // 21: supplychain.v1.Predicate
+	(*GeoRadius)(nil),                       // 22: supplychain.v1.GeoRadius
+	(*SupplyChainEvent)(nil),                // 23: supplychain.v1.SupplyChainEvent
+	(*Page)(nil),                            // 24: supplychain.v1.Page
+	(*Product)(nil),                         // 25: supplychain.v1.Product
+}
+var file_supplychain_v1_supply_chain_proto_depIdxs = []int32{
+	19, // 0: supplychain.v1.RecordEventRequest.timestamp:type_name -> google.protobuf.Timestamp
+	20, // 1: supplychain.v1.RecordEventRequest.metadata:type_name -> google.protobuf.Struct
+	0,  // 2: supplychain.v1.RecordEventRequest.inputs:type_name -> supplychain.v1.TransformationLine
+	0,  // 3: supplychain.v1.RecordEventRequest.outputs:type_name -> supplychain.v1.TransformationLine
+	19, // 4: supplychain.v1.ListEventsRequest.from_date:type_name -> google.protobuf.Timestamp
+	19, // 5: supplychain.v1.ListEventsRequest.to_date:type_name -> google.protobuf.Timestamp
+	21, // 6: supplychain.v1.ListEventsRequest.where:type_name -> supplychain.v1.Predicate
+	22, // 7: supplychain.v1.ListEventsRequest.near:type_name -> supplychain.v1.GeoRadius
+	23, // 8: supplychain.v1.ListEventsResponse.events:type_name -> supplychain.v1.SupplyChainEvent
+	24, // 9: supplychain.v1.ListEventsResponse.page:type_name -> supplychain.v1.Page
+	23, // 10: supplychain.v1.InheritedEvent.event:type_name -> supplychain.v1.SupplyChainEvent
+	25, // 11: supplychain.v1.ProductTrace.product:type_name -> supplychain.v1.Product
+	23, // 12: supplychain.v1.ProductTrace.events:type_name -> supplychain.v1.SupplyChainEvent
+	8,  // 13: supplychain.v1.ProductTrace.inherited_events:type_name -> supplychain.v1.InheritedEvent
+	23, // 14: supplychain.v1.ListEventsByProductResponse.events:type_name -> supplychain.v1.SupplyChainEvent
+	23, // 15: supplychain.v1.ListEventsByStakeholderResponse.events:type_name -> supplychain.v1.SupplyChainEvent
+	23, // 16: supplychain.v1.EventMessage.event:type_name -> supplychain.v1.SupplyChainEvent
+	17, // 17: supplychain.v1.RecordEventsResponse.results:type_name -> supplychain.v1.RecordEventResult
+	1,  // 18: supplychain.v1.SupplyChainService.RecordEvent:input_type -> supplychain.v1.RecordEventRequest
+	2,  // 19: supplychain.v1.SupplyChainService.GetEvent:input_type -> supplychain.v1.GetEventRequest
+	3,  // 20: supplychain.v1.SupplyChainService.DeleteEvent:input_type -> supplychain.v1.DeleteEventRequest
+	5,  // 21: supplychain.v1.SupplyChainService.ListEvents:input_type -> supplychain.v1.ListEventsRequest
+	7,  // 22: supplychain.v1.SupplyChainService.GetProductTrace:input_type -> supplychain.v1.GetProductTraceRequest
+	10, // 23: supplychain.v1.SupplyChainService.VerifyEvent:input_type -> supplychain.v1.VerifyEventRequest
+	11, // 24: supplychain.v1.SupplyChainService.ListEventsByProduct:input_type -> supplychain.v1.ListEventsByProductRequest
+	13, // 25: supplychain.v1.SupplyChainService.ListEventsByStakeholder:input_type -> supplychain.v1.ListEventsByStakeholderRequest
+	15, // 26: supplychain.v1.SupplyChainService.WatchEvents:input_type -> supplychain.v1.WatchEventsRequest
+	1,  // 27: supplychain.v1.SupplyChainService.RecordEvents:input_type -> supplychain.v1.RecordEventRequest
+	23, // 28: supplychain.v1.SupplyChainService.RecordEvent:output_type -> supplychain.v1.SupplyChainEvent
+	23, // 29: supplychain.v1.SupplyChainService.GetEvent:output_type -> supplychain.v1.SupplyChainEvent
+	4,  // 30: supplychain.v1.SupplyChainService.DeleteEvent:output_type -> supplychain.v1.DeleteEventResponse
+	6,  // 31: supplychain.v1.SupplyChainService.ListEvents:output_type -> supplychain.v1.ListEventsResponse
+	9,  // 32: supplychain.v1.SupplyChainService.GetProductTrace:output_type -> supplychain.v1.ProductTrace
+	23, // 33: supplychain.v1.SupplyChainService.VerifyEvent:output_type -> supplychain.v1.SupplyChainEvent
+	12, // 34: supplychain.v1.SupplyChainService.ListEventsByProduct:output_type -> supplychain.v1.ListEventsByProductResponse
+	14, // 35: supplychain.v1.SupplyChainService.ListEventsByStakeholder:output_type -> supplychain.v1.ListEventsByStakeholderResponse
+	16, // 36: supplychain.v1.SupplyChainService.WatchEvents:output_type -> supplychain.v1.EventMessage
+	18, // 37: supplychain.v1.SupplyChainService.RecordEvents:output_type -> supplychain.v1.RecordEventsResponse
+	28, // [28:38] is the sub-list for method output_type
+	18, // [18:28] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_supplychain_v1_supply_chain_proto_init() }
+func file_supplychain_v1_supply_chain_proto_init() {
+	if File_supplychain_v1_supply_chain_proto != nil {
+		return
+	}
+	file_supplychain_v1_types_proto_init()
+	file_supplychain_v1_supply_chain_proto_msgTypes[0].OneofWrappers = []any{}
+	file_supplychain_v1_supply_chain_proto_msgTypes[1].OneofWrappers = []any{}
+	file_supplychain_v1_supply_chain_proto_msgTypes[5].OneofWrappers = []any{}
+	file_supplychain_v1_supply_chain_proto_msgTypes[15].OneofWrappers = []any{}
+	file_supplychain_v1_supply_chain_proto_msgTypes[17].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_supplychain_v1_supply_chain_proto_rawDesc), len(file_supplychain_v1_supply_chain_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_supplychain_v1_supply_chain_proto_goTypes,
+		DependencyIndexes: file_supplychain_v1_supply_chain_proto_depIdxs,
+		MessageInfos:      file_supplychain_v1_supply_chain_proto_msgTypes,
+	}.Build()
+	File_supplychain_v1_supply_chain_proto = out.File
+	file_supplychain_v1_supply_chain_proto_goTypes = nil
+	file_supplychain_v1_supply_chain_proto_depIdxs = nil
+}
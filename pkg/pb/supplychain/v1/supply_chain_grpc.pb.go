@@ -0,0 +1,468 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: supplychain/v1/supply_chain.proto
+
+package supplychainv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SupplyChainService_RecordEvent_FullMethodName             = "/supplychain.v1.SupplyChainService/RecordEvent"
+	SupplyChainService_GetEvent_FullMethodName                = "/supplychain.v1.SupplyChainService/GetEvent"
+	SupplyChainService_DeleteEvent_FullMethodName             = "/supplychain.v1.SupplyChainService/DeleteEvent"
+	SupplyChainService_ListEvents_FullMethodName              = "/supplychain.v1.SupplyChainService/ListEvents"
+	SupplyChainService_GetProductTrace_FullMethodName         = "/supplychain.v1.SupplyChainService/GetProductTrace"
+	SupplyChainService_VerifyEvent_FullMethodName             = "/supplychain.v1.SupplyChainService/VerifyEvent"
+	SupplyChainService_ListEventsByProduct_FullMethodName     = "/supplychain.v1.SupplyChainService/ListEventsByProduct"
+	SupplyChainService_ListEventsByStakeholder_FullMethodName = "/supplychain.v1.SupplyChainService/ListEventsByStakeholder"
+	SupplyChainService_WatchEvents_FullMethodName             = "/supplychain.v1.SupplyChainService/WatchEvents"
+	SupplyChainService_RecordEvents_FullMethodName            = "/supplychain.v1.SupplyChainService/RecordEvents"
+)
+
+// SupplyChainServiceClient is the client API for SupplyChainService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SupplyChainServiceClient interface {
+	RecordEvent(ctx context.Context, in *RecordEventRequest, opts ...grpc.CallOption) (*SupplyChainEvent, error)
+	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*SupplyChainEvent, error)
+	DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*DeleteEventResponse, error)
+	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	GetProductTrace(ctx context.Context, in *GetProductTraceRequest, opts ...grpc.CallOption) (*ProductTrace, error)
+	VerifyEvent(ctx context.Context, in *VerifyEventRequest, opts ...grpc.CallOption) (*SupplyChainEvent, error)
+	ListEventsByProduct(ctx context.Context, in *ListEventsByProductRequest, opts ...grpc.CallOption) (*ListEventsByProductResponse, error)
+	ListEventsByStakeholder(ctx context.Context, in *ListEventsByStakeholderRequest, opts ...grpc.CallOption) (*ListEventsByStakeholderResponse, error)
+	// WatchEvents streams events as they are recorded or verified. With a cursor it first replays
+	// everything after it; resume after a disconnect with the last cursor received.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EventMessage], error)
+	// RecordEvents records a stream of events in the order they are sent. Each event is validated
+	// and recorded on its own, so one rejected event does not stop the others.
+	RecordEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RecordEventRequest, RecordEventsResponse], error)
+}
+
+type supplyChainServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSupplyChainServiceClient(cc grpc.ClientConnInterface) SupplyChainServiceClient {
+	return &supplyChainServiceClient{cc}
+}
+
+func (c *supplyChainServiceClient) RecordEvent(ctx context.Context, in *RecordEventRequest, opts ...grpc.CallOption) (*SupplyChainEvent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SupplyChainEvent)
+	err := c.cc.Invoke(ctx, SupplyChainService_RecordEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyChainServiceClient) GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*SupplyChainEvent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SupplyChainEvent)
+	err := c.cc.Invoke(ctx, SupplyChainService_GetEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyChainServiceClient) DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*DeleteEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteEventResponse)
+	err := c.cc.Invoke(ctx, SupplyChainService_DeleteEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyChainServiceClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEventsResponse)
+	err := c.cc.Invoke(ctx, SupplyChainService_ListEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyChainServiceClient) GetProductTrace(ctx context.Context, in *GetProductTraceRequest, opts ...grpc.CallOption) (*ProductTrace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductTrace)
+	err := c.cc.Invoke(ctx, SupplyChainService_GetProductTrace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyChainServiceClient) VerifyEvent(ctx context.Context, in *VerifyEventRequest, opts ...grpc.CallOption) (*SupplyChainEvent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SupplyChainEvent)
+	err := c.cc.Invoke(ctx, SupplyChainService_VerifyEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyChainServiceClient) ListEventsByProduct(ctx context.Context, in *ListEventsByProductRequest, opts ...grpc.CallOption) (*ListEventsByProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEventsByProductResponse)
+	err := c.cc.Invoke(ctx, SupplyChainService_ListEventsByProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyChainServiceClient) ListEventsByStakeholder(ctx context.Context, in *ListEventsByStakeholderRequest, opts ...grpc.CallOption) (*ListEventsByStakeholderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEventsByStakeholderResponse)
+	err := c.cc.Invoke(ctx, SupplyChainService_ListEventsByStakeholder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyChainServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EventMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SupplyChainService_ServiceDesc.Streams[0], SupplyChainService_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, EventMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SupplyChainService_WatchEventsClient = grpc.ServerStreamingClient[EventMessage]
+
+func (c *supplyChainServiceClient) RecordEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RecordEventRequest, RecordEventsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SupplyChainService_ServiceDesc.Streams[1], SupplyChainService_RecordEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RecordEventRequest, RecordEventsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SupplyChainService_RecordEventsClient = grpc.ClientStreamingClient[RecordEventRequest, RecordEventsResponse]
+
+// SupplyChainServiceServer is the server API for SupplyChainService service.
+// All implementations must embed UnimplementedSupplyChainServiceServer
+// for forward compatibility.
+type SupplyChainServiceServer interface {
+	RecordEvent(context.Context, *RecordEventRequest) (*SupplyChainEvent, error)
+	GetEvent(context.Context, *GetEventRequest) (*SupplyChainEvent, error)
+	DeleteEvent(context.Context, *DeleteEventRequest) (*DeleteEventResponse, error)
+	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	GetProductTrace(context.Context, *GetProductTraceRequest) (*ProductTrace, error)
+	VerifyEvent(context.Context, *VerifyEventRequest) (*SupplyChainEvent, error)
+	ListEventsByProduct(context.Context, *ListEventsByProductRequest) (*ListEventsByProductResponse, error)
+	ListEventsByStakeholder(context.Context, *ListEventsByStakeholderRequest) (*ListEventsByStakeholderResponse, error)
+	// WatchEvents streams events as they are recorded or verified. With a cursor it first replays
+	// everything after it; resume after a disconnect with the last cursor received.
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[EventMessage]) error
+	// RecordEvents records a stream of events in the order they are sent. Each event is validated
+	// and recorded on its own, so one rejected event does not stop the others.
+	RecordEvents(grpc.ClientStreamingServer[RecordEventRequest, RecordEventsResponse]) error
+	mustEmbedUnimplementedSupplyChainServiceServer()
+}
+
+// UnimplementedSupplyChainServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSupplyChainServiceServer struct{}
+
+func (UnimplementedSupplyChainServiceServer) RecordEvent(context.Context, *RecordEventRequest) (*SupplyChainEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordEvent not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) GetEvent(context.Context, *GetEventRequest) (*SupplyChainEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvent not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) DeleteEvent(context.Context, *DeleteEventRequest) (*DeleteEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEvent not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) GetProductTrace(context.Context, *GetProductTraceRequest) (*ProductTrace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductTrace not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) VerifyEvent(context.Context, *VerifyEventRequest) (*SupplyChainEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEvent not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) ListEventsByProduct(context.Context, *ListEventsByProductRequest) (*ListEventsByProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEventsByProduct not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) ListEventsByStakeholder(context.Context, *ListEventsByStakeholderRequest) (*ListEventsByStakeholderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEventsByStakeholder not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[EventMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) RecordEvents(grpc.ClientStreamingServer[RecordEventRequest, RecordEventsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method RecordEvents not implemented")
+}
+func (UnimplementedSupplyChainServiceServer) mustEmbedUnimplementedSupplyChainServiceServer() {}
+func (UnimplementedSupplyChainServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeSupplyChainServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SupplyChainServiceServer will
+// result in compilation errors.
+type UnsafeSupplyChainServiceServer interface {
+	mustEmbedUnimplementedSupplyChainServiceServer()
+}
+
+func RegisterSupplyChainServiceServer(s grpc.ServiceRegistrar, srv SupplyChainServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSupplyChainServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SupplyChainService_ServiceDesc, srv)
+}
+
+func _SupplyChainService_RecordEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyChainServiceServer).RecordEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplyChainService_RecordEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyChainServiceServer).RecordEvent(ctx, req.(*RecordEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyChainService_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyChainServiceServer).GetEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplyChainService_GetEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyChainServiceServer).GetEvent(ctx, req.(*GetEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyChainService_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyChainServiceServer).DeleteEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplyChainService_DeleteEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyChainServiceServer).DeleteEvent(ctx, req.(*DeleteEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyChainService_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyChainServiceServer).ListEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplyChainService_ListEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyChainServiceServer).ListEvents(ctx, req.(*ListEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyChainService_GetProductTrace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductTraceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyChainServiceServer).GetProductTrace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplyChainService_GetProductTrace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyChainServiceServer).GetProductTrace(ctx, req.(*GetProductTraceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyChainService_VerifyEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyChainServiceServer).VerifyEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplyChainService_VerifyEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyChainServiceServer).VerifyEvent(ctx, req.(*VerifyEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyChainService_ListEventsByProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventsByProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyChainServiceServer).ListEventsByProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplyChainService_ListEventsByProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyChainServiceServer).ListEventsByProduct(ctx, req.(*ListEventsByProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyChainService_ListEventsByStakeholder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventsByStakeholderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyChainServiceServer).ListEventsByStakeholder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplyChainService_ListEventsByStakeholder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyChainServiceServer).ListEventsByStakeholder(ctx, req.(*ListEventsByStakeholderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyChainService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SupplyChainServiceServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, EventMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SupplyChainService_WatchEventsServer = grpc.ServerStreamingServer[EventMessage]
+
+func _SupplyChainService_RecordEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SupplyChainServiceServer).RecordEvents(&grpc.GenericServerStream[RecordEventRequest, RecordEventsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SupplyChainService_RecordEventsServer = grpc.ClientStreamingServer[RecordEventRequest, RecordEventsResponse]
+
+// SupplyChainService_ServiceDesc is the grpc.ServiceDesc for SupplyChainService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SupplyChainService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "supplychain.v1.SupplyChainService",
+	HandlerType: (*SupplyChainServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RecordEvent",
+			Handler:    _SupplyChainService_RecordEvent_Handler,
+		},
+		{
+			MethodName: "GetEvent",
+			Handler:    _SupplyChainService_GetEvent_Handler,
+		},
+		{
+			MethodName: "DeleteEvent",
+			Handler:    _SupplyChainService_DeleteEvent_Handler,
+		},
+		{
+			MethodName: "ListEvents",
+			Handler:    _SupplyChainService_ListEvents_Handler,
+		},
+		{
+			MethodName: "GetProductTrace",
+			Handler:    _SupplyChainService_GetProductTrace_Handler,
+		},
+		{
+			MethodName: "VerifyEvent",
+			Handler:    _SupplyChainService_VerifyEvent_Handler,
+		},
+		{
+			MethodName: "ListEventsByProduct",
+			Handler:    _SupplyChainService_ListEventsByProduct_Handler,
+		},
+		{
+			MethodName: "ListEventsByStakeholder",
+			Handler:    _SupplyChainService_ListEventsByStakeholder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _SupplyChainService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RecordEvents",
+			Handler:       _SupplyChainService_RecordEvents_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "supplychain/v1/supply_chain.proto",
+}
@@ -0,0 +1,886 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: supplychain/v1/types.proto
+
+package supplychainv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Stakeholder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // manufacturer, distributor or retailer
+	WalletAddress *string                `protobuf:"bytes,4,opt,name=wallet_address,json=walletAddress,proto3,oneof" json:"wallet_address,omitempty"`
+	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         *string                `protobuf:"bytes,6,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	Address       *string                `protobuf:"bytes,7,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	IsVerified    bool                   `protobuf:"varint,8,opt,name=is_verified,json=isVerified,proto3" json:"is_verified,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stakeholder) Reset() {
+	*x = Stakeholder{}
+	mi := &file_supplychain_v1_types_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stakeholder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stakeholder) ProtoMessage() {}
+
+func (x *Stakeholder) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_types_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stakeholder.ProtoReflect.Descriptor instead.
+func (*Stakeholder) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Stakeholder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Stakeholder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Stakeholder) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Stakeholder) GetWalletAddress() string {
+	if x != nil && x.WalletAddress != nil {
+		return *x.WalletAddress
+	}
+	return ""
+}
+
+func (x *Stakeholder) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Stakeholder) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *Stakeholder) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *Stakeholder) GetIsVerified() bool {
+	if x != nil {
+		return x.IsVerified
+	}
+	return false
+}
+
+func (x *Stakeholder) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Stakeholder) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Product struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sku            string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description    *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Category       *string                `protobuf:"bytes,5,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	ManufacturerId *string                `protobuf:"bytes,6,opt,name=manufacturer_id,json=manufacturerId,proto3,oneof" json:"manufacturer_id,omitempty"`
+	LotNumber      *string                `protobuf:"bytes,7,opt,name=lot_number,json=lotNumber,proto3,oneof" json:"lot_number,omitempty"`
+	SerialNumber   *string                `protobuf:"bytes,8,opt,name=serial_number,json=serialNumber,proto3,oneof" json:"serial_number,omitempty"`
+	Metadata       *structpb.Struct       `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Manufacturer   *Stakeholder           `protobuf:"bytes,12,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"` // set when loaded with the product
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_supplychain_v1_types_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_types_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *Product) GetManufacturerId() string {
+	if x != nil && x.ManufacturerId != nil {
+		return *x.ManufacturerId
+	}
+	return ""
+}
+
+func (x *Product) GetLotNumber() string {
+	if x != nil && x.LotNumber != nil {
+		return *x.LotNumber
+	}
+	return ""
+}
+
+func (x *Product) GetSerialNumber() string {
+	if x != nil && x.SerialNumber != nil {
+		return *x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Product) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetManufacturer() *Stakeholder {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return nil
+}
+
+type SupplyChainEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId      *string                `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3,oneof" json:"product_id,omitempty"`
+	StakeholderId  *string                `protobuf:"bytes,3,opt,name=stakeholder_id,json=stakeholderId,proto3,oneof" json:"stakeholder_id,omitempty"`
+	EventType      string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Location       *string                `protobuf:"bytes,5,opt,name=location,proto3,oneof" json:"location,omitempty"`
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Metadata       *structpb.Struct       `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	BlockchainHash *string                `protobuf:"bytes,8,opt,name=blockchain_hash,json=blockchainHash,proto3,oneof" json:"blockchain_hash,omitempty"`
+	IsVerified     bool                   `protobuf:"varint,9,opt,name=is_verified,json=isVerified,proto3" json:"is_verified,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Product        *Product               `protobuf:"bytes,11,opt,name=product,proto3" json:"product,omitempty"`                               // set when loaded with the event
+	Stakeholder    *Stakeholder           `protobuf:"bytes,12,opt,name=stakeholder,proto3" json:"stakeholder,omitempty"`                       // set when loaded with the event
+	LocationId     *string                `protobuf:"bytes,13,opt,name=location_id,json=locationId,proto3,oneof" json:"location_id,omitempty"` // the registered location the event was recorded at
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SupplyChainEvent) Reset() {
+	*x = SupplyChainEvent{}
+	mi := &file_supplychain_v1_types_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SupplyChainEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupplyChainEvent) ProtoMessage() {}
+
+func (x *SupplyChainEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_types_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupplyChainEvent.ProtoReflect.Descriptor instead.
+func (*SupplyChainEvent) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SupplyChainEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SupplyChainEvent) GetProductId() string {
+	if x != nil && x.ProductId != nil {
+		return *x.ProductId
+	}
+	return ""
+}
+
+func (x *SupplyChainEvent) GetStakeholderId() string {
+	if x != nil && x.StakeholderId != nil {
+		return *x.StakeholderId
+	}
+	return ""
+}
+
+func (x *SupplyChainEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *SupplyChainEvent) GetLocation() string {
+	if x != nil && x.Location != nil {
+		return *x.Location
+	}
+	return ""
+}
+
+func (x *SupplyChainEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SupplyChainEvent) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SupplyChainEvent) GetBlockchainHash() string {
+	if x != nil && x.BlockchainHash != nil {
+		return *x.BlockchainHash
+	}
+	return ""
+}
+
+func (x *SupplyChainEvent) GetIsVerified() bool {
+	if x != nil {
+		return x.IsVerified
+	}
+	return false
+}
+
+func (x *SupplyChainEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SupplyChainEvent) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *SupplyChainEvent) GetStakeholder() *Stakeholder {
+	if x != nil {
+		return x.Stakeholder
+	}
+	return nil
+}
+
+func (x *SupplyChainEvent) GetLocationId() string {
+	if x != nil && x.LocationId != nil {
+		return *x.LocationId
+	}
+	return ""
+}
+
+type BlockchainTransaction struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId         *string                `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3,oneof" json:"event_id,omitempty"`
+	TransactionHash string                 `protobuf:"bytes,3,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	BlockNumber     *int64                 `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3,oneof" json:"block_number,omitempty"`
+	GasUsed         *int64                 `protobuf:"varint,5,opt,name=gas_used,json=gasUsed,proto3,oneof" json:"gas_used,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // pending, confirmed or failed
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BlockchainTransaction) Reset() {
+	*x = BlockchainTransaction{}
+	mi := &file_supplychain_v1_types_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockchainTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockchainTransaction) ProtoMessage() {}
+
+func (x *BlockchainTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_types_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockchainTransaction.ProtoReflect.Descriptor instead.
+func (*BlockchainTransaction) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BlockchainTransaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BlockchainTransaction) GetEventId() string {
+	if x != nil && x.EventId != nil {
+		return *x.EventId
+	}
+	return ""
+}
+
+func (x *BlockchainTransaction) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *BlockchainTransaction) GetBlockNumber() int64 {
+	if x != nil && x.BlockNumber != nil {
+		return *x.BlockNumber
+	}
+	return 0
+}
+
+func (x *BlockchainTransaction) GetGasUsed() int64 {
+	if x != nil && x.GasUsed != nil {
+		return *x.GasUsed
+	}
+	return 0
+}
+
+func (x *BlockchainTransaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BlockchainTransaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Predicate is a list query filter, as filter[field][op]=value is in the REST API
+type Predicate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // a field, or a key path in a JSON field such as metadata.temperature
+	Op            string                 `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`       // eq (default), ne, gt, gte, lt, lte, in, like or exists
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"` // comma separated for in, true or false for exists
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Predicate) Reset() {
+	*x = Predicate{}
+	mi := &file_supplychain_v1_types_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Predicate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Predicate) ProtoMessage() {}
+
+func (x *Predicate) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_types_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Predicate.ProtoReflect.Descriptor instead.
+func (*Predicate) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Predicate) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Predicate) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Predicate) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// GeoRadius selects what lies within radius_km kilometres of a point
+type GeoRadius struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	RadiusKm      float64                `protobuf:"fixed64,3,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoRadius) Reset() {
+	*x = GeoRadius{}
+	mi := &file_supplychain_v1_types_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoRadius) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoRadius) ProtoMessage() {}
+
+func (x *GeoRadius) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_types_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoRadius.ProtoReflect.Descriptor instead.
+func (*GeoRadius) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GeoRadius) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoRadius) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoRadius) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+// Page mirrors the REST API's paginated response
+type Page struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Total          *int32                 `protobuf:"varint,1,opt,name=total,proto3,oneof" json:"total,omitempty"` // unset when count is "none"
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset         int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	HasMore        bool                   `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	TotalEstimated bool                   `protobuf:"varint,5,opt,name=total_estimated,json=totalEstimated,proto3" json:"total_estimated,omitempty"`
+	NextCursor     *string                `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"` // pass as cursor to get the next page
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_supplychain_v1_types_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_supplychain_v1_types_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_supplychain_v1_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Page) GetTotal() int32 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+func (x *Page) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Page) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Page) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *Page) GetTotalEstimated() bool {
+	if x != nil {
+		return x.TotalEstimated
+	}
+	return false
+}
+
+func (x *Page) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+var File_supplychain_v1_types_proto protoreflect.FileDescriptor
+
+const file_supplychain_v1_types_proto_rawDesc = "" +
+	"\n" +
+	"\x1asupplychain/v1/types.proto\x12\x0esupplychain.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x03\n" +
+	"\vStakeholder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12*\n" +
+	"\x0ewallet_address\x18\x04 \x01(\tH\x00R\rwalletAddress\x88\x01\x01\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x19\n" +
+	"\x05phone\x18\x06 \x01(\tH\x01R\x05phone\x88\x01\x01\x12\x1d\n" +
+	"\aaddress\x18\a \x01(\tH\x02R\aaddress\x88\x01\x01\x12\x1f\n" +
+	"\vis_verified\x18\b \x01(\bR\n" +
+	"isVerified\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x11\n" +
+	"\x0f_wallet_addressB\b\n" +
+	"\x06_phoneB\n" +
+	"\n" +
+	"\b_address\"\xc1\x04\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\x05 \x01(\tH\x01R\bcategory\x88\x01\x01\x12,\n" +
+	"\x0fmanufacturer_id\x18\x06 \x01(\tH\x02R\x0emanufacturerId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"lot_number\x18\a \x01(\tH\x03R\tlotNumber\x88\x01\x01\x12(\n" +
+	"\rserial_number\x18\b \x01(\tH\x04R\fserialNumber\x88\x01\x01\x123\n" +
+	"\bmetadata\x18\t \x01(\v2\x17.google.protobuf.StructR\bmetadata\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12?\n" +
+	"\fmanufacturer\x18\f \x01(\v2\x1b.supplychain.v1.StakeholderR\fmanufacturerB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_categoryB\x12\n" +
+	"\x10_manufacturer_idB\r\n" +
+	"\v_lot_numberB\x10\n" +
+	"\x0e_serial_number\"\x96\x05\n" +
+	"\x10SupplyChainEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tH\x00R\tproductId\x88\x01\x01\x12*\n" +
+	"\x0estakeholder_id\x18\x03 \x01(\tH\x01R\rstakeholderId\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12\x1f\n" +
+	"\blocation\x18\x05 \x01(\tH\x02R\blocation\x88\x01\x01\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x123\n" +
+	"\bmetadata\x18\a \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12,\n" +
+	"\x0fblockchain_hash\x18\b \x01(\tH\x03R\x0eblockchainHash\x88\x01\x01\x12\x1f\n" +
+	"\vis_verified\x18\t \x01(\bR\n" +
+	"isVerified\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x121\n" +
+	"\aproduct\x18\v \x01(\v2\x17.supplychain.v1.ProductR\aproduct\x12=\n" +
+	"\vstakeholder\x18\f \x01(\v2\x1b.supplychain.v1.StakeholderR\vstakeholder\x12$\n" +
+	"\vlocation_id\x18\r \x01(\tH\x04R\n" +
+	"locationId\x88\x01\x01B\r\n" +
+	"\v_product_idB\x11\n" +
+	"\x0f_stakeholder_idB\v\n" +
+	"\t_locationB\x12\n" +
+	"\x10_blockchain_hashB\x0e\n" +
+	"\f_location_id\"\xb8\x02\n" +
+	"\x15BlockchainTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\bevent_id\x18\x02 \x01(\tH\x00R\aeventId\x88\x01\x01\x12)\n" +
+	"\x10transaction_hash\x18\x03 \x01(\tR\x0ftransactionHash\x12&\n" +
+	"\fblock_number\x18\x04 \x01(\x03H\x01R\vblockNumber\x88\x01\x01\x12\x1e\n" +
+	"\bgas_used\x18\x05 \x01(\x03H\x02R\agasUsed\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\v\n" +
+	"\t_event_idB\x0f\n" +
+	"\r_block_numberB\v\n" +
+	"\t_gas_used\"G\n" +
+	"\tPredicate\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"b\n" +
+	"\tGeoRadius\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1b\n" +
+	"\tradius_km\x18\x03 \x01(\x01R\bradiusKm\"\xd3\x01\n" +
+	"\x04Page\x12\x19\n" +
+	"\x05total\x18\x01 \x01(\x05H\x00R\x05total\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x19\n" +
+	"\bhas_more\x18\x04 \x01(\bR\ahasMore\x12'\n" +
+	"\x0ftotal_estimated\x18\x05 \x01(\bR\x0etotalEstimated\x12$\n" +
+	"\vnext_cursor\x18\x06 \x01(\tH\x01R\n" +
+	"nextCursor\x88\x01\x01B\b\n" +
+	"\x06_totalB\x0e\n" +
+	"\f_next_cursorBJZHgithub.com/koriebruh/suplyChainTrack/pkg/pb/supplychain/v1;supplychainv1b\x06proto3"
+
+var (
+	file_supplychain_v1_types_proto_rawDescOnce sync.Once
+	file_supplychain_v1_types_proto_rawDescData []byte
+)
+
+func file_supplychain_v1_types_proto_rawDescGZIP() []byte {
+	file_supplychain_v1_types_proto_rawDescOnce.Do(func() {
+		file_supplychain_v1_types_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_supplychain_v1_types_proto_rawDesc), len(file_supplychain_v1_types_proto_rawDesc)))
+	})
+	return file_supplychain_v1_types_proto_rawDescData
+}
+
+var file_supplychain_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_supplychain_v1_types_proto_goTypes = []any{
+	(*Stakeholder)(nil),           // 0: supplychain.v1.Stakeholder
+	(*Product)(nil),               // 1: supplychain.v1.Product
+	(*SupplyChainEvent)(nil),      // 2: supplychain.v1.SupplyChainEvent
+	(*BlockchainTransaction)(nil), // 3: supplychain.v1.BlockchainTransaction
+	(*Predicate)(nil),             // 4: supplychain.v1.Predicate
+	(*GeoRadius)(nil),             // 5: supplychain.v1.GeoRadius
+	(*Page)(nil),                  // 6: supplychain.v1.Page
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 8: google.protobuf.Struct
+}
+var file_supplychain_v1_types_proto_depIdxs = []int32{
+	7,  // 0: supplychain.v1.Stakeholder.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 1: supplychain.v1.Stakeholder.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: supplychain.v1.Product.metadata:type_name -> google.protobuf.Struct
+	7,  // 3: supplychain.v1.Product.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 4: supplychain.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: supplychain.v1.Product.manufacturer:type_name -> supplychain.v1.Stakeholder
+	7,  // 6: supplychain.v1.SupplyChainEvent.timestamp:type_name -> google.protobuf.Timestamp
+	8,  // 7: supplychain.v1.SupplyChainEvent.metadata:type_name -> google.protobuf.Struct
+	7,  // 8: supplychain.v1.SupplyChainEvent.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: supplychain.v1.SupplyChainEvent.product:type_name -> supplychain.v1.Product
+	0,  // 10: supplychain.v1.SupplyChainEvent.stakeholder:type_name -> supplychain.v1.Stakeholder
+	7,  // 11: supplychain.v1.BlockchainTransaction.created_at:type_name -> google.protobuf.Timestamp
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_supplychain_v1_types_proto_init() }
+func file_supplychain_v1_types_proto_init() {
+	if File_supplychain_v1_types_proto != nil {
+		return
+	}
+	file_supplychain_v1_types_proto_msgTypes[0].OneofWrappers = []any{}
+	file_supplychain_v1_types_proto_msgTypes[1].OneofWrappers = []any{}
+	file_supplychain_v1_types_proto_msgTypes[2].OneofWrappers = []any{}
+	file_supplychain_v1_types_proto_msgTypes[3].OneofWrappers = []any{}
+	file_supplychain_v1_types_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_supplychain_v1_types_proto_rawDesc), len(file_supplychain_v1_types_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_supplychain_v1_types_proto_goTypes,
+		DependencyIndexes: file_supplychain_v1_types_proto_depIdxs,
+		MessageInfos:      file_supplychain_v1_types_proto_msgTypes,
+	}.Build()
+	File_supplychain_v1_types_proto = out.File
+	file_supplychain_v1_types_proto_goTypes = nil
+	file_supplychain_v1_types_proto_depIdxs = nil
+}
@@ -1,16 +1,22 @@
 package route
 
 import (
+	"context"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
-	"github.com/gofiber/fiber/v2/middleware/etag"
 	"github.com/gofiber/swagger"
 	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/database"
+	"github.com/koriebruh/suplyChainTrack/internal/gql"
+	"github.com/koriebruh/suplyChainTrack/internal/grpcapi"
 	"github.com/koriebruh/suplyChainTrack/internal/handler"
 	"github.com/koriebruh/suplyChainTrack/internal/metirc"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
 	"github.com/koriebruh/suplyChainTrack/pkg"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"log/slog"
+	"net"
 )
 
 func RunApplicationContext() {
@@ -18,6 +24,33 @@ func RunApplicationContext() {
 	config := conf.LoadConfig() // Load configuration from .env or environment variables
 	metricsExporter := metirc.NewAppMetricsExporter()
 
+	/* SERVICES */
+	db, err := database.NewPostgres(config.DatabaseConfig)
+	if err != nil {
+		panic(err)
+	}
+	service := services.NewServiceManager(repository.NewRepositories(db), metricsExporter)
+
+	app := NewApp(config, service, metricsExporter)
+
+	/* BACKGROUND JOBS */
+	go service.Import.RunRecovery(context.Background()) // Fail imports a restart interrupted
+
+	/* GRPC SERVER */
+	if config.GRPCConfig.Port != 0 {
+		go RunGRPCServer(config, service)
+	}
+
+	if err := app.Listen(fmt.Sprintf(":%v", config.AppConfig.Port)); err != nil {
+		panic(err)
+	}
+
+	// Graceful shutdown
+}
+
+// NewApp builds the HTTP application over the service layer: middleware, the metrics endpoint and
+// every route
+func NewApp(config *conf.Config, service *services.ServiceManager, metricsExporter *metirc.AppMetricsExporter) *fiber.App {
 	/* APPLICATION SETTING */
 	app := fiber.New()
 	app.Use(metricsExporter.FiberMetricMiddleware()) // Middleware for collecting metrics
@@ -27,21 +60,60 @@ func RunApplicationContext() {
 	app.Use(conf.CompressionConfig)                  // Compress responses for more fast delivery
 	app.Use(conf.CORSConfig)                         // Enable CORS for all routes
 	app.Use(conf.RateLimitConfig)                    // Rate limiting to prevent abuse
-	app.Use(etag.New())                              // ETag middleware for caching
+	app.Use(conf.ETagConfig)                         // ETag middleware for caching
 
 	/*ROUTE FOR METRIC EXPORTER*/
-	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler())) // Endpoint to expose metrics
+	app.Get("/metrics", adaptor.HTTPHandler(metricsExporter.MetricsHandler())) // Endpoint to expose metrics
 
 	/* ROUTES */
+	DigitalLinkRoute(app, handler.NewDigitalLinkHandler(service.DigitalLink, config.DigitalLinkConfig))
+
 	api := app.Group("/api/v1")
 	MetricRoute(api, config)
+	// Stream connections check the API key or a stream token themselves, since browsers cannot set
+	// headers on them; issuing tokens needs the API key up front
+	api.Use("/stream/tokens", conf.APIKeyMiddleware())
+	StreamRoute(api, api, handler.NewStreamHandler(service.Stream, *config))
+	graphqlServer, err := gql.NewServer(service, gql.DefaultLimits)
+	if err != nil {
+		panic(err)
+	}
+	GraphQLRoute(api, conf.APIKeyMiddleware(), handler.NewGraphQLHandler(graphqlServer, *config))
 	api.Use(conf.APIKeyMiddleware())
 
-	if err := app.Listen(fmt.Sprintf(":%v", config.AppConfig.Port)); err != nil {
+	ContainmentRoute(api, handler.NewContainmentHandler(service.SupplyChain))
+	GenealogyRoute(api, handler.NewGenealogyHandler(service.SupplyChain))
+	CustodyRoute(api, handler.NewCustodyHandler(service.Custody))
+	InventoryRoute(api, handler.NewInventoryHandler(service.Custody))
+	RecallRoute(api, handler.NewRecallHandler(service.Recall))
+	EPCISRoute(api, handler.NewEPCISHandler(service.EPCIS))
+	LabelRoute(api, handler.NewLabelHandler(service.Label, config.DigitalLinkConfig))
+	ImportRoute(api, handler.NewImportHandler(service.Import))
+	ExportRoute(api, handler.NewExportHandler(service.Export))
+	WebhookRoute(api, handler.NewWebhookHandler(service.Webhook))
+	SearchRoute(api, handler.NewSearchHandler(service.Search))
+	LocationRoute(api, handler.NewLocationHandler(service.Location))
+	RoutePlanRoute(api, handler.NewRouteHandler(service.Route))
+	TelemetryRoute(api, handler.NewTelemetryHandler(service.Telemetry))
+	ExcursionRoute(api, handler.NewExcursionHandler(service.Excursion))
+	AnalyticsRoute(api, handler.NewAnalyticsHandler(service.Analytics))
+	ScorecardRoute(api, handler.NewScorecardHandler(service.Scorecard))
+	return app
+}
+
+// RunGRPCServer serves the gRPC API for partner systems over the REST API's service layer, so both
+// share one connection pool, the metrics and the API key
+func RunGRPCServer(config *conf.Config, service *services.ServiceManager) {
+	server := grpcapi.NewServer(service, *config)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%v", config.GRPCConfig.Port))
+	if err != nil {
+		panic(err)
+	}
+	slog.Info("gRPC server listening", "port", config.GRPCConfig.Port)
+	if err := server.Serve(listener); err != nil {
 		panic(err)
 	}
-
-	// Graceful shutdown
 }
 
 func MetricRoute(r fiber.Router, config *conf.Config) {
@@ -57,3 +129,209 @@ func BlockchainTxRoute(r fiber.Router, config *conf.Config) {}
 func SupplyChainRoute(r fiber.Router, config *conf.Config) {}
 
 func StakeHolderRoute(r fiber.Router, config *conf.Config) {}
+
+func ContainmentRoute(r fiber.Router, h handler.ContainmentHandler) {
+	products := r.Group("/products")
+	products.Get("/:id/containment", h.GetContainment)
+	products.Get("/:id/containment/history", h.GetContainmentHistory)
+}
+
+func GenealogyRoute(r fiber.Router, h handler.GenealogyHandler) {
+	r.Get("/products/:id/genealogy/upstream", h.GetUpstream)
+	r.Get("/products/:id/genealogy/downstream", h.GetDownstream)
+	r.Get("/lots/:lot/genealogy/upstream", h.GetLotUpstream)
+	r.Get("/lots/:lot/genealogy/downstream", h.GetLotDownstream)
+	r.Get("/supply-chain/events/:id/transformation", h.GetTransformationLines)
+}
+
+func CustodyRoute(r fiber.Router, h handler.CustodyHandler) {
+	transfers := r.Group("/custody-transfers")
+	transfers.Post("/", h.CreateTransfer)
+	transfers.Get("/", h.ListTransfers)
+	transfers.Get("/:id", h.GetTransfer)
+	transfers.Post("/:id/accept", h.AcceptTransfer)
+	transfers.Post("/:id/reject", h.RejectTransfer)
+
+	r.Get("/stakeholders/:id/custody-transfers/pending", h.ListPendingTransfers)
+}
+
+func InventoryRoute(r fiber.Router, h handler.InventoryHandler) {
+	r.Get("/products/:id/custody", h.GetCurrentCustody)
+	r.Get("/stakeholders/:id/inventory", h.ListStakeholderInventory)
+	r.Get("/inventory", h.ListInventory)
+}
+
+func RecallRoute(r fiber.Router, h handler.RecallHandler) {
+	recalls := r.Group("/recalls")
+	recalls.Post("/", h.CreateRecall)
+	recalls.Get("/", h.ListRecalls)
+	recalls.Get("/:id", h.GetRecall)
+	recalls.Post("/:id/activate", h.ActivateRecall)
+	recalls.Post("/:id/notify", h.NotifyStakeholders)
+	recalls.Post("/:id/acknowledge", h.AcknowledgeRecall)
+	recalls.Post("/:id/close", h.CloseRecall)
+	recalls.Get("/:id/units", h.ListAffectedUnits)
+	recalls.Get("/:id/report", h.GetRecallReport)
+
+	r.Get("/stakeholders/:id/recalls", h.ListStakeholderRecalls)
+}
+
+func EPCISRoute(r fiber.Router, h handler.EPCISHandler) {
+	epcis := r.Group("/epcis")
+	epcis.Post("/capture", h.Capture)
+	epcis.Get("/events", h.QueryEvents)
+	epcis.Get("/products/:id/trace", h.GetProductTrace)
+
+	xml := epcis.Group("/xml")
+	xml.Post("/capture", h.CaptureXML)
+	xml.Get("/events", h.ExportEventsXML)
+	xml.Get("/products/:id/trace", h.GetProductTraceXML)
+}
+
+func LabelRoute(r fiber.Router, h handler.LabelHandler) {
+	labels := r.Group("/labels")
+	labels.Get("/products/:id", h.GetProductLabel)
+	labels.Get("/products/:id/qr", h.GetProductQR)
+	labels.Get("/products/:id/lots/:lot", h.GetLotLabel)
+	labels.Get("/products/:id/lots/:lot/qr", h.GetLotQR)
+	labels.Post("/sheet", h.CreateLabelSheet)
+	labels.Get("/custody-transfers/:id/sheet", h.GetShipmentLabelSheet)
+}
+
+func ImportRoute(r fiber.Router, h handler.ImportHandler) {
+	imports := r.Group("/imports")
+	imports.Get("/", h.ListImportJobs)
+	imports.Get("/:id", h.GetImportJob)
+	imports.Get("/:id/errors", h.ListImportErrors)
+	imports.Post("/:kind", h.StartImport)
+}
+
+func WebhookRoute(r fiber.Router, h handler.WebhookHandler) {
+	webhooks := r.Group("/webhooks")
+	webhooks.Post("/", h.CreateSubscription)
+	webhooks.Get("/", h.ListSubscriptions)
+	webhooks.Get("/deliveries", h.ListDeliveries)
+	webhooks.Get("/deliveries/:id", h.GetDelivery)
+	webhooks.Post("/deliveries/:id/replay", h.ReplayDelivery)
+	webhooks.Get("/:id", h.GetSubscription)
+	webhooks.Patch("/:id", h.UpdateSubscription)
+	webhooks.Delete("/:id", h.DeleteSubscription)
+	webhooks.Post("/:id/rotate-secret", h.RotateSecret)
+	webhooks.Get("/:id/deliveries", h.ListDeliveries)
+}
+
+func ExportRoute(r fiber.Router, h handler.ExportHandler) {
+	exports := r.Group("/exports")
+	exports.Get("/events", h.ExportEvents)
+	exports.Get("/products", h.ExportProducts)
+}
+
+func SearchRoute(r fiber.Router, h handler.SearchHandler) {
+	r.Get("/search", h.Search)
+}
+
+func LocationRoute(r fiber.Router, h handler.LocationHandler) {
+	locations := r.Group("/locations")
+	locations.Post("/", h.CreateLocation)
+	locations.Get("/", h.ListLocations)
+	locations.Get("/nearby", h.ListNearby)
+	locations.Get("/nearby/events", h.ListEvents)
+	locations.Get("/:id", h.GetLocation)
+	locations.Put("/:id", h.UpdateLocation)
+	locations.Delete("/:id", h.DeleteLocation)
+	locations.Get("/:id/events", h.ListEvents)
+}
+
+func RoutePlanRoute(r fiber.Router, h handler.RouteHandler) {
+	transfers := r.Group("/custody-transfers")
+	transfers.Put("/:id/route", h.SetRoutePlan)
+	transfers.Get("/:id/route", h.GetRoutePlan)
+	transfers.Delete("/:id/route", h.DeleteRoutePlan)
+	transfers.Get("/:id/alerts", h.ListAlerts)
+
+	alerts := r.Group("/route-alerts")
+	alerts.Get("/", h.ListAlerts)
+	alerts.Get("/:id", h.GetAlert)
+	alerts.Post("/:id/acknowledge", h.AcknowledgeAlert)
+
+	r.Post("/positions", h.ReportPosition)
+}
+
+func TelemetryRoute(r fiber.Router, h handler.TelemetryHandler) {
+	devices := r.Group("/devices")
+	devices.Post("/", h.RegisterDevice)
+	devices.Get("/", h.ListDevices)
+	devices.Get("/:id", h.GetDevice)
+	devices.Put("/:id", h.UpdateDevice)
+	devices.Delete("/:id", h.DeleteDevice)
+	devices.Post("/:id/attach", h.AttachDevice)
+	devices.Post("/:id/detach", h.DetachDevice)
+
+	telemetry := r.Group("/telemetry")
+	telemetry.Post("/", h.Ingest)
+	telemetry.Get("/readings", h.ListReadings)
+	telemetry.Get("/series", h.GetSeries)
+}
+
+func ExcursionRoute(r fiber.Router, h handler.ExcursionHandler) {
+	rules := r.Group("/excursion-rules")
+	rules.Post("/", h.CreateRule)
+	rules.Get("/", h.ListRules)
+	rules.Get("/:id", h.GetRule)
+	rules.Put("/:id", h.UpdateRule)
+	rules.Delete("/:id", h.DeleteRule)
+
+	incidents := r.Group("/excursion-incidents")
+	incidents.Get("/", h.ListIncidents)
+	incidents.Get("/:id", h.GetIncident)
+	incidents.Post("/:id/acknowledge", h.AcknowledgeIncident)
+	r.Get("/custody-transfers/:id/excursions", h.ListIncidents)
+
+	quarantines := r.Group("/quarantines")
+	quarantines.Get("/", h.ListQuarantines)
+	quarantines.Get("/:id", h.GetQuarantine)
+	quarantines.Post("/:id/release", h.ReleaseQuarantine)
+	r.Get("/products/:id/quarantines", h.ListQuarantines)
+}
+
+func AnalyticsRoute(r fiber.Router, h handler.AnalyticsHandler) {
+	analytics := r.Group("/analytics")
+	analytics.Get("/dashboard", h.GetDashboard)
+	analytics.Get("/transit", h.GetTransitReport)
+	analytics.Get("/transit/legs", h.ListLegs)
+	analytics.Get("/dwell", h.GetDwellReport)
+	analytics.Get("/dwell/stays", h.ListDwells)
+	analytics.Get("/throughput", h.GetThroughput)
+	analytics.Post("/refresh", h.Refresh)
+}
+
+func ScorecardRoute(r fiber.Router, h handler.ScorecardHandler) {
+	r.Get("/stakeholders/:id/scorecard", h.GetScorecard)
+	r.Get("/scorecards", h.RankStakeholders)
+}
+
+// StreamRoute mounts the real-time stream. The connections check the API key or a stream token
+// themselves, so they belong outside the API key group; issuing tokens does not.
+func StreamRoute(public fiber.Router, protected fiber.Router, h handler.StreamHandler) {
+	stream := public.Group("/stream")
+	stream.Get("/ws", h.Authorize, h.WebSocket)
+	stream.Get("/sse", h.SSE)
+
+	protected.Post("/stream/tokens", h.CreateToken)
+}
+
+// GraphQLRoute mounts the GraphQL endpoint behind the API key, and its subscription socket, which
+// authenticates in connection_init, outside it. The socket shares the endpoint's path prefix, so
+// the key is checked per route rather than by a middleware on the prefix.
+func GraphQLRoute(r fiber.Router, apiKey fiber.Handler, h handler.GraphQLHandler) {
+	r.Get("/graphql", apiKey, h.Query)
+	r.Post("/graphql", apiKey, h.Query)
+
+	r.Get("/graphql/ws", h.Upgrade, h.WebSocket)
+}
+
+// DigitalLinkRoute mounts the GS1 Digital Link resolver; it belongs at the root of the public
+// domain printed on labels, outside the API key group
+func DigitalLinkRoute(r fiber.Router, h handler.DigitalLinkHandler) {
+	r.Get("/01/*", h.Resolve)
+}
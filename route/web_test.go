@@ -0,0 +1,100 @@
+package route
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/koriebruh/suplyChainTrack/conf"
+	"github.com/koriebruh/suplyChainTrack/internal/metirc"
+	"github.com/koriebruh/suplyChainTrack/internal/repository"
+	"github.com/koriebruh/suplyChainTrack/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testAPIKey = "test-api-key"
+
+// newTestApp builds the real application over a database that is never reached: the requests
+// below are answered before any query runs
+func newTestApp(t *testing.T) *fiber.App {
+	t.Helper()
+	t.Setenv("API_KEY", testAPIKey)
+
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=test dbname=test sslmode=disable"), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("gorm.Open unexpected error: %v", err)
+	}
+	config := &conf.Config{
+		AppConfig:    conf.AppConfig{ApiKey: testAPIKey},
+		StreamConfig: conf.StreamConfig{TokenSecret: "test-secret"},
+	}
+	metrics := metirc.NewAppMetricsExporter()
+	return NewApp(config, services.NewServiceManager(repository.NewRepositories(db), metrics), metrics)
+}
+
+func TestDigitalLinkRoute(t *testing.T) {
+	app := newTestApp(t)
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		// The resolver is mounted at the root and needs no API key; a bad check digit is refused
+		// before the product is looked up
+		{name: "wrong check digit", path: "/01/4006381333932", want: fiber.StatusBadRequest},
+		{name: "not a GTIN", path: "/01/abc", want: fiber.StatusBadRequest},
+		{name: "other application identifier", path: "/02/4006381333931", want: fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatalf("GET %s unexpected error: %v", tt.path, err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("GET %s = %d, want %d", tt.path, resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQLRoute(t *testing.T) {
+	app := newTestApp(t)
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		apiKey string
+		body   string
+		want   int
+	}{
+		{name: "query", method: fiber.MethodPost, path: "/api/v1/graphql", apiKey: testAPIKey, body: `{"query": "{ __typename }"}`, want: fiber.StatusOK},
+		{name: "query over GET", method: fiber.MethodGet, path: "/api/v1/graphql?query=%7B__typename%7D", apiKey: testAPIKey, want: fiber.StatusOK},
+		{name: "query without the API key", method: fiber.MethodPost, path: "/api/v1/graphql", body: `{"query": "{ __typename }"}`, want: fiber.StatusUnauthorized},
+		{name: "query with a wrong API key", method: fiber.MethodPost, path: "/api/v1/graphql", apiKey: "nope", body: `{"query": "{ __typename }"}`, want: fiber.StatusUnauthorized},
+		// The subscription socket authenticates in connection_init, so it is reached without a key
+		{name: "subscription socket without an upgrade", method: fiber.MethodGet, path: "/api/v1/graphql/ws", want: fiber.StatusUpgradeRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("%s %s unexpected error: %v", tt.method, tt.path, err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.want {
+				t.Fatalf("%s %s = %d %s, want %d", tt.method, tt.path, resp.StatusCode, body, tt.want)
+			}
+			if tt.want == fiber.StatusOK && !strings.Contains(string(body), `"__typename":"Query"`) {
+				t.Errorf("%s %s body = %s, want the __typename of the query root", tt.method, tt.path, body)
+			}
+		})
+	}
+}